@@ -55,6 +55,9 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// recording records (or replays) the HTTP interactions for this test, when `ARM_TEST_RECORD` is set
+	recording *testRecorder
 }
 
 // BuildTestData generates some test data for the given resource
@@ -85,6 +88,8 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
 	}
 
+	testData.useRecorder(t)
+
 	return testData
 }
 
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm
+
+import (
+	"strings"
+)
+
+func segments(id string) []string {
+	return strings.Split(strings.Trim(id, "/"), "/")
+}
+
+// resourceKey returns the key used to store the resource, since Resource IDs are case-insensitive
+func resourceKey(id string) string {
+	return "/" + strings.ToLower(strings.Trim(id, "/"))
+}
+
+// parentId returns the ID of the parent resource, for example the Resource Group for a top-level resource
+// or the resource which an Extension Resource (`{scope}/providers/{namespace}/{type}/{name}`) is scoped to.
+func parentId(id string) string {
+	s := segments(id)
+	if len(s) < 2 {
+		return ""
+	}
+	s = s[:len(s)-2]
+
+	if len(s) >= 2 && strings.EqualFold(s[len(s)-2], "providers") {
+		s = s[:len(s)-2]
+	}
+
+	return "/" + strings.Join(s, "/")
+}
+
+func resourceName(id string) string {
+	s := segments(id)
+	return s[len(s)-1]
+}
+
+// resourceType returns the type of the resource in the form `{namespace}/{type}[/{nestedType}]`
+func resourceType(id string) string {
+	s := segments(id)
+
+	providersIndex := -1
+	for i := len(s) - 2; i >= 0; i-- {
+		if strings.EqualFold(s[i], "providers") {
+			providersIndex = i
+			break
+		}
+	}
+	if providersIndex == -1 {
+		if len(s) == 4 && strings.EqualFold(s[2], "resourceGroups") {
+			return "Microsoft.Resources/resourceGroups"
+		}
+		return ""
+	}
+
+	types := []string{s[providersIndex+1]}
+	for i := providersIndex + 2; i < len(s); i += 2 {
+		types = append(types, s[i])
+	}
+	return strings.Join(types, "/")
+}
+
+func setProvisioningState(body map[string]interface{}, provisioningState string) {
+	properties, ok := body["properties"].(map[string]interface{})
+	if !ok {
+		properties = map[string]interface{}{}
+		body["properties"] = properties
+	}
+	properties["provisioningState"] = provisioningState
+}
+
+// mergePatch applies the JSON Merge Patch (RFC 7396) to the target
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	} else {
+		targetObject = copyObject(targetObject)
+	}
+
+	for k, v := range patchObject {
+		if v == nil {
+			delete(targetObject, k)
+			continue
+		}
+		targetObject[k] = mergePatch(targetObject[k], v)
+	}
+	return targetObject
+}
+
+func copyObject(input map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input))
+	for k, v := range input {
+		output[k] = copyValue(v)
+	}
+	return output
+}
+
+func copyValue(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		return copyObject(v)
+	case []interface{}:
+		output := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			output = append(output, copyValue(item))
+		}
+		return output
+	default:
+		return v
+	}
+}
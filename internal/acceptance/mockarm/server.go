@@ -0,0 +1,547 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package mockarm provides an in-process fake of the Azure Resource Manager API, which allows the Create, Read,
+// Update and Delete functions of Typed Resources to be run within `go test` without credentials.
+//
+// The Server implements generic PUT/GET/PATCH/DELETE semantics for any Resource ID, rather than the behaviour of
+// any specific Resource Provider - as such this is intended to exercise the plumbing within a Resource (mapping
+// to and from the API models, ID parsing, requires-import checks and removal from the state when gone) rather than
+// replacing the Acceptance Tests.
+package mockarm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+)
+
+const (
+	// EnvironmentName is the name of the Environment returned from the Server's Metadata endpoint
+	EnvironmentName = "AzureMockResourceManager"
+
+	operationsPath = "/mockarm/operations/"
+
+	provisioningStateAccepted  = "Accepted"
+	provisioningStateDeleting  = "Deleting"
+	provisioningStateSucceeded = "Succeeded"
+)
+
+// Server is an in-process fake of the Azure Resource Manager API, which also serves the token and metadata
+// endpoints required to configure a client against it.
+type Server struct {
+	// URL is the base URL of the Server, which is used as both the Resource Manager and Login endpoint
+	URL string
+
+	// ClientId and ClientSecret are the credentials which the Server will issue tokens for
+	ClientId     string
+	ClientSecret string
+
+	// ObjectId, SubscriptionId and TenantId are the identifiers of the principal which the tokens are issued to
+	ObjectId       string
+	SubscriptionId string
+	TenantId       string
+
+	server *httptest.Server
+
+	lock       sync.Mutex
+	resources  map[string]*resource
+	operations map[string]*operation
+
+	// pollsUntilComplete is the number of times a long-running operation is polled before it completes, when zero
+	// requests complete synchronously
+	pollsUntilComplete int
+	operationCount     int
+}
+
+type resource struct {
+	id   string
+	body map[string]interface{}
+
+	// operation is the long-running operation currently in progress for this resource, if any
+	operation *operation
+}
+
+type operation struct {
+	id         string
+	resourceId string
+	delete     bool
+	polls      int
+	completed  bool
+}
+
+// NewServer starts a new Server which is stopped when the test completes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		ClientId:       "11111111-1111-1111-1111-111111111111",
+		ClientSecret:   "mock-client-secret",
+		ObjectId:       "22222222-2222-2222-2222-222222222222",
+		SubscriptionId: "33333333-3333-3333-3333-333333333333",
+		TenantId:       "44444444-4444-4444-4444-444444444444",
+
+		resources:  map[string]*resource{},
+		operations: map[string]*operation{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+// UseLongRunningOperations configures the Server to complete PUT, PATCH and DELETE requests asynchronously, returning
+// an `Azure-AsyncOperation` (and for deletions a `Location`) header which is polled `polls` times before completing.
+func (s *Server) UseLongRunningOperations(polls int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pollsUntilComplete = polls
+}
+
+// Environment returns an Environment which uses the Server for both Resource Manager and authentication.
+//
+// Data Plane APIs (such as Key Vault and Storage) are not implemented by the Server and continue to use the
+// endpoints for Azure Public.
+func (s *Server) Environment() *environments.Environment {
+	env := environments.AzurePublic()
+	env.Name = EnvironmentName
+	env.Authorization.Audiences = []string{s.URL}
+	env.Authorization.LoginEndpoint = s.URL
+	env.MicrosoftGraph = environments.MicrosoftGraphAPI(s.URL)
+	env.ResourceManager = environments.ResourceManagerAPI(s.URL)
+	return env
+}
+
+// Client builds a Client which is configured to use the Server, authenticating using a Client Secret.
+func (s *Server) Client(t testing.TB) *clients.Client {
+	t.Helper()
+
+	authConfig := auth.Credentials{
+		Environment:  *s.Environment(),
+		ClientID:     s.ClientId,
+		ClientSecret: s.ClientSecret,
+		TenantID:     s.TenantId,
+
+		EnableAuthenticatingUsingClientSecret: true,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	client, err := clients.Build(ctx, clients.ClientBuilder{
+		AuthConfig:                &authConfig,
+		DisableTerraformPartnerID: true,
+		Features:                  features.Default(),
+		SubscriptionID:            s.SubscriptionId,
+		TerraformVersion:          "0.0.0",
+	})
+	if err != nil {
+		t.Fatalf("building the Client for the Mock Resource Manager: %+v", err)
+	}
+
+	// the Resource Providers are cached for the lifetime of the process, so shouldn't leak into other tests
+	t.Cleanup(resourceproviders.ClearCache)
+
+	return client
+}
+
+// SetResource creates (or replaces) the resource with the specified ID, for example to seed a Resource Group
+// which the resource being tested is created within.
+func (s *Server) SetResource(id string, body map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.put(id, body, provisioningStateSucceeded)
+}
+
+// Resource returns the current representation of the resource with the specified ID, or nil if it doesn't exist.
+func (s *Server) Resource(id string) map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, ok := s.resources[resourceKey(id)]
+	if !ok {
+		return nil
+	}
+	return copyObject(existing.body)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/oauth2/v2.0/token"):
+		s.token(w, r)
+
+	case r.Method == http.MethodGet && path == "/metadata/endpoints":
+		s.metadata(w)
+
+	case !strings.HasPrefix(strings.ToLower(path), "/subscriptions/") && !strings.HasPrefix(path, operationsPath):
+		writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("the path %q is not implemented by the Mock Resource Manager", path))
+
+	case !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "):
+		writeError(w, http.StatusUnauthorized, "AuthenticationFailed", "Authentication failed. The 'Authorization' header is missing.")
+
+	case r.URL.Query().Get("api-version") == "":
+		writeError(w, http.StatusBadRequest, "MissingApiVersionParameter", "The api-version query parameter (?api-version=) is required for all requests.")
+
+	case strings.HasPrefix(path, operationsPath):
+		s.pollOperation(w, r, path)
+
+	default:
+		s.serveResource(w, r, path)
+	}
+}
+
+func (s *Server) serveResource(w http.ResponseWriter, r *http.Request, id string) {
+	// Resource IDs are made up of key/value pairs, so an odd number of segments refers to a collection
+	if len(segments(id))%2 == 1 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("the method %q is not supported for the collection %q", r.Method, id))
+			return
+		}
+		s.list(w, id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		existing, ok := s.resources[resourceKey(id)]
+		if ok && existing.operation != nil {
+			s.advance(existing.operation)
+			existing, ok = s.resources[resourceKey(id)]
+		}
+		if !ok {
+			writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+			return
+		}
+		writeJSON(w, http.StatusOK, existing.body)
+
+	case http.MethodPut:
+		var body map[string]interface{}
+		if !readBody(w, r, &body) {
+			return
+		}
+		if !s.parentExists(w, id) {
+			return
+		}
+
+		_, exists := s.resources[resourceKey(id)]
+		statusCode := http.StatusCreated
+		if exists {
+			statusCode = http.StatusOK
+		}
+
+		if s.pollsUntilComplete == 0 {
+			writeJSON(w, statusCode, s.put(id, body, provisioningStateSucceeded).body)
+			return
+		}
+
+		updated := s.put(id, body, provisioningStateAccepted)
+		op := s.startOperation(updated, false)
+		w.Header().Set("Azure-AsyncOperation", s.operationUrl(op, r))
+		w.Header().Set("Retry-After", "0")
+		writeJSON(w, statusCode, updated.body)
+
+	case http.MethodPatch:
+		var patch map[string]interface{}
+		if !readBody(w, r, &patch) {
+			return
+		}
+		existing, ok := s.resources[resourceKey(id)]
+		if !ok {
+			writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+			return
+		}
+
+		provisioningState := provisioningStateSucceeded
+		if s.pollsUntilComplete > 0 {
+			provisioningState = provisioningStateAccepted
+		}
+		updated := s.put(existing.id, mergePatch(existing.body, patch).(map[string]interface{}), provisioningState)
+		if s.pollsUntilComplete > 0 {
+			op := s.startOperation(updated, false)
+			w.Header().Set("Azure-AsyncOperation", s.operationUrl(op, r))
+			w.Header().Set("Retry-After", "0")
+		}
+		writeJSON(w, http.StatusOK, updated.body)
+
+	case http.MethodDelete:
+		existing, ok := s.resources[resourceKey(id)]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if s.pollsUntilComplete == 0 {
+			s.delete(existing.id)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		setProvisioningState(existing.body, provisioningStateDeleting)
+		op := s.startOperation(existing, true)
+		w.Header().Set("Azure-AsyncOperation", s.operationUrl(op, r))
+		w.Header().Set("Location", s.operationUrl(op, r)+"&result=true")
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		writeError(w, http.StatusNotImplemented, "ActionNotSupported", fmt.Sprintf("the method %q is not implemented by the Mock Resource Manager", r.Method))
+	}
+}
+
+// parentExists checks that the parent of the resource exists, writing a 404 when it doesn't
+func (s *Server) parentExists(w http.ResponseWriter, id string) bool {
+	parent := parentId(id)
+	if len(segments(parent)) <= 2 {
+		// the Subscription is assumed to exist
+		return true
+	}
+	if _, ok := s.resources[resourceKey(parent)]; ok {
+		return true
+	}
+
+	code := "ParentResourceNotFound"
+	if len(segments(parent)) == 4 {
+		code = "ResourceGroupNotFound"
+	}
+	writeError(w, http.StatusNotFound, code, fmt.Sprintf("The parent resource %q of %q was not found.", parent, id))
+	return false
+}
+
+func (s *Server) list(w http.ResponseWriter, collection string) {
+	prefix := resourceKey(collection) + "/"
+
+	keys := make([]string, 0)
+	for k := range s.resources {
+		if strings.HasPrefix(k, prefix) && !strings.Contains(strings.TrimPrefix(k, prefix), "/") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	values := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, s.resources[k].body)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+}
+
+// put stores the resource, populating the Read-Only fields which are returned from Resource Manager
+func (s *Server) put(id string, body map[string]interface{}, provisioningState string) *resource {
+	key := resourceKey(id)
+	if existing, ok := s.resources[key]; ok {
+		// Resource Manager retains the casing used when the resource was created
+		id = existing.id
+	}
+
+	body = copyObject(body)
+	body["id"] = id
+	body["name"] = resourceName(id)
+	if t := resourceType(id); t != "" {
+		body["type"] = t
+	}
+	setProvisioningState(body, provisioningState)
+
+	s.resources[key] = &resource{
+		id:   id,
+		body: body,
+	}
+	return s.resources[key]
+}
+
+// delete removes the resource and any nested resources
+func (s *Server) delete(id string) {
+	key := resourceKey(id)
+	for k := range s.resources {
+		if k == key || strings.HasPrefix(k, key+"/") {
+			delete(s.resources, k)
+		}
+	}
+}
+
+func (s *Server) startOperation(r *resource, delete bool) *operation {
+	s.operationCount++
+	op := &operation{
+		id:         fmt.Sprintf("%d", s.operationCount),
+		resourceId: r.id,
+		delete:     delete,
+	}
+	s.operations[op.id] = op
+	r.operation = op
+	return op
+}
+
+func (s *Server) operationUrl(op *operation, r *http.Request) string {
+	return fmt.Sprintf("%s%s%s?api-version=%s", s.URL, operationsPath, op.id, r.URL.Query().Get("api-version"))
+}
+
+// advance records a poll of the operation - either of the operation itself or of the resource - and completes
+// the operation once it has been polled enough times
+func (s *Server) advance(op *operation) {
+	if op.completed {
+		return
+	}
+
+	op.polls++
+	if op.polls < s.pollsUntilComplete {
+		return
+	}
+
+	op.completed = true
+	existing, ok := s.resources[resourceKey(op.resourceId)]
+	if !ok {
+		return
+	}
+	existing.operation = nil
+
+	if op.delete {
+		s.delete(op.resourceId)
+		return
+	}
+	setProvisioningState(existing.body, provisioningStateSucceeded)
+}
+
+func (s *Server) pollOperation(w http.ResponseWriter, r *http.Request, path string) {
+	op, ok := s.operations[strings.TrimPrefix(path, operationsPath)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OperationNotFound", fmt.Sprintf("The Operation %q was not found.", path))
+		return
+	}
+	s.advance(op)
+
+	// the `Location` URI returns a 202 until the operation completes, followed by the result
+	if r.URL.Query().Get("result") != "" {
+		if !op.completed {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if existing, ok := s.resources[resourceKey(op.resourceId)]; ok && !op.delete {
+			writeJSON(w, http.StatusOK, existing.body)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// whereas the `Azure-AsyncOperation` URI returns the status of the operation
+	status := "InProgress"
+	if op.completed {
+		status = provisioningStateSucceeded
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":     path,
+		"name":   op.id,
+		"status": status,
+	})
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error":             "invalid_request",
+			"error_description": err.Error(),
+		})
+		return
+	}
+	if r.PostForm.Get("client_id") != s.ClientId || r.PostForm.Get("client_secret") != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"error":             "invalid_client",
+			"error_description": "the Client ID or Client Secret is invalid",
+		})
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"aud":   strings.TrimSuffix(r.PostForm.Get("scope"), "/.default"),
+		"appid": s.ClientId,
+		"exp":   now.Add(time.Hour).Unix(),
+		"iat":   now.Unix(),
+		"nbf":   now.Unix(),
+		"oid":   s.ObjectId,
+		"tid":   s.TenantId,
+	}
+	payload, _ := json.Marshal(claims)
+	accessToken := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)),
+		base64.RawURLEncoding.EncodeToString(payload),
+		base64.RawURLEncoding.EncodeToString([]byte("mockarm")),
+	}, ".")
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"expires_in":   3600,
+		"token_type":   "Bearer",
+	})
+}
+
+// metadata serves the Metadata endpoint, so that the Environment can be loaded using `environments.FromEndpoint`
+func (s *Server) metadata(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":            EnvironmentName,
+		"resourceManager": s.URL,
+		"authentication": map[string]interface{}{
+			"audiences":        []string{s.URL},
+			"identityProvider": "AAD",
+			"loginEndpoint":    s.URL,
+			"tenant":           "common",
+		},
+		"microsoftGraphResourceId": s.URL,
+		"suffixes": map[string]interface{}{
+			"keyVaultDns": "vault.azure.net",
+			"storage":     "core.windows.net",
+		},
+	})
+}
+
+func readBody(w http.ResponseWriter, r *http.Request, v *map[string]interface{}) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("reading the request body: %+v", err))
+		return false
+	}
+	if err := json.Unmarshal(body, v); err != nil || *v == nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("the request body must be a JSON object: %q", string(body)))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
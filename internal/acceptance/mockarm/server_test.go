@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+const (
+	testResourceGroupId = "/subscriptions/33333333-3333-3333-3333-333333333333/resourceGroups/example"
+	testResourceId      = testResourceGroupId + "/providers/Microsoft.Example/widgets/first"
+)
+
+func TestServer_CRUD(t *testing.T) {
+	s := NewServer(t)
+
+	if status, body := send(t, s, http.MethodPut, testResourceId, `{"location":"westeurope"}`); status != http.StatusNotFound || !strings.Contains(body, "ResourceGroupNotFound") {
+		t.Fatalf("expected a 404 ResourceGroupNotFound when the Resource Group doesn't exist but got %d: %s", status, body)
+	}
+
+	s.SetResource(testResourceGroupId, map[string]interface{}{"location": "westeurope"})
+
+	status, body := send(t, s, http.MethodPut, testResourceId, `{"location":"westeurope","properties":{"size":1,"enabled":true}}`)
+	if status != http.StatusCreated {
+		t.Fatalf("expected a 201 when creating the resource but got %d: %s", status, body)
+	}
+	created := decode(t, body)
+	if created["type"] != "Microsoft.Example/widgets" || created["name"] != "first" || created["id"] != testResourceId {
+		t.Fatalf("expected the Read-Only fields to be populated but got %s", body)
+	}
+
+	if status, body := send(t, s, http.MethodPut, strings.ToUpper(testResourceId), `{"location":"westeurope","properties":{"size":2}}`); status != http.StatusOK {
+		t.Fatalf("expected a 200 when updating the resource but got %d: %s", status, body)
+	}
+
+	if status, body := send(t, s, http.MethodPatch, testResourceId, `{"properties":{"enabled":false,"size":null},"tags":{"env":"test"}}`); status != http.StatusOK {
+		t.Fatalf("expected a 200 when patching the resource but got %d: %s", status, body)
+	}
+	patched := s.Resource(testResourceId)
+	properties := patched["properties"].(map[string]interface{})
+	if patched["id"] != testResourceId || properties["enabled"] != false || properties["size"] != nil || patched["tags"] == nil {
+		t.Fatalf("expected the patch to be merged into the resource but got %+v", patched)
+	}
+
+	nestedId := testResourceId + "/gadgets/nested"
+	if status, body := send(t, s, http.MethodPut, nestedId, `{}`); status != http.StatusCreated {
+		t.Fatalf("expected a 201 when creating the nested resource but got %d: %s", status, body)
+	}
+
+	status, body = send(t, s, http.MethodGet, testResourceGroupId+"/providers/Microsoft.Example/widgets", "")
+	if status != http.StatusOK {
+		t.Fatalf("expected a 200 when listing the collection but got %d: %s", status, body)
+	}
+	if values := decode(t, body)["value"].([]interface{}); len(values) != 1 {
+		t.Fatalf("expected the collection to only contain the direct children but got %s", body)
+	}
+
+	if status, body := send(t, s, http.MethodDelete, testResourceGroupId, ""); status != http.StatusOK {
+		t.Fatalf("expected a 200 when deleting the Resource Group but got %d: %s", status, body)
+	}
+	for _, id := range []string{testResourceGroupId, testResourceId, nestedId} {
+		if status, body := send(t, s, http.MethodGet, id, ""); status != http.StatusNotFound || !strings.Contains(body, "ResourceNotFound") {
+			t.Fatalf("expected a 404 for %q once deleted but got %d: %s", id, status, body)
+		}
+	}
+
+	if status, _ := send(t, s, http.MethodDelete, testResourceGroupId, ""); status != http.StatusNoContent {
+		t.Fatalf("expected a 204 when deleting a resource which doesn't exist but got %d", status)
+	}
+}
+
+func TestServer_LongRunningOperations(t *testing.T) {
+	s := NewServer(t)
+	s.SetResource(testResourceGroupId, map[string]interface{}{"location": "westeurope"})
+	s.UseLongRunningOperations(2)
+
+	response := do(t, s, http.MethodPut, testResourceId, `{"location":"westeurope"}`)
+	pollingUrl := response.Header.Get("Azure-AsyncOperation")
+	if response.StatusCode != http.StatusCreated || pollingUrl == "" {
+		t.Fatalf("expected a 201 with an `Azure-AsyncOperation` header but got %d: %+v", response.StatusCode, response.Header)
+	}
+
+	for _, expected := range []string{"InProgress", "Succeeded", "Succeeded"} {
+		status, body := send(t, s, http.MethodGet, strings.TrimPrefix(pollingUrl, s.URL), "")
+		if status != http.StatusOK || decode(t, body)["status"] != expected {
+			t.Fatalf("expected the operation to be %q but got %d: %s", expected, status, body)
+		}
+	}
+	if state := s.Resource(testResourceId)["properties"].(map[string]interface{})["provisioningState"]; state != "Succeeded" {
+		t.Fatalf("expected the resource to be provisioned once the operation completed but got %q", state)
+	}
+
+	response = do(t, s, http.MethodDelete, testResourceId, "")
+	location := response.Header.Get("Location")
+	if response.StatusCode != http.StatusAccepted || location == "" {
+		t.Fatalf("expected a 202 with a `Location` header but got %d: %+v", response.StatusCode, response.Header)
+	}
+	if status, _ := send(t, s, http.MethodGet, strings.TrimPrefix(location, s.URL), ""); status != http.StatusAccepted {
+		t.Fatalf("expected a 202 whilst the deletion is in progress but got %d", status)
+	}
+
+	// polling the resource also progresses the operation, as done by the pollers for Deletions
+	if status, _ := send(t, s, http.MethodGet, testResourceId, ""); status != http.StatusNotFound {
+		t.Fatalf("expected a 404 once the deletion completed but got %d", status)
+	}
+	if status, _ := send(t, s, http.MethodGet, strings.TrimPrefix(location, s.URL), ""); status != http.StatusNoContent {
+		t.Fatalf("expected a 204 once the deletion completed but got %d", status)
+	}
+}
+
+func TestServer_Environment(t *testing.T) {
+	s := NewServer(t)
+
+	env, err := environments.FromEndpoint(context.TODO(), s.URL)
+	if err != nil {
+		t.Fatalf("loading the Environment from the Metadata endpoint: %+v", err)
+	}
+	if env.IsAzureStack() {
+		t.Fatalf("expected the Environment not to be detected as Azure Stack")
+	}
+	if endpoint, ok := env.ResourceManager.Endpoint(); !ok || *endpoint != s.URL {
+		t.Fatalf("expected the Resource Manager endpoint to be %q but got %v", s.URL, endpoint)
+	}
+
+	client := s.Client(t)
+	if client.Account.ObjectId != s.ObjectId || client.Account.TenantId != s.TenantId {
+		t.Fatalf("expected the Account to be populated from the token but got %+v", client.Account)
+	}
+}
+
+func do(t *testing.T, s *Server, method, path, body string) *http.Response {
+	t.Helper()
+
+	if !strings.Contains(path, "api-version=") {
+		path += "?api-version=2023-01-01"
+	}
+	request, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("building the request: %+v", err)
+	}
+	request.Header.Set("Authorization", "Bearer mock")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("sending the request: %+v", err)
+	}
+	return response
+}
+
+func send(t *testing.T, s *Server, method, path, body string) (int, string) {
+	t.Helper()
+
+	response := do(t, s, method, path, body)
+	defer response.Body.Close()
+
+	contents, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("reading the response: %+v", err)
+	}
+	return response.StatusCode, string(contents)
+}
+
+func decode(t *testing.T, body string) map[string]interface{} {
+	t.Helper()
+
+	var out map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &out); err != nil {
+		t.Fatalf("decoding %q: %+v", body, err)
+	}
+	return out
+}
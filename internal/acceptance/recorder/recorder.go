@@ -0,0 +1,504 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"golang.org/x/oauth2"
+)
+
+// Mode determines whether HTTP interactions are recorded to, or replayed from, a cassette
+type Mode string
+
+const (
+	ModeRecord Mode = "record"
+	ModeReplay Mode = "replay"
+
+	// EnvTestRecord specifies the Mode used for the Acceptance Tests - when unset the tests run against Azure
+	EnvTestRecord = "ARM_TEST_RECORD"
+
+	// RecordedSubscriptionId is the Subscription ID which replaces any Subscription IDs within a cassette
+	RecordedSubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	// RecordedTenantId is the Tenant ID which replaces the Tenant ID of the authenticated principal within a cassette
+	RecordedTenantId = "00000000-0000-0000-0000-000000000001"
+
+	// RecordedClientId is the Client ID which replaces the Client ID of the authenticated principal within a cassette
+	RecordedClientId = "00000000-0000-0000-0000-000000000002"
+
+	// RecordedObjectId is the Object ID which replaces the Object ID of the authenticated principal within a cassette
+	RecordedObjectId = "00000000-0000-0000-0000-000000000003"
+)
+
+var subscriptionIdRegex = regexp.MustCompile(`(?i)(/subscriptions/)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// ModeFromEnvironment returns the Mode specified in the `ARM_TEST_RECORD` environment variable, which is empty
+// when HTTP interactions shouldn't be recorded or replayed.
+func ModeFromEnvironment() (Mode, error) {
+	v := Mode(strings.ToLower(strings.TrimSpace(os.Getenv(EnvTestRecord))))
+	switch v {
+	case "", ModeRecord, ModeReplay:
+		return v, nil
+	}
+
+	return "", fmt.Errorf("`%s` must be either %q or %q, got %q", EnvTestRecord, ModeRecord, ModeReplay, v)
+}
+
+// Cassette is the set of (sanitised) HTTP interactions recorded for a test, alongside any variables
+// (such as the random values used to generate names) which must be pinned for the interactions to be replayed
+type Cassette struct {
+	Variables    map[string]string     `json:"variables"`
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+type CassetteInteraction struct {
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	RequestBody     string              `json:"request_body,omitempty"`
+	StatusCode      int                 `json:"status_code"`
+	ResponseHeaders map[string][]string `json:"response_headers,omitempty"`
+	ResponseBody    string              `json:"response_body,omitempty"`
+}
+
+// Recorder records the HTTP interactions with Azure to a cassette, or replays them from a previously recorded
+// cassette - such that tests can be run without access to Azure.
+//
+// The interactions are sanitised prior to being written to the cassette, where:
+//
+// * The Subscription, Tenant, Client and Object IDs of the authenticated principal (either registered using
+// ReplaceValue, or taken from the claims of the access token) are replaced with the Recorded IDs.
+// * Any other Subscription IDs within a Resource ID are replaced with RecordedSubscriptionId.
+// * The Authorization header, SAS Signatures and the string values of any sensitive fields within JSON bodies are
+// removed or redacted in the same way as when these are logged.
+type Recorder struct {
+	mode Mode
+	path string
+
+	// transport is used to send requests to Azure when recording
+	transport http.RoundTripper
+
+	lock     sync.Mutex
+	cassette Cassette
+
+	// recorded are the (unsanitised) interactions recorded so far, which are sanitised once the Recorder is stopped
+	// - since the IDs within the claims of the access token aren't known until the first request has been sent
+	recorded []recordedInteraction
+
+	// replacements are the values (in lower-case) which are replaced within the cassette, mapped to their replacement
+	replacements map[string]string
+
+	// replayed is whether each interaction within the cassette has been replayed
+	replayed []bool
+}
+
+type recordedInteraction struct {
+	method          string
+	url             *url.URL
+	requestBody     []byte
+	statusCode      int
+	responseHeaders http.Header
+	responseBody    []byte
+}
+
+var _ http.RoundTripper = &Recorder{}
+
+// NewRecorder returns a Recorder which records to (or replays from) the cassette at `path`, the Recorder must be
+// stopped once the test has completed, to write the cassette when recording.
+func NewRecorder(mode Mode, path string) (*Recorder, error) {
+	r := &Recorder{
+		mode: mode,
+		path: path,
+		transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+		cassette: Cassette{
+			Variables:    make(map[string]string),
+			Interactions: make([]CassetteInteraction, 0),
+		},
+		replacements: make(map[string]string),
+	}
+
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading the cassette %q: %+v", path, err)
+		}
+		if err := json.Unmarshal(contents, &r.cassette); err != nil {
+			return nil, fmt.Errorf("parsing the cassette %q: %+v", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unsupported Recorder Mode %q", mode)
+	}
+
+	return r, nil
+}
+
+// Mode returns the Mode this Recorder is using
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// ReplaceValue replaces `value` (case-insensitively) with `replacement` within the cassette, for example to replace
+// the Subscription ID being used with RecordedSubscriptionId
+func (r *Recorder) ReplaceValue(value string, replacement string) {
+	if value == "" {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.replacements[strings.ToLower(value)] = replacement
+}
+
+// Variable returns the value of the variable `name` pinned within the cassette - when recording the value
+// is generated using `generate`, when replaying the recorded value is returned.
+func (r *Recorder) Variable(name string, generate func() string) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.mode == ModeReplay {
+		v, ok := r.cassette.Variables[name]
+		if !ok {
+			return "", fmt.Errorf("the variable %q was not found within the cassette %q", name, r.path)
+		}
+		return v, nil
+	}
+
+	v := generate()
+	r.cassette.Variables[name] = v
+	return v, nil
+}
+
+// Stop writes the cassette when recording
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	s := newSanitiser(r.replacements)
+	for _, v := range r.recorded {
+		interaction := CassetteInteraction{
+			Method:          v.method,
+			URL:             s.url(v.url),
+			StatusCode:      v.statusCode,
+			ResponseHeaders: s.headers(v.responseHeaders),
+			ResponseBody:    s.body(v.responseBody),
+		}
+		if len(v.requestBody) > 0 {
+			interaction.RequestBody = s.body(v.requestBody)
+		}
+		r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	}
+	r.recorded = nil
+
+	contents, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing the cassette %q: %+v", r.path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating the directory for the cassette %q: %+v", r.path, err)
+	}
+	if err := os.WriteFile(r.path, append(contents, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing the cassette %q: %+v", r.path, err)
+	}
+
+	return nil
+}
+
+// Authorizer returns an auth.Authorizer for use when replaying, which returns a static access token
+func (r *Recorder) Authorizer() auth.Authorizer {
+	return replayAuthorizer{}
+}
+
+// RoundTrip records or replays the request
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(request, requestBody), nil
+	}
+
+	return r.record(request, requestBody)
+}
+
+func (r *Recorder) record(request *http.Request, requestBody []byte) (*http.Response, error) {
+	response, err := r.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading the response body: %+v", err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	uri := *request.URL
+	interaction := recordedInteraction{
+		method:          request.Method,
+		url:             &uri,
+		requestBody:     requestBody,
+		statusCode:      response.StatusCode,
+		responseHeaders: response.Header.Clone(),
+		responseBody:    responseBody,
+	}
+
+	r.lock.Lock()
+	r.recorded = append(r.recorded, interaction)
+	for value, replacement := range idsFromAccessToken(request.Header.Get("Authorization")) {
+		r.replacements[value] = replacement
+	}
+	r.lock.Unlock()
+
+	return response, nil
+}
+
+// replay returns the recorded response for the request, which is matched using the method, URL and request body:
+//
+// * The first interaction which hasn't been replayed with the same request body is used.
+// * Otherwise the interactions are replayed in the order these were recorded, for example when the request body
+// contains a value which differs each time it's sent (such as a timestamp).
+// * Once each of the recorded interactions has been replayed, the last interaction is returned for any subsequent
+// requests (for example when polling).
+func (r *Recorder) replay(request *http.Request, requestBody []byte) *http.Response {
+	method := request.Method
+
+	r.lock.Lock()
+	s := newSanitiser(r.replacements)
+	uri := s.url(request.URL)
+	body := ""
+	if len(requestBody) > 0 {
+		body = s.body(requestBody)
+	}
+
+	matched, next, last := -1, -1, -1
+	for i, v := range r.cassette.Interactions {
+		if v.Method != method || v.URL != uri {
+			continue
+		}
+
+		last = i
+		if r.replayed[i] {
+			continue
+		}
+		if next == -1 {
+			next = i
+		}
+		if matched == -1 && v.RequestBody == body {
+			matched = i
+		}
+	}
+
+	index := matched
+	if index == -1 {
+		index = next
+	}
+	if index == -1 {
+		index = last
+	}
+	if index != -1 {
+		r.replayed[index] = true
+	}
+	r.lock.Unlock()
+
+	if index == -1 {
+		// a 501 is returned (rather than an error) since this isn't retried by the clients
+		message := fmt.Sprintf(`{"error":{"code":"InteractionNotRecorded","message":"no interaction was recorded for %s %s within the cassette %q"}}`, method, uri, r.path)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented)),
+			StatusCode:    http.StatusNotImplemented,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(strings.NewReader(message)),
+			ContentLength: int64(len(message)),
+			Request:       request,
+		}
+	}
+	interaction := r.cassette.Interactions[index]
+
+	header := http.Header{}
+	for k, v := range interaction.ResponseHeaders {
+		header[k] = v
+	}
+	// there's no need to wait between polls when replaying
+	if header.Get("Retry-After") != "" {
+		header.Set("Retry-After", "0")
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       request,
+	}
+}
+
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading the request body: %+v", err)
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// idsFromAccessToken returns the Tenant, Client and Object IDs within the claims of the access token in the
+// Authorization header (mapped to the Recorded IDs which replace them) - the signature isn't validated, since
+// the access token is only used to determine which values to replace
+func idsFromAccessToken(header string) map[string]string {
+	parts := strings.Split(strings.TrimPrefix(header, "Bearer "), ".")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil
+	}
+
+	var claims struct {
+		TenantId string `json:"tid"`
+		ClientId string `json:"appid"`
+		ObjectId string `json:"oid"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil
+	}
+
+	output := make(map[string]string)
+	for value, replacement := range map[string]string{
+		claims.TenantId: RecordedTenantId,
+		claims.ClientId: RecordedClientId,
+		claims.ObjectId: RecordedObjectId,
+	} {
+		if value != "" {
+			output[strings.ToLower(value)] = replacement
+		}
+	}
+	return output
+}
+
+type replayAuthorizer struct{}
+
+var _ auth.Authorizer = replayAuthorizer{}
+
+func (replayAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "replayed",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (replayAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
+
+// sanitiser removes the sensitive values from the HTTP interactions written to the cassette
+type sanitiser struct {
+	replacements []replacement
+}
+
+type replacement struct {
+	regex *regexp.Regexp
+	value string
+}
+
+func newSanitiser(replacements map[string]string) sanitiser {
+	output := sanitiser{
+		replacements: make([]replacement, 0, len(replacements)),
+	}
+	for value, v := range replacements {
+		output.replacements = append(output.replacements, replacement{
+			regex: regexp.MustCompile("(?i)" + regexp.QuoteMeta(value)),
+			value: v,
+		})
+	}
+	return output
+}
+
+func (s sanitiser) url(input *url.URL) string {
+	return s.replace(common.RedactURL(input))
+}
+
+func (s sanitiser) headers(input http.Header) map[string][]string {
+	output := make(map[string][]string, len(input))
+	for k, v := range input {
+		if common.IsSensitiveHeader(k) || http.CanonicalHeaderKey(k) == "Content-Length" {
+			continue
+		}
+
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			// headers such as `Location` and `Azure-AsyncOperation` contain URIs, which may contain a SAS Signature
+			if u, err := url.Parse(value); err == nil && u.IsAbs() {
+				value = common.RedactURL(u)
+			}
+			values = append(values, s.replace(value))
+		}
+		output[k] = values
+	}
+	return output
+}
+
+// body redacts the sensitive fields within a JSON body, unlike the HTTP Logger any other bodies are retained
+// verbatim since these are required to replay the interaction
+func (s sanitiser) body(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err == nil {
+		if output, err := json.Marshal(common.RedactJSONValue(v)); err == nil {
+			body = output
+		}
+	}
+
+	return s.replace(string(body))
+}
+
+func (s sanitiser) replace(input string) string {
+	for _, v := range s.replacements {
+		input = v.regex.ReplaceAllLiteralString(input, v.value)
+	}
+	return subscriptionIdRegex.ReplaceAllString(input, "${1}"+RecordedSubscriptionId)
+}
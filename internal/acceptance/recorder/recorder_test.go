@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recorder
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TestAccExample_basic.json")
+	subscriptionId := "11111111-2222-3333-4444-555555555555"
+	uri := "https://management.azure.com/subscriptions/" + subscriptionId + "/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys?api-version=2023-01-01"
+
+	recorder, err := NewRecorder(ModeRecord, path)
+	if err != nil {
+		t.Fatalf("building the Recorder: %+v", err)
+	}
+	responses := []string{
+		`{"keys":[{"keyName":"key1","value":"s3cr3t"}],"id":"/subscriptions/` + subscriptionId + `/example"}`,
+		`{"keys":[{"keyName":"key1","value":"r0t4t3d"}],"id":"/subscriptions/` + subscriptionId + `/example"}`,
+	}
+	sent := 0
+	recorder.transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		if auth := request.Header.Get("Authorization"); auth != "Bearer abc123" {
+			t.Fatalf("expected the Authorization header to be sent when recording but got %q", auth)
+		}
+		body := responses[sent]
+		sent++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":   []string{"application/json"},
+				"Content-Length": []string{"1234"},
+			},
+			Body: io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+
+	randomInteger, err := recorder.Variable("random_integer", func() string { return "1234" })
+	if err != nil {
+		t.Fatalf("retrieving the variable: %+v", err)
+	}
+	if randomInteger != "1234" {
+		t.Fatalf("expected the variable to be %q but got %q", "1234", randomInteger)
+	}
+
+	for i := range responses {
+		request, _ := http.NewRequest(http.MethodPost, uri, nil)
+		request.Header.Set("Authorization", "Bearer abc123")
+		response, err := recorder.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("recording the request: %+v", err)
+		}
+
+		// the response returned when recording must be unchanged
+		if body, _ := io.ReadAll(response.Body); string(body) != responses[i] {
+			t.Fatalf("expected the recorded response to be %q but got %q", responses[i], string(body))
+		}
+	}
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("stopping the Recorder: %+v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading the cassette: %+v", err)
+	}
+	for _, v := range []string{subscriptionId, "s3cr3t", "r0t4t3d", "abc123", "Content-Length"} {
+		if strings.Contains(string(contents), v) {
+			t.Fatalf("expected the cassette not to contain %q but got:\n%s", v, string(contents))
+		}
+	}
+
+	replayer, err := NewRecorder(ModeReplay, path)
+	if err != nil {
+		t.Fatalf("building the Recorder: %+v", err)
+	}
+	defer replayer.Stop()
+
+	randomInteger, err = replayer.Variable("random_integer", func() string { return "5678" })
+	if err != nil {
+		t.Fatalf("retrieving the variable: %+v", err)
+	}
+	if randomInteger != "1234" {
+		t.Fatalf("expected the pinned variable to be %q but got %q", "1234", randomInteger)
+	}
+	if _, err := replayer.Variable("random_string", func() string { return "abcde" }); err == nil {
+		t.Fatalf("expected an error when retrieving a variable which wasn't recorded")
+	}
+
+	// the responses are replayed in order, with the last response repeated thereafter
+	replayedUri := strings.ReplaceAll(uri, subscriptionId, RecordedSubscriptionId)
+	for _, expected := range []string{"key1", "key1", "key1"} {
+		request, _ := http.NewRequest(http.MethodPost, replayedUri, nil)
+		response, err := replayer.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("replaying the request: %+v", err)
+		}
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("expected the replayed status code to be %d but got %d", http.StatusOK, response.StatusCode)
+		}
+		if body, _ := io.ReadAll(response.Body); !strings.Contains(string(body), expected) {
+			t.Fatalf("expected the replayed body to contain %q but got %q", expected, string(body))
+		}
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, replayedUri, nil)
+	response, err := replayer.RoundTrip(request)
+	if err != nil {
+		t.Fatalf("replaying the request: %+v", err)
+	}
+	if response.StatusCode != http.StatusNotImplemented {
+		t.Fatalf("expected a %d for an interaction which wasn't recorded but got %d", http.StatusNotImplemented, response.StatusCode)
+	}
+}
+
+func TestRecorder_ReplacesIds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TestAccExample_basic.json")
+	subscriptionId := "11111111-2222-3333-4444-555555555555"
+	tenantId := "22222222-3333-4444-5555-666666666666"
+	clientId := "33333333-4444-5555-6666-777777777777"
+	objectId := "44444444-5555-6666-7777-888888888888"
+
+	recorder, err := NewRecorder(ModeRecord, path)
+	if err != nil {
+		t.Fatalf("building the Recorder: %+v", err)
+	}
+	recorder.ReplaceValue(subscriptionId, RecordedSubscriptionId)
+	recorder.transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		body := `{"properties":{"principalId":"` + strings.ToUpper(objectId) + `","tenantId":"` + tenantId + `","scope":"/subscriptions/` + subscriptionId + `"}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Location": []string{"https://login.microsoftonline.com/" + tenantId + "/example"},
+			},
+			Body: io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"tid":"` + tenantId + `","appid":"` + clientId + `","oid":"` + objectId + `"}`))
+	request, _ := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/"+subscriptionId+"/providers/Microsoft.Authorization/roleAssignments/example?clientId="+clientId, strings.NewReader(`{"properties":{"principalId":"`+objectId+`"}}`))
+	request.Header.Set("Authorization", "Bearer header."+claims+".signature")
+	if _, err := recorder.RoundTrip(request); err != nil {
+		t.Fatalf("recording the request: %+v", err)
+	}
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("stopping the Recorder: %+v", err)
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading the cassette: %+v", err)
+	}
+	for _, v := range []string{subscriptionId, tenantId, clientId, objectId, strings.ToUpper(objectId)} {
+		if strings.Contains(string(contents), v) {
+			t.Fatalf("expected the cassette not to contain %q but got:\n%s", v, string(contents))
+		}
+	}
+	for _, v := range []string{RecordedSubscriptionId, RecordedTenantId, RecordedClientId, RecordedObjectId} {
+		if !strings.Contains(string(contents), v) {
+			t.Fatalf("expected the cassette to contain %q but got:\n%s", v, string(contents))
+		}
+	}
+}
+
+func TestRecorder_ReplayMatchesRequestBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TestAccExample_basic.json")
+	cassette := `{
+  "variables": {},
+  "interactions": [
+    {
+      "method": "PUT",
+      "url": "https://management.azure.com/example",
+      "request_body": "{\"name\":\"first\"}",
+      "status_code": 200,
+      "response_body": "first"
+    },
+    {
+      "method": "PUT",
+      "url": "https://management.azure.com/example",
+      "request_body": "{\"name\":\"second\"}",
+      "status_code": 200,
+      "response_body": "second"
+    }
+  ]
+}`
+	if err := os.WriteFile(path, []byte(cassette), 0o644); err != nil {
+		t.Fatalf("writing the cassette: %+v", err)
+	}
+
+	replayer, err := NewRecorder(ModeReplay, path)
+	if err != nil {
+		t.Fatalf("building the Recorder: %+v", err)
+	}
+
+	// the interaction with the same request body is replayed, regardless of the order these were recorded in - and
+	// otherwise the interactions are replayed in order
+	for _, v := range []struct {
+		body     string
+		expected string
+	}{
+		{body: `{"name": "second"}`, expected: "second"},
+		{body: `{"name": "changed"}`, expected: "first"},
+		{body: `{"name": "second"}`, expected: "second"},
+	} {
+		request, _ := http.NewRequest(http.MethodPut, "https://management.azure.com/example", strings.NewReader(v.body))
+		response, err := replayer.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("replaying the request: %+v", err)
+		}
+		if body, _ := io.ReadAll(response.Body); string(body) != v.expected {
+			t.Fatalf("expected the replayed body for %q to be %q but got %q", v.body, v.expected, string(body))
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recorder"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// EnvTestCassetteDirectory is the directory containing the cassettes used when recording or replaying the tests,
+// relative to the directory containing the tests - defaults to `testdata/recordings`
+const EnvTestCassetteDirectory = "ARM_TEST_CASSETTE_DIR"
+
+var (
+	cassetteNameRegex = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+	// testRecorders holds the Recorder for each test, since BuildTestData can be called multiple times within a test
+	testRecorders     = map[string]*testRecorder{}
+	testRecordersLock = &sync.Mutex{}
+)
+
+type testRecorder struct {
+	recorder *recorder.Recorder
+
+	// transport sends the requests made by the clients using the Recorder
+	transport *common.TransportServer
+
+	// testDataCount is the number of times BuildTestData has been called within the test
+	testDataCount int
+}
+
+// buildOptions returns the options used to build the clients, such that the requests are recorded (or replayed)
+func (r *testRecorder) buildOptions() clients.BuildOptions {
+	options := clients.BuildOptions{
+		Transport: r.transport,
+	}
+	if r.recorder.Mode() == recorder.ModeReplay {
+		options.Authorizer = r.recorder.Authorizer()
+		options.ObjectId = recorder.RecordedObjectId
+	}
+	return options
+}
+
+// useRecorder configures the TestData to record to (or replay from) the cassette for this test, when
+// the `ARM_TEST_RECORD` environment variable is set - pinning any random values within the cassette.
+func (td *TestData) useRecorder(t *testing.T) {
+	mode, err := recorder.ModeFromEnvironment()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode == "" {
+		return
+	}
+
+	recording, index := recorderForTest(t, mode)
+	td.recording = recording
+
+	if mode == recorder.ModeReplay {
+		// the credentials are not used when replaying, however they're required to configure the provider
+		t.Setenv("ARM_CLIENT_ID", recorder.RecordedClientId)
+		t.Setenv("ARM_CLIENT_SECRET", "replayed")
+		t.Setenv("ARM_SUBSCRIPTION_ID", recorder.RecordedSubscriptionId)
+		t.Setenv("ARM_TENANT_ID", recorder.RecordedTenantId)
+		td.Subscriptions = Subscriptions{
+			Primary:   recorder.RecordedSubscriptionId,
+			Secondary: recorder.RecordedSubscriptionId,
+		}
+	}
+
+	// each TestData within a test requires its own set of variables
+	variable := func(name string, value string) string {
+		if index > 0 {
+			name = fmt.Sprintf("%s_%d", name, index)
+		}
+
+		v, err := recording.recorder.Variable(name, func() string { return value })
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	randomInteger := variable("random_integer", strconv.Itoa(td.RandomInteger))
+	if td.RandomInteger, err = strconv.Atoi(randomInteger); err != nil {
+		t.Fatalf("parsing the recorded variable `random_integer`: %+v", err)
+	}
+	td.RandomString = variable("random_string", td.RandomString)
+	td.Locations = Regions{
+		Primary:   variable("location_primary", td.Locations.Primary),
+		Secondary: variable("location_secondary", td.Locations.Secondary),
+		Ternary:   variable("location_ternary", td.Locations.Ternary),
+	}
+
+	if mode == recorder.ModeReplay {
+		// the locations are validated as being set prior to running the tests
+		t.Setenv("ARM_TEST_LOCATION", td.Locations.Primary)
+		t.Setenv("ARM_TEST_LOCATION_ALT", td.Locations.Secondary)
+		t.Setenv("ARM_TEST_LOCATION_ALT2", td.Locations.Ternary)
+	}
+}
+
+func recorderForTest(t *testing.T, mode recorder.Mode) (*testRecorder, int) {
+	testRecordersLock.Lock()
+	defer testRecordersLock.Unlock()
+
+	if v, ok := testRecorders[t.Name()]; ok {
+		v.testDataCount++
+		return v, v.testDataCount - 1
+	}
+
+	directory := os.Getenv(EnvTestCassetteDirectory)
+	if directory == "" {
+		directory = filepath.Join("testdata", "recordings")
+	}
+	path := filepath.Join(directory, fmt.Sprintf("%s.json", cassetteNameRegex.ReplaceAllString(t.Name(), "_")))
+
+	rec, err := recorder.NewRecorder(mode, path)
+	if err != nil {
+		t.Fatalf("building the Recorder for %q: %+v", t.Name(), err)
+	}
+	if mode == recorder.ModeRecord {
+		// the IDs within the access token are also replaced, however these may be used prior to a request being sent
+		rec.ReplaceValue(os.Getenv("ARM_SUBSCRIPTION_ID"), recorder.RecordedSubscriptionId)
+		rec.ReplaceValue(os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"), recorder.RecordedSubscriptionId)
+		rec.ReplaceValue(os.Getenv("ARM_TENANT_ID"), recorder.RecordedTenantId)
+		rec.ReplaceValue(os.Getenv("ARM_CLIENT_ID"), recorder.RecordedClientId)
+	}
+
+	transport, err := common.NewTransportServer(rec)
+	if err != nil {
+		t.Fatalf("building the Transport for %q: %+v", t.Name(), err)
+	}
+
+	recording := &testRecorder{
+		recorder:      rec,
+		transport:     transport,
+		testDataCount: 1,
+	}
+	testRecorders[t.Name()] = recording
+
+	t.Cleanup(func() {
+		testclient.UseBuildOptions(nil)
+
+		testRecordersLock.Lock()
+		delete(testRecorders, t.Name())
+		testRecordersLock.Unlock()
+
+		if err := transport.Close(); err != nil {
+			t.Errorf("stopping the Transport for %q: %+v", t.Name(), err)
+		}
+		if err := rec.Stop(); err != nil {
+			t.Errorf("stopping the Recorder for %q: %+v", t.Name(), err)
+		}
+	})
+
+	return recording, 0
+}
+
+// withRecorder configures the provider to record to (or replay from) the Recorder for this test
+func (td TestData) withRecorder(provider *schema.Provider) *schema.Provider {
+	if td.recording == nil {
+		return provider
+	}
+
+	options := td.recording.buildOptions()
+	configure := provider.ConfigureContextFunc
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configure(clients.ContextWithBuildOptions(ctx, options), d)
+	}
+	return provider
+}
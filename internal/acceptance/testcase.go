@@ -4,16 +4,20 @@
 package acceptance
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/types"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
 )
 
 func (td TestData) DataSourceTest(t *testing.T, steps []TestStep) {
@@ -41,7 +45,40 @@ func (td TestData) DataSourceTestInSequence(t *testing.T, steps []TestStep) {
 	td.runAcceptanceSequentialTest(t, testCase)
 }
 
+func (td TestData) EphemeralResourceTest(t *testing.T, steps []TestStep) {
+	// Ephemeral Resources are only exposed via the Framework Provider and aren't persisted to the state,
+	// as such there's nothing to check destroy - and the values must be asserted using a `postcondition`
+
+	//lintignore:AT001
+	testCase := resource.TestCase{
+		PreCheck: func() { PreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.10.0"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		ExternalProviders:        td.externalProviders(),
+		Steps:                    steps,
+	}
+
+	resource.ParallelTest(t, testCase)
+}
+
 func (td TestData) ResourceTest(t *testing.T, testResource types.TestResource, steps []TestStep) {
+	testCase := td.resourceTestCase(t, testResource, steps)
+	td.runAcceptanceTest(t, testCase)
+}
+
+// ResourceTestWriteOnly runs the same as ResourceTest, but is skipped when using a version of Terraform which
+// doesn't support Write-Only Attributes (which are supported in Terraform 1.11 and later)
+func (td TestData) ResourceTestWriteOnly(t *testing.T, testResource types.TestResource, steps []TestStep) {
+	testCase := td.resourceTestCase(t, testResource, steps)
+	testCase.TerraformVersionChecks = []tfversion.TerraformVersionCheck{
+		tfversion.SkipBelow(version.Must(version.NewVersion("1.11.0"))),
+	}
+	td.runAcceptanceTest(t, testCase)
+}
+
+func (td TestData) resourceTestCase(t *testing.T, testResource types.TestResource, steps []TestStep) resource.TestCase {
 	// Testing framework as of 1.6.0 no longer auto-refreshes state, so adding it back in here for all steps that update
 	// the config rather than having to modify 1000's of tests individually to add a refresh-only step
 	var refreshStep = TestStep{
@@ -59,7 +96,7 @@ func (td TestData) ResourceTest(t *testing.T, testResource types.TestResource, s
 	}
 	steps = newSteps
 
-	testCase := resource.TestCase{
+	return resource.TestCase{
 		PreCheck: func() { PreCheck(t) },
 		CheckDestroy: func(s *terraform.State) error {
 			client, err := testclient.Build()
@@ -70,7 +107,6 @@ func (td TestData) ResourceTest(t *testing.T, testResource types.TestResource, s
 		},
 		Steps: steps,
 	}
-	td.runAcceptanceTest(t, testCase)
 }
 
 // ResourceTestIgnoreCheckDestroyed skips the check to confirm the resource test has been destroyed.
@@ -127,6 +163,14 @@ func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ExternalProviders = td.externalProviders()
 	testCase.ProviderFactories = td.providers()
 
+	// tests which are recorded or replayed are run sequentially, since the test clients are shared
+	if td.recording != nil {
+		options := td.recording.buildOptions()
+		testclient.UseBuildOptions(&options)
+		resource.Test(t, testCase)
+		return
+	}
+
 	resource.ParallelTest(t, testCase)
 }
 
@@ -134,17 +178,22 @@ func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.T
 	testCase.ExternalProviders = td.externalProviders()
 	testCase.ProviderFactories = td.providers()
 
+	if td.recording != nil {
+		options := td.recording.buildOptions()
+		testclient.UseBuildOptions(&options)
+	}
+
 	resource.Test(t, testCase)
 }
 
 func (td TestData) providers() map[string]func() (*schema.Provider, error) {
 	return map[string]func() (*schema.Provider, error){
 		"azurerm": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
+			azurerm := td.withRecorder(provider.TestAzureProvider())
 			return azurerm, nil
 		},
 		"azurerm-alt": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
+			azurerm := td.withRecorder(provider.TestAzureProvider())
 			return azurerm, nil
 		},
 	}
@@ -18,67 +18,100 @@ import (
 var (
 	_client    *clients.Client
 	clientLock = &sync.Mutex{}
+
+	// _buildOptions are the options used to build the client for the current test (for example when the test is
+	// being recorded or replayed), if any
+	_buildOptions       *clients.BuildOptions
+	_buildOptionsClient *clients.Client
 )
 
-func Build() (*clients.Client, error) {
+// UseBuildOptions configures the clients returned from Build to be built using the BuildOptions, which are used for
+// the current test - since tests which are recorded or replayed are run sequentially
+func UseBuildOptions(options *clients.BuildOptions) {
 	clientLock.Lock()
 	defer clientLock.Unlock()
 
-	if _client == nil {
-		var (
-			ctx = context.TODO()
-
-			env *environments.Environment
-			err error
-
-			metadataHost = os.Getenv("ARM_METADATA_HOSTNAME")
-		)
+	_buildOptions = options
+	_buildOptionsClient = nil
+}
 
-		envName, exists := os.LookupEnv("ARM_ENVIRONMENT")
-		if !exists {
-			envName = "public"
-		}
+func Build() (*clients.Client, error) {
+	clientLock.Lock()
+	defer clientLock.Unlock()
 
-		if metadataHost != "" {
-			if env, err = environments.FromEndpoint(ctx, fmt.Sprintf("https://%s", metadataHost)); err != nil {
-				return nil, fmt.Errorf("building test client: %+v", err)
+	if _buildOptions != nil {
+		if _buildOptionsClient == nil {
+			client, err := build(clients.ContextWithBuildOptions(context.TODO(), *_buildOptions))
+			if err != nil {
+				return nil, err
 			}
-		} else if env, err = environments.FromName(envName); err != nil {
-			return nil, fmt.Errorf("building test client: %+v", err)
+			_buildOptionsClient = client
 		}
 
-		authConfig := auth.Credentials{
-			Environment: *env,
-			ClientID:    os.Getenv("ARM_CLIENT_ID"),
-			TenantID:    os.Getenv("ARM_TENANT_ID"),
-
-			ClientCertificatePath:     os.Getenv("ARM_CLIENT_CERTIFICATE_PATH"),
-			ClientCertificatePassword: os.Getenv("ARM_CLIENT_CERTIFICATE_PASSWORD"),
-			ClientSecret:              os.Getenv("ARM_CLIENT_SECRET"),
-
-			EnableAuthenticatingUsingClientCertificate: true,
-			EnableAuthenticatingUsingClientSecret:      true,
-			EnableAuthenticatingUsingAzureCLI:          false,
-			EnableAuthenticatingUsingManagedIdentity:   false,
-			EnableAuthenticationUsingOIDC:              false,
-			EnableAuthenticationUsingGitHubOIDC:        false,
-		}
+		return _buildOptionsClient, nil
+	}
 
-		clientBuilder := clients.ClientBuilder{
-			AuthConfig:        &authConfig,
-			TerraformVersion:  os.Getenv("TERRAFORM_CORE_VERSION"),
-			Features:          features.Default(),
-			StorageUseAzureAD: false,
-			SubscriptionID:    os.Getenv("ARM_SUBSCRIPTION_ID"),
+	if _client == nil {
+		client, err := build(context.TODO())
+		if err != nil {
+			return nil, err
 		}
+		_client = client
+	}
 
-		client, err := clients.Build(ctx, clientBuilder)
-		if err != nil {
+	return _client, nil
+}
+
+func build(ctx context.Context) (*clients.Client, error) {
+	var (
+		env *environments.Environment
+		err error
+
+		metadataHost = os.Getenv("ARM_METADATA_HOSTNAME")
+	)
+
+	envName, exists := os.LookupEnv("ARM_ENVIRONMENT")
+	if !exists {
+		envName = "public"
+	}
+
+	if metadataHost != "" {
+		if env, err = environments.FromEndpoint(ctx, fmt.Sprintf("https://%s", metadataHost)); err != nil {
 			return nil, fmt.Errorf("building test client: %+v", err)
 		}
+	} else if env, err = environments.FromName(envName); err != nil {
+		return nil, fmt.Errorf("building test client: %+v", err)
+	}
 
-		_client = client
+	authConfig := auth.Credentials{
+		Environment: *env,
+		ClientID:    os.Getenv("ARM_CLIENT_ID"),
+		TenantID:    os.Getenv("ARM_TENANT_ID"),
+
+		ClientCertificatePath:     os.Getenv("ARM_CLIENT_CERTIFICATE_PATH"),
+		ClientCertificatePassword: os.Getenv("ARM_CLIENT_CERTIFICATE_PASSWORD"),
+		ClientSecret:              os.Getenv("ARM_CLIENT_SECRET"),
+
+		EnableAuthenticatingUsingClientCertificate: true,
+		EnableAuthenticatingUsingClientSecret:      true,
+		EnableAuthenticatingUsingAzureCLI:          false,
+		EnableAuthenticatingUsingManagedIdentity:   false,
+		EnableAuthenticationUsingOIDC:              false,
+		EnableAuthenticationUsingGitHubOIDC:        false,
 	}
 
-	return _client, nil
+	clientBuilder := clients.ClientBuilder{
+		AuthConfig:        &authConfig,
+		TerraformVersion:  os.Getenv("TERRAFORM_CORE_VERSION"),
+		Features:          features.Default(),
+		StorageUseAzureAD: false,
+		SubscriptionID:    os.Getenv("ARM_SUBSCRIPTION_ID"),
+	}
+
+	client, err := clients.Build(ctx, clientBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("building test client: %+v", err)
+	}
+
+	return client, nil
 }
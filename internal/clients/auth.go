@@ -14,10 +14,161 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/claims"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients/graph"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 )
 
+// ClientCertificateProvider configures where the Client Certificate used to authenticate as a Service Principal is
+// held, when the private key can't be exported - only one of KeyVault or PKCS11 should be specified
+type ClientCertificateProvider struct {
+	KeyVault *KeyVaultCertificateProvider
+	PKCS11   *PKCS11CertificateProvider
+}
+
+// KeyVaultCertificateProvider signs the Client Assertion using the key for a Certificate within Key Vault
+type KeyVaultCertificateProvider struct {
+	// CertificateId is the (optionally versioned) ID of the Certificate within Key Vault
+	CertificateId string
+
+	// BootstrapClientId is the Client ID of the identity used to access Key Vault, see bootstrapCredentials
+	BootstrapClientId string
+}
+
+// PKCS11CertificateProvider signs the Client Assertion using a private key held within a PKCS#11 token
+type PKCS11CertificateProvider struct {
+	ModulePath string
+	SlotId     int64
+
+	// KeyLabel is the label of both the private key and the certificate within the token
+	KeyLabel string
+	Pin      string
+}
+
+// ClientSecretProvider configures where the Client Secret used to authenticate as a Service Principal is held
+type ClientSecretProvider struct {
+	KeyVault *KeyVaultSecretProvider
+}
+
+// KeyVaultSecretProvider retrieves the Client Secret from a Secret within Key Vault
+type KeyVaultSecretProvider struct {
+	// SecretId is the (optionally versioned) ID of the Secret within Key Vault
+	SecretId string
+
+	// BootstrapClientId is the Client ID of the identity used to access Key Vault, see bootstrapCredentials
+	BootstrapClientId string
+}
+
+// ConfigureCredentialProviders resolves the Client Secret from the specified provider into the Credentials, or
+// returns a function which builds the Authorizer for an API when using a Client Certificate provider.
+//
+// Since the private key for the Client Certificate can't be retrieved, a Client Assertion is signed by the provider
+// and is then used to authenticate as the Service Principal, in the same way as an OIDC token. Since a Client
+// Assertion is only valid for clientAssertionLifetime, the Authorizer signs a new one each time a token is needed.
+func ConfigureCredentialProviders(ctx context.Context, config *auth.Credentials, certificateProvider *ClientCertificateProvider, secretProvider *ClientSecretProvider) (common.ApiAuthorizerFunc, error) {
+	if certificateProvider == nil && secretProvider == nil {
+		return nil, nil
+	}
+
+	if strings.TrimSpace(config.TenantID) == "" || strings.TrimSpace(config.ClientID) == "" {
+		return nil, fmt.Errorf("`tenant_id` and `client_id` must be specified when using `client_certificate_provider` or `client_secret_provider`")
+	}
+	if certificateProvider != nil && secretProvider != nil {
+		return nil, fmt.Errorf("only one of `client_certificate_provider` or `client_secret_provider` can be specified")
+	}
+
+	if secretProvider != nil {
+		if config.ClientSecret != "" {
+			return nil, fmt.Errorf("`client_secret_provider` cannot be specified when a Client Secret is also specified")
+		}
+		if secretProvider.KeyVault == nil {
+			return nil, fmt.Errorf("a `key_vault` block must be specified within `client_secret_provider`")
+		}
+
+		secretId, err := parse.ParseOptionallyVersionedNestedItemID(secretProvider.KeyVault.SecretId)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the `secret_id` within `client_secret_provider`: %+v", err)
+		}
+
+		authorizer, err := auth.NewAuthorizerFromCredentials(ctx, bootstrapCredentials(*config, secretProvider.KeyVault.BootstrapClientId), config.Environment.KeyVault)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build the bootstrap authorizer for Key Vault: %+v", err)
+		}
+
+		log.Printf("[DEBUG] Retrieving the Client Secret from %s", *secretId)
+		clientSecret, err := keyVaultSecretValue(ctx, authorizer, *secretId)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving the Client Secret: %+v", err)
+		}
+
+		config.ClientSecret = *clientSecret
+		config.EnableAuthenticatingUsingClientSecret = true
+		return nil, nil
+	}
+
+	if len(config.ClientCertificateData) > 0 || config.ClientCertificatePath != "" || config.ClientSecret != "" || config.OIDCAssertionToken != "" {
+		return nil, fmt.Errorf("`client_certificate_provider` cannot be specified when a Client Certificate, Client Secret or OIDC Token is also specified")
+	}
+
+	// the audience for the Client Assertion is the token endpoint for the primary tenant, so it can't be used to
+	// obtain tokens for the auxiliary tenants
+	if len(config.AuxiliaryTenantIDs) > 0 {
+		return nil, fmt.Errorf("`auxiliary_tenant_ids` cannot be specified when using `client_certificate_provider`")
+	}
+
+	var signer clientAssertionSigner
+	switch {
+	case certificateProvider.KeyVault != nil:
+		certificateId, err := parse.ParseOptionallyVersionedNestedItemID(certificateProvider.KeyVault.CertificateId)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the `certificate_id` within `client_certificate_provider`: %+v", err)
+		}
+
+		authorizer, err := auth.NewAuthorizerFromCredentials(ctx, bootstrapCredentials(*config, certificateProvider.KeyVault.BootstrapClientId), config.Environment.KeyVault)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build the bootstrap authorizer for Key Vault: %+v", err)
+		}
+
+		log.Printf("[DEBUG] Signing the Client Assertion using %s", *certificateId)
+		signer = newKeyVaultClientAssertionSigner(authorizer, *certificateId)
+
+	case certificateProvider.PKCS11 != nil:
+		log.Printf("[DEBUG] Signing the Client Assertion using the PKCS#11 module %q", certificateProvider.PKCS11.ModulePath)
+		signer = pkcs11ClientAssertionSigner{
+			modulePath: certificateProvider.PKCS11.ModulePath,
+			slotId:     certificateProvider.PKCS11.SlotId,
+			keyLabel:   certificateProvider.PKCS11.KeyLabel,
+			pin:        certificateProvider.PKCS11.Pin,
+		}
+
+	default:
+		return nil, fmt.Errorf("one of the `key_vault` or `pkcs11` blocks must be specified within `client_certificate_provider`")
+	}
+
+	credentials := *config
+	return func(api environments.Api) (auth.Authorizer, error) {
+		return newClientAssertionAuthorizer(signer, credentials, api)
+	}, nil
+}
+
+// bootstrapCredentials returns the Credentials for the identity used to access Key Vault, which authenticates using
+// the Managed Identity, OIDC and Azure CLI settings for the Provider - optionally using a different Client ID (for
+// example for a User Assigned Identity)
+func bootstrapCredentials(config auth.Credentials, clientId string) auth.Credentials {
+	config.ClientID = clientId
+	config.AuxiliaryTenantIDs = nil
+
+	config.ClientCertificateData = nil
+	config.ClientCertificatePath = ""
+	config.ClientCertificatePassword = ""
+	config.ClientSecret = ""
+	config.EnableAuthenticatingUsingClientCertificate = false
+	config.EnableAuthenticatingUsingClientSecret = false
+
+	return config
+}
+
 type ResourceManagerAccount struct {
 	Environment environments.Environment
 
@@ -30,8 +181,10 @@ type ResourceManagerAccount struct {
 	RegisteredResourceProviders      resourceproviders.ResourceProviders
 }
 
-func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, subscriptionId string, registeredResourceProviders resourceproviders.ResourceProviders) (*ResourceManagerAccount, error) {
-	authorizer, err := auth.NewAuthorizerFromCredentials(ctx, config, config.Environment.MicrosoftGraph)
+// NewResourceManagerAccount returns the ResourceManagerAccount for the authenticated principal, where newAuthorizer
+// builds the Authorizer for the specified API
+func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, newAuthorizer common.ApiAuthorizerFunc, subscriptionId string, registeredResourceProviders resourceproviders.ResourceProviders) (*ResourceManagerAccount, error) {
+	authorizer, err := newAuthorizer(config.Environment.MicrosoftGraph)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Microsoft Graph API: %+v", err)
 	}
@@ -120,6 +273,31 @@ func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, sub
 		}
 	}
 
+	// Likewise defer to the Azure Developer CLI or Azure PowerShell when used to authenticate
+	if tool, ok := realAuthorizer.(developerToolAuthorizer); ok {
+		// Use the tenant ID from the developer tool when otherwise unknown
+		if tenantId == "" {
+			if tool.signedInTenantId() == "" {
+				return nil, fmt.Errorf("%s could not determine tenant ID to use", tool.toolName())
+			}
+			tenantId = tool.signedInTenantId()
+			log.Printf("[DEBUG] Using tenant ID from %s: %q", tool.toolName(), tenantId)
+		}
+
+		// TODO: remove this in v4.0
+		if !features.FourPointOhBeta() {
+			// Use the default subscription ID from the developer tool when otherwise unknown
+			if subscriptionId == "" {
+				if tool.defaultSubscriptionId() == "" {
+					return nil, fmt.Errorf("%s could not determine subscription ID to use and no subscription was specified", tool.toolName())
+				}
+
+				subscriptionId = tool.defaultSubscriptionId()
+				log.Printf("[DEBUG] Using default subscription ID from %s: %q", tool.toolName(), subscriptionId)
+			}
+		}
+	}
+
 	// We'll permit the provider to proceed with an unknown client ID since it only affects a small number of use cases when authenticating as a user
 	if tenantId == "" {
 		return nil, fmt.Errorf("unable to configure ResourceManagerAccount: tenant ID could not be determined and was not specified")
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"golang.org/x/oauth2"
+)
+
+const azureDeveloperCliCommand = "azd"
+
+type AzureDeveloperCliAuthorizerOptions struct {
+	// Api describes the Azure API being used
+	Api environments.Api
+
+	// TenantId is the tenant to authenticate against
+	TenantId string
+
+	// AuxTenantIds lists additional tenants to authenticate against
+	AuxTenantIds []string
+}
+
+// NewAzureDeveloperCliAuthorizer returns an Authorizer which authenticates using the Azure Developer CLI, or nil if
+// the Azure Developer CLI isn't installed.
+func NewAzureDeveloperCliAuthorizer(ctx context.Context, options AzureDeveloperCliAuthorizerOptions) (auth.Authorizer, error) {
+	path, err := exec.LookPath(azureDeveloperCliCommand)
+	if err != nil {
+		return nil, nil
+	}
+
+	authorizer := &AzureDeveloperCliAuthorizer{
+		TenantID:     options.TenantId,
+		api:          options.Api,
+		auxTenantIds: options.AuxTenantIds,
+		path:         path,
+	}
+
+	if authorizer.TenantID == "" {
+		// the Azure Developer CLI doesn't expose the signed in tenant, so this is determined from an access token
+		token, err := authorizer.token(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("obtaining an access token to determine the tenant ID: %+v", err)
+		}
+		if authorizer.TenantID, err = tenantIdFromAccessToken(token.AccessToken); err != nil {
+			return nil, err
+		}
+	}
+
+	// `azd config get` returns an error when the value isn't set, in which case there's no default subscription
+	if out, err := runDeveloperTool(ctx, path, "config", "get", "defaults.subscription"); err == nil {
+		var subscriptionId string
+		if err := json.Unmarshal(out, &subscriptionId); err != nil {
+			return nil, fmt.Errorf("parsing the default subscription for the Azure Developer CLI: %+v", err)
+		}
+		authorizer.DefaultSubscriptionID = subscriptionId
+	}
+
+	return auth.NewCachedAuthorizer(authorizer)
+}
+
+var _ developerToolAuthorizer = &AzureDeveloperCliAuthorizer{}
+
+// AzureDeveloperCliAuthorizer is an Authorizer which supports the Azure Developer CLI.
+type AzureDeveloperCliAuthorizer struct {
+	// TenantID is the specified tenant ID, or the auto-detected tenant ID if none was specified
+	TenantID string
+
+	// DefaultSubscriptionID is the default subscription, when detected
+	DefaultSubscriptionID string
+
+	api          environments.Api
+	auxTenantIds []string
+	path         string
+}
+
+type azureDeveloperCliToken struct {
+	Token     string `json:"token"`
+	ExpiresOn string `json:"expiresOn"`
+}
+
+// Token returns an access token using the Azure Developer CLI as an authentication mechanism.
+func (a *AzureDeveloperCliAuthorizer) Token(ctx context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return a.token(ctx, a.TenantID)
+}
+
+// AuxiliaryTokens returns additional tokens for auxiliary tenant IDs, for use in multi-tenant scenarios
+func (a *AzureDeveloperCliAuthorizer) AuxiliaryTokens(ctx context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	tokens := make([]*oauth2.Token, 0, len(a.auxTenantIds))
+	for _, tenantId := range a.auxTenantIds {
+		token, err := a.token(ctx, tenantId)
+		if err != nil {
+			return nil, fmt.Errorf("obtaining an access token for the auxiliary tenant %q: %+v", tenantId, err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (a *AzureDeveloperCliAuthorizer) token(ctx context.Context, tenantId string) (*oauth2.Token, error) {
+	scope, err := environments.Scope(a.api)
+	if err != nil {
+		return nil, fmt.Errorf("determining scope for %q: %+v", a.api.Name(), err)
+	}
+
+	args := []string{"auth", "token", "--output", "json", "--scope", *scope}
+	if tenantId != "" {
+		args = append(args, "--tenant-id", tenantId)
+	}
+
+	out, err := runDeveloperTool(ctx, a.path, args...)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining an access token from the Azure Developer CLI (run `azd auth login` to sign in): %+v", err)
+	}
+
+	var token azureDeveloperCliToken
+	if err := json.Unmarshal(out, &token); err != nil {
+		return nil, fmt.Errorf("parsing the access token from the Azure Developer CLI: %+v", err)
+	}
+	if strings.TrimSpace(token.Token) == "" {
+		return nil, fmt.Errorf("the Azure Developer CLI returned an empty access token")
+	}
+
+	var expiry time.Time
+	if token.ExpiresOn != "" {
+		if expiry, err = time.Parse(time.RFC3339, token.ExpiresOn); err != nil {
+			return nil, fmt.Errorf("parsing the expiry %q for the access token from the Azure Developer CLI: %+v", token.ExpiresOn, err)
+		}
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.Token,
+		Expiry:      expiry,
+		TokenType:   "Bearer",
+	}, nil
+}
+
+func (a *AzureDeveloperCliAuthorizer) toolName() string {
+	return "Azure Developer CLI"
+}
+
+func (a *AzureDeveloperCliAuthorizer) signedInTenantId() string {
+	return a.TenantID
+}
+
+func (a *AzureDeveloperCliAuthorizer) defaultSubscriptionId() string {
+	return a.DefaultSubscriptionID
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"golang.org/x/oauth2"
+)
+
+// azurePowerShellCommands are the PowerShell executables to look for, in order of preference
+var azurePowerShellCommands = []string{"pwsh", "powershell"}
+
+// azurePowerShellContextScript outputs the tenant and subscription for the current Azure PowerShell context
+const azurePowerShellContextScript = `$ErrorActionPreference = 'Stop'
+$context = Get-AzContext
+if ($null -eq $context) {
+  throw 'no Azure PowerShell context was found'
+}
+[pscustomobject]@{ tenantId = $context.Tenant.Id; subscriptionId = $context.Subscription.Id } | ConvertTo-Json -Compress`
+
+type AzurePowerShellAuthorizerOptions struct {
+	// Api describes the Azure API being used
+	Api environments.Api
+
+	// TenantId is the tenant to authenticate against
+	TenantId string
+
+	// AuxTenantIds lists additional tenants to authenticate against
+	AuxTenantIds []string
+}
+
+// NewAzurePowerShellAuthorizer returns an Authorizer which authenticates using Azure PowerShell, or nil if PowerShell
+// isn't installed.
+func NewAzurePowerShellAuthorizer(ctx context.Context, options AzurePowerShellAuthorizerOptions) (auth.Authorizer, error) {
+	path := ""
+	for _, command := range azurePowerShellCommands {
+		if v, err := exec.LookPath(command); err == nil {
+			path = v
+			break
+		}
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	authorizer := &AzurePowerShellAuthorizer{
+		TenantID:     options.TenantId,
+		api:          options.Api,
+		auxTenantIds: options.AuxTenantIds,
+		path:         path,
+	}
+
+	out, err := authorizer.run(ctx, azurePowerShellContextScript)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining the Azure PowerShell context (run `Connect-AzAccount` to sign in): %+v", err)
+	}
+
+	var azContext azurePowerShellContext
+	if err := json.Unmarshal(out, &azContext); err != nil {
+		return nil, fmt.Errorf("parsing the Azure PowerShell context: %+v", err)
+	}
+
+	if authorizer.TenantID == "" {
+		authorizer.TenantID = azContext.TenantId
+	}
+	authorizer.DefaultSubscriptionID = azContext.SubscriptionId
+
+	return auth.NewCachedAuthorizer(authorizer)
+}
+
+var _ developerToolAuthorizer = &AzurePowerShellAuthorizer{}
+
+// AzurePowerShellAuthorizer is an Authorizer which supports Azure PowerShell.
+type AzurePowerShellAuthorizer struct {
+	// TenantID is the specified tenant ID, or the auto-detected tenant ID if none was specified
+	TenantID string
+
+	// DefaultSubscriptionID is the subscription for the current Azure PowerShell context, when detected
+	DefaultSubscriptionID string
+
+	api          environments.Api
+	auxTenantIds []string
+	path         string
+}
+
+type azurePowerShellContext struct {
+	TenantId       string `json:"tenantId"`
+	SubscriptionId string `json:"subscriptionId"`
+}
+
+type azurePowerShellToken struct {
+	Token     string `json:"token"`
+	ExpiresOn int64  `json:"expiresOn"`
+}
+
+// Token returns an access token using Azure PowerShell as an authentication mechanism.
+func (a *AzurePowerShellAuthorizer) Token(ctx context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return a.token(ctx, a.TenantID)
+}
+
+// AuxiliaryTokens returns additional tokens for auxiliary tenant IDs, for use in multi-tenant scenarios
+func (a *AzurePowerShellAuthorizer) AuxiliaryTokens(ctx context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	tokens := make([]*oauth2.Token, 0, len(a.auxTenantIds))
+	for _, tenantId := range a.auxTenantIds {
+		token, err := a.token(ctx, tenantId)
+		if err != nil {
+			return nil, fmt.Errorf("obtaining an access token for the auxiliary tenant %q: %+v", tenantId, err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (a *AzurePowerShellAuthorizer) token(ctx context.Context, tenantId string) (*oauth2.Token, error) {
+	scope, err := environments.Scope(a.api)
+	if err != nil {
+		return nil, fmt.Errorf("determining scope for %q: %+v", a.api.Name(), err)
+	}
+
+	out, err := a.run(ctx, azurePowerShellTokenScript(strings.TrimSuffix(*scope, "/.default"), tenantId))
+	if err != nil {
+		return nil, fmt.Errorf("obtaining an access token from Azure PowerShell (run `Connect-AzAccount` to sign in): %+v", err)
+	}
+
+	var token azurePowerShellToken
+	if err := json.Unmarshal(out, &token); err != nil {
+		return nil, fmt.Errorf("parsing the access token from Azure PowerShell: %+v", err)
+	}
+	if strings.TrimSpace(token.Token) == "" {
+		return nil, fmt.Errorf("Azure PowerShell returned an empty access token")
+	}
+
+	var expiry time.Time
+	if token.ExpiresOn > 0 {
+		expiry = time.Unix(token.ExpiresOn, 0)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.Token,
+		Expiry:      expiry,
+		TokenType:   "Bearer",
+	}, nil
+}
+
+// run invokes PowerShell with the specified script, and returns the output written to stdout
+func (a *AzurePowerShellAuthorizer) run(ctx context.Context, script string) ([]byte, error) {
+	return runDeveloperTool(ctx, a.path, "-NoProfile", "-NonInteractive", "-Command", script)
+}
+
+func (a *AzurePowerShellAuthorizer) toolName() string {
+	return "Azure PowerShell"
+}
+
+func (a *AzurePowerShellAuthorizer) signedInTenantId() string {
+	return a.TenantID
+}
+
+func (a *AzurePowerShellAuthorizer) defaultSubscriptionId() string {
+	return a.DefaultSubscriptionID
+}
+
+// azurePowerShellTokenScript returns a script which outputs an access token for the specified resource, handling
+// newer versions of Az.Accounts which return the token as a SecureString
+func azurePowerShellTokenScript(resource, tenantId string) string {
+	command := fmt.Sprintf("Get-AzAccessToken -ResourceUrl %s -WarningAction SilentlyContinue", powerShellQuote(resource))
+	if tenantId != "" {
+		command += fmt.Sprintf(" -TenantId %s", powerShellQuote(tenantId))
+	}
+
+	return fmt.Sprintf(`$ErrorActionPreference = 'Stop'
+$token = %s
+$value = $token.Token
+if ($value -is [System.Security.SecureString]) {
+  $value = [System.Net.NetworkCredential]::new('', $value).Password
+}
+[pscustomobject]@{ token = $value; expiresOn = $token.ExpiresOn.ToUnixTimeSeconds() } | ConvertTo-Json -Compress`, command)
+}
+
+// powerShellQuote returns the input as a single-quoted PowerShell string
+func powerShellQuote(input string) string {
+	return "'" + strings.ReplaceAll(input, "'", "''") + "'"
+}
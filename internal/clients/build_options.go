@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// BuildOptions override how the Client is built, which are used by the Acceptance Tests (for example to record or
+// replay the requests made to Azure) - since the Client is built when the Provider is configured, these are passed
+// to Build within the context.
+type BuildOptions struct {
+	// Transport sends the requests made by all clients, rather than these being sent directly to Azure
+	Transport *common.TransportServer
+
+	// Authorizer is used for all APIs when set, rather than authenticating using the AuthConfig
+	Authorizer auth.Authorizer
+
+	// ObjectId is the Object ID of the authenticated principal, which is used when Authorizer is set - since this
+	// can't be determined from the access token
+	ObjectId string
+}
+
+type buildOptionsContextKey struct{}
+
+// ContextWithBuildOptions returns a copy of `ctx` containing the BuildOptions, which are used by Build
+func ContextWithBuildOptions(ctx context.Context, options BuildOptions) context.Context {
+	return context.WithValue(ctx, buildOptionsContextKey{}, options)
+}
+
+func buildOptionsFromContext(ctx context.Context) BuildOptions {
+	if v, ok := ctx.Value(buildOptionsContextKey{}).(BuildOptions); ok {
+		return v
+	}
+	return BuildOptions{}
+}
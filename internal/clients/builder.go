@@ -17,18 +17,36 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 )
 
 type ClientBuilder struct {
 	AuthConfig *auth.Credentials
 	Features   features.UserFeatures
 
+	// ClientCertificateProvider and ClientSecretProvider are resolved into AuthConfig when the client is built
+	ClientCertificateProvider *ClientCertificateProvider
+	ClientSecretProvider      *ClientSecretProvider
+
+	// DeveloperTools configures the developer tools which can be used to obtain tokens, in addition to those supported by AuthConfig
+	DeveloperTools DeveloperToolCredentials
+
+	ARMRequestsPerSecond  float64
+	MaxConcurrentRequests int
+
 	CustomCorrelationRequestID  string
+	DefaultTags                 map[string]string
+	DefaultTimeouts             *timeouts.Defaults
 	DisableCorrelationRequestID bool
 	DisableTerraformPartnerID   bool
+	IgnoreTagKeys               []string
+	IgnoreTagKeyPrefixes        []string
 	MetadataHost                string
 	PartnerID                   string
 	RegisteredResourceProviders resourceproviders.ResourceProviders
+	RetryPolicyOverrides        common.RetryPolicyOverrides
+	RetryRules                  []common.RetryRule
 	StorageUseAzureAD           bool
 	SubscriptionID              string
 	TerraformVersion            string
@@ -51,25 +69,45 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf(azureStackEnvironmentError)
 	}
 
+	// the Acceptance Tests can specify an Authorizer (for example when replaying the requests made to Azure), in
+	// which case there's no need to authenticate
+	options := buildOptionsFromContext(ctx)
+	var clientAssertionAuthorizer common.ApiAuthorizerFunc
+	if options.Authorizer == nil {
+		if clientAssertionAuthorizer, err = ConfigureCredentialProviders(ctx, builder.AuthConfig, builder.ClientCertificateProvider, builder.ClientSecretProvider); err != nil {
+			return nil, err
+		}
+	}
+
+	newAuthorizer := func(api environments.Api) (auth.Authorizer, error) {
+		if options.Authorizer != nil {
+			return options.Authorizer, nil
+		}
+		if clientAssertionAuthorizer != nil {
+			return clientAssertionAuthorizer(api)
+		}
+		return NewAuthorizer(ctx, *builder.AuthConfig, builder.DeveloperTools, api)
+	}
+
 	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth auth.Authorizer
 
-	resourceManagerAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ResourceManager)
+	resourceManagerAuth, err = newAuthorizer(builder.AuthConfig.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Resource Manager API: %+v", err)
 	}
 
-	storageAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Storage)
+	storageAuth, err = newAuthorizer(builder.AuthConfig.Environment.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Storage API: %+v", err)
 	}
 
-	keyVaultAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.KeyVault)
+	keyVaultAuth, err = newAuthorizer(builder.AuthConfig.Environment.KeyVault)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Key Vault API: %+v", err)
 	}
 
 	if builder.AuthConfig.Environment.Synapse.Available() {
-		synapseAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Synapse)
+		synapseAuth, err = newAuthorizer(builder.AuthConfig.Environment.Synapse)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Synapse API: %+v", err)
 		}
@@ -78,7 +116,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	if builder.AuthConfig.Environment.Batch.Available() {
-		batchManagementAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Batch)
+		batchManagementAuth, err = newAuthorizer(builder.AuthConfig.Environment.Batch)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Batch Management API: %+v", err)
 		}
@@ -88,7 +126,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 
 	// Helper for obtaining endpoint-specific tokens
 	authorizerFunc := common.ApiAuthorizerFunc(func(api environments.Api) (auth.Authorizer, error) {
-		authorizer, err := auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+		authorizer, err := newAuthorizer(api)
 		if err != nil {
 			return nil, fmt.Errorf("building custom authorizer for API %q: %+v", api.Name(), err)
 		}
@@ -96,14 +134,32 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return authorizer, nil
 	})
 
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.RegisteredResourceProviders)
+	var account *ResourceManagerAccount
+	if options.Authorizer != nil {
+		account = &ResourceManagerAccount{
+			Environment:                      builder.AuthConfig.Environment,
+			ClientId:                         builder.AuthConfig.ClientID,
+			ObjectId:                         options.ObjectId,
+			SubscriptionId:                   builder.SubscriptionID,
+			TenantId:                         builder.AuthConfig.TenantID,
+			AuthenticatedAsAServicePrincipal: true,
+			RegisteredResourceProviders:      builder.RegisteredResourceProviders,
+		}
+	} else {
+		account, err = NewResourceManagerAccount(ctx, *builder.AuthConfig, newAuthorizer, builder.SubscriptionID, builder.RegisteredResourceProviders)
+		if err != nil {
+			return nil, fmt.Errorf("building account: %+v", err)
+		}
+	}
+
+	diskCache, err := resourceproviders.DiskCacheFromEnvironment(account.TenantId)
 	if err != nil {
-		return nil, fmt.Errorf("building account: %+v", err)
+		return nil, fmt.Errorf("configuring the Resource Provider cache: %+v", err)
 	}
 
 	var managedHSMAuth auth.Authorizer
 	if builder.AuthConfig.Environment.ManagedHSM.Available() {
-		managedHSMAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ManagedHSM)
+		managedHSMAuth, err = newAuthorizer(builder.AuthConfig.Environment.ManagedHSM)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Managed HSM API: %+v", err)
 		}
@@ -117,7 +173,8 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	client := Client{
-		Account: account,
+		Account:               account,
+		ResourceProviderCache: diskCache,
 	}
 
 	o := &common.ClientOptions{
@@ -153,12 +210,27 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
 
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
+		ResourceProviderCache:   diskCache,
+		RateLimiter:             common.NewRateLimiter(builder.ARMRequestsPerSecond, builder.MaxConcurrentRequests, *resourceManagerEndpoint),
+		RetryPolicy:             common.NewRetryPolicy(builder.RetryRules, builder.RetryPolicyOverrides, *resourceManagerEndpoint),
+		HTTPLogger:              common.NewHTTPLogger(ctx, "AzureRM", *resourceManagerEndpoint),
+		Transport:               options.Transport,
+	}
+
+	if err := configureLockBackend(o); err != nil {
+		return nil, fmt.Errorf("configuring the Lock Backend: %+v", err)
 	}
 
 	if err := client.Build(ctx, o); err != nil {
 		return nil, fmt.Errorf("building Client: %+v", err)
 	}
 
+	client.DefaultTimeouts = builder.DefaultTimeouts
+	client.Tags = tags.Configuration{
+		DefaultTags: builder.DefaultTags,
+		IgnoredTags: tags.NewIgnoredTags(builder.IgnoreTagKeys, builder.IgnoreTagKeyPrefixes),
+	}
+
 	if features.EnhancedValidationEnabled() {
 		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
 
@@ -166,7 +238,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		defer cancel()
 
 		location.CacheSupportedLocations(ctx2, *resourceManagerEndpoint)
-		if err := resourceproviders.CacheSupportedProviders(ctx2, client.Resource.ResourceProvidersClient, subscriptionId); err != nil {
+		if err := resourceproviders.CacheSupportedProviders(ctx2, client.Resource.ResourceProvidersClient, subscriptionId, client.ResourceProviderCache); err != nil {
 			log.Printf("[DEBUG] error retrieving providers: %s. Enhanced validation will be unavailable", err)
 		}
 	}
@@ -26,6 +26,7 @@ import (
 	workloads_v2023_04_01 "github.com/hashicorp/go-azure-sdk/resource-manager/workloads/2023-04-01"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	aadb2c "github.com/hashicorp/terraform-provider-azurerm/internal/services/aadb2c/client"
 	advisor "github.com/hashicorp/terraform-provider-azurerm/internal/services/advisor/client"
 	analysisServices "github.com/hashicorp/terraform-provider-azurerm/internal/services/analysisservices/client"
@@ -152,6 +153,8 @@ import (
 	voiceServices "github.com/hashicorp/terraform-provider-azurerm/internal/services/voiceservices/client"
 	web "github.com/hashicorp/terraform-provider-azurerm/internal/services/web/client"
 	workloads "github.com/hashicorp/terraform-provider-azurerm/internal/services/workloads/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 )
 
 type Client struct {
@@ -163,6 +166,22 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// ResourceProviderRegistration registers the Resource Providers required by a Resource (or Data Source) when
+	// it's first used, this is nil unless `resource_provider_registrations` is set to `lazy`
+	ResourceProviderRegistration *resourceproviders.LazyRegistration
+
+	// ResourceProviderCache caches the Resource Providers available within the Subscription on disk for this instance
+	// of the Provider, this is nil unless `ARM_RESOURCE_PROVIDER_CACHE_TTL` is set
+	ResourceProviderCache *resourceproviders.DiskCache
+
+	// Tags is the configuration for the `default_tags` and `ignore_tags` blocks for this instance of the Provider,
+	// which is applied to each Resource with a top-level `tags` field
+	Tags tags.Configuration
+
+	// DefaultTimeouts are the timeouts specified in the `default_timeouts` block for this instance of the Provider,
+	// which are nil when this isn't specified
+	DefaultTimeouts *timeouts.Defaults
+
 	AadB2c                            *aadb2c_v2021_04_01_preview.Client
 	Advisor                           *advisor.Client
 	AnalysisServices                  *analysisservices_v2017_08_01.Client
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/go-uuid"
+	"golang.org/x/oauth2"
+)
+
+// clientAssertionLifetime is how long a Client Assertion signed by a clientAssertionSigner is valid for, which
+// matches the lifetime of the Client Assertions signed by go-azure-sdk
+const clientAssertionLifetime = time.Hour
+
+// clientAssertionAuthorizer is an Authorizer which authenticates as a Service Principal using a Client Assertion
+// signed by a clientAssertionSigner. Since a Client Assertion is only valid for clientAssertionLifetime, a new
+// Client Assertion is signed each time a token is requested - which is cached until it's due for renewal.
+type clientAssertionAuthorizer struct {
+	signer clientAssertionSigner
+
+	environment environments.Environment
+	api         environments.Api
+	tenantId    string
+	clientId    string
+}
+
+var _ auth.Authorizer = &clientAssertionAuthorizer{}
+
+// newClientAssertionAuthorizer returns an Authorizer for the API, which authenticates as the Service Principal
+// using a Client Assertion signed by the specified signer
+func newClientAssertionAuthorizer(signer clientAssertionSigner, config auth.Credentials, api environments.Api) (auth.Authorizer, error) {
+	return auth.NewCachedAuthorizer(&clientAssertionAuthorizer{
+		signer:      signer,
+		environment: config.Environment,
+		api:         api,
+		tenantId:    config.TenantID,
+		clientId:    config.ClientID,
+	})
+}
+
+func (a *clientAssertionAuthorizer) Token(ctx context.Context, req *http.Request) (*oauth2.Token, error) {
+	assertion, err := newClientAssertion(ctx, a.signer, a.environment, a.tenantId, a.clientId, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("building the Client Assertion: %+v", err)
+	}
+
+	authorizer, err := auth.NewOIDCAuthorizer(ctx, auth.OIDCAuthorizerOptions{
+		Environment:        a.environment,
+		Api:                a.api,
+		TenantId:           a.tenantId,
+		ClientId:           a.clientId,
+		FederatedAssertion: *assertion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building the authorizer for the Client Assertion: %+v", err)
+	}
+
+	return authorizer.Token(ctx, req)
+}
+
+// AuxiliaryTokens returns no tokens, since the audience for the Client Assertion is the token endpoint for the
+// primary tenant - as such auxiliary tenants aren't supported
+func (a *clientAssertionAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
+
+// clientAssertionSigner signs a Client Assertion using a private key which is held outside of the Provider, such as
+// within Key Vault or a Hardware Security Module
+type clientAssertionSigner interface {
+	// Certificate returns the Client Certificate associated with the Service Principal
+	Certificate(ctx context.Context) (*x509.Certificate, error)
+
+	// Sign returns the signature for the SHA-256 digest using the specified JWS algorithm (`RS256` or `ES256`)
+	Sign(ctx context.Context, algorithm string, digest []byte) ([]byte, error)
+}
+
+type clientAssertionHeader struct {
+	Algorithm  string `json:"alg"`
+	Type       string `json:"typ"`
+	Thumbprint string `json:"x5t"`
+}
+
+type clientAssertionClaims struct {
+	Audience  string `json:"aud"`
+	Expiry    int64  `json:"exp"`
+	Issuer    string `json:"iss"`
+	JwtId     string `json:"jti"`
+	NotBefore int64  `json:"nbf"`
+	Subject   string `json:"sub"`
+}
+
+// newClientAssertion returns a Client Assertion for the Service Principal, signed by the specified signer, which
+// can be exchanged for an access token using the token endpoint for the tenant
+func newClientAssertion(ctx context.Context, signer clientAssertionSigner, env environments.Environment, tenantId, clientId string, now time.Time) (*string, error) {
+	if env.Authorization == nil {
+		return nil, fmt.Errorf("no `authorization` configuration was found for this environment")
+	}
+
+	certificate, err := signer.Certificate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the Client Certificate: %+v", err)
+	}
+
+	algorithm, err := clientAssertionAlgorithm(certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtId, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("generating the ID for the Client Assertion: %+v", err)
+	}
+
+	thumbprint := sha1.Sum(certificate.Raw)
+	header, err := encodeClientAssertionSegment(clientAssertionHeader{
+		Algorithm:  algorithm,
+		Type:       "JWT",
+		Thumbprint: base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := encodeClientAssertionSegment(clientAssertionClaims{
+		Audience:  fmt.Sprintf("%s/%s/oauth2/v2.0/token", env.Authorization.LoginEndpoint, tenantId),
+		Expiry:    now.Add(clientAssertionLifetime).Unix(),
+		Issuer:    clientId,
+		JwtId:     jwtId,
+		NotBefore: now.Unix(),
+		Subject:   clientId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unsigned := fmt.Sprintf("%s.%s", header, claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := signer.Sign(ctx, algorithm, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("signing the Client Assertion: %+v", err)
+	}
+
+	assertion := fmt.Sprintf("%s.%s", unsigned, base64.RawURLEncoding.EncodeToString(signature))
+	return &assertion, nil
+}
+
+// clientAssertionAlgorithm returns the JWS algorithm used to sign a Client Assertion using the private key for the
+// specified certificate
+func clientAssertionAlgorithm(certificate *x509.Certificate) (string, error) {
+	switch key := certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return "RS256", nil
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return "", fmt.Errorf("only ECDSA keys using the P-256 curve are supported but the Client Certificate uses %q", key.Curve.Params().Name)
+		}
+		return "ES256", nil
+	}
+
+	return "", fmt.Errorf("the Client Certificate uses an unsupported key type %T - only RSA and ECDSA keys are supported", certificate.PublicKey)
+}
+
+func encodeClientAssertionSegment(input interface{}) (string, error) {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("encoding the Client Assertion: %+v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	authWrapper "github.com/hashicorp/go-azure-sdk/sdk/auth/autorest"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	dataplane "github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+var _ clientAssertionSigner = &keyVaultClientAssertionSigner{}
+
+// keyVaultClientAssertionSigner signs a Client Assertion using the key for a Certificate within Key Vault, such that
+// the private key never leaves Key Vault
+type keyVaultClientAssertionSigner struct {
+	client        *dataplane.BaseClient
+	certificateId parse.NestedItemId
+
+	// keyId is the ID of the key backing the certificate, which is populated when the certificate is retrieved
+	keyId *parse.NestedItemId
+}
+
+func newKeyVaultClientAssertionSigner(authorizer auth.Authorizer, certificateId parse.NestedItemId) *keyVaultClientAssertionSigner {
+	return &keyVaultClientAssertionSigner{
+		client:        newKeyVaultDataPlaneClient(authorizer),
+		certificateId: certificateId,
+	}
+}
+
+func (s *keyVaultClientAssertionSigner) Certificate(ctx context.Context) (*x509.Certificate, error) {
+	resp, err := s.client.GetCertificate(ctx, s.certificateId.KeyVaultBaseUrl, s.certificateId.Name, s.certificateId.Version)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", s.certificateId, err)
+	}
+	if resp.Cer == nil || len(*resp.Cer) == 0 {
+		return nil, fmt.Errorf("retrieving %s: `cer` was nil", s.certificateId)
+	}
+	if resp.Kid == nil {
+		return nil, fmt.Errorf("retrieving %s: `kid` was nil", s.certificateId)
+	}
+
+	keyId, err := parse.ParseNestedItemID(*resp.Kid)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the Key ID %q for %s: %+v", *resp.Kid, s.certificateId, err)
+	}
+	s.keyId = keyId
+
+	certificate, err := x509.ParseCertificate(*resp.Cer)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %+v", s.certificateId, err)
+	}
+
+	return certificate, nil
+}
+
+func (s *keyVaultClientAssertionSigner) Sign(ctx context.Context, algorithm string, digest []byte) ([]byte, error) {
+	if s.keyId == nil {
+		return nil, fmt.Errorf("internal-error: the Key ID for %s wasn't retrieved", s.certificateId)
+	}
+
+	parameters := dataplane.KeySignParameters{
+		Algorithm: dataplane.JSONWebKeySignatureAlgorithm(algorithm),
+		Value:     pointer.To(base64.RawURLEncoding.EncodeToString(digest)),
+	}
+	resp, err := s.client.Sign(ctx, s.keyId.KeyVaultBaseUrl, s.keyId.Name, s.keyId.Version, parameters)
+	if err != nil {
+		return nil, fmt.Errorf("signing using %s: %+v", s.keyId, err)
+	}
+	if resp.Result == nil {
+		return nil, fmt.Errorf("signing using %s: `value` was nil", s.keyId)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(*resp.Result)
+	if err != nil {
+		return nil, fmt.Errorf("decoding the signature returned from %s: %+v", s.keyId, err)
+	}
+
+	return signature, nil
+}
+
+// keyVaultSecretValue returns the value of the specified Secret within Key Vault
+func keyVaultSecretValue(ctx context.Context, authorizer auth.Authorizer, secretId parse.NestedItemId) (*string, error) {
+	client := newKeyVaultDataPlaneClient(authorizer)
+	resp, err := client.GetSecret(ctx, secretId.KeyVaultBaseUrl, secretId.Name, secretId.Version)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", secretId, err)
+	}
+	if resp.Value == nil || *resp.Value == "" {
+		return nil, fmt.Errorf("retrieving %s: `value` was nil", secretId)
+	}
+
+	return resp.Value, nil
+}
+
+func newKeyVaultDataPlaneClient(authorizer auth.Authorizer) *dataplane.BaseClient {
+	client := dataplane.New()
+	client.Authorizer = authWrapper.AutorestAuthorizer(authorizer).BearerAuthorizerCallback()
+	return &client
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// pkcs11ToolPinEnvironmentVariable is the environment variable used to pass the PIN to `pkcs11-tool`, so that it
+// isn't visible in the arguments for the process
+const pkcs11ToolPinEnvironmentVariable = "TF_AZURERM_PKCS11_PIN"
+
+// sha256DigestInfoPrefix is the DER encoded DigestInfo prefix for a SHA-256 digest, which is prepended to the digest
+// when signing using the `RSA-PKCS` mechanism, as described in RFC 8017 section 9.2
+var sha256DigestInfoPrefix = []byte{0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20}
+
+var _ clientAssertionSigner = pkcs11ClientAssertionSigner{}
+
+// pkcs11ClientAssertionSigner signs a Client Assertion using a private key held within a PKCS#11 token (such as a
+// Hardware Security Module), using `pkcs11-tool` from OpenSC
+type pkcs11ClientAssertionSigner struct {
+	modulePath string
+	slotId     int64
+	keyLabel   string
+	pin        string
+}
+
+func (s pkcs11ClientAssertionSigner) Certificate(ctx context.Context) (*x509.Certificate, error) {
+	out, err := s.run(ctx, nil, "--read-object", "--type", "cert", "--label", s.keyLabel)
+	if err != nil {
+		return nil, fmt.Errorf("reading the certificate with the label %q: %+v", s.keyLabel, err)
+	}
+
+	certificate, err := x509.ParseCertificate(out)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the certificate with the label %q: %+v", s.keyLabel, err)
+	}
+
+	return certificate, nil
+}
+
+func (s pkcs11ClientAssertionSigner) Sign(ctx context.Context, algorithm string, digest []byte) ([]byte, error) {
+	var mechanism string
+	var input []byte
+	switch algorithm {
+	case "RS256":
+		mechanism = "RSA-PKCS"
+		input = append(append([]byte{}, sha256DigestInfoPrefix...), digest...)
+	case "ES256":
+		// the signature is output as the concatenated `r` and `s` values, as required by JWS
+		mechanism = "ECDSA"
+		input = digest
+	default:
+		return nil, fmt.Errorf("internal-error: unsupported algorithm %q", algorithm)
+	}
+
+	signature, err := s.run(ctx, input, "--login", "--sign", "--mechanism", mechanism, "--label", s.keyLabel)
+	if err != nil {
+		return nil, fmt.Errorf("signing using the key with the label %q: %+v", s.keyLabel, err)
+	}
+
+	return signature, nil
+}
+
+// run invokes `pkcs11-tool` with the specified arguments, and returns the contents of the output file
+func (s pkcs11ClientAssertionSigner) run(ctx context.Context, input []byte, args ...string) ([]byte, error) {
+	directory, err := os.MkdirTemp("", "terraform-provider-azurerm-pkcs11")
+	if err != nil {
+		return nil, fmt.Errorf("creating a temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(directory)
+
+	args = append([]string{"--module", s.modulePath, "--slot", strconv.FormatInt(s.slotId, 10)}, args...)
+
+	outputPath := filepath.Join(directory, "output")
+	args = append(args, "--output-file", outputPath)
+
+	if input != nil {
+		inputPath := filepath.Join(directory, "input")
+		if err := os.WriteFile(inputPath, input, 0o600); err != nil {
+			return nil, fmt.Errorf("writing the input file: %+v", err)
+		}
+		args = append(args, "--input-file", inputPath)
+	}
+
+	env := os.Environ()
+	if s.pin != "" {
+		args = append(args, "--pin", "env:"+pkcs11ToolPinEnvironmentVariable)
+		env = append(env, fmt.Sprintf("%s=%s", pkcs11ToolPinEnvironmentVariable, s.pin))
+	}
+
+	cmd := exec.CommandContext(ctx, "pkcs11-tool", args...)
+	cmd.Env = env
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running `pkcs11-tool`: %+v\n\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	output, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading the output from `pkcs11-tool`: %+v", err)
+	}
+
+	return output, nil
+}
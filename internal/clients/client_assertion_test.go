@@ -0,0 +1,298 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+var _ clientAssertionSigner = localClientAssertionSigner{}
+
+// localClientAssertionSigner signs the Client Assertion using a private key held in memory
+type localClientAssertionSigner struct {
+	certificate *x509.Certificate
+	key         crypto.Signer
+}
+
+func newLocalClientAssertionSigner(t *testing.T, key crypto.Signer) localClientAssertionSigner {
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "acctest"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, &template, &template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("creating certificate: %+v", err)
+	}
+	certificate, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatalf("parsing certificate: %+v", err)
+	}
+
+	return localClientAssertionSigner{
+		certificate: certificate,
+		key:         key,
+	}
+}
+
+func (s localClientAssertionSigner) Certificate(_ context.Context) (*x509.Certificate, error) {
+	return s.certificate, nil
+}
+
+func (s localClientAssertionSigner) Sign(_ context.Context, _ string, digest []byte) ([]byte, error) {
+	if k, ok := s.key.(*ecdsa.PrivateKey); ok {
+		r, sig, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			return nil, err
+		}
+		return append(r.FillBytes(make([]byte, 32)), sig.FillBytes(make([]byte, 32))...), nil
+	}
+	return s.key.Sign(rand.Reader, digest, crypto.SHA256)
+}
+
+func TestNewClientAssertion(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %+v", err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating ECDSA key: %+v", err)
+	}
+
+	testData := []struct {
+		name      string
+		key       crypto.Signer
+		algorithm string
+	}{
+		{
+			name:      "RSA",
+			key:       rsaKey,
+			algorithm: "RS256",
+		},
+		{
+			name:      "ECDSA",
+			key:       ecdsaKey,
+			algorithm: "ES256",
+		},
+	}
+
+	env := environments.AzurePublic()
+	now := time.Unix(1700000000, 0)
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			signer := newLocalClientAssertionSigner(t, v.key)
+			assertion, err := newClientAssertion(context.Background(), signer, *env, "tenant", "client", now)
+			if err != nil {
+				t.Fatalf("building Client Assertion: %+v", err)
+			}
+
+			segments := strings.Split(*assertion, ".")
+			if len(segments) != 3 {
+				t.Fatalf("expected the Client Assertion to contain 3 segments but got %d", len(segments))
+			}
+
+			var header clientAssertionHeader
+			decodeClientAssertionSegment(t, segments[0], &header)
+			if header.Algorithm != v.algorithm {
+				t.Fatalf("expected the algorithm to be %q but got %q", v.algorithm, header.Algorithm)
+			}
+			if header.Thumbprint == "" {
+				t.Fatalf("expected the `x5t` header to be set")
+			}
+
+			var claims clientAssertionClaims
+			decodeClientAssertionSegment(t, segments[1], &claims)
+			if expected := "https://login.microsoftonline.com/tenant/oauth2/v2.0/token"; claims.Audience != expected {
+				t.Fatalf("expected the audience to be %q but got %q", expected, claims.Audience)
+			}
+			if claims.Issuer != "client" || claims.Subject != "client" {
+				t.Fatalf("expected the issuer and subject to be the Client ID but got %q and %q", claims.Issuer, claims.Subject)
+			}
+			if claims.NotBefore != now.Unix() || claims.Expiry != now.Add(clientAssertionLifetime).Unix() {
+				t.Fatalf("unexpected lifetime for the Client Assertion: %d - %d", claims.NotBefore, claims.Expiry)
+			}
+
+			signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+			if err != nil {
+				t.Fatalf("decoding signature: %+v", err)
+			}
+			digest := sha256.Sum256([]byte(segments[0] + "." + segments[1]))
+			switch k := v.key.(type) {
+			case *rsa.PrivateKey:
+				if err := rsa.VerifyPKCS1v15(&k.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+					t.Fatalf("verifying signature: %+v", err)
+				}
+			case *ecdsa.PrivateKey:
+				r := new(big.Int).SetBytes(signature[:32])
+				s := new(big.Int).SetBytes(signature[32:])
+				if !ecdsa.Verify(&k.PublicKey, digest[:], r, s) {
+					t.Fatalf("verifying signature failed")
+				}
+			}
+		})
+	}
+}
+
+func TestNewClientAssertionUnsupportedCurve(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating ECDSA key: %+v", err)
+	}
+
+	signer := newLocalClientAssertionSigner(t, key)
+	if _, err := newClientAssertion(context.Background(), signer, *environments.AzurePublic(), "tenant", "client", time.Now()); err == nil {
+		t.Fatalf("expected an error for a P-384 key but didn't get one")
+	}
+}
+
+func TestClientAssertionAuthorizer(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating ECDSA key: %+v", err)
+	}
+
+	// the first token is due for renewal immediately, the second is valid for an hour
+	assertions := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parsing the token request: %+v", err)
+		}
+		assertions = append(assertions, r.PostForm.Get("client_assertion"))
+
+		expiresIn := 60
+		if len(assertions) > 1 {
+			expiresIn = 3600
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token%d","token_type":"Bearer","expires_in":%d}`, len(assertions), expiresIn)
+	}))
+	defer server.Close()
+
+	env := environments.AzurePublic()
+	env.Authorization.LoginEndpoint = server.URL
+	config := auth.Credentials{
+		Environment: *env,
+		TenantID:    "tenant",
+		ClientID:    "client",
+	}
+	authorizer, err := newClientAssertionAuthorizer(newLocalClientAssertionSigner(t, key), config, env.ResourceManager)
+	if err != nil {
+		t.Fatalf("building authorizer: %+v", err)
+	}
+
+	for i, expected := range []string{"token1", "token2", "token2"} {
+		token, err := authorizer.Token(context.Background(), &http.Request{})
+		if err != nil {
+			t.Fatalf("obtaining token %d: %+v", i, err)
+		}
+		if token.AccessToken != expected {
+			t.Fatalf("expected token %d to be %q but got %q", i, expected, token.AccessToken)
+		}
+	}
+
+	// a new Client Assertion is signed each time a token is requested, rather than re-using one which may have expired
+	if len(assertions) != 2 {
+		t.Fatalf("expected 2 token requests but got %d", len(assertions))
+	}
+	if assertions[0] == "" || assertions[0] == assertions[1] {
+		t.Fatalf("expected a new Client Assertion to be signed for each token request")
+	}
+}
+
+func TestConfigureCredentialProvidersValidation(t *testing.T) {
+	keyVaultCertificate := &ClientCertificateProvider{
+		KeyVault: &KeyVaultCertificateProvider{
+			CertificateId: "https://example.vault.azure.net/certificates/example",
+		},
+	}
+	keyVaultSecret := &ClientSecretProvider{
+		KeyVault: &KeyVaultSecretProvider{
+			SecretId: "https://example.vault.azure.net/secrets/example",
+		},
+	}
+
+	testData := []struct {
+		name        string
+		config      auth.Credentials
+		certificate *ClientCertificateProvider
+		secret      *ClientSecretProvider
+	}{
+		{
+			name:        "missing tenant",
+			config:      auth.Credentials{ClientID: "client"},
+			certificate: keyVaultCertificate,
+		},
+		{
+			name:        "both providers",
+			config:      auth.Credentials{ClientID: "client", TenantID: "tenant"},
+			certificate: keyVaultCertificate,
+			secret:      keyVaultSecret,
+		},
+		{
+			name:   "client secret also specified",
+			config: auth.Credentials{ClientID: "client", TenantID: "tenant", ClientSecret: "secret"},
+			secret: keyVaultSecret,
+		},
+		{
+			name:        "client certificate also specified",
+			config:      auth.Credentials{ClientID: "client", TenantID: "tenant", ClientCertificatePath: "/tmp/cert.pfx"},
+			certificate: keyVaultCertificate,
+		},
+		{
+			name:        "auxiliary tenants",
+			config:      auth.Credentials{ClientID: "client", TenantID: "tenant", AuxiliaryTenantIDs: []string{"other"}},
+			certificate: keyVaultCertificate,
+		},
+		{
+			name:        "no provider specified",
+			config:      auth.Credentials{ClientID: "client", TenantID: "tenant"},
+			certificate: &ClientCertificateProvider{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			if _, err := ConfigureCredentialProviders(context.Background(), &v.config, v.certificate, v.secret); err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+		})
+	}
+
+	config := auth.Credentials{}
+	if _, err := ConfigureCredentialProviders(context.Background(), &config, nil, nil); err != nil {
+		t.Fatalf("expected no error when no providers are specified but got: %+v", err)
+	}
+}
+
+func decodeClientAssertionSegment(t *testing.T, input string, output interface{}) {
+	b, err := base64.RawURLEncoding.DecodeString(input)
+	if err != nil {
+		t.Fatalf("decoding segment: %+v", err)
+	}
+	if err := json.Unmarshal(b, output); err != nil {
+		t.Fatalf("unmarshaling segment: %+v", err)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+// DeveloperToolCredentials configures the developer tools which can be used to obtain tokens, in addition to the
+// authentication mechanisms supported by go-azure-sdk
+type DeveloperToolCredentials struct {
+	// EnableAuthenticatingUsingAzureDeveloperCLI specifies whether Azure Developer CLI (`azd`) authentication should be checked.
+	EnableAuthenticatingUsingAzureDeveloperCLI bool
+
+	// EnableAuthenticatingUsingAzurePowerShell specifies whether Azure PowerShell authentication should be checked.
+	EnableAuthenticatingUsingAzurePowerShell bool
+}
+
+// developerToolAuthorizer is an Authorizer which obtains tokens by running a developer tool, which exposes the
+// account that the tool is signed in to so that the tenant and subscription can be auto-detected
+type developerToolAuthorizer interface {
+	auth.Authorizer
+
+	// toolName returns the name of the developer tool, for use in log and error messages
+	toolName() string
+
+	// signedInTenantId returns the specified tenant ID, or the auto-detected tenant ID if none was specified
+	signedInTenantId() string
+
+	// defaultSubscriptionId returns the default subscription for the developer tool, when detected
+	defaultSubscriptionId() string
+}
+
+// NewAuthorizer returns an Authorizer for the specified API.
+//
+// The mechanisms supported by go-azure-sdk are attempted first, with the exception of Azure CLI. The Azure Developer
+// CLI and Azure PowerShell (if enabled) are then attempted, and Azure CLI (if enabled) is attempted last.
+func NewAuthorizer(ctx context.Context, config auth.Credentials, developerTools DeveloperToolCredentials, api environments.Api) (auth.Authorizer, error) {
+	if !developerTools.EnableAuthenticatingUsingAzureDeveloperCLI && !developerTools.EnableAuthenticatingUsingAzurePowerShell {
+		return auth.NewAuthorizerFromCredentials(ctx, config, api)
+	}
+
+	if credentialsConfigured(config) {
+		withoutAzureCli := config
+		withoutAzureCli.EnableAuthenticatingUsingAzureCLI = false
+		return auth.NewAuthorizerFromCredentials(ctx, withoutAzureCli, api)
+	}
+
+	if developerTools.EnableAuthenticatingUsingAzureDeveloperCLI {
+		a, err := NewAzureDeveloperCliAuthorizer(ctx, AzureDeveloperCliAuthorizerOptions{
+			Api:          api,
+			TenantId:     config.TenantID,
+			AuxTenantIds: config.AuxiliaryTenantIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not configure AzureDeveloperCli Authorizer: %s", err)
+		}
+		if a != nil {
+			return a, nil
+		}
+	}
+
+	if developerTools.EnableAuthenticatingUsingAzurePowerShell {
+		a, err := NewAzurePowerShellAuthorizer(ctx, AzurePowerShellAuthorizerOptions{
+			Api:          api,
+			TenantId:     config.TenantID,
+			AuxTenantIds: config.AuxiliaryTenantIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not configure AzurePowerShell Authorizer: %s", err)
+		}
+		if a != nil {
+			return a, nil
+		}
+	}
+
+	return auth.NewAuthorizerFromCredentials(ctx, config, api)
+}
+
+// credentialsConfigured returns whether any of the authentication mechanisms supported by go-azure-sdk (other than
+// Azure CLI) are configured, using the same conditions as auth.NewAuthorizerFromCredentials
+func credentialsConfigured(c auth.Credentials) bool {
+	if c.EnableAuthenticatingUsingManagedIdentity {
+		return true
+	}
+
+	if strings.TrimSpace(c.TenantID) == "" || strings.TrimSpace(c.ClientID) == "" {
+		return false
+	}
+
+	return (c.EnableAuthenticatingUsingClientCertificate && (len(c.ClientCertificateData) > 0 || strings.TrimSpace(c.ClientCertificatePath) != "")) ||
+		(c.EnableAuthenticatingUsingClientSecret && strings.TrimSpace(c.ClientSecret) != "") ||
+		(c.EnableAuthenticationUsingOIDC && strings.TrimSpace(c.OIDCAssertionToken) != "") ||
+		(c.EnableAuthenticationUsingGitHubOIDC && strings.TrimSpace(c.GitHubOIDCTokenRequestURL) != "" && strings.TrimSpace(c.GitHubOIDCTokenRequestToken) != "")
+}
+
+// runDeveloperTool runs the specified developer tool and returns the output written to stdout
+func runDeveloperTool(ctx context.Context, path string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = strings.TrimSpace(stdout.String())
+		}
+		return nil, fmt.Errorf("running %q: %+v\n\n%s", path, err, message)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// tenantIdFromAccessToken returns the tenant ID from the `tid` claim of the specified access token, which is parsed
+// but not verified since it has been obtained from a trusted developer tool
+func tenantIdFromAccessToken(accessToken string) (string, error) {
+	segments := strings.Split(accessToken, ".")
+	if len(segments) != 3 {
+		return "", fmt.Errorf("parsing the access token: expected 3 segments but got %d", len(segments))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segments[1], "="))
+	if err != nil {
+		return "", fmt.Errorf("decoding the access token claims: %+v", err)
+	}
+
+	var claims struct {
+		TenantId string `json:"tid"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("parsing the access token claims: %+v", err)
+	}
+	if claims.TenantId == "" {
+		return "", fmt.Errorf("the access token doesn't contain a `tid` claim")
+	}
+
+	return claims.TenantId, nil
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+const (
+	stubTenantId       = "00000000-0000-0000-0000-000000000001"
+	stubSubscriptionId = "00000000-0000-0000-0000-000000000002"
+)
+
+// stubAccessToken is an unsigned access token containing the `tid` claim for the stub tenant
+var stubAccessToken = fmt.Sprintf("eyJhbGciOiJub25lIn0.%s.c2lnbmF0dXJl", base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"tid":%q}`, stubTenantId))))
+
+// writeStubExecutable writes a shell script with the specified name into a new directory, which replaces the PATH
+// for the remainder of the test. The arguments for each invocation are appended to the returned log file.
+func writeStubExecutable(t *testing.T, name, body string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub executables are shell scripts, which aren't supported on Windows")
+	}
+
+	directory := t.TempDir()
+	argsPath := filepath.Join(directory, "args")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$@\" >> %q\n%s\n", argsPath, body)
+	if err := os.WriteFile(filepath.Join(directory, name), []byte(script), 0o755); err != nil {
+		t.Fatalf("writing stub executable %q: %+v", name, err)
+	}
+	t.Setenv("PATH", directory)
+
+	return argsPath
+}
+
+func readStubArgs(t *testing.T, argsPath string) string {
+	b, err := os.ReadFile(argsPath)
+	if err != nil {
+		t.Fatalf("reading the arguments for the stub executable: %+v", err)
+	}
+	return string(b)
+}
+
+func TestAzureDeveloperCliAuthorizer(t *testing.T) {
+	argsPath := writeStubExecutable(t, "azd", fmt.Sprintf(`case "$1 $2" in
+  "auth token") echo '{"token":"%s","expiresOn":"2030-01-02T03:04:05Z"}' ;;
+  "config get") echo '"%s"' ;;
+  *) exit 1 ;;
+esac`, stubAccessToken, stubSubscriptionId))
+
+	ctx := context.Background()
+	a, err := NewAzureDeveloperCliAuthorizer(ctx, AzureDeveloperCliAuthorizerOptions{
+		Api:          environments.AzurePublic().ResourceManager,
+		AuxTenantIds: []string{"aux-tenant"},
+	})
+	if err != nil {
+		t.Fatalf("building Authorizer: %+v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected an Authorizer but got nil")
+	}
+
+	authorizer := a.(*auth.CachedAuthorizer).Source.(*AzureDeveloperCliAuthorizer)
+	if authorizer.TenantID != stubTenantId {
+		t.Fatalf("expected the tenant ID to be detected as %q but got %q", stubTenantId, authorizer.TenantID)
+	}
+	if authorizer.DefaultSubscriptionID != stubSubscriptionId {
+		t.Fatalf("expected the default subscription ID to be detected as %q but got %q", stubSubscriptionId, authorizer.DefaultSubscriptionID)
+	}
+
+	token, err := a.Token(ctx, &http.Request{})
+	if err != nil {
+		t.Fatalf("obtaining token: %+v", err)
+	}
+	if token.AccessToken != stubAccessToken {
+		t.Fatalf("unexpected access token %q", token.AccessToken)
+	}
+	if expected := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC); !token.Expiry.Equal(expected) {
+		t.Fatalf("expected the expiry to be %s but got %s", expected, token.Expiry)
+	}
+
+	auxTokens, err := a.AuxiliaryTokens(ctx, &http.Request{})
+	if err != nil {
+		t.Fatalf("obtaining auxiliary tokens: %+v", err)
+	}
+	if len(auxTokens) != 1 {
+		t.Fatalf("expected 1 auxiliary token but got %d", len(auxTokens))
+	}
+
+	args := readStubArgs(t, argsPath)
+	for _, expected := range []string{
+		"auth token --output json --scope https://management.azure.com/.default\n",
+		fmt.Sprintf("auth token --output json --scope https://management.azure.com/.default --tenant-id %s\n", stubTenantId),
+		"auth token --output json --scope https://management.azure.com/.default --tenant-id aux-tenant\n",
+		"config get defaults.subscription\n",
+	} {
+		if !strings.Contains(args, expected) {
+			t.Fatalf("expected the Azure Developer CLI to be invoked with %q but got:\n%s", expected, args)
+		}
+	}
+}
+
+func TestAzureDeveloperCliAuthorizerNoDefaultSubscription(t *testing.T) {
+	writeStubExecutable(t, "azd", `case "$1 $2" in
+  "auth token") echo '{"token":"token","expiresOn":"2030-01-02T03:04:05Z"}' ;;
+  *) echo 'ERROR: no value stored at path' >&2; exit 1 ;;
+esac`)
+
+	a, err := NewAzureDeveloperCliAuthorizer(context.Background(), AzureDeveloperCliAuthorizerOptions{
+		Api:      environments.AzurePublic().ResourceManager,
+		TenantId: "specified-tenant",
+	})
+	if err != nil {
+		t.Fatalf("building Authorizer: %+v", err)
+	}
+
+	authorizer := a.(*auth.CachedAuthorizer).Source.(*AzureDeveloperCliAuthorizer)
+	if authorizer.TenantID != "specified-tenant" {
+		t.Fatalf("expected the specified tenant ID to be used but got %q", authorizer.TenantID)
+	}
+	if authorizer.DefaultSubscriptionID != "" {
+		t.Fatalf("expected no default subscription ID but got %q", authorizer.DefaultSubscriptionID)
+	}
+}
+
+func TestAzureDeveloperCliAuthorizerNotSignedIn(t *testing.T) {
+	writeStubExecutable(t, "azd", `echo 'ERROR: not logged in, run azd auth login to login' >&2
+exit 1`)
+
+	_, err := NewAzureDeveloperCliAuthorizer(context.Background(), AzureDeveloperCliAuthorizerOptions{
+		Api: environments.AzurePublic().ResourceManager,
+	})
+	if err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), "not logged in") {
+		t.Fatalf("expected the error to contain the output from the Azure Developer CLI but got: %+v", err)
+	}
+}
+
+func TestAzurePowerShellAuthorizer(t *testing.T) {
+	argsPath := writeStubExecutable(t, "pwsh", fmt.Sprintf(`case "$4" in
+  *Get-AzContext*) echo '{"tenantId":"%s","subscriptionId":"%s"}' ;;
+  *Get-AzAccessToken*) echo '{"token":"token","expiresOn":1893553445}' ;;
+  *) exit 1 ;;
+esac`, stubTenantId, stubSubscriptionId))
+
+	ctx := context.Background()
+	a, err := NewAzurePowerShellAuthorizer(ctx, AzurePowerShellAuthorizerOptions{
+		Api: environments.AzurePublic().KeyVault,
+	})
+	if err != nil {
+		t.Fatalf("building Authorizer: %+v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected an Authorizer but got nil")
+	}
+
+	authorizer := a.(*auth.CachedAuthorizer).Source.(*AzurePowerShellAuthorizer)
+	if authorizer.TenantID != stubTenantId {
+		t.Fatalf("expected the tenant ID to be detected as %q but got %q", stubTenantId, authorizer.TenantID)
+	}
+	if authorizer.DefaultSubscriptionID != stubSubscriptionId {
+		t.Fatalf("expected the default subscription ID to be detected as %q but got %q", stubSubscriptionId, authorizer.DefaultSubscriptionID)
+	}
+
+	token, err := a.Token(ctx, &http.Request{})
+	if err != nil {
+		t.Fatalf("obtaining token: %+v", err)
+	}
+	if token.AccessToken != "token" {
+		t.Fatalf("unexpected access token %q", token.AccessToken)
+	}
+	if expected := time.Unix(1893553445, 0); !token.Expiry.Equal(expected) {
+		t.Fatalf("expected the expiry to be %s but got %s", expected, token.Expiry)
+	}
+
+	args := readStubArgs(t, argsPath)
+	for _, expected := range []string{
+		"-NoProfile -NonInteractive -Command",
+		fmt.Sprintf("Get-AzAccessToken -ResourceUrl 'https://vault.azure.net' -WarningAction SilentlyContinue -TenantId '%s'", stubTenantId),
+	} {
+		if !strings.Contains(args, expected) {
+			t.Fatalf("expected PowerShell to be invoked with %q but got:\n%s", expected, args)
+		}
+	}
+}
+
+func TestAzurePowerShellTokenScriptQuoting(t *testing.T) {
+	script := azurePowerShellTokenScript("https://example.com", "ten'ant")
+	if !strings.Contains(script, "-TenantId 'ten''ant'") {
+		t.Fatalf("expected the tenant ID to be quoted but got:\n%s", script)
+	}
+}
+
+func TestNewAuthorizerUsingDeveloperTools(t *testing.T) {
+	writeStubExecutable(t, "pwsh", fmt.Sprintf(`case "$4" in
+  *Get-AzContext*) echo '{"tenantId":"%s","subscriptionId":"%s"}' ;;
+  *) exit 1 ;;
+esac`, stubTenantId, stubSubscriptionId))
+
+	ctx := context.Background()
+	api := environments.AzurePublic().ResourceManager
+	developerTools := DeveloperToolCredentials{
+		EnableAuthenticatingUsingAzureDeveloperCLI: true,
+		EnableAuthenticatingUsingAzurePowerShell:   true,
+	}
+
+	// the Azure Developer CLI isn't installed, so Azure PowerShell should be used
+	a, err := NewAuthorizer(ctx, auth.Credentials{Environment: *environments.AzurePublic()}, developerTools, api)
+	if err != nil {
+		t.Fatalf("building Authorizer: %+v", err)
+	}
+	if _, ok := a.(*auth.CachedAuthorizer).Source.(*AzurePowerShellAuthorizer); !ok {
+		t.Fatalf("expected an AzurePowerShellAuthorizer but got %T", a.(*auth.CachedAuthorizer).Source)
+	}
+
+	// explicitly configured credentials take precedence over the developer tools
+	a, err = NewAuthorizer(ctx, auth.Credentials{
+		Environment:                           *environments.AzurePublic(),
+		TenantID:                              stubTenantId,
+		ClientID:                              "client",
+		ClientSecret:                          "secret",
+		EnableAuthenticatingUsingClientSecret: true,
+		EnableAuthenticatingUsingAzureCLI:     true,
+	}, developerTools, api)
+	if err != nil {
+		t.Fatalf("building Authorizer: %+v", err)
+	}
+	if cached, ok := a.(*auth.CachedAuthorizer); ok {
+		if _, ok := cached.Source.(developerToolAuthorizer); ok {
+			t.Fatalf("expected the Client Secret to be used but got %T", cached.Source)
+		}
+	}
+
+	// when neither tool is installed, and Azure CLI isn't enabled, there's nothing to authenticate with
+	t.Setenv("PATH", t.TempDir())
+	if _, err := NewAuthorizer(ctx, auth.Credentials{Environment: *environments.AzurePublic()}, developerTools, api); err == nil {
+		t.Fatalf("expected an error when no developer tools are installed but didn't get one")
+	}
+}
+
+func TestTenantIdFromAccessToken(t *testing.T) {
+	tenantId, err := tenantIdFromAccessToken(stubAccessToken)
+	if err != nil {
+		t.Fatalf("parsing access token: %+v", err)
+	}
+	if tenantId != stubTenantId {
+		t.Fatalf("expected the tenant ID to be %q but got %q", stubTenantId, tenantId)
+	}
+
+	if _, err := tenantIdFromAccessToken("not-a-token"); err == nil {
+		t.Fatalf("expected an error for an invalid access token but didn't get one")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+// configureLockBackend configures the Backend used to serialise changes to resources across Provider processes,
+// when one is specified in the Environment. The Backend is shared by every Provider block within this process, so is
+// only built by the first Provider block to be configured (see locks.ConfigureBackend)
+func configureLockBackend(o *common.ClientOptions) error {
+	config, err := locks.BackendConfigFromEnvironment()
+	if err != nil {
+		return err
+	}
+
+	return locks.ConfigureBackend(config, func(config locks.BackendConfig) (locks.Backend, error) {
+		switch config.Type {
+		case locks.BackendTypeFile:
+			return locks.NewFileBackend(config.Directory)
+
+		case locks.BackendTypeBlob:
+			baseUri, containerName, err := parseLockStorageContainerURL(config.StorageContainerURL)
+			if err != nil {
+				return nil, err
+			}
+
+			client, err := blobs.NewWithBaseUri(baseUri)
+			if err != nil {
+				return nil, fmt.Errorf("building Blob Storage client: %+v", err)
+			}
+			o.Configure(client.Client, o.Authorizers.Storage)
+
+			return locks.NewBlobBackend(client, containerName), nil
+		}
+
+		return nil, fmt.Errorf("unsupported Lock Backend %q", config.Type)
+	})
+}
+
+// parseLockStorageContainerURL splits a Storage Container URL (e.g. `https://account.blob.core.windows.net/locks`)
+// into the Blob Endpoint and the name of the Container
+func parseLockStorageContainerURL(input string) (string, string, error) {
+	uri, err := url.Parse(input)
+	if err != nil || uri.Scheme == "" || uri.Host == "" {
+		return "", "", fmt.Errorf("%q must be the URL of a Storage Container (e.g. `https://account.blob.core.windows.net/locks`) but got %q", locks.EnvStorageContainerURL, input)
+	}
+
+	containerName := strings.Trim(uri.Path, "/")
+	if containerName == "" || strings.Contains(containerName, "/") {
+		return "", "", fmt.Errorf("%q must be the URL of a Storage Container (e.g. `https://account.blob.core.windows.net/locks`) but got %q", locks.EnvStorageContainerURL, input)
+	}
+
+	return fmt.Sprintf("%s://%s", uri.Scheme, uri.Host), containerName, nil
+}
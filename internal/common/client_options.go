@@ -5,6 +5,7 @@ package common
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/version"
 )
 
@@ -50,6 +52,23 @@ type ClientOptions struct {
 
 	ResourceManagerEndpoint string
 
+	// ResourceProviderCache is the on-disk Resource Provider cache for this instance of the Provider, which is
+	// invalidated when Resource Manager reports that a Resource Provider isn't registered
+	ResourceProviderCache *resourceproviders.DiskCache
+
+	// RateLimiter paces the requests made to Resource Manager, shared between all clients
+	RateLimiter *RateLimiter
+
+	// RetryPolicy retries requests to Resource Manager which fail with a transient error, shared between all clients
+	RetryPolicy *RetryPolicy
+
+	// HTTPLogger logs the requests made to, and responses received from, Azure - shared between all clients
+	HTTPLogger *HTTPLogger
+
+	// Transport sends the requests made by all clients rather than these being sent directly to Azure, for example
+	// to record (or replay) the requests made during the Acceptance Tests
+	Transport *TransportServer
+
 	// Legacy authorizers for go-autorest
 	BatchManagementAuthorizer autorest.Authorizer
 	KeyVaultAuthorizer        autorest.Authorizer
@@ -74,8 +93,38 @@ func (o ClientOptions) Configure(c client.BaseClient, authorizer auth.Authorizer
 		c.AppendRequestMiddleware(correlationRequestIDMiddleware(id))
 	}
 
-	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM"))
-	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM"))
+	// the Transport must restore the original URI before any other Response Middleware is called
+	if o.Transport != nil {
+		c.AppendResponseMiddleware(o.Transport.responseMiddleware())
+	}
+
+	// the RateLimiter releases the request's concurrency slot before any retries are sent by the RetryPolicy
+	if o.RateLimiter != nil {
+		c.AppendRequestMiddleware(o.RateLimiter.requestMiddleware())
+		c.AppendResponseMiddleware(o.RateLimiter.responseMiddleware())
+	}
+
+	if o.HTTPLogger != nil {
+		c.AppendRequestMiddleware(o.HTTPLogger.requestMiddleware())
+		c.AppendResponseMiddleware(o.HTTPLogger.responseMiddleware())
+	}
+
+	// go-azure-sdk doesn't allow the transport to be configured, so any retries are sent using the same transport
+	// as the go-autorest clients - such that these are also recorded, logged and rate limited
+	if o.RetryPolicy != nil {
+		c.AppendRequestMiddleware(o.RetryPolicy.requestMiddleware())
+		c.AppendResponseMiddleware(o.RetryPolicy.responseMiddleware(o.transport()))
+	}
+
+	// a Resource Provider which turns out to be unregistered means the on-disk Resource Provider cache is out of date
+	if o.ResourceProviderCache != nil {
+		c.AppendResponseMiddleware(o.ResourceProviderCache.InvalidateResponseMiddleware)
+	}
+
+	// the Transport redirects the request to its local server, so must be called after any other Request Middleware
+	if o.Transport != nil {
+		c.AppendRequestMiddleware(o.Transport.requestMiddleware())
+	}
 }
 
 // ConfigureClient sets up an autorest.Client using an autorest.Authorizer
@@ -84,6 +133,16 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 
 	c.Authorizer = authorizer
 	c.Sender = sender.BuildSender("AzureRM")
+	if o.HTTPLogger != nil || o.Transport != nil || o.RateLimiter != nil || o.RetryPolicy != nil || o.ResourceProviderCache != nil {
+		transport := o.transport()
+		if o.RetryPolicy != nil {
+			transport = o.RetryPolicy.roundTripper(transport)
+		}
+		if o.ResourceProviderCache != nil {
+			transport = responseMiddlewareRoundTripper(transport, o.ResourceProviderCache.InvalidateResponseMiddleware)
+		}
+		c.Sender = &http.Client{Transport: transport}
+	}
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
@@ -94,6 +153,24 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	}
 }
 
+// transport returns the http.RoundTripper used to send the requests made by go-autorest clients (and any requests
+// retried by the RetryPolicy), which are logged and rate limited in the same way as requests made by go-azure-sdk clients
+func (o ClientOptions) transport() http.RoundTripper {
+	var transport http.RoundTripper = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+	if o.Transport != nil {
+		transport = o.Transport
+	}
+	if o.HTTPLogger != nil {
+		transport = o.HTTPLogger.roundTripper(transport)
+	}
+	if o.RateLimiter != nil {
+		transport = o.RateLimiter.roundTripper(transport)
+	}
+	return transport
+}
+
 func userAgent(userAgent, tfVersion, partnerID string, disableTerraformPartnerID bool) string {
 	tfUserAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io)", tfVersion)
 
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+)
+
+func TestClientOptions_InvalidatesResourceProviderCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":{"code":"MissingSubscriptionRegistration","message":"The subscription is not registered to use namespace 'Microsoft.Web'."}}`))
+	}))
+	defer server.Close()
+
+	cache := &resourceproviders.DiskCache{
+		Directory: t.TempDir(),
+		TenantId:  "00000000-0000-0000-0000-000000000000",
+		TTL:       time.Hour,
+	}
+	options := ClientOptions{
+		DisableCorrelationRequestID: true,
+		ResourceProviderCache:       cache,
+	}
+	path := "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example/providers/Microsoft.Web/sites/example"
+	cacheFile := filepath.Join(cache.Directory, fmt.Sprintf("resource-providers-%s-11111111-1111-1111-1111-111111111111.json", cache.TenantId))
+
+	writeCacheFile := func() {
+		contents := fmt.Sprintf(`{"created_at":%q,"providers":[{"namespace":"Microsoft.Web","registered":true}]}`, time.Now().UTC().Format(time.RFC3339))
+		if err := os.WriteFile(cacheFile, []byte(contents), 0o600); err != nil {
+			t.Fatalf("writing the cache file: %+v", err)
+		}
+	}
+	assertInvalidated := func(clientType string) {
+		if _, err := os.Stat(cacheFile); !os.IsNotExist(err) {
+			t.Fatalf("expected the Resource Provider cache to be invalidated by the %s client", clientType)
+		}
+	}
+
+	t.Log("go-azure-sdk..")
+	writeCacheFile()
+	c := client.NewClient(server.URL, "Example", "2020-01-01")
+	options.Configure(c, nil)
+	request, err := c.NewRequest(context.TODO(), client.RequestOptions{
+		ContentType:         "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{http.StatusOK},
+		HttpMethod:          http.MethodPut,
+		Path:                path,
+	})
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	if _, err := c.Execute(context.TODO(), request); err == nil {
+		t.Fatalf("expected an error for an unregistered Resource Provider")
+	}
+	assertInvalidated("go-azure-sdk")
+
+	t.Log("go-autorest..")
+	writeCacheFile()
+	autorestClient := autorest.NewClientWithUserAgent("")
+	options.ConfigureClient(&autorestClient, autorest.NullAuthorizer{})
+	autorestRequest, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, server.URL+path, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := autorestClient.Send(autorestRequest)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	resp.Body.Close()
+	assertInvalidated("go-autorest")
+}
@@ -0,0 +1,466 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// EnvLogServices is a comma-separated list of the Services (for example `keyvault,storage`) for which the
+	// HTTP requests and responses should be logged - when unset, all HTTP requests and responses are logged
+	EnvLogServices = "ARM_LOG_SERVICES"
+
+	// EnvLogBodies determines whether the (redacted) bodies of HTTP requests and responses should be logged
+	EnvLogBodies = "ARM_LOG_BODIES"
+
+	// HeaderRequestID is the ID assigned to the request by Azure
+	HeaderRequestID = "x-ms-request-id"
+
+	// envTfLog, envTfLogProvider and envTfAccLogPath are the environment variables which Terraform (and the Acceptance
+	// Test framework) use to determine which of the log entries written by providers are kept
+	envTfLog         = "TF_LOG"
+	envTfLogProvider = "TF_LOG_PROVIDER"
+	envTfAccLogPath  = "TF_ACC_LOG_PATH"
+
+	redactedValue = "REDACTED"
+)
+
+var (
+	providerNamespaceFromPathRegex = regexp.MustCompile(`(?i)/providers/([^/]+)`)
+
+	// sensitiveHeaders are the (canonical) names of the HTTP headers whose values are never logged
+	sensitiveHeaders = map[string]struct{}{
+		"Authorization":                  {},
+		"Ocp-Apim-Subscription-Key":      {},
+		"X-Functions-Key":                {},
+		"X-Ms-Authorization-Auxiliary":   {},
+		"X-Ms-Copy-Source-Authorization": {},
+		"X-Ms-Encryption-Key":            {},
+	}
+
+	// sensitiveQueryParameters are the names of the query string parameters whose values are never logged
+	sensitiveQueryParameters = []string{"code", "sig"}
+
+	// sensitiveFieldNameSuffixes are the (lower-cased) suffixes of the names of the fields within a JSON body whose
+	// (string) values are redacted, for example `primaryKey`, `adminPassword` and `primaryConnectionString`
+	sensitiveFieldNameSuffixes = []string{
+		"connectionstring",
+		"key",
+		"password",
+		"secret",
+		"signature",
+		"token",
+	}
+
+	// dataPlaneServices maps a component of the hostname for a data plane API to the Service it belongs to
+	dataPlaneServices = map[string]string{
+		".azconfig.":       "appconfiguration",
+		".azuresynapse.":   "synapse",
+		".batch.":          "batch",
+		".blob.":           "storage",
+		".dfs.":            "storage",
+		".file.":           "storage",
+		".managedhsm.":     "keyvault",
+		".queue.":          "storage",
+		".table.":          "storage",
+		".vault.":          "keyvault",
+		".vaultcore.":      "keyvault",
+		".search.windows.": "search",
+	}
+)
+
+// HTTPLogger logs the HTTP requests sent to, and the responses received from, Azure as structured log entries
+// using `tflog` - where any secrets within the headers, URI or JSON body are redacted.
+//
+// The volume of log entries can be controlled using the `ARM_LOG_SERVICES` and `ARM_LOG_BODIES` environment variables.
+type HTTPLogger struct {
+	// ctx is the context containing the provider logger, which is used for requests whose context doesn't contain
+	// a logger
+	ctx context.Context
+
+	// enabled is whether Debug log entries are kept (see debugLogsEnabled) - when they're not, requests and
+	// responses are neither read nor redacted
+	enabled bool
+
+	providerName        string
+	resourceManagerHost string
+
+	// services is the set of Services to log requests for, where an empty set means all Services are logged
+	services  map[string]struct{}
+	logBodies bool
+
+	// inflight holds the time each in-flight request was sent, to determine the duration of the request
+	inflight sync.Map
+}
+
+type inflightHTTPRequest struct {
+	sent time.Time
+	stop func() bool
+}
+
+// NewHTTPLogger returns an HTTPLogger configured using the `ARM_LOG_SERVICES` and `ARM_LOG_BODIES` environment
+// variables, which logs using the logger within the context of each request.
+//
+// The logger within `ctx` is used for requests whose context doesn't contain a logger, since most requests use a
+// context derived from the provider's StopContext, which doesn't contain one.
+func NewHTTPLogger(ctx context.Context, providerName string, resourceManagerEndpoint string) *HTTPLogger {
+	host := ""
+	if u, err := url.Parse(resourceManagerEndpoint); err == nil {
+		host = u.Host
+	}
+
+	services := make(map[string]struct{})
+	for _, v := range strings.Split(os.Getenv(EnvLogServices), ",") {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			services[v] = struct{}{}
+		}
+	}
+
+	logBodies := true
+	if v := os.Getenv(EnvLogBodies); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Printf("[WARN] ignoring the invalid value %q for %s - expected a boolean", v, EnvLogBodies)
+		} else {
+			logBodies = b
+		}
+	}
+
+	return &HTTPLogger{
+		ctx:                 ctx,
+		enabled:             debugLogsEnabled(providerName),
+		providerName:        providerName,
+		resourceManagerHost: host,
+		services:            services,
+		logBodies:           logBodies,
+	}
+}
+
+func (l *HTTPLogger) requestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		if !l.appliesTo(request) {
+			return request, nil
+		}
+
+		// the Response Middleware isn't called when the request fails, so the entry is also removed
+		// once the request's context is done, should that happen first
+		stop := context.AfterFunc(request.Context(), func() {
+			l.inflight.Delete(request)
+		})
+		l.inflight.Store(request, inflightHTTPRequest{
+			sent: time.Now(),
+			stop: stop,
+		})
+
+		l.logRequest(request)
+		return request, nil
+	}
+}
+
+func (l *HTTPLogger) responseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		if !l.appliesTo(request) {
+			return response, nil
+		}
+
+		var duration *time.Duration
+		if v, ok := l.inflight.LoadAndDelete(request); ok {
+			inflight := v.(inflightHTTPRequest)
+			inflight.stop()
+			d := time.Since(inflight.sent)
+			duration = &d
+		}
+
+		l.logResponse(request, response, duration)
+		return response, nil
+	}
+}
+
+// roundTripper returns an http.RoundTripper which logs each request sent using `transport`, and the response
+func (l *HTTPLogger) roundTripper(transport http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		if !l.appliesTo(request) {
+			return transport.RoundTrip(request)
+		}
+
+		l.logRequest(request)
+		sent := time.Now()
+		response, err := transport.RoundTrip(request)
+		duration := time.Since(sent)
+
+		if err != nil {
+			fields := l.requestFields(request)
+			fields["http_duration_ms"] = duration.Milliseconds()
+			fields["error"] = err.Error()
+			tflog.Debug(l.loggingContext(request), fmt.Sprintf("%s HTTP Request Error", l.providerName), fields)
+		}
+		if response != nil {
+			l.logResponse(request, response, &duration)
+		}
+
+		return response, err
+	})
+}
+
+func (l *HTTPLogger) appliesTo(request *http.Request) bool {
+	if l == nil || !l.enabled || request == nil || request.URL == nil {
+		return false
+	}
+
+	if len(l.services) == 0 {
+		return true
+	}
+
+	_, ok := l.services[l.serviceForRequest(request.URL)]
+	return ok
+}
+
+// serviceForRequest returns the name of the Service the request is for, which is either the name of the Resource
+// Provider (without the `Microsoft.` prefix, for example `keyvault` for `Microsoft.KeyVault`) or, for data plane
+// APIs, the name of the Service the API belongs to
+func (l *HTTPLogger) serviceForRequest(uri *url.URL) string {
+	host := strings.ToLower(uri.Host)
+	if strings.EqualFold(host, l.resourceManagerHost) {
+		matches := providerNamespaceFromPathRegex.FindAllStringSubmatch(uri.Path, -1)
+		if len(matches) == 0 {
+			// Subscriptions and Resource Groups are part of the `Microsoft.Resources` Resource Provider
+			return "resources"
+		}
+
+		// a Resource ID can contain multiple Resource Providers when it's scoped to another Resource,
+		// however the Service is defined by the final Resource Provider
+		namespace := strings.ToLower(matches[len(matches)-1][1])
+		return strings.TrimPrefix(namespace, "microsoft.")
+	}
+
+	for component, service := range dataPlaneServices {
+		if strings.Contains(host, component) {
+			return service
+		}
+	}
+
+	return ""
+}
+
+func (l *HTTPLogger) logRequest(request *http.Request) {
+	fields := l.requestFields(request)
+	fields["http_request_headers"] = redactHeaders(request.Header)
+
+	if l.logBodies && request.Body != nil && request.Body != http.NoBody {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			log.Printf("[DEBUG] %s reading the body of the HTTP Request: %+v", l.providerName, err)
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		fields["http_request_body"] = redactBody(body)
+	}
+
+	tflog.Debug(l.loggingContext(request), fmt.Sprintf("%s HTTP Request", l.providerName), fields)
+}
+
+func (l *HTTPLogger) logResponse(request *http.Request, response *http.Response, duration *time.Duration) {
+	fields := l.requestFields(request)
+	fields["http_status_code"] = response.StatusCode
+	fields["http_response_headers"] = redactHeaders(response.Header)
+	if v := response.Header.Get(HeaderRequestID); v != "" {
+		fields["request_id"] = v
+	}
+	if duration != nil {
+		fields["http_duration_ms"] = duration.Milliseconds()
+	}
+
+	if l.logBodies && response.Body != nil && response.Body != http.NoBody {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			log.Printf("[DEBUG] %s reading the body of the HTTP Response: %+v", l.providerName, err)
+		}
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(body))
+		fields["http_response_body"] = redactBody(body)
+	}
+
+	tflog.Debug(l.loggingContext(request), fmt.Sprintf("%s HTTP Response", l.providerName), fields)
+}
+
+// loggingContext returns the context used to log the request and its response, which is the context of the request
+// when it contains a logger (for example one including the fields describing the resource sending the request) -
+// falling back to the provider logger within `l.ctx` otherwise
+func (l *HTTPLogger) loggingContext(request *http.Request) context.Context {
+	return requestLoggingContext{
+		Context:  request.Context(),
+		fallback: l.ctx,
+	}
+}
+
+// requestLoggingContext is the context of a request, where values which aren't present in the context of the request
+// (such as the logger) are looked up in the fallback context
+type requestLoggingContext struct {
+	context.Context
+
+	fallback context.Context
+}
+
+func (c requestLoggingContext) Value(key interface{}) interface{} {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+	return c.fallback.Value(key)
+}
+
+// debugLogsEnabled returns whether the Debug log entries written by the provider are kept, using the same environment
+// variables Terraform uses to filter the log entries written by providers - together with the environment variable
+// for the level of the provider logger itself (for example `TF_LOG_PROVIDER_AZURERM`)
+func debugLogsEnabled(providerName string) bool {
+	if v := os.Getenv(fmt.Sprintf("%s_%s", envTfLogProvider, strings.ToUpper(providerName))); v != "" {
+		if level := hclog.LevelFromString(v); level != hclog.NoLevel && level > hclog.Debug {
+			return false
+		}
+	}
+
+	if os.Getenv(envTfAccLogPath) != "" {
+		return true
+	}
+
+	v := os.Getenv(envTfLogProvider)
+	if v == "" {
+		v = os.Getenv(envTfLog)
+	}
+	if strings.EqualFold(v, "JSON") {
+		return true
+	}
+
+	level := hclog.LevelFromString(v)
+	return level != hclog.NoLevel && level <= hclog.Debug
+}
+
+func (l *HTTPLogger) requestFields(request *http.Request) map[string]interface{} {
+	fields := map[string]interface{}{
+		"http_method": request.Method,
+		"http_url":    RedactURL(request.URL),
+		"service":     l.serviceForRequest(request.URL),
+	}
+	if v := request.Header.Get(HeaderCorrelationRequestID); v != "" {
+		fields["correlation_request_id"] = v
+	}
+	return fields
+}
+
+func redactHeaders(input http.Header) map[string]string {
+	output := make(map[string]string, len(input))
+	for k, v := range input {
+		if IsSensitiveHeader(k) {
+			output[k] = redactedValue
+			continue
+		}
+		output[k] = strings.Join(v, ", ")
+	}
+	return output
+}
+
+// IsSensitiveHeader returns whether the value of the HTTP header is a secret (for example the `Authorization` header)
+func IsSensitiveHeader(name string) bool {
+	_, ok := sensitiveHeaders[http.CanonicalHeaderKey(name)]
+	return ok
+}
+
+// RedactURL returns the URI with the values of any sensitive query string parameters (such as a SAS Signature) redacted
+func RedactURL(input *url.URL) string {
+	query := input.Query()
+	redacted := false
+	for _, v := range sensitiveQueryParameters {
+		if query.Has(v) {
+			query.Set(v, redactedValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return input.String()
+	}
+
+	output := *input
+	output.RawQuery = query.Encode()
+	return output.String()
+}
+
+// redactBody returns the body with the values of any sensitive fields redacted - since it's not possible to
+// determine which values are sensitive within other formats, only JSON bodies are logged
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return fmt.Sprintf("(%d bytes omitted since the body isn't JSON)", len(body))
+	}
+
+	output, err := json.Marshal(RedactJSONValue(v))
+	if err != nil {
+		return fmt.Sprintf("(%d bytes omitted since the body couldn't be redacted)", len(body))
+	}
+	return string(output)
+}
+
+// RedactJSONValue redacts the (string) values of any sensitive fields within the decoded JSON value, which is updated
+// in-place - see isSensitiveFieldName
+func RedactJSONValue(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if s, ok := value.(string); ok && s != "" && isSensitiveFieldName(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = RedactJSONValue(value)
+		}
+		return v
+
+	case []interface{}:
+		for i, value := range v {
+			v[i] = RedactJSONValue(value)
+		}
+		return v
+	}
+
+	return input
+}
+
+// isSensitiveFieldName determines whether the (string) value of the field within a JSON body should be redacted.
+//
+// Fields named `value` are treated as sensitive, since this is used for the values of Key Vault Secrets and for
+// Access Keys (for example by the `listKeys` API for Storage Accounts) - however lists of items (which are also
+// returned in a field named `value`) are unaffected, since only string values are redacted.
+func isSensitiveFieldName(name string) bool {
+	name = strings.ToLower(name)
+	if name == "value" {
+		return true
+	}
+
+	for _, suffix := range sensitiveFieldNameSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
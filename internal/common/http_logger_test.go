@@ -0,0 +1,313 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestRedactBody(t *testing.T) {
+	testData := map[string]string{
+		``:               ``,
+		`not json`:       `(8 bytes omitted since the body isn't JSON)`,
+		`{"name":"abc"}`: `{"name":"abc"}`,
+		`{"keys":[{"keyName":"key1","permissions":"FULL","value":"s3cr3t"}]}`:                          `{"keys":[{"keyName":"key1","permissions":"FULL","value":"REDACTED"}]}`,
+		`{"properties":{"adminPassword":"p4ss","primaryConnectionString":"Endpoint=sb://","count":5}}`: `{"properties":{"adminPassword":"REDACTED","count":5,"primaryConnectionString":"REDACTED"}}`,
+		`{"value":[{"id":"/subscriptions/123","name":"example"}],"nextLink":"https://example"}`:        `{"nextLink":"https://example","value":[{"id":"/subscriptions/123","name":"example"}]}`,
+		`{"clientSecret":"","secretName":"example"}`:                                                   `{"clientSecret":"","secretName":"example"}`,
+		`{"primaryKey":"abc","sasToken":"def","customerManagedKey":{"keyVaultKeyId":"https://ex"}}`:    `{"customerManagedKey":{"keyVaultKeyId":"https://ex"},"primaryKey":"REDACTED","sasToken":"REDACTED"}`,
+	}
+
+	for input, expected := range testData {
+		if actual := redactBody([]byte(input)); actual != expected {
+			t.Fatalf("expected the redacted body for %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	testData := map[string]string{
+		"https://management.azure.com/subscriptions/123?api-version=2022-01-01":           "https://management.azure.com/subscriptions/123?api-version=2022-01-01",
+		"https://example.blob.core.windows.net/container/blob?sig=abc123&sp=r&sv=2020-01": "https://example.blob.core.windows.net/container/blob?sig=REDACTED&sp=r&sv=2020-01",
+	}
+
+	for input, expected := range testData {
+		u, err := url.Parse(input)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", input, err)
+		}
+		if actual := RedactURL(u); actual != expected {
+			t.Fatalf("expected the redacted URL for %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer abc123")
+	headers.Set("x-ms-authorization-auxiliary", "Bearer def456")
+	headers.Set(HeaderCorrelationRequestID, "00000000-0000-0000-0000-000000000000")
+
+	actual := redactHeaders(headers)
+	if v := actual["Authorization"]; v != redactedValue {
+		t.Fatalf("expected the Authorization header to be redacted but got %q", v)
+	}
+	if v := actual["X-Ms-Authorization-Auxiliary"]; v != redactedValue {
+		t.Fatalf("expected the X-Ms-Authorization-Auxiliary header to be redacted but got %q", v)
+	}
+	if v := actual["X-Ms-Correlation-Request-Id"]; v != "00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("expected the X-Ms-Correlation-Request-Id header not to be redacted but got %q", v)
+	}
+
+	// the original headers must be unchanged, since these are sent to Azure
+	if v := headers.Get("Authorization"); v != "Bearer abc123" {
+		t.Fatalf("expected the original Authorization header to be unchanged but got %q", v)
+	}
+}
+
+func TestHTTPLogger_ServiceForRequest(t *testing.T) {
+	logger := NewHTTPLogger(context.TODO(), "AzureRM", "https://management.azure.com/")
+	testData := map[string]string{
+		"https://management.azure.com/subscriptions/123/resourceGroups/example":                                                                                                     "resources",
+		"https://management.azure.com/subscriptions/123/resourceGroups/example/providers/Microsoft.KeyVault/vaults/example":                                                         "keyvault",
+		"https://management.azure.com/subscriptions/123/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/providers/Microsoft.Authorization/locks/example": "authorization",
+		"https://example.vault.azure.net/secrets/example":                                                                                                                           "keyvault",
+		"https://example.blob.core.windows.net/container":                                                                                                                           "storage",
+		"https://example.com/some/path": "",
+	}
+
+	for input, expected := range testData {
+		u, err := url.Parse(input)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", input, err)
+		}
+		if actual := logger.serviceForRequest(u); actual != expected {
+			t.Fatalf("expected the Service for %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestHTTPLogger_Services(t *testing.T) {
+	setTerraformLogLevel(t, "DEBUG")
+	t.Setenv(EnvLogServices, "KeyVault, storage")
+	logger := NewHTTPLogger(context.TODO(), "AzureRM", "https://management.azure.com/")
+
+	testData := map[string]bool{
+		"https://management.azure.com/subscriptions/123/resourceGroups/example/providers/Microsoft.KeyVault/vaults/example":         true,
+		"https://example.blob.core.windows.net/container":                                                                           true,
+		"https://management.azure.com/subscriptions/123/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example": false,
+	}
+
+	for input, expected := range testData {
+		request, err := http.NewRequest(http.MethodGet, input, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		if actual := logger.appliesTo(request); actual != expected {
+			t.Fatalf("expected requests to %q to be logged to be %t but got %t", input, expected, actual)
+		}
+	}
+}
+
+func TestHTTPLogger_BodiesArePreserved(t *testing.T) {
+	setTerraformLogLevel(t, "DEBUG")
+	logger := NewHTTPLogger(context.TODO(), "AzureRM", "https://management.azure.com/")
+
+	body := `{"properties":{"adminPassword":"p4ss"}}`
+	request, err := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/123", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	request, err = logger.requestMiddleware()(request)
+	if err != nil {
+		t.Fatalf("logging request: %+v", err)
+	}
+	if actual, _ := io.ReadAll(request.Body); string(actual) != body {
+		t.Fatalf("expected the request body to be %q but got %q", body, string(actual))
+	}
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	response, err = logger.responseMiddleware()(request, response)
+	if err != nil {
+		t.Fatalf("logging response: %+v", err)
+	}
+	if actual, _ := io.ReadAll(response.Body); string(actual) != body {
+		t.Fatalf("expected the response body to be %q but got %q", body, string(actual))
+	}
+}
+
+func TestHTTPLogger_LogBodiesDisabled(t *testing.T) {
+	t.Setenv(EnvLogBodies, "false")
+	logger := NewHTTPLogger(context.TODO(), "AzureRM", "https://management.azure.com/")
+	if logger.logBodies {
+		t.Fatalf("expected bodies not to be logged when %s is `false`", EnvLogBodies)
+	}
+}
+
+func TestHTTPLogger_DebugLogsEnabled(t *testing.T) {
+	testData := []struct {
+		env      map[string]string
+		expected bool
+	}{
+		{
+			env:      map[string]string{},
+			expected: false,
+		},
+		{
+			env:      map[string]string{"TF_LOG": "DEBUG"},
+			expected: true,
+		},
+		{
+			env:      map[string]string{"TF_LOG": "trace"},
+			expected: true,
+		},
+		{
+			env:      map[string]string{"TF_LOG": "JSON"},
+			expected: true,
+		},
+		{
+			env:      map[string]string{"TF_LOG": "INFO"},
+			expected: false,
+		},
+		{
+			env:      map[string]string{"TF_LOG": "DEBUG", "TF_LOG_PROVIDER": "WARN"},
+			expected: false,
+		},
+		{
+			env:      map[string]string{"TF_LOG": "INFO", "TF_LOG_PROVIDER": "DEBUG"},
+			expected: true,
+		},
+		{
+			env:      map[string]string{"TF_LOG": "DEBUG", "TF_LOG_PROVIDER_AZURERM": "ERROR"},
+			expected: false,
+		},
+		{
+			env:      map[string]string{"TF_ACC_LOG_PATH": "terraform.log"},
+			expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		for _, name := range []string{"TF_LOG", "TF_LOG_PROVIDER", "TF_LOG_PROVIDER_AZURERM", "TF_ACC_LOG_PATH"} {
+			t.Setenv(name, v.env[name])
+		}
+
+		if actual := debugLogsEnabled("AzureRM"); actual != v.expected {
+			t.Fatalf("expected Debug logs to be enabled to be %t for %+v but got %t", v.expected, v.env, actual)
+		}
+	}
+}
+
+func TestHTTPLogger_BodiesAreNotReadWhenNotLogged(t *testing.T) {
+	setTerraformLogLevel(t, "INFO")
+	logger := NewHTTPLogger(context.TODO(), "AzureRM", "https://management.azure.com/")
+
+	requestBody := &readTrackingBody{Reader: strings.NewReader(`{}`)}
+	request, err := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/123", requestBody)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	if _, err := logger.requestMiddleware()(request); err != nil {
+		t.Fatalf("logging request: %+v", err)
+	}
+
+	responseBody := &readTrackingBody{Reader: strings.NewReader(`{}`)}
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       responseBody,
+	}
+	if _, err := logger.responseMiddleware()(request, response); err != nil {
+		t.Fatalf("logging response: %+v", err)
+	}
+
+	if requestBody.read || responseBody.read {
+		t.Fatalf("expected the bodies not to be read when Debug log entries are dropped")
+	}
+}
+
+func TestHTTPLogger_LogsUsingTheRequestContext(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "terraform.log")
+	setTerraformLogLevel(t, "JSON")
+	t.Setenv("TF_LOG_PATH", logFile)
+	t.Setenv("TF_LOG_PATH_MASK", "")
+
+	ctx := tfsdklog.RegisterTestSink(context.Background(), t)
+	ctx = tfsdklog.NewRootProviderLogger(ctx)
+	logger := NewHTTPLogger(tflog.SetField(ctx, "logged_using", "provider"), "AzureRM", "https://management.azure.com/")
+
+	// requests sent by a resource using a context containing a logger are logged using that logger, whereas those
+	// sent using a context derived from the StopContext are logged using the provider logger
+	for _, requestCtx := range []context.Context{tflog.SetField(ctx, "logged_using", "request"), context.Background()} {
+		request, err := http.NewRequestWithContext(requestCtx, http.MethodGet, "https://management.azure.com/subscriptions/123", nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		if _, err := logger.requestMiddleware()(request); err != nil {
+			t.Fatalf("logging request: %+v", err)
+		}
+	}
+
+	f, err := os.Open(logFile)
+	if err != nil {
+		t.Fatalf("opening the log file: %+v", err)
+	}
+	defer f.Close()
+
+	loggedUsing := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := make(map[string]interface{})
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("parsing the log entry %q: %+v", scanner.Text(), err)
+		}
+		if entry["@message"] == "AzureRM HTTP Request" {
+			loggedUsing = append(loggedUsing, entry["logged_using"].(string))
+		}
+	}
+
+	if len(loggedUsing) != 2 || loggedUsing[0] != "request" || loggedUsing[1] != "provider" {
+		t.Fatalf("expected the requests to be logged using the request and then the provider logger but got %+v", loggedUsing)
+	}
+}
+
+// setTerraformLogLevel sets the level of the log entries written by providers which Terraform keeps
+func setTerraformLogLevel(t *testing.T, level string) {
+	t.Setenv("TF_LOG", level)
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG_PROVIDER_AZURERM", "")
+	t.Setenv("TF_ACC_LOG_PATH", "")
+}
+
+type readTrackingBody struct {
+	io.Reader
+
+	read bool
+}
+
+func (b *readTrackingBody) Read(p []byte) (int, error) {
+	b.read = true
+	return b.Reader.Read(p)
+}
+
+func (b *readTrackingBody) Close() error {
+	return nil
+}
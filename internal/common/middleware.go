@@ -4,9 +4,7 @@
 package common
 
 import (
-	"log"
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 )
@@ -19,41 +17,21 @@ func correlationRequestIDMiddleware(id string) client.RequestMiddleware {
 	}
 }
 
-func requestLoggerMiddleware(providerName string) client.RequestMiddleware {
-	return func(request *http.Request) (*http.Request, error) {
-		// strip the authorization header prior to printing
-		authHeaderName := "Authorization"
-		auth := request.Header.Get(authHeaderName)
-		if auth != "" {
-			request.Header.Del(authHeaderName)
-		}
+// roundTripperFunc is an adapter allowing a function to be used as an http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
 
-		// dump request to wire format
-		if dump, err := httputil.DumpRequestOut(request, true); err == nil {
-			log.Printf("[DEBUG] %s Request: \n%s\n", providerName, dump)
-		} else {
-			// fallback to basic message
-			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, request.URL)
-		}
-
-		// add the auth header back
-		if auth != "" {
-			request.Header.Add(authHeaderName, auth)
-		}
-
-		return request, nil
-	}
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
 }
 
-func responseLoggerMiddleware(providerName string) client.ResponseMiddleware {
-	return func(request *http.Request, response *http.Response) (*http.Response, error) {
-		// dump response to wire format
-		if dump, err2 := httputil.DumpResponse(response, true); err2 == nil {
-			log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, request.URL, dump)
-		} else {
-			// fallback to basic message
-			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, request.URL)
+// responseMiddlewareRoundTripper returns an http.RoundTripper which calls the Response Middleware for each response
+// received using `transport`, so that a Response Middleware can also be used by the go-autorest clients
+func responseMiddlewareRoundTripper(transport http.RoundTripper, middleware client.ResponseMiddleware) http.RoundTripper {
+	return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		response, err := transport.RoundTrip(request)
+		if err != nil {
+			return response, err
 		}
-		return response, nil
-	}
+		return middleware(request, response)
+	})
 }
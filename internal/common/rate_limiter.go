@@ -0,0 +1,373 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+const (
+	// HeaderRateLimitRemainingSubscriptionReads is the number of read requests remaining for the Subscription
+	HeaderRateLimitRemainingSubscriptionReads = "x-ms-ratelimit-remaining-subscription-reads"
+
+	// HeaderRateLimitRemainingSubscriptionWrites is the number of write requests remaining for the Subscription
+	HeaderRateLimitRemainingSubscriptionWrites = "x-ms-ratelimit-remaining-subscription-writes"
+
+	// rateLimitLowWatermark is the fraction of the (observed) request quota below which requests are slowed down
+	rateLimitLowWatermark = 0.2
+
+	// rateLimitAdaptiveRequestsPerSecond is the rate used as the starting point for slowing down requests when
+	// no `arm_requests_per_second` has been configured
+	rateLimitAdaptiveRequestsPerSecond = 20
+
+	// rateLimitMinimumRequestsPerSecond is the slowest rate that requests will be slowed down to
+	rateLimitMinimumRequestsPerSecond = 0.5
+)
+
+var subscriptionIdFromPathRegex = regexp.MustCompile(`(?i)^/subscriptions/([^/]+)`)
+
+// RateLimiter paces the requests made to Azure Resource Manager on a per-Subscription basis, by limiting both the
+// number of requests per second and the number of concurrent requests.
+//
+// In addition, the rate of requests is adapted based on the number of requests remaining for the Subscription, as
+// returned by Azure Resource Manager in the `x-ms-ratelimit-remaining-subscription-reads/writes` headers - such that
+// requests are slowed down before Azure starts to reject them (with a 429 status code).
+type RateLimiter struct {
+	requestsPerSecond     float64
+	maxConcurrentRequests int
+	resourceManagerHost   string
+
+	lock          sync.Mutex
+	subscriptions map[string]*subscriptionRateLimiter
+}
+
+// NewRateLimiter returns a RateLimiter for requests to the Resource Manager Endpoint, where `requestsPerSecond` and
+// `maxConcurrentRequests` are the limits per Subscription - a value of 0 means there's no limit.
+func NewRateLimiter(requestsPerSecond float64, maxConcurrentRequests int, resourceManagerEndpoint string) *RateLimiter {
+	host := ""
+	if u, err := url.Parse(resourceManagerEndpoint); err == nil {
+		host = u.Host
+	}
+
+	return &RateLimiter{
+		requestsPerSecond:     requestsPerSecond,
+		maxConcurrentRequests: maxConcurrentRequests,
+		resourceManagerHost:   host,
+		subscriptions:         make(map[string]*subscriptionRateLimiter),
+	}
+}
+
+type subscriptionRateLimiter struct {
+	// concurrency is nil when the number of concurrent requests isn't limited
+	concurrency chan struct{}
+
+	reads  *requestPacer
+	writes *requestPacer
+}
+
+func (l *RateLimiter) appliesTo(request *http.Request) bool {
+	return l != nil && request != nil && request.URL != nil && strings.EqualFold(request.URL.Host, l.resourceManagerHost)
+}
+
+func (l *RateLimiter) forSubscription(subscriptionId string) *subscriptionRateLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := strings.ToLower(subscriptionId)
+	if v, ok := l.subscriptions[key]; ok {
+		return v
+	}
+
+	v := &subscriptionRateLimiter{
+		reads:  newRequestPacer(l.requestsPerSecond),
+		writes: newRequestPacer(l.requestsPerSecond),
+	}
+	if l.maxConcurrentRequests > 0 {
+		v.concurrency = make(chan struct{}, l.maxConcurrentRequests)
+	}
+	l.subscriptions[key] = v
+	return v
+}
+
+// wait blocks until the request can be sent, returning a function which must be called once the request has completed
+func (l *RateLimiter) wait(request *http.Request) (func(), error) {
+	ctx := request.Context()
+	subscription := l.forSubscription(subscriptionIdFromPath(request.URL.Path))
+
+	release := func() {}
+	if subscription.concurrency != nil {
+		select {
+		case subscription.concurrency <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		var once sync.Once
+		release = func() {
+			once.Do(func() {
+				<-subscription.concurrency
+			})
+		}
+	}
+
+	if err := subscription.pacerFor(request.Method).wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// adapt updates the rate of requests for the Subscription based on the rate limit headers within the response
+func (l *RateLimiter) adapt(request *http.Request, response *http.Response) {
+	if response == nil {
+		return
+	}
+
+	subscription := l.forSubscription(subscriptionIdFromPath(request.URL.Path))
+	for header, pacer := range map[string]*requestPacer{
+		HeaderRateLimitRemainingSubscriptionReads:  subscription.reads,
+		HeaderRateLimitRemainingSubscriptionWrites: subscription.writes,
+	} {
+		if v := response.Header.Get(header); v != "" {
+			if remaining, err := strconv.ParseInt(v, 10, 64); err == nil {
+				pacer.adapt(remaining, header)
+			}
+		}
+	}
+}
+
+// rateLimiterAttemptKey is the context key for the rateLimiterAttempt of a request sent by go-azure-sdk
+type rateLimiterAttemptKey struct{}
+
+// rateLimiterAttempt holds the concurrency slot for the current attempt at sending a request made by go-azure-sdk
+type rateLimiterAttempt struct {
+	lock    sync.Mutex
+	release func()
+}
+
+func (a *rateLimiterAttempt) acquire(release func()) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.release != nil {
+		a.release()
+	}
+	a.release = release
+}
+
+// done releases the concurrency slot held by the current attempt, if it's still held
+func (a *rateLimiterAttempt) done() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.release != nil {
+		a.release()
+		a.release = nil
+	}
+}
+
+// requestMiddleware applies the RateLimiter to each attempt at sending a request made by go-azure-sdk.
+//
+// go-azure-sdk retries requests (for example when these are throttled) internally, after the Request Middlewares have
+// been called, and (in this version) doesn't allow the transport to be configured - as such the RateLimiter is applied
+// using a ClientTrace, since the transport calls `GetConn` before sending each attempt.
+func (l *RateLimiter) requestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		if !l.appliesTo(request) {
+			return request, nil
+		}
+
+		// the concurrency slot held by an attempt is released once the response is received, when the transport
+		// reports an error sending the request, or otherwise once the request's context is done
+		attempt := &rateLimiterAttempt{}
+		ctx := context.WithValue(request.Context(), rateLimiterAttemptKey{}, attempt)
+		context.AfterFunc(ctx, attempt.done)
+
+		// the request is updated in-place, since the other middlewares track requests by pointer
+		*request = *request.WithContext(httptrace.WithClientTrace(ctx, l.clientTrace(request, attempt)))
+
+		return request, nil
+	}
+}
+
+// clientTrace returns a ClientTrace which waits for a concurrency slot (and paces the request) before each attempt
+// at sending the request is made, releasing the slot once the response is received or sending the request fails
+func (l *RateLimiter) clientTrace(request *http.Request, attempt *rateLimiterAttempt) *httptrace.ClientTrace {
+	releaseOnError := func(err error) {
+		if err != nil {
+			attempt.done()
+		}
+	}
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			attempt.done()
+			release, err := l.wait(request)
+			if err != nil {
+				// the context is done, so the transport fails to send the request
+				return
+			}
+			attempt.acquire(release)
+		},
+		DNSDone: func(info httptrace.DNSDoneInfo) {
+			releaseOnError(info.Err)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			releaseOnError(err)
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			releaseOnError(err)
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			releaseOnError(info.Err)
+		},
+		GotFirstResponseByte: attempt.done,
+	}
+}
+
+func (l *RateLimiter) responseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		if !l.appliesTo(request) {
+			return response, nil
+		}
+
+		if attempt, ok := request.Context().Value(rateLimiterAttemptKey{}).(*rateLimiterAttempt); ok {
+			attempt.done()
+		}
+		l.adapt(request, response)
+
+		return response, nil
+	}
+}
+
+// roundTripper returns an http.RoundTripper which applies the RateLimiter to requests sent using `transport`, where
+// the concurrency slot is released once the request has completed - regardless of whether it succeeded
+func (l *RateLimiter) roundTripper(transport http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		if !l.appliesTo(request) {
+			return transport.RoundTrip(request)
+		}
+
+		// a request made by go-azure-sdk which is retried by the RetryPolicy is already limited by its ClientTrace
+		if _, ok := request.Context().Value(rateLimiterAttemptKey{}).(*rateLimiterAttempt); !ok {
+			release, err := l.wait(request)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+		}
+
+		response, err := transport.RoundTrip(request)
+		l.adapt(request, response)
+		return response, err
+	})
+}
+
+func (s *subscriptionRateLimiter) pacerFor(method string) *requestPacer {
+	if method == http.MethodGet || method == http.MethodHead {
+		return s.reads
+	}
+	return s.writes
+}
+
+// requestPacer spaces out requests such that no more than `current` requests are sent per second
+type requestPacer struct {
+	lock sync.Mutex
+
+	// configured is the rate of requests configured by the user, where 0 means there's no limit
+	configured float64
+
+	// current is the rate of requests currently in use, where 0 means there's no limit
+	current float64
+
+	// capacity is the highest number of remaining requests observed, which is used as an approximation of the quota
+	capacity int64
+
+	next time.Time
+}
+
+func newRequestPacer(requestsPerSecond float64) *requestPacer {
+	return &requestPacer{
+		configured: requestsPerSecond,
+		current:    requestsPerSecond,
+	}
+}
+
+func (p *requestPacer) wait(ctx context.Context) error {
+	p.lock.Lock()
+	if p.current <= 0 {
+		p.lock.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	if p.next.Before(now) {
+		p.next = now
+	}
+	delay := p.next.Sub(now)
+	p.next = p.next.Add(time.Duration(float64(time.Second) / p.current))
+	p.lock.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *requestPacer) adapt(remaining int64, header string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if remaining > p.capacity {
+		p.capacity = remaining
+	}
+
+	rate := p.configured
+	lowWatermark := float64(p.capacity) * rateLimitLowWatermark
+	if float64(remaining) < lowWatermark {
+		base := p.configured
+		if base <= 0 {
+			base = rateLimitAdaptiveRequestsPerSecond
+		}
+
+		rate = base * float64(remaining) / lowWatermark
+		if rate < rateLimitMinimumRequestsPerSecond {
+			rate = rateLimitMinimumRequestsPerSecond
+		}
+	}
+
+	if rate != p.current {
+		if rate == p.configured {
+			log.Printf("[DEBUG] Rate Limiting: %d requests remaining (%s) - restoring the configured rate of requests", remaining, header)
+		} else {
+			log.Printf("[DEBUG] Rate Limiting: %d requests remaining (%s) - slowing down to %.2f requests per second", remaining, header, rate)
+		}
+		p.current = rate
+	}
+}
+
+func subscriptionIdFromPath(path string) string {
+	if v := subscriptionIdFromPathRegex.FindStringSubmatch(path); len(v) == 2 {
+		return v[1]
+	}
+	return ""
+}
@@ -0,0 +1,277 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+func TestRateLimiter_RequestsPerSecond(t *testing.T) {
+	limiter := NewRateLimiter(20, 0, "https://management.azure.com/")
+	request := rateLimiterTestRequest(t, context.TODO(), http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example")
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := limiter.wait(request)
+		if err != nil {
+			t.Fatalf("waiting: %+v", err)
+		}
+		release()
+	}
+
+	// the first request is sent immediately, the remaining 4 are spaced 50ms apart
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected the requests to take at least 200ms but took %s", elapsed)
+	}
+}
+
+func TestRateLimiter_MaxConcurrentRequests(t *testing.T) {
+	limiter := NewRateLimiter(0, 1, "https://management.azure.com/")
+	firstCtx, firstCancel := context.WithCancel(context.TODO())
+	defer firstCancel()
+	first := rateLimiterTestRequest(t, firstCtx, http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/first")
+
+	if !rateLimiterTestAttempt(t, limiter, first) {
+		t.Fatalf("expected the first request to be sent")
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	second := rateLimiterTestRequest(t, ctx, http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/second")
+	if rateLimiterTestAttempt(t, limiter, second) {
+		t.Fatalf("expected the second request to be blocked whilst the first is in-flight")
+	}
+
+	// requests for other subscriptions are limited separately
+	other := rateLimiterTestRequest(t, context.TODO(), http.MethodGet, "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/other")
+	if !rateLimiterTestAttempt(t, limiter, other) {
+		t.Fatalf("expected a request for another subscription to be sent")
+	}
+
+	if _, err := limiter.responseMiddleware()(first, &http.Response{Header: http.Header{}}); err != nil {
+		t.Fatalf("completing the first request: %+v", err)
+	}
+
+	thirdCtx, thirdCancel := context.WithTimeout(context.TODO(), time.Second)
+	defer thirdCancel()
+	third := rateLimiterTestRequest(t, thirdCtx, http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/third")
+	if !rateLimiterTestAttempt(t, limiter, third) {
+		t.Fatalf("expected the third request to be sent once the first completed")
+	}
+}
+
+func TestRateLimiter_ReleasedWhenContextIsDone(t *testing.T) {
+	limiter := NewRateLimiter(0, 1, "https://management.azure.com/")
+
+	// the Response Middleware isn't called when a request fails, so the slot must be released once the context is done
+	ctx, cancel := context.WithCancel(context.TODO())
+	first := rateLimiterTestRequest(t, ctx, http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/first")
+	if !rateLimiterTestAttempt(t, limiter, first) {
+		t.Fatalf("expected the first request to be sent")
+	}
+	cancel()
+
+	ctx2, cancel2 := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel2()
+	second := rateLimiterTestRequest(t, ctx2, http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/second")
+	if !rateLimiterTestAttempt(t, limiter, second) {
+		t.Fatalf("expected the second request to be sent once the first was cancelled")
+	}
+}
+
+func TestRateLimiter_RequestsSharingAContext(t *testing.T) {
+	limiter := NewRateLimiter(0, 1, "https://management.azure.com/")
+
+	// requests sent concurrently using the same context each hold their own concurrency slot
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	first := rateLimiterTestRequest(t, ctx, http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/first")
+	if !rateLimiterTestAttempt(t, limiter, first) {
+		t.Fatalf("expected the first request to be sent")
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer waitCancel()
+	second := rateLimiterTestRequest(t, waitCtx, http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/second")
+	if rateLimiterTestAttempt(t, limiter, second) {
+		t.Fatalf("expected the second request to wait for the first request's concurrency slot")
+	}
+}
+
+func TestRateLimiter_ReleasedOnTransportError(t *testing.T) {
+	limiter := NewRateLimiter(0, 1, "https://management.azure.com/")
+
+	// the Response Middleware isn't called when sending the request fails, so the slot is released by the ClientTrace
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	first := rateLimiterTestRequest(t, ctx, http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/first")
+	if !rateLimiterTestAttempt(t, limiter, first) {
+		t.Fatalf("expected the first request to be sent")
+	}
+	httptrace.ContextClientTrace(first.Context()).WroteRequest(httptrace.WroteRequestInfo{Err: fmt.Errorf("connection reset")})
+
+	second := rateLimiterTestRequest(t, ctx, http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/second")
+	if !rateLimiterTestAttempt(t, limiter, second) {
+		t.Fatalf("expected the second request to be sent once the first failed")
+	}
+}
+
+func TestRateLimiter_RetriesArePaced(t *testing.T) {
+	var attempts []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, time.Now())
+		if len(attempts) < 4 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// go-azure-sdk retries the throttled request itself, each attempt must wait for the RateLimiter
+	c := client.NewClient(server.URL, "Example", "2020-01-01")
+	ClientOptions{
+		DisableCorrelationRequestID: true,
+		RateLimiter:                 NewRateLimiter(5, 1, server.URL),
+	}.Configure(c, nil)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	request, err := c.NewRequest(ctx, client.RequestOptions{
+		ContentType:         "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{http.StatusOK},
+		HttpMethod:          http.MethodGet,
+		Path:                "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+	})
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	if _, err := c.Execute(ctx, request); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	if len(attempts) != 4 {
+		t.Fatalf("expected 4 attempts but got %d", len(attempts))
+	}
+	for i := 1; i < len(attempts); i++ {
+		// requests are spaced 200ms apart, allowing for the precision of the timer
+		if gap := attempts[i].Sub(attempts[i-1]); gap < 190*time.Millisecond {
+			t.Fatalf("expected attempt %d to be sent at least 200ms after the previous attempt but was sent after %s", i+1, gap)
+		}
+	}
+}
+
+func TestRateLimiter_RoundTripperReleasedWhenTheRequestFails(t *testing.T) {
+	limiter := NewRateLimiter(0, 1, "https://management.azure.com/")
+	transport := limiter.roundTripper(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("connection reset")
+	}))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		request := rateLimiterTestRequest(t, ctx, http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example")
+		if _, err := transport.RoundTrip(request); err == nil || err.Error() != "connection reset" {
+			t.Fatalf("expected request %d to fail with the transport error but got: %+v", i, err)
+		}
+	}
+}
+
+func TestRateLimiter_AdaptsToRemainingRequests(t *testing.T) {
+	limiter := NewRateLimiter(0, 0, "https://management.azure.com/")
+	request := rateLimiterTestRequest(t, context.TODO(), http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example")
+	writes := limiter.forSubscription("00000000-0000-0000-0000-000000000000").writes
+	reads := limiter.forSubscription("00000000-0000-0000-0000-000000000000").reads
+
+	respond := func(header string, remaining string) {
+		response := &http.Response{Header: http.Header{}}
+		response.Header.Set(header, remaining)
+		if _, err := limiter.responseMiddleware()(request, response); err != nil {
+			t.Fatalf("completing the request: %+v", err)
+		}
+	}
+
+	respond(HeaderRateLimitRemainingSubscriptionWrites, "1000")
+	if writes.current != 0 {
+		t.Fatalf("expected writes not to be limited with plenty of requests remaining but got %.2f", writes.current)
+	}
+
+	respond(HeaderRateLimitRemainingSubscriptionWrites, "100")
+	if expected := float64(rateLimitAdaptiveRequestsPerSecond) / 2; writes.current != expected {
+		t.Fatalf("expected writes to be slowed down to %.2f but got %.2f", expected, writes.current)
+	}
+	if reads.current != 0 {
+		t.Fatalf("expected reads not to be limited but got %.2f", reads.current)
+	}
+
+	respond(HeaderRateLimitRemainingSubscriptionWrites, "0")
+	if writes.current != rateLimitMinimumRequestsPerSecond {
+		t.Fatalf("expected writes to be slowed down to %.2f but got %.2f", float64(rateLimitMinimumRequestsPerSecond), writes.current)
+	}
+
+	respond(HeaderRateLimitRemainingSubscriptionWrites, "900")
+	if writes.current != 0 {
+		t.Fatalf("expected writes to be restored once requests are replenished but got %.2f", writes.current)
+	}
+}
+
+func TestRateLimiter_OnlyAppliesToResourceManager(t *testing.T) {
+	limiter := NewRateLimiter(0, 1, "https://management.azure.com/")
+
+	for i := 0; i < 3; i++ {
+		request := rateLimiterTestRequest(t, context.TODO(), http.MethodGet, "https://example.blob.core.windows.net/container")
+		if !rateLimiterTestAttempt(t, limiter, request) {
+			t.Fatalf("expected request %d to be sent", i)
+		}
+	}
+}
+
+func TestSubscriptionIdFromPath(t *testing.T) {
+	testData := map[string]string{
+		"/subscriptions/00000000-0000-0000-0000-000000000000":                        "00000000-0000-0000-0000-000000000000",
+		"/SUBSCRIPTIONS/00000000-0000-0000-0000-000000000000/resourceGroups/example": "00000000-0000-0000-0000-000000000000",
+		"/providers/Microsoft.Management/managementGroups/example":                   "",
+	}
+	for input, expected := range testData {
+		if actual := subscriptionIdFromPath(input); actual != expected {
+			t.Fatalf("expected the Subscription ID for %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
+
+// rateLimiterTestAttempt calls the Request Middleware and then starts an attempt at sending the request (as the
+// transport does), returning whether the attempt was able to acquire a concurrency slot
+func rateLimiterTestAttempt(t *testing.T, limiter *RateLimiter, request *http.Request) bool {
+	if _, err := limiter.requestMiddleware()(request); err != nil {
+		t.Fatalf("calling the request middleware: %+v", err)
+	}
+
+	attempt, ok := request.Context().Value(rateLimiterAttemptKey{}).(*rateLimiterAttempt)
+	if !ok {
+		// the RateLimiter doesn't apply to this request
+		return true
+	}
+	httptrace.ContextClientTrace(request.Context()).GetConn(request.URL.Host)
+
+	attempt.lock.Lock()
+	defer attempt.lock.Unlock()
+	return attempt.release != nil
+}
+
+func rateLimiterTestRequest(t *testing.T, ctx context.Context, method, uri string) *http.Request {
+	request, err := http.NewRequestWithContext(ctx, method, uri, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	return request
+}
@@ -0,0 +1,376 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+const (
+	defaultRetryMaxAttempts = 10
+	defaultRetryBackoff     = 5 * time.Second
+	defaultRetryMaxBackoff  = time.Minute
+)
+
+// RetryRule describes a transient error returned by Azure Resource Manager (for example due to eventual
+// consistency), which is retried automatically rather than being returned to the resource.
+type RetryRule struct {
+	// Namespace optionally limits this rule to requests for the Resource Provider (e.g. `Microsoft.Authorization`)
+	Namespace string
+
+	// StatusCode optionally limits this rule to responses with this HTTP Status Code
+	StatusCode int
+
+	// ErrorCode is the `code` of the error returned by Azure (e.g. `AnotherOperationInProgress`) - this can be
+	// omitted when MessageContains is specified to match any error code
+	ErrorCode string
+
+	// MessageContains optionally limits this rule to errors where the `message` contains this value
+	MessageContains string
+
+	// MaxAttempts is the maximum number of times the request is sent (including the first attempt)
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry, which is doubled for each subsequent retry up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+func (r RetryRule) matches(namespace string, statusCode int, errorCode, message string) bool {
+	if r.Namespace != "" && !strings.EqualFold(r.Namespace, namespace) {
+		return false
+	}
+	if r.StatusCode != 0 && r.StatusCode != statusCode {
+		return false
+	}
+	if r.ErrorCode != "" && !strings.EqualFold(r.ErrorCode, errorCode) {
+		return false
+	}
+	if r.MessageContains != "" && !strings.Contains(strings.ToLower(message), strings.ToLower(r.MessageContains)) {
+		return false
+	}
+	if retriedByClient(statusCode) {
+		return false
+	}
+	return r.ErrorCode != "" || r.MessageContains != ""
+}
+
+// retriedByClient returns whether a response with this status code is already retried by the go-azure-sdk and
+// go-autorest clients, which the RetryPolicy doesn't retry, so that these retries aren't stacked on top of each other
+func retriedByClient(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusFailedDependency, http.StatusTooManyRequests:
+		return true
+	}
+	return statusCode >= http.StatusInternalServerError && statusCode != http.StatusNotImplemented
+}
+
+// delay returns the delay before sending the request for the specified attempt, where the first retry is attempt 2
+func (r RetryRule) delay(attempt int) time.Duration {
+	delay := r.Backoff
+	for i := 2; i < attempt && delay < r.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.MaxBackoff {
+		delay = r.MaxBackoff
+	}
+	return delay
+}
+
+// OperationInProgressRetryRules returns the rules for the errors returned when another operation is in progress on the
+// resource, which a Service can opt into for its Resource Provider (see `sdk.ServiceRegistrationWithRetryRules`) once
+// the Resource Provider is known to return these as transient errors.
+func OperationInProgressRetryRules(namespace string) []RetryRule {
+	return []RetryRule{
+		{
+			Namespace: namespace,
+			ErrorCode: "RetryableError",
+		},
+		{
+			Namespace: namespace,
+			ErrorCode: "RetryableErrorDueToAnotherOperation",
+		},
+		{
+			Namespace: namespace,
+			ErrorCode: "AnotherOperationInProgress",
+		},
+	}
+}
+
+// RetryPolicyOverrides are the overrides for the RetryRules specified in the Provider block
+type RetryPolicyOverrides struct {
+	// MaxAttempts overrides the maximum number of attempts for every rule, when non-zero
+	MaxAttempts int
+
+	// MaxBackoff overrides the maximum delay between attempts for every rule, when non-zero
+	MaxBackoff time.Duration
+
+	// AdditionalErrorCodes are error codes which should also be retried for all Resource Providers
+	AdditionalErrorCodes []string
+
+	// DisabledErrorCodes are error codes which shouldn't be retried, even if they're retried for the Resource Provider
+	DisabledErrorCodes []string
+}
+
+// RetryPolicy retries requests to Azure Resource Manager which fail with a transient error, as described by a set
+// of RetryRules - meaning that resources get consistent handling of eventual consistency, rather than each resource
+// retrying these errors itself.
+type RetryPolicy struct {
+	rules               []RetryRule
+	resourceManagerHost string
+
+	sleep func(ctx context.Context, delay time.Duration) bool
+}
+
+// NewRetryPolicy returns a RetryPolicy for requests to the Resource Manager Endpoint, using the rules with any
+// overrides specified in the Provider block applied.
+func NewRetryPolicy(rules []RetryRule, overrides RetryPolicyOverrides, resourceManagerEndpoint string) *RetryPolicy {
+	host := ""
+	if u, err := url.Parse(resourceManagerEndpoint); err == nil {
+		host = u.Host
+	}
+
+	for _, code := range overrides.AdditionalErrorCodes {
+		rules = append(rules, RetryRule{
+			ErrorCode: code,
+		})
+	}
+
+	output := make([]RetryRule, 0, len(rules))
+	for _, rule := range rules {
+		disabled := false
+		for _, code := range overrides.DisabledErrorCodes {
+			if rule.ErrorCode != "" && strings.EqualFold(rule.ErrorCode, code) {
+				disabled = true
+			}
+		}
+		if disabled {
+			continue
+		}
+		if rule.StatusCode != 0 && retriedByClient(rule.StatusCode) {
+			log.Printf("[WARN] Ignoring the retry rule for %q since responses with the status code %d are already retried", rule.ErrorCode, rule.StatusCode)
+			continue
+		}
+
+		if rule.MaxAttempts == 0 {
+			rule.MaxAttempts = defaultRetryMaxAttempts
+		}
+		if overrides.MaxAttempts != 0 {
+			rule.MaxAttempts = overrides.MaxAttempts
+		}
+		if rule.Backoff == 0 {
+			rule.Backoff = defaultRetryBackoff
+		}
+		if rule.MaxBackoff == 0 {
+			rule.MaxBackoff = defaultRetryMaxBackoff
+		}
+		if overrides.MaxBackoff != 0 {
+			rule.MaxBackoff = overrides.MaxBackoff
+		}
+		if rule.Backoff > rule.MaxBackoff {
+			rule.Backoff = rule.MaxBackoff
+		}
+		output = append(output, rule)
+	}
+
+	return &RetryPolicy{
+		rules:               output,
+		resourceManagerHost: host,
+		sleep: func(ctx context.Context, delay time.Duration) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case <-time.After(delay):
+				return true
+			}
+		},
+	}
+}
+
+// requestMiddleware retains the body of the request, so that the request can be sent again when retried
+func (p *RetryPolicy) requestMiddleware() client.RequestMiddleware {
+	return func(req *http.Request) (*http.Request, error) {
+		return req, retainRequestBody(req)
+	}
+}
+
+// responseMiddleware sends the request again using `transport` whilst the response is a transient error matching
+// one of the rules - such that the retried requests are logged and rate limited in the same way as the original.
+//
+// The Response Middlewares are called once go-azure-sdk has finished retrying the request, and the rules only match
+// responses which go-azure-sdk doesn't retry - as such the RetryPolicy is the only layer retrying these responses.
+func (p *RetryPolicy) responseMiddleware(transport http.RoundTripper) client.ResponseMiddleware {
+	return func(req *http.Request, resp *http.Response) (*http.Response, error) {
+		if !p.isResourceManagerRequest(req) {
+			return resp, nil
+		}
+
+		return p.retry(req, resp, transport), nil
+	}
+}
+
+// roundTripper returns an http.RoundTripper which sends requests using `transport`, sending the request again
+// whilst the response is a transient error matching one of the rules - this is used by the go-autorest clients
+func (p *RetryPolicy) roundTripper(transport http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if !p.isResourceManagerRequest(req) {
+			return transport.RoundTrip(req)
+		}
+
+		if err := retainRequestBody(req); err != nil {
+			return nil, err
+		}
+
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		return p.retry(req, resp, transport), nil
+	})
+}
+
+// retry sends the request again using `transport` whilst the response is a transient error matching one of the
+// rules, returning the last response received. Each attempt is sent once, a retried request which is throttled (or
+// fails with a server error) is instead retried by this loop within the same number of attempts, since the client
+// has already finished retrying the original request.
+func (p *RetryPolicy) retry(req *http.Request, resp *http.Response, transport http.RoundTripper) *http.Response {
+	rule, errorCode := p.match(req, resp)
+	for attempt := 2; rule != nil && attempt <= rule.MaxAttempts; attempt++ {
+		delay := rule.delay(attempt)
+		if retryAfter := retryAfterDelay(resp); retryAfter > delay {
+			delay = retryAfter
+		}
+
+		log.Printf("[DEBUG] Azure returned the transient error %q for %s %s - retrying in %s (attempt %d of %d)", errorCode, req.Method, req.URL.Path, delay, attempt, rule.MaxAttempts)
+		if !p.sleep(req.Context(), delay) {
+			return resp
+		}
+
+		retryReq := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp
+			}
+			retryReq.Body = body
+		}
+
+		retryResp, err := transport.RoundTrip(retryReq)
+		if err != nil {
+			log.Printf("[DEBUG] Retrying %s %s: %+v", req.Method, req.URL.Path, err)
+			return resp
+		}
+
+		resp.Body.Close()
+		resp = retryResp
+
+		if retriedByClient(resp.StatusCode) {
+			errorCode = http.StatusText(resp.StatusCode)
+			continue
+		}
+		rule, errorCode = p.match(req, resp)
+	}
+
+	return resp
+}
+
+// match returns the rule matching the response (and the error code returned by Azure), or nil if the response isn't
+// a transient error
+func (p *RetryPolicy) match(req *http.Request, resp *http.Response) (*RetryRule, string) {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest || resp.Body == nil {
+		return nil, ""
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, ""
+	}
+
+	errorCode, message := parseResourceManagerError(body)
+	namespace := resourceProviderNamespaceFromPath(req.URL.Path)
+	for i := range p.rules {
+		if p.rules[i].matches(namespace, resp.StatusCode, errorCode, message) {
+			return &p.rules[i], errorCode
+		}
+	}
+
+	return nil, ""
+}
+
+func (p *RetryPolicy) isResourceManagerRequest(req *http.Request) bool {
+	return p.resourceManagerHost != "" && strings.EqualFold(req.URL.Host, p.resourceManagerHost)
+}
+
+// retainRequestBody buffers the body of the request, so that the request can be sent again when retried
+func retainRequestBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return nil
+}
+
+// parseResourceManagerError returns the code and message from an error returned by Azure Resource Manager, which is
+// either in the form `{"error": {"code": "", "message": ""}}` or `{"code": "", "message": ""}`
+func parseResourceManagerError(body []byte) (string, string) {
+	type armError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	var out struct {
+		armError
+		Error *armError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", ""
+	}
+
+	if out.Error != nil {
+		return out.Error.Code, out.Error.Message
+	}
+	return out.Code, out.Message
+}
+
+// resourceProviderNamespaceFromPath returns the (last) Resource Provider namespace within the request path, which for
+// an extension resource (e.g. a Role Assignment scoped to a Virtual Machine) is the namespace of the extension
+func resourceProviderNamespaceFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 2; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
+
+func retryAfterDelay(resp *http.Response) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
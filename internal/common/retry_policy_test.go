@@ -0,0 +1,287 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_RetriesTransientErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"example"}` {
+			t.Errorf("expected the request body to be sent on each attempt but got %q", string(body))
+		}
+
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":{"code":"AnotherOperationInProgress","message":"Another operation is in progress"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := retryPolicyForTest(OperationInProgressRetryRules("Microsoft.Network"), RetryPolicyOverrides{}, server.URL)
+	resp := retryPolicyTestSend(t, policy, server.URL+"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example")
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the request to succeed once retried but got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts but got %d", attempts)
+	}
+}
+
+func TestRetryPolicy_RoundTripper(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"example"}` {
+			t.Errorf("expected the request body to be sent on each attempt but got %q", string(body))
+		}
+
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":{"code":"AnotherOperationInProgress","message":"Another operation is in progress"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// the go-autorest clients send requests using the RoundTripper, rather than the middlewares
+	policy := retryPolicyForTest(OperationInProgressRetryRules("Microsoft.Network"), RetryPolicyOverrides{}, server.URL)
+	httpClient := &http.Client{
+		Transport: policy.roundTripper(http.DefaultTransport),
+	}
+	uri := server.URL + "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example"
+	request, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, uri, strings.NewReader(`{"name":"example"}`))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := httpClient.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the request to succeed once retried but got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts but got %d", attempts)
+	}
+}
+
+func TestRetryPolicy_MaxAttempts(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":"PrincipalNotFound","message":"Principal 123 does not exist in the directory"}}`))
+	}))
+	defer server.Close()
+
+	rules := []RetryRule{
+		{
+			Namespace:  "Microsoft.Authorization",
+			StatusCode: http.StatusBadRequest,
+			ErrorCode:  "PrincipalNotFound",
+		},
+	}
+	policy := retryPolicyForTest(rules, RetryPolicyOverrides{MaxAttempts: 4}, server.URL)
+	resp := retryPolicyTestSend(t, policy, server.URL+"/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleAssignments/example")
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected the last error to be returned but got %d", resp.StatusCode)
+	}
+	if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), "PrincipalNotFound") {
+		t.Fatalf("expected the body of the last error to be returned but got %q", string(body))
+	}
+	if attempts != 4 {
+		t.Fatalf("expected 4 attempts but got %d", attempts)
+	}
+
+	// the rule is limited to `Microsoft.Authorization`
+	attempts = 0
+	retryPolicyTestSend(t, policy, server.URL+"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example")
+	if attempts != 1 {
+		t.Fatalf("expected errors from other Resource Providers not to be retried but got %d attempts", attempts)
+	}
+}
+
+func TestRetryPolicy_Overrides(t *testing.T) {
+	policy := NewRetryPolicy(OperationInProgressRetryRules("Microsoft.Network"), RetryPolicyOverrides{
+		MaxBackoff:           10 * time.Second,
+		AdditionalErrorCodes: []string{"SubnetIsFull"},
+		DisabledErrorCodes:   []string{"anotheroperationinprogress"},
+	}, "https://management.azure.com/")
+
+	testData := []struct {
+		code     string
+		expected bool
+	}{
+		{
+			code:     "RetryableError",
+			expected: true,
+		},
+		{
+			code:     "SubnetIsFull",
+			expected: true,
+		},
+		{
+			code:     "AnotherOperationInProgress",
+			expected: false,
+		},
+		{
+			code:     "ResourceNotFound",
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		matched := false
+		for _, rule := range policy.rules {
+			if rule.matches("Microsoft.Network", http.StatusBadRequest, v.code, "") {
+				matched = true
+			}
+		}
+		if matched != v.expected {
+			t.Fatalf("expected %q to be retried to be %t but got %t", v.code, v.expected, matched)
+		}
+	}
+
+	for _, rule := range policy.rules {
+		if rule.MaxBackoff != 10*time.Second {
+			t.Fatalf("expected the `MaxBackoff` to be overridden for every rule but got %s", rule.MaxBackoff)
+		}
+		if rule.delay(10) != 10*time.Second {
+			t.Fatalf("expected the delay to be limited to the `MaxBackoff` but got %s", rule.delay(10))
+		}
+	}
+}
+
+func TestRetryPolicy_OtherHosts(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code":"RetryableError","message":"Please retry"}`))
+	}))
+	defer server.Close()
+
+	policy := retryPolicyForTest(OperationInProgressRetryRules("Microsoft.Network"), RetryPolicyOverrides{}, "https://management.azure.com/")
+	retryPolicyTestSend(t, policy, server.URL+"/example")
+
+	if attempts != 1 {
+		t.Fatalf("expected requests to data plane APIs not to be retried but got %d attempts", attempts)
+	}
+}
+
+func TestRetryPolicy_StatusCodesRetriedByClient(t *testing.T) {
+	// throttled requests and server errors are retried by the go-azure-sdk and go-autorest clients, so aren't retried
+	policy := retryPolicyForTest(append(OperationInProgressRetryRules("Microsoft.Network"), RetryRule{
+		StatusCode: http.StatusTooManyRequests,
+		ErrorCode:  "TooManyRequests",
+	}), RetryPolicyOverrides{}, "https://management.azure.com/")
+	if len(policy.rules) != 3 {
+		t.Fatalf("expected the rule for a status code retried by the clients to be ignored but got %d rules", len(policy.rules))
+	}
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"code":"RetryableError","message":"Please retry"}}`))
+	}))
+	defer server.Close()
+
+	policy = retryPolicyForTest(OperationInProgressRetryRules("Microsoft.Network"), RetryPolicyOverrides{}, server.URL)
+	retryPolicyTestSend(t, policy, server.URL+"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example")
+	if attempts != 1 {
+		t.Fatalf("expected a server error not to be retried by the RetryPolicy but got %d attempts", attempts)
+	}
+}
+
+func TestRetryPolicy_ThrottledRetry(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":{"code":"AnotherOperationInProgress","message":"Another operation is in progress"}}`))
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	// the client has finished retrying the original request, so a retry which is throttled is retried by the policy
+	policy := retryPolicyForTest(OperationInProgressRetryRules("Microsoft.Network"), RetryPolicyOverrides{MaxAttempts: 3}, server.URL)
+	resp := retryPolicyTestSend(t, policy, server.URL+"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example")
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the request to succeed once retried but got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts but got %d", attempts)
+	}
+}
+
+func TestResourceProviderNamespaceFromPath(t *testing.T) {
+	testData := map[string]string{
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example":                                                                                                           "",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Network/locations/westeurope":                                                                                 "Microsoft.Network",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Compute/virtualMachines/vm1":                                                           "Microsoft.Compute",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Compute/virtualMachines/vm1/providers/Microsoft.Authorization/roleAssignments/example": "Microsoft.Authorization",
+	}
+
+	for input, expected := range testData {
+		if actual := resourceProviderNamespaceFromPath(input); actual != expected {
+			t.Fatalf("expected the namespace for %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
+
+func retryPolicyForTest(rules []RetryRule, overrides RetryPolicyOverrides, endpoint string) *RetryPolicy {
+	policy := NewRetryPolicy(rules, overrides, endpoint)
+	policy.sleep = func(ctx context.Context, delay time.Duration) bool {
+		return ctx.Err() == nil
+	}
+	return policy
+}
+
+func retryPolicyTestSend(t *testing.T, policy *RetryPolicy, uri string) *http.Response {
+	request, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, uri, strings.NewReader(`{"name":"example"}`))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	request, err = policy.requestMiddleware()(request)
+	if err != nil {
+		t.Fatalf("calling the request middleware: %+v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+
+	resp, err = policy.responseMiddleware(http.DefaultTransport)(request, resp)
+	if err != nil {
+		t.Fatalf("calling the response middleware: %+v", err)
+	}
+	return resp
+}
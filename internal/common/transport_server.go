@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// TransportServer sends the requests made by all clients using the specified http.RoundTripper, rather than these
+// being sent directly to Azure - for example to record (or replay) the requests made during the Acceptance Tests.
+//
+// go-azure-sdk doesn't support using a custom transport, as such requests from those clients are redirected to a
+// local server which sends them using the http.RoundTripper - whereas the go-autorest clients use it directly.
+type TransportServer struct {
+	transport http.RoundTripper
+
+	address string
+	server  *http.Server
+}
+
+var _ http.RoundTripper = &TransportServer{}
+
+// NewTransportServer starts a local server which sends the requests it receives using `transport`, this must be
+// closed once the clients are no longer used.
+func NewTransportServer(transport http.RoundTripper) (*TransportServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("starting the local server for the Transport: %+v", err)
+	}
+
+	s := &TransportServer{
+		transport: transport,
+		address:   listener.Addr().String(),
+	}
+	s.server = &http.Server{
+		Handler:           http.HandlerFunc(s.serveHTTP),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[ERROR] serving the local server for the Transport: %+v", err)
+		}
+	}()
+
+	return s, nil
+}
+
+// Close stops the local server
+func (s *TransportServer) Close() error {
+	if err := s.server.Close(); err != nil {
+		return fmt.Errorf("stopping the local server for the Transport: %+v", err)
+	}
+	return nil
+}
+
+// RoundTrip sends the request using the http.RoundTripper
+func (s *TransportServer) RoundTrip(request *http.Request) (*http.Response, error) {
+	return s.transport.RoundTrip(request)
+}
+
+// serveHTTP handles the requests redirected to the local server by the Request Middleware
+func (s *TransportServer) serveHTTP(w http.ResponseWriter, request *http.Request) {
+	uri := fmt.Sprintf("https://%s%s", request.Host, request.URL.RequestURI())
+	outbound, err := http.NewRequestWithContext(request.Context(), request.Method, uri, request.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("building the request for %q: %+v", uri, err), http.StatusBadGateway)
+		return
+	}
+	outbound.Header = request.Header.Clone()
+	outbound.ContentLength = request.ContentLength
+
+	// the transport transparently decompresses the response only when it's requested the compression itself
+	outbound.Header.Del("Accept-Encoding")
+	outbound.Header.Del("Connection")
+
+	response, err := s.RoundTrip(outbound)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("sending the request to %q: %+v", uri, err), http.StatusBadGateway)
+		return
+	}
+	defer response.Body.Close()
+
+	for k, v := range response.Header {
+		w.Header()[k] = v
+	}
+	// the body may have been changed by the transport, as such the length is determined when writing the response
+	w.Header().Del("Content-Length")
+	w.WriteHeader(response.StatusCode)
+	if _, err := io.Copy(w, response.Body); err != nil {
+		log.Printf("[DEBUG] writing the response for %q: %+v", uri, err)
+	}
+}
+
+func (s *TransportServer) requestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		// the request is updated in-place, since the other middlewares track requests by pointer
+		request.Host = request.URL.Host
+		request.URL.Scheme = "http"
+		request.URL.Host = s.address
+		return request, nil
+	}
+}
+
+func (s *TransportServer) responseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		// restore the original URI, since this is used by the pollers (and the other middlewares)
+		if request.URL.Host == s.address {
+			request.URL.Scheme = "https"
+			request.URL.Host = request.Host
+			request.Host = ""
+		}
+		return response, nil
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTransportServer_Middleware(t *testing.T) {
+	uri := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example?api-version=2020-06-01"
+
+	var sent string
+	server, err := NewTransportServer(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		sent = request.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":   []string{"application/json"},
+				"Content-Length": []string{"1234"},
+			},
+			Body: io.NopCloser(strings.NewReader(`{"name":"example"}`)),
+		}, nil
+	}))
+	if err != nil {
+		t.Fatalf("building the Transport Server: %+v", err)
+	}
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, uri, nil)
+	redirected, err := server.requestMiddleware()(request)
+	if err != nil {
+		t.Fatalf("redirecting the request: %+v", err)
+	}
+	if redirected != request {
+		t.Fatalf("expected the request to be updated in-place")
+	}
+	if redirected.URL.Host != server.address {
+		t.Fatalf("expected the request to be redirected to %q but got %q", server.address, redirected.URL.Host)
+	}
+
+	response, err := http.DefaultClient.Do(redirected)
+	if err != nil {
+		t.Fatalf("sending the request: %+v", err)
+	}
+	defer response.Body.Close()
+
+	if sent != uri {
+		t.Fatalf("expected the request to be sent to %q using the transport but got %q", uri, sent)
+	}
+
+	if _, err := server.responseMiddleware()(redirected, response); err != nil {
+		t.Fatalf("restoring the request: %+v", err)
+	}
+	if actual := redirected.URL.String(); actual != uri {
+		t.Fatalf("expected the original URI %q to be restored but got %q", uri, actual)
+	}
+
+	if body, _ := io.ReadAll(response.Body); string(body) != `{"name":"example"}` {
+		t.Fatalf("expected the body returned by the transport but got %q", string(body))
+	}
+}
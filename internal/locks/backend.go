@@ -0,0 +1,353 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvBackend specifies the Backend used to serialise changes across Provider processes, either `file` or `blob`
+	EnvBackend = "ARM_LOCK_BACKEND"
+
+	// EnvDirectory specifies the directory used for lock files when EnvBackend is `file`
+	EnvDirectory = "ARM_LOCK_DIRECTORY"
+
+	// EnvStorageContainerURL specifies the Storage Container (e.g. `https://account.blob.core.windows.net/locks`)
+	// used for lock blobs when EnvBackend is `blob`
+	EnvStorageContainerURL = "ARM_LOCK_STORAGE_CONTAINER_URL"
+
+	// EnvTimeout specifies how long to wait for a lock held by another process, as a duration (e.g. `30m`)
+	EnvTimeout = "ARM_LOCK_TIMEOUT"
+
+	// EnvOwner is an optional description of this process (e.g. a pipeline URL), which is logged when another
+	// process is waiting on a lock held by this process
+	EnvOwner = "ARM_LOCK_OWNER"
+
+	// EnvWaitWarning specifies how long to wait on a lock held by another resource before logging a warning naming
+	// the holder of the lock, as a duration (e.g. `1m`) - which is then logged periodically whilst waiting
+	EnvWaitWarning = "ARM_LOCK_WAIT_WARNING"
+
+	BackendTypeFile = "file"
+	BackendTypeBlob = "blob"
+
+	defaultBackendTimeout = 20 * time.Minute
+
+	defaultWaitWarning = 30 * time.Second
+)
+
+// Backend serialises changes to a resource across multiple Provider processes - for example multiple pipelines
+// modifying Subnets within the same Virtual Network - in addition to the in-process locking provided by mutexKV.
+type Backend interface {
+	// TryLock attempts to acquire the lock for the specified key without blocking - returning nil when the lock
+	// has been acquired, or the current Holder when the lock is held by another process.
+	TryLock(ctx context.Context, key string, holder Holder) (*Holder, error)
+
+	// Unlock releases the lock for the specified key, which must have been acquired using TryLock
+	Unlock(ctx context.Context, key string) error
+}
+
+// BackendWithLostLocks is implemented by a Backend whose locks can be lost whilst held, for example when the lease
+// on a lock blob can't be renewed
+type BackendWithLostLocks interface {
+	// Lost returns an error when the lock for the specified key has been lost since it was acquired using TryLock
+	Lost(key string) error
+}
+
+// Holder describes the process holding a lock
+type Holder struct {
+	Key        string    `json:"key"`
+	Hostname   string    `json:"hostname"`
+	ProcessId  int       `json:"pid"`
+	Owner      string    `json:"owner,omitempty"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+func (h Holder) String() string {
+	if h.Hostname == "" && h.ProcessId == 0 {
+		return "an unknown process"
+	}
+
+	out := fmt.Sprintf("process %d on %q", h.ProcessId, h.Hostname)
+	if h.Owner != "" {
+		out += fmt.Sprintf(" (%s)", h.Owner)
+	}
+	if !h.AcquiredAt.IsZero() {
+		out += fmt.Sprintf(" since %s", h.AcquiredAt.Format(time.RFC3339))
+	}
+	return out
+}
+
+// BackendConfig is the configuration for a Backend, sourced from the Environment
+type BackendConfig struct {
+	Type string
+
+	// Directory is the directory used for lock files when Type is `file`
+	Directory string
+
+	// StorageContainerURL is the Storage Container used for lock blobs when Type is `blob`
+	StorageContainerURL string
+
+	// Timeout is how long to wait for a lock held by another process
+	Timeout time.Duration
+}
+
+// BackendConfigFromEnvironment returns the BackendConfig specified in the Environment, or nil when the locks
+// should only be held within this process (the default).
+func BackendConfigFromEnvironment() (*BackendConfig, error) {
+	backendType := strings.ToLower(strings.TrimSpace(os.Getenv(EnvBackend)))
+	if backendType == "" {
+		return nil, nil
+	}
+
+	config := BackendConfig{
+		Type:    backendType,
+		Timeout: defaultBackendTimeout,
+	}
+
+	if v := os.Getenv(EnvTimeout); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("parsing %q: expected a positive duration (e.g. `30m`) but got %q", EnvTimeout, v)
+		}
+		config.Timeout = timeout
+	}
+
+	switch backendType {
+	case BackendTypeFile:
+		config.Directory = os.Getenv(EnvDirectory)
+		if config.Directory == "" {
+			config.Directory = defaultLockDirectory()
+		}
+
+	case BackendTypeBlob:
+		config.StorageContainerURL = os.Getenv(EnvStorageContainerURL)
+		if config.StorageContainerURL == "" {
+			return nil, fmt.Errorf("%q must be specified when %q is set to %q", EnvStorageContainerURL, EnvBackend, BackendTypeBlob)
+		}
+
+	default:
+		return nil, fmt.Errorf("%q must be either %q or %q but got %q", EnvBackend, BackendTypeFile, BackendTypeBlob, backendType)
+	}
+
+	return &config, nil
+}
+
+// distributedLocks acquires the locks from the Backend once the in-process lock for a key is held
+type distributedLocks struct {
+	backend      Backend
+	timeout      time.Duration
+	pollInterval time.Duration
+
+	lock sync.Mutex
+	held map[string]struct{}
+}
+
+var (
+	distributedLock sync.RWMutex
+	distributed     *distributedLocks
+
+	// configuredBackend is the BackendConfig specified by the first Provider block configured within this process
+	// (see ConfigureBackend), which is nil when locking is limited to this process
+	configuredBackend     *BackendConfig
+	configuredBackendDone bool
+)
+
+// ConfigureBackend configures the Backend specified in config, which is built using newBackend - or limits locking
+// to this process when config is nil. Since the locks are shared by every Provider block within this process, the
+// Backend is only configured by the first Provider block - as such the Blob Backend authenticates using the
+// credentials of that Provider block - and an error is returned when a later Provider block specifies a different
+// configuration, rather than replacing the Backend whilst locks may be held using it.
+func ConfigureBackend(config *BackendConfig, newBackend func(config BackendConfig) (Backend, error)) error {
+	distributedLock.Lock()
+	defer distributedLock.Unlock()
+
+	if configuredBackendDone {
+		if !sameBackendConfig(configuredBackend, config) {
+			return fmt.Errorf("the Lock Backend is shared by every Provider block within this process and has already been configured as %s, so can't be configured as %s", describeBackendConfig(configuredBackend), describeBackendConfig(config))
+		}
+		return nil
+	}
+
+	if config != nil {
+		backend, err := newBackend(*config)
+		if err != nil {
+			return err
+		}
+		distributed = newDistributedLocks(backend, config.Timeout)
+	}
+
+	configuredBackend = config
+	configuredBackendDone = true
+	return nil
+}
+
+// useBackend replaces the Backend used to serialise changes across Provider processes, waiting up to the timeout
+// for a lock held by another process - passing a nil Backend limits locking to this process. In either case the
+// Backend can then be configured again using ConfigureBackend.
+func useBackend(backend Backend, timeout time.Duration) {
+	distributedLock.Lock()
+	defer distributedLock.Unlock()
+
+	distributed = nil
+	if backend != nil {
+		distributed = newDistributedLocks(backend, timeout)
+	}
+	configuredBackend = nil
+	configuredBackendDone = false
+}
+
+func newDistributedLocks(backend Backend, timeout time.Duration) *distributedLocks {
+	return &distributedLocks{
+		backend:      backend,
+		timeout:      timeout,
+		pollInterval: 2 * time.Second,
+		held:         make(map[string]struct{}),
+	}
+}
+
+func sameBackendConfig(first, second *BackendConfig) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+	return *first == *second
+}
+
+func describeBackendConfig(config *BackendConfig) string {
+	if config == nil {
+		return "disabled"
+	}
+
+	location := config.Directory
+	if config.Type == BackendTypeBlob {
+		location = config.StorageContainerURL
+	}
+	return fmt.Sprintf("%q using %q with a timeout of %s", config.Type, location, config.Timeout)
+}
+
+func currentBackend() *distributedLocks {
+	distributedLock.RLock()
+	defer distributedLock.RUnlock()
+	return distributed
+}
+
+// lockDistributedWithContext acquires the lock for the key from the Backend (if configured), waiting until either the
+// context is cancelled or the timeout for the Backend is reached
+func lockDistributedWithContext(ctx context.Context, key string) error {
+	d := currentBackend()
+	if d == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	if err := d.acquire(ctx, key); err != nil {
+		return fmt.Errorf("acquiring the lock %q from the Lock Backend: %+v", key, err)
+	}
+
+	return nil
+}
+
+func unlockDistributed(key string) {
+	d := currentBackend()
+	if d == nil {
+		return
+	}
+
+	if err := d.release(context.Background(), key); err != nil {
+		log.Printf("[WARN] Unable to release the lock %q from the Lock Backend: %+v", key, err)
+	}
+}
+
+// lostDistributed returns an error when the lock for the key was acquired from the Backend (if configured) but has
+// since been lost, meaning that it may now be held by another process
+func lostDistributed(key string) error {
+	d := currentBackend()
+	if d == nil {
+		return nil
+	}
+
+	backend, ok := d.backend.(BackendWithLostLocks)
+	if !ok {
+		return nil
+	}
+
+	backendKey := strings.ToLower(key)
+	d.lock.Lock()
+	_, held := d.held[backendKey]
+	d.lock.Unlock()
+	if !held {
+		return nil
+	}
+
+	return backend.Lost(backendKey)
+}
+
+func (d *distributedLocks) acquire(ctx context.Context, key string) error {
+	backendKey := strings.ToLower(key)
+	holder := newHolder(backendKey)
+
+	var blockedBy *Holder
+	for {
+		current, err := d.backend.TryLock(ctx, backendKey, holder)
+		if err != nil {
+			return fmt.Errorf("acquiring lock: %+v", err)
+		}
+
+		if current == nil {
+			if blockedBy != nil {
+				log.Printf("[INFO] Acquired the lock %q which was held by %s", key, *blockedBy)
+			}
+
+			d.lock.Lock()
+			d.held[backendKey] = struct{}{}
+			d.lock.Unlock()
+			return nil
+		}
+
+		if blockedBy == nil || *blockedBy != *current {
+			log.Printf("[INFO] Waiting for the lock %q which is held by %s", key, *current)
+		}
+		blockedBy = current
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the lock %q which is held by %s", key, *blockedBy)
+		case <-time.After(d.pollInterval):
+		}
+	}
+}
+
+func (d *distributedLocks) release(ctx context.Context, key string) error {
+	backendKey := strings.ToLower(key)
+
+	// the lock may not have been acquired from the Backend, in which case there's nothing to release
+	d.lock.Lock()
+	_, ok := d.held[backendKey]
+	delete(d.held, backendKey)
+	d.lock.Unlock()
+	if !ok {
+		return nil
+	}
+
+	return d.backend.Unlock(ctx, backendKey)
+}
+
+func newHolder(key string) Holder {
+	hostname, _ := os.Hostname()
+	return Holder{
+		Key:        key,
+		Hostname:   hostname,
+		ProcessId:  os.Getpid(),
+		Owner:      os.Getenv(EnvOwner),
+		AcquiredAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+const (
+	// blobLeaseDuration is the duration of the lease on a lock blob, which is renewed whilst the lock is held,
+	// meaning that the lock is released shortly after the process holding it is killed
+	blobLeaseDuration = 30 * time.Second
+
+	blobLeaseRenewalInterval = 10 * time.Second
+)
+
+var _ Backend = &BlobBackend{}
+
+// BlobBackend holds locks as leases on blobs within a Storage Container, allowing Provider processes running on
+// different hosts (for example separate CI/CD pipelines) to serialise changes to the same resources.
+type BlobBackend struct {
+	client        *blobs.Client
+	containerName string
+
+	lock   sync.Mutex
+	leases map[string]*blobLease
+}
+
+type blobLease struct {
+	id   string
+	stop chan struct{}
+
+	// lost is the error from renewing the lease once it has been lost, guarded by BlobBackend.lock
+	lost error
+}
+
+func NewBlobBackend(client *blobs.Client, containerName string) *BlobBackend {
+	return &BlobBackend{
+		client:        client,
+		containerName: containerName,
+		leases:        make(map[string]*blobLease),
+	}
+}
+
+func (b *BlobBackend) TryLock(ctx context.Context, key string, holder Holder) (*Holder, error) {
+	blobName := b.blobName(key)
+
+	props, err := b.client.GetProperties(ctx, b.containerName, blobName, blobs.GetPropertiesInput{})
+	if err != nil {
+		if !response.WasNotFound(props.HttpResponse) {
+			return nil, fmt.Errorf("retrieving the lock blob %q: %+v", blobName, err)
+		}
+
+		// another process may create (and lease) the blob concurrently, in which case acquiring the lease fails below
+		content := []byte(key)
+		if _, err := b.client.PutBlockBlob(ctx, b.containerName, blobName, blobs.PutBlockBlobInput{Content: &content}); err != nil {
+			log.Printf("[DEBUG] Creating the lock blob %q: %+v", blobName, err)
+		}
+	}
+
+	lease, err := b.client.AcquireLease(ctx, b.containerName, blobName, blobs.AcquireLeaseInput{
+		LeaseDuration: int(blobLeaseDuration.Seconds()),
+	})
+	if err != nil {
+		if lease.HttpResponse != nil && lease.HttpResponse.StatusCode == http.StatusConflict {
+			return b.currentHolder(ctx, blobName), nil
+		}
+		return nil, fmt.Errorf("acquiring a lease on the lock blob %q: %+v", blobName, err)
+	}
+
+	// the holder is informational, so this isn't fatal
+	if _, err := b.client.SetMetaData(ctx, b.containerName, blobName, blobs.SetMetaDataInput{
+		LeaseID:  &lease.LeaseID,
+		MetaData: holderToMetaData(holder),
+	}); err != nil {
+		log.Printf("[DEBUG] Setting the holder of the lock blob %q: %+v", blobName, err)
+	}
+
+	b.startRenewal(key, blobName, lease.LeaseID)
+	return nil, nil
+}
+
+// Lost returns an error when the lease on the lock blob for the key has been lost whilst the lock is held, such that
+// the lock may now be held by another process
+func (b *BlobBackend) Lost(key string) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if lease, ok := b.leases[key]; ok {
+		return lease.lost
+	}
+	return nil
+}
+
+func (b *BlobBackend) Unlock(ctx context.Context, key string) error {
+	b.lock.Lock()
+	lease, ok := b.leases[key]
+	delete(b.leases, key)
+	b.lock.Unlock()
+	if !ok {
+		return nil
+	}
+
+	close(lease.stop)
+
+	// the lease can't be released once it's been lost, since it may now be held by another process
+	b.lock.Lock()
+	lost := lease.lost
+	b.lock.Unlock()
+	if lost != nil {
+		return lost
+	}
+
+	if _, err := b.client.ReleaseLease(ctx, b.containerName, b.blobName(key), blobs.ReleaseLeaseInput{LeaseID: lease.id}); err != nil {
+		return fmt.Errorf("releasing the lease on the lock blob: %+v", err)
+	}
+
+	return nil
+}
+
+func (b *BlobBackend) currentHolder(ctx context.Context, blobName string) *Holder {
+	props, err := b.client.GetProperties(ctx, b.containerName, blobName, blobs.GetPropertiesInput{})
+	if err != nil {
+		return &Holder{}
+	}
+	return holderFromMetaData(props.MetaData)
+}
+
+// startRenewal renews the lease on the lock blob whilst the lock is held
+func (b *BlobBackend) startRenewal(key, blobName, leaseId string) {
+	lease := &blobLease{
+		id:   leaseId,
+		stop: make(chan struct{}),
+	}
+
+	b.lock.Lock()
+	b.leases[key] = lease
+	b.lock.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(blobLeaseRenewalInterval)
+		defer ticker.Stop()
+
+		renewedAt := time.Now()
+		for {
+			select {
+			case <-lease.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), blobLeaseRenewalInterval)
+				resp, err := b.client.RenewLease(ctx, b.containerName, blobName, blobs.RenewLeaseInput{LeaseID: leaseId})
+				cancel()
+				if err == nil {
+					renewedAt = time.Now()
+					continue
+				}
+
+				// a conflict means the lease is held by another process, otherwise it's retried until it's expired
+				conflict := resp.HttpResponse != nil && resp.HttpResponse.StatusCode == http.StatusConflict
+				if !conflict && time.Since(renewedAt) < blobLeaseDuration {
+					log.Printf("[WARN] Renewing the lease on the lock blob %q, retrying: %+v", blobName, err)
+					continue
+				}
+
+				log.Printf("[ERROR] The lease on the lock blob %q has been lost, so the lock %q is no longer held: %+v", blobName, key, err)
+				b.lock.Lock()
+				lease.lost = fmt.Errorf("the lease on the lock blob %q was lost whilst the lock %q was held, so it may have been acquired by another process: %+v", blobName, key, err)
+				b.lock.Unlock()
+				return
+			}
+		}
+	}()
+}
+
+func (b *BlobBackend) blobName(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:]) + ".lock"
+}
+
+func holderToMetaData(holder Holder) map[string]string {
+	out := map[string]string{
+		"hostname":   holder.Hostname,
+		"pid":        strconv.Itoa(holder.ProcessId),
+		"acquiredat": holder.AcquiredAt.Format(time.RFC3339),
+	}
+	if holder.Owner != "" {
+		out["owner"] = holder.Owner
+	}
+	return out
+}
+
+func holderFromMetaData(input map[string]string) *Holder {
+	holder := &Holder{
+		Hostname: input["hostname"],
+		Owner:    input["owner"],
+	}
+	if v, err := strconv.Atoi(input["pid"]); err == nil {
+		holder.ProcessId = v
+	}
+	if v, err := time.Parse(time.RFC3339, input["acquiredat"]); err == nil {
+		holder.AcquiredAt = v
+	}
+	return holder
+}
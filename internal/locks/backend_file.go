@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var _ Backend = &FileBackend{}
+
+// FileBackend holds locks as files within a directory, allowing multiple Provider processes running on the same
+// host (or sharing a network file system) to serialise changes to the same resources.
+type FileBackend struct {
+	// Directory is the directory containing the lock files
+	Directory string
+
+	// StaleAfter is the duration after which a lock file which hasn't been refreshed is considered abandoned,
+	// for example when the process holding the lock was killed
+	StaleAfter time.Duration
+
+	lock       sync.Mutex
+	heartbeats map[string]chan struct{}
+}
+
+func NewFileBackend(directory string) (*FileBackend, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, fmt.Errorf("creating the lock directory %q: %+v", directory, err)
+	}
+
+	return &FileBackend{
+		Directory:  directory,
+		StaleAfter: 2 * time.Minute,
+		heartbeats: make(map[string]chan struct{}),
+	}, nil
+}
+
+func (b *FileBackend) TryLock(_ context.Context, key string, holder Holder) (*Holder, error) {
+	path := b.path(key)
+
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			contents, _ := json.Marshal(holder)
+			_, err = file.Write(contents)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("writing the lock file %q: %+v", path, err)
+			}
+
+			b.startHeartbeat(key, path)
+			return nil, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("creating the lock file %q: %+v", path, err)
+		}
+
+		current, stale := b.currentHolder(path)
+		if current == nil {
+			// the lock has been released in the meantime, so we can try again
+			continue
+		}
+		if !stale {
+			return current, nil
+		}
+
+		log.Printf("[WARN] Removing the abandoned lock %q held by %s", key, *current)
+		if err := b.removeAbandoned(path); err != nil {
+			return nil, err
+		}
+	}
+
+	// another process acquired the lock in the meantime
+	if current, _ := b.currentHolder(path); current != nil {
+		return current, nil
+	}
+	return &Holder{}, nil
+}
+
+func (b *FileBackend) Unlock(_ context.Context, key string) error {
+	b.lock.Lock()
+	if stop, ok := b.heartbeats[key]; ok {
+		close(stop)
+		delete(b.heartbeats, key)
+	}
+	b.lock.Unlock()
+
+	if err := os.Remove(b.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing the lock file: %+v", err)
+	}
+
+	return nil
+}
+
+// removeAbandoned removes the abandoned lock file at path. Since other processes may also have found this lock file
+// to be abandoned - and one of these may have removed it and acquired the lock in the meantime - the lock file is
+// first renamed to a unique name (which only one process can do), and only removed if it's still abandoned.
+func (b *FileBackend) removeAbandoned(path string) error {
+	abandoned := fmt.Sprintf("%s.%d.%d.abandoned", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, abandoned); err != nil {
+		// another process has removed the lock file already
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("renaming the abandoned lock file %q: %+v", path, err)
+	}
+
+	if _, stale := b.currentHolder(abandoned); stale {
+		if err := os.Remove(abandoned); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing the abandoned lock file %q: %+v", abandoned, err)
+		}
+		return nil
+	}
+
+	// the lock has been acquired by another process since it was found to be abandoned, so the lock file is restored
+	// using a hard link - which (unlike renaming it back) fails rather than replacing a lock file created in the meantime
+	if err := os.Link(abandoned, path); err != nil {
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("restoring the lock file %q: %+v", path, err)
+		}
+		log.Printf("[WARN] Unable to restore the lock file %q, since it was re-created in the meantime", path)
+	}
+	if err := os.Remove(abandoned); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing %q: %+v", abandoned, err)
+	}
+
+	return nil
+}
+
+// currentHolder returns the Holder of the lock file (or nil if the lock file doesn't exist) and whether the lock
+// has been abandoned
+func (b *FileBackend) currentHolder(path string) (*Holder, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+
+	holder := &Holder{}
+
+	// the lock file may still be being written, in which case the holder is unknown
+	if contents, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(contents, holder)
+	}
+
+	return holder, time.Since(info.ModTime()) > b.StaleAfter
+}
+
+// startHeartbeat periodically refreshes the modification time of the lock file, so that it's not considered abandoned
+func (b *FileBackend) startHeartbeat(key, path string) {
+	stop := make(chan struct{})
+
+	b.lock.Lock()
+	b.heartbeats[key] = stop
+	b.lock.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(b.StaleAfter / 4)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				if err := os.Chtimes(path, now, now); err != nil {
+					log.Printf("[WARN] Refreshing the lock file %q: %+v", path, err)
+				}
+			}
+		}
+	}()
+}
+
+func (b *FileBackend) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(b.Directory, hex.EncodeToString(hash[:])+".lock")
+}
+
+func defaultLockDirectory() string {
+	return filepath.Join(os.TempDir(), "terraform-provider-azurerm", "locks")
+}
@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackendConfigFromEnvironment(t *testing.T) {
+	t.Setenv(EnvBackend, "")
+	if config, err := BackendConfigFromEnvironment(); err != nil || config != nil {
+		t.Fatalf("expected no Backend by default but got %+v / %+v", config, err)
+	}
+
+	t.Setenv(EnvBackend, "consul")
+	if _, err := BackendConfigFromEnvironment(); err == nil {
+		t.Fatalf("expected an error for an unsupported Backend")
+	}
+
+	t.Setenv(EnvBackend, BackendTypeBlob)
+	t.Setenv(EnvStorageContainerURL, "")
+	if _, err := BackendConfigFromEnvironment(); err == nil {
+		t.Fatalf("expected an error when the Storage Container URL isn't specified")
+	}
+
+	t.Setenv(EnvBackend, BackendTypeFile)
+	t.Setenv(EnvDirectory, "example")
+	t.Setenv(EnvTimeout, "soon")
+	if _, err := BackendConfigFromEnvironment(); err == nil {
+		t.Fatalf("expected an error for an invalid timeout")
+	}
+
+	t.Setenv(EnvTimeout, "5m")
+	config, err := BackendConfigFromEnvironment()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if config.Type != BackendTypeFile || config.Directory != "example" || config.Timeout != 5*time.Minute {
+		t.Fatalf("unexpected config: %+v", *config)
+	}
+}
+
+func TestFileBackend_Contention(t *testing.T) {
+	directory := t.TempDir()
+	first, err := NewFileBackend(directory)
+	if err != nil {
+		t.Fatalf("building Backend: %+v", err)
+	}
+	second, _ := NewFileBackend(directory)
+
+	ctx := context.TODO()
+	key := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example/providers/microsoft.network/virtualnetworks/example"
+	holder := Holder{Key: key, Hostname: "first", ProcessId: 1234, Owner: "pipeline 1"}
+
+	if current, err := first.TryLock(ctx, key, holder); err != nil || current != nil {
+		t.Fatalf("expected the first process to acquire the lock but got %+v / %+v", current, err)
+	}
+
+	current, err := second.TryLock(ctx, key, Holder{Key: key, Hostname: "second", ProcessId: 5678})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if current == nil || *current != holder {
+		t.Fatalf("expected the lock to be held by %s but got %+v", holder, current)
+	}
+
+	if err := first.Unlock(ctx, key); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+
+	if current, err := second.TryLock(ctx, key, Holder{Key: key, Hostname: "second", ProcessId: 5678}); err != nil || current != nil {
+		t.Fatalf("expected the second process to acquire the lock once released but got %+v / %+v", current, err)
+	}
+	if err := second.Unlock(ctx, key); err != nil {
+		t.Fatalf("unlocking: %+v", err)
+	}
+}
+
+func TestFileBackend_AbandonedLock(t *testing.T) {
+	directory := t.TempDir()
+	abandoned, _ := NewFileBackend(directory)
+	backend, _ := NewFileBackend(directory)
+
+	ctx := context.TODO()
+	if current, err := abandoned.TryLock(ctx, "example", Holder{Hostname: "abandoned", ProcessId: 1}); err != nil || current != nil {
+		t.Fatalf("expected to acquire the lock but got %+v / %+v", current, err)
+	}
+
+	// simulate the process holding the lock being killed, so that the lock file is no longer refreshed
+	abandoned.lock.Lock()
+	close(abandoned.heartbeats["example"])
+	abandoned.lock.Unlock()
+	past := time.Now().Add(-1 * time.Hour)
+	if err := os.Chtimes(backend.path("example"), past, past); err != nil {
+		t.Fatalf("updating the lock file: %+v", err)
+	}
+
+	if current, err := backend.TryLock(ctx, "example", Holder{Hostname: "new", ProcessId: 2}); err != nil || current != nil {
+		t.Fatalf("expected the abandoned lock to be acquired but got %+v / %+v", current, err)
+	}
+	_ = backend.Unlock(ctx, "example")
+}
+
+func TestFileBackend_AbandonedLockReacquired(t *testing.T) {
+	directory := t.TempDir()
+	other, _ := NewFileBackend(directory)
+	backend, _ := NewFileBackend(directory)
+
+	// another process removes the abandoned lock file and acquires the lock, after this process found it abandoned
+	ctx := context.TODO()
+	if current, err := other.TryLock(ctx, "example", Holder{Hostname: "other", ProcessId: 1}); err != nil || current != nil {
+		t.Fatalf("expected to acquire the lock but got %+v / %+v", current, err)
+	}
+	defer other.Unlock(ctx, "example") // nolint: errcheck
+
+	if err := backend.removeAbandoned(backend.path("example")); err != nil {
+		t.Fatalf("removing the abandoned lock: %+v", err)
+	}
+
+	current, stale := backend.currentHolder(backend.path("example"))
+	if current == nil || stale || current.Hostname != "other" {
+		t.Fatalf("expected the lock acquired by the other process to be retained but got %+v (stale: %t)", current, stale)
+	}
+	if entries, _ := os.ReadDir(directory); len(entries) != 1 {
+		t.Fatalf("expected only the lock file to remain but got %d files", len(entries))
+	}
+}
+
+func TestDistributedLocks_Timeout(t *testing.T) {
+	directory := t.TempDir()
+	other, _ := NewFileBackend(directory)
+	backend, _ := NewFileBackend(directory)
+
+	ctx := context.TODO()
+	if _, err := other.TryLock(ctx, "network.example", Holder{Hostname: "other", ProcessId: 1234, Owner: "pipeline 1"}); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	defer other.Unlock(ctx, "network.example") // nolint: errcheck
+
+	locks := &distributedLocks{
+		backend:      backend,
+		timeout:      100 * time.Millisecond,
+		pollInterval: 10 * time.Millisecond,
+		held:         make(map[string]struct{}),
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, locks.timeout)
+	defer cancel()
+	err := locks.acquire(timeoutCtx, "Network.Example")
+	if err == nil {
+		t.Fatalf("expected acquiring a lock held by another process to time out")
+	}
+	if !strings.Contains(err.Error(), `process 1234 on "other" (pipeline 1)`) {
+		t.Fatalf("expected the error to name the holder of the lock but got: %+v", err)
+	}
+
+	// since the lock wasn't acquired, releasing it mustn't remove the other process's lock
+	if err := locks.release(ctx, "Network.Example"); err != nil {
+		t.Fatalf("releasing: %+v", err)
+	}
+	if current, _ := backend.TryLock(ctx, "network.example", Holder{}); current == nil {
+		t.Fatalf("expected the lock to still be held by the other process")
+	}
+}
+
+func TestByNameWithContext_BackendTimeout(t *testing.T) {
+	directory := t.TempDir()
+	other, _ := NewFileBackend(directory)
+	backend, _ := NewFileBackend(directory)
+
+	ctx := context.TODO()
+	if _, err := other.TryLock(ctx, "network.example", Holder{Hostname: "other", ProcessId: 1234}); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	useBackend(backend, 20*time.Millisecond)
+	defer useBackend(nil, 0)
+
+	// the lock held by the other process is given up on once the timeout for the Backend is reached
+	err := ByNameWithContext(ctx, "example", "network")
+	if err == nil || !strings.Contains(err.Error(), `process 1234 on "other"`) {
+		t.Fatalf("expected an error naming the holder of the lock but got: %+v", err)
+	}
+
+	// and the in-process lock is released, so the lock can be acquired once the other process releases it
+	if err := other.Unlock(ctx, "network.example"); err != nil {
+		t.Fatalf("releasing: %+v", err)
+	}
+	if err := ByNameWithContext(ctx, "example", "network"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	UnlockByName("example", "network")
+}
+
+func TestConfigureBackend(t *testing.T) {
+	defer useBackend(nil, 0)
+
+	built := 0
+	newBackend := func(config BackendConfig) (Backend, error) {
+		built++
+		return NewFileBackend(config.Directory)
+	}
+
+	first := &BackendConfig{Type: BackendTypeFile, Directory: t.TempDir(), Timeout: time.Minute}
+	if err := ConfigureBackend(first, newBackend); err != nil {
+		t.Fatalf("configuring: %+v", err)
+	}
+
+	// each Provider block within this process shares the Backend configured by the first
+	second := *first
+	if err := ConfigureBackend(&second, newBackend); err != nil {
+		t.Fatalf("configuring the same Backend again: %+v", err)
+	}
+	if built != 1 {
+		t.Fatalf("expected the Backend to be built once but it was built %d times", built)
+	}
+
+	// whereas a different configuration is rejected, rather than replacing the Backend
+	for _, config := range []*BackendConfig{
+		nil,
+		{Type: BackendTypeFile, Directory: t.TempDir(), Timeout: time.Minute},
+		{Type: BackendTypeFile, Directory: first.Directory, Timeout: time.Hour},
+	} {
+		if err := ConfigureBackend(config, newBackend); err == nil {
+			t.Fatalf("expected an error configuring %s", describeBackendConfig(config))
+		}
+	}
+	if current := currentBackend(); current == nil || current.timeout != time.Minute {
+		t.Fatalf("expected the first Backend to still be used")
+	}
+
+	// as is enabling a Backend when locking has been limited to this process
+	useBackend(nil, 0)
+	if err := ConfigureBackend(nil, newBackend); err != nil {
+		t.Fatalf("configuring: %+v", err)
+	}
+	if err := ConfigureBackend(first, newBackend); err == nil {
+		t.Fatalf("expected an error enabling the Backend once locking has been limited to this process")
+	}
+}
+
+type lostLockBackend struct {
+	*FileBackend
+}
+
+func (lostLockBackend) Lost(key string) error {
+	return fmt.Errorf("the lease on %q was lost", key)
+}
+
+func TestLostByName(t *testing.T) {
+	backend, _ := NewFileBackend(t.TempDir())
+	useBackend(lostLockBackend{backend}, time.Second)
+	defer useBackend(nil, 0)
+
+	if err := LostByName("example", "network"); err != nil {
+		t.Fatalf("expected a lock which isn't held to not be lost but got: %+v", err)
+	}
+
+	ctx := WithResourceAddress(context.TODO(), "azurerm_subnet.example")
+	if err := ByNameWithContext(ctx, "example", "network"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	if err := LostByName("example", "network"); err == nil || !strings.Contains(err.Error(), "network.example") {
+		t.Fatalf("expected the lost lock to return an error but got: %+v", err)
+	}
+
+	UnlockByName("example", "network")
+	if warnings := Warnings(ctx); len(warnings) != 1 || !strings.Contains(warnings[0], "network.example") {
+		t.Fatalf("expected a warning for the lost lock once it was released but got: %+v", warnings)
+	}
+}
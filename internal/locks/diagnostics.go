@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+type lockContextKey struct{}
+
+// lockContext tracks the locks held by a single resource operation, which is used to detect locks being acquired
+// in an inconsistent order across resources, and two resources each waiting on a lock held by the other
+type lockContext struct {
+	address string
+
+	// waiting is the key of the lock this resource is currently waiting on, guarded by mutexKV.lock
+	waiting string
+
+	lock     sync.Mutex
+	held     []string
+	warnings []string
+}
+
+// WithResourceAddress returns a context recording the address of the resource acquiring locks (for example
+// `azurerm_subnet (/subscriptions/.../subnets/example)`), which is logged when another caller is waiting on a lock
+// held by this resource, and which allows the order the locks are acquired in to be checked.
+func WithResourceAddress(ctx context.Context, address string) context.Context {
+	return context.WithValue(ctx, lockContextKey{}, &lockContext{
+		address: address,
+	})
+}
+
+// WithResourceAddressFrom returns a context recording the same resource as source (see WithResourceAddress), such
+// that the locks acquired using either context are tracked for the same resource
+func WithResourceAddressFrom(ctx context.Context, source context.Context) context.Context {
+	if c := lockContextFrom(source); c != nil {
+		return context.WithValue(ctx, lockContextKey{}, c)
+	}
+	return ctx
+}
+
+// HasResourceAddress returns whether the context records the address of the resource acquiring locks
+func HasResourceAddress(ctx context.Context) bool {
+	return lockContextFrom(ctx) != nil
+}
+
+// Warnings returns a warning for each lock which the resource recorded in the context has waited on for longer
+// than the interval specified in EnvWaitWarning, or which was lost from the Lock Backend whilst held by the resource,
+// so that these can be surfaced as diagnostics
+func Warnings(ctx context.Context) []string {
+	if c := lockContextFrom(ctx); c != nil {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return append([]string{}, c.warnings...)
+	}
+	return nil
+}
+
+func lockContextFrom(ctx context.Context) *lockContext {
+	if v, ok := ctx.Value(lockContextKey{}).(*lockContext); ok {
+		return v
+	}
+	return nil
+}
+
+func (c *lockContext) acquired(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.held = append(c.held, key)
+}
+
+func (c *lockContext) released(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i, v := range c.held {
+		if v == key {
+			c.held = append(c.held[:i], c.held[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *lockContext) currentlyHeld() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]string{}, c.held...)
+}
+
+func (c *lockContext) holds(key string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, v := range c.held {
+		if v == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *lockContext) warn(message string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.warnings = append(c.warnings, message)
+}
+
+// lockOrdering records the order in which pairs of locks have been acquired, so that two resources acquiring the
+// same locks in the opposite order (which can deadlock if both run at the same time) can be logged
+type lockOrdering struct {
+	lock sync.Mutex
+
+	// acquiredBefore maps a pair of keys to the address which acquired the first key before the second, for as long
+	// as either key is held (see released) - such that this is bounded by the locks currently held
+	acquiredBefore map[[2]string]string
+}
+
+// check records that the key is being acquired whilst holding the held keys, returning a warning when another
+// caller has previously acquired these locks in the opposite order. This isn't an error, since the locks may have
+// been acquired one after the other (for example moving one App Service Slot from Plan A to Plan B, and another from
+// Plan B to Plan A) - only two callers waiting on each other at the same time is (see mutexKV.waitingFor).
+func (o *lockOrdering) check(held []string, key string, address string) string {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	warning := ""
+	for _, h := range held {
+		if h == key {
+			continue
+		}
+
+		if other, ok := o.acquiredBefore[[2]string{key, h}]; ok && warning == "" {
+			warning = fmt.Sprintf("inconsistent lock ordering: %s acquired the lock %q before %q, but %s is acquiring them in the opposite order - which can deadlock if both run at the same time", other, key, h, address)
+		}
+	}
+
+	for _, h := range held {
+		if _, ok := o.acquiredBefore[[2]string{h, key}]; !ok && h != key {
+			o.acquiredBefore[[2]string{h, key}] = address
+		}
+	}
+
+	return warning
+}
+
+// released removes the pairs of keys including the key which has been released, where the other key isn't held
+func (o *lockOrdering) released(key string, isHeld func(key string) bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	for pair := range o.acquiredBefore {
+		other := ""
+		switch key {
+		case pair[0]:
+			other = pair[1]
+		case pair[1]:
+			other = pair[0]
+		default:
+			continue
+		}
+
+		if !isHeld(other) {
+			delete(o.acquiredBefore, pair)
+		}
+	}
+}
+
+// callerAddress returns the name of the function acquiring the lock (e.g. `network.resourceSubnetCreate`), which is
+// used to identify the holder of a lock when the resource address isn't available in the context
+func callerAddress() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "/internal/locks.") {
+			name := frame.Function
+			if i := strings.LastIndex(name, "/"); i >= 0 {
+				name = name[i+1:]
+			}
+			if name == "" {
+				break
+			}
+			return name
+		}
+		if !more {
+			break
+		}
+	}
+
+	return "an unknown caller"
+}
+
+func addressFromContext(ctx context.Context) string {
+	if c := lockContextFrom(ctx); c != nil && c.address != "" {
+		return c.address
+	}
+	return callerAddress()
+}
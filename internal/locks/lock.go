@@ -3,36 +3,105 @@
 
 package locks
 
+import (
+	"context"
+	"sort"
+)
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = newMutexKV()
 
-func ByID(id string) {
-	armMutexKV.Lock(id)
+// ByIDWithContext acquires the lock for the specified ID, returning an error naming the current holder of the lock
+// if it can't be acquired before the context is cancelled
+func ByIDWithContext(ctx context.Context, id string) error {
+	if err := armMutexKV.LockWithContext(ctx, id); err != nil {
+		return err
+	}
+
+	if err := lockDistributedWithContext(ctx, id); err != nil {
+		armMutexKV.Unlock(id)
+		return err
+	}
+
+	return nil
 }
 
-// handle the case of using the same name for different kinds of resources
-func ByName(name string, resourceType string) {
-	updatedName := resourceType + "." + name
-	armMutexKV.Lock(updatedName)
+// ByNameWithContext acquires the lock for the specified name of the resource type, returning an error naming the
+// current holder of the lock if it can't be acquired before the context is cancelled - the resource type handles the
+// case of using the same name for different kinds of resources
+func ByNameWithContext(ctx context.Context, name string, resourceType string) error {
+	return ByIDWithContext(ctx, resourceType+"."+name)
 }
 
-func MultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+// MultipleByNameWithContext acquires the locks for each of the names in a consistent (sorted) order, such that two
+// resources locking an overlapping set of names can't deadlock - releasing any acquired locks if all of the locks
+// can't be acquired before the context is cancelled
+func MultipleByNameWithContext(ctx context.Context, names *[]string, resourceType string) error {
+	newSlice := sortedNames(*names)
 
-	for _, name := range newSlice {
-		ByName(name, resourceType)
+	for i, name := range newSlice {
+		if err := ByNameWithContext(ctx, name, resourceType); err != nil {
+			for _, acquired := range newSlice[:i] {
+				UnlockByName(acquired, resourceType)
+			}
+			return err
+		}
 	}
+
+	return nil
+}
+
+// MultipleByIDWithContext acquires the locks for each of the IDs in a consistent (sorted) order, releasing any
+// acquired locks if all of the locks can't be acquired before the context is cancelled
+func MultipleByIDWithContext(ctx context.Context, ids *[]string) error {
+	newSlice := sortedNames(*ids)
+
+	for i, id := range newSlice {
+		if err := ByIDWithContext(ctx, id); err != nil {
+			for _, acquired := range newSlice[:i] {
+				UnlockByID(acquired)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LostByID returns an error when the lock for the specified ID has been lost from the Lock Backend since it was
+// acquired (for example as the lease on the lock blob couldn't be renewed), in which case the lock may now be held
+// by another process - allowing callers performing long-running operations to stop rather than continue unlocked
+func LostByID(id string) error {
+	return lostDistributed(id)
+}
+
+// LostByName returns an error when the lock for the specified name of the resource type has been lost from the
+// Lock Backend since it was acquired (see LostByID)
+func LostByName(name string, resourceType string) error {
+	return lostDistributed(resourceType + "." + name)
 }
 
 func UnlockByID(id string) {
+	warnIfLost(id)
+	unlockDistributed(id)
 	armMutexKV.Unlock(id)
 }
 
 func UnlockByName(name string, resourceType string) {
 	updatedName := resourceType + "." + name
+	warnIfLost(updatedName)
+	unlockDistributed(updatedName)
 	armMutexKV.Unlock(updatedName)
 }
 
+func UnlockMultipleByID(ids *[]string) {
+	newSlice := removeDuplicatesFromStringArray(*ids)
+
+	for _, id := range newSlice {
+		UnlockByID(id)
+	}
+}
+
 func UnlockMultipleByName(names *[]string, resourceType string) {
 	newSlice := removeDuplicatesFromStringArray(*names)
 
@@ -40,3 +109,17 @@ func UnlockMultipleByName(names *[]string, resourceType string) {
 		UnlockByName(name, resourceType)
 	}
 }
+
+// warnIfLost records a warning against the resource holding the lock when the lock was lost from the Lock Backend
+// whilst held, since another process may have modified the resource at the same time
+func warnIfLost(key string) {
+	if err := lostDistributed(key); err != nil {
+		armMutexKV.warnHolder(key, err.Error())
+	}
+}
+
+func sortedNames(names []string) []string {
+	out := removeDuplicatesFromStringArray(names)
+	sort.Strings(out)
+	return out
+}
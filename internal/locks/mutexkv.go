@@ -4,8 +4,12 @@
 package locks
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"os"
 	"sync"
+	"time"
 )
 
 // mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
@@ -13,39 +17,223 @@ import (
 // keys they must serialize on.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*keyedMutex
+
+	// warnAfter is the interval at which a warning is logged whilst waiting on a lock
+	warnAfter time.Duration
+
+	ordering *lockOrdering
+}
+
+// keyedMutex is a mutex which can be acquired with a context, and which tracks its current holder
+type keyedMutex struct {
+	ch chan struct{}
+
+	// holder is the current holder of the lock, guarded by mutexKV.lock
+	holder *lockHolder
+}
+
+// lockHolder describes the caller currently holding a lock
+type lockHolder struct {
+	// address is the address of the resource which acquired the lock, or the calling function when unknown
+	address    string
+	acquiredAt time.Time
+
+	// owner tracks the locks held by the resource which acquired this lock, if known
+	owner *lockContext
 }
 
-// Locks the mutex for the given key. Caller is responsible for calling Unlock
-// for the same key
-func (m *mutexKV) Lock(key string) {
+func (h *lockHolder) String() string {
+	if h == nil {
+		return "an unknown caller"
+	}
+	return fmt.Sprintf("%s (for %s)", h.address, time.Since(h.acquiredAt).Round(time.Second))
+}
+
+// LockWithContext locks the mutex for the given key, waiting until the context is cancelled - logging a warning
+// naming the current holder periodically whilst waiting. When the context records the resource acquiring the lock,
+// an error is returned without waiting further when the holder of this lock is waiting on a lock held by this
+// resource (since neither can continue), and a warning is recorded for this resource once the lock is acquired if it
+// was waited on for longer than warnAfter (see Warnings). Caller is responsible for calling Unlock for the same key
+func (m *mutexKV) LockWithContext(ctx context.Context, key string) error {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
+	address := addressFromContext(ctx)
+	owner := lockContextFrom(ctx)
+	if owner != nil {
+		if warning := m.ordering.check(owner.currentlyHeld(), key, address); warning != "" {
+			log.Printf("[WARN] %s", warning)
+		}
+	}
+
+	mutex := m.get(key)
+
+	select {
+	case mutex.ch <- struct{}{}:
+	default:
+		if owner != nil {
+			if err := m.waitingFor(owner, mutex, key); err != nil {
+				return err
+			}
+			defer m.stoppedWaiting(owner)
+		}
+
+		waitingSince := time.Now()
+		ticker := time.NewTicker(m.warnAfter)
+		defer ticker.Stop()
+
+		// heldBy is the last known holder of the lock, which is cleared when the lock is released
+		heldBy := "an unknown caller"
+		warned := false
+	wait:
+		for {
+			select {
+			case mutex.ch <- struct{}{}:
+				break wait
+			case <-ctx.Done():
+				return fmt.Errorf("waiting for the lock %q which is held by %s: %+v", key, m.holder(mutex), ctx.Err())
+			case <-ticker.C:
+				holder := m.holder(mutex)
+				if holder != nil {
+					heldBy = holder.address
+				}
+				warned = true
+				log.Printf("[WARN] %s has been waiting %s for the lock %q which is held by %s", address, time.Since(waitingSince).Round(time.Second), key, holder)
+			}
+		}
+
+		if owner != nil && warned {
+			owner.warn(fmt.Sprintf("waited %s for the lock %q, which was held by %s", time.Since(waitingSince).Round(time.Second), key, heldBy))
+		}
+	}
+
+	m.lock.Lock()
+	mutex.holder = &lockHolder{
+		address:    address,
+		acquiredAt: time.Now(),
+		owner:      owner,
+	}
+	m.lock.Unlock()
+
+	if owner != nil {
+		owner.acquired(key)
+	}
+
 	log.Printf("[DEBUG] Locked %q", key)
+	return nil
+}
+
+// waitingFor records that the owner is waiting on the lock for the key, returning an error when the holder of this
+// lock is itself waiting on a lock held by the owner - which would otherwise wait until either context is cancelled.
+//
+// This is checked (and the lock being waited on recorded) whilst holding m.lock, such that when two resources start
+// waiting on each other at the same time, the second of these to start waiting detects this.
+func (m *mutexKV) waitingFor(owner *lockContext, mutex *keyedMutex, key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if holder := mutex.holder; holder != nil && holder.owner != nil && holder.owner != owner {
+		if waiting := holder.owner.waiting; waiting != "" && owner.holds(waiting) {
+			return fmt.Errorf("deadlock: %s is waiting for the lock %q which is held by %s, which is waiting for the lock %q held by %s - these locks should be acquired in a consistent order", owner.address, key, holder.owner.address, waiting, owner.address)
+		}
+	}
+
+	owner.waiting = key
+	return nil
+}
+
+func (m *mutexKV) stoppedWaiting(owner *lockContext) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	owner.waiting = ""
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+	mutex := m.get(key)
+
+	m.lock.Lock()
+	if holder := mutex.holder; holder != nil && holder.owner != nil {
+		holder.owner.released(key)
+	}
+	mutex.holder = nil
+	m.lock.Unlock()
+
+	select {
+	case <-mutex.ch:
+	default:
+		panic(fmt.Sprintf("unlock of unlocked lock %q", key))
+	}
+	m.ordering.released(key, m.isHeld)
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
+// isHeld returns whether the lock for the given key is currently held
+func (m *mutexKV) isHeld(key string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	mutex, ok := m.store[key]
+	return ok && len(mutex.ch) > 0
+}
+
+// warnHolder records the warning against the resource currently holding the lock for the key, if known
+func (m *mutexKV) warnHolder(key string, message string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if mutex, ok := m.store[key]; ok && mutex.holder != nil && mutex.holder.owner != nil {
+		mutex.holder.owner.warn(message)
+	}
+}
+
 // Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+func (m *mutexKV) get(key string) *keyedMutex {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	mutex, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
+		mutex = &keyedMutex{
+			ch: make(chan struct{}, 1),
+		}
 		m.store[key] = mutex
 	}
 	return mutex
 }
 
+// holder returns a copy of the current holder of the mutex
+func (m *mutexKV) holder(mutex *keyedMutex) *lockHolder {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if mutex.holder == nil {
+		return nil
+	}
+	holder := *mutex.holder
+	return &holder
+}
+
 // newMutexKV returns a properly initialized mutexKV
 func newMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store:     make(map[string]*keyedMutex),
+		warnAfter: waitWarningFromEnvironment(),
+		ordering: &lockOrdering{
+			acquiredBefore: make(map[[2]string]string),
+		},
+	}
+}
+
+// waitWarningFromEnvironment returns the interval specified in EnvWaitWarning, after which a warning is logged (and
+// surfaced as a diagnostic) whilst waiting on a lock - defaulting to 30 seconds
+func waitWarningFromEnvironment() time.Duration {
+	v := os.Getenv(EnvWaitWarning)
+	if v == "" {
+		return defaultWaitWarning
 	}
+
+	interval, err := time.ParseDuration(v)
+	if err != nil || interval <= 0 {
+		log.Printf("[WARN] parsing %q: expected a positive duration (e.g. `1m`) but got %q - using the default of %s", EnvWaitWarning, v, defaultWaitWarning)
+		return defaultWaitWarning
+	}
+
+	return interval
 }
@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKV_LockWithContextTimeout(t *testing.T) {
+	m := newMutexKV()
+
+	holder := WithResourceAddress(context.TODO(), "azurerm_subnet (example)")
+	if err := m.LockWithContext(holder, "network.example"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	err := m.LockWithContext(ctx, "network.example")
+	if err == nil {
+		t.Fatalf("expected acquiring a held lock to time out")
+	}
+	if !strings.Contains(err.Error(), "azurerm_subnet (example)") {
+		t.Fatalf("expected the error to name the holder of the lock but got: %+v", err)
+	}
+
+	m.Unlock("network.example")
+	if err := m.LockWithContext(context.TODO(), "network.example"); err != nil {
+		t.Fatalf("expected the lock to be acquired once released but got: %+v", err)
+	}
+	m.Unlock("network.example")
+}
+
+func TestMutexKV_UnlockOfUnlockedLock(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected unlocking an unlocked lock to panic")
+		}
+	}()
+
+	newMutexKV().Unlock("example")
+}
+
+func TestMutexKV_LockOrdering(t *testing.T) {
+	m := newMutexKV()
+
+	first := WithResourceAddress(context.TODO(), "azurerm_first")
+	_ = m.LockWithContext(first, "a")
+	_ = m.LockWithContext(first, "b")
+	m.Unlock("b")
+
+	if warning := m.ordering.check([]string{"a"}, "b", "azurerm_second"); warning != "" {
+		t.Fatalf("expected acquiring `a` then `b` to be consistent but got: %s", warning)
+	}
+	if warning := m.ordering.check([]string{"b"}, "a", "azurerm_third"); !strings.Contains(warning, "azurerm_first") {
+		t.Fatalf("expected acquiring `b` then `a` to return a warning naming `azurerm_first` but got: %q", warning)
+	}
+
+	m.Unlock("a")
+	if held := lockContextFrom(first).currentlyHeld(); len(held) != 0 {
+		t.Fatalf("expected no locks to be held once released but got %+v", held)
+	}
+	if len(m.ordering.acquiredBefore) != 0 {
+		t.Fatalf("expected the lock ordering to be forgotten once neither lock is held but got %+v", m.ordering.acquiredBefore)
+	}
+
+	// acquiring the locks in the opposite order once they've been released can't deadlock, so isn't an error
+	third := WithResourceAddress(context.TODO(), "azurerm_third")
+	if err := m.LockWithContext(third, "b"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	if err := m.LockWithContext(third, "a"); err != nil {
+		t.Fatalf("expected acquiring `b` then `a` once released to succeed but got: %+v", err)
+	}
+	m.Unlock("a")
+	m.Unlock("b")
+}
+
+func TestMutexKV_Deadlock(t *testing.T) {
+	m := newMutexKV()
+
+	first := WithResourceAddress(context.TODO(), "azurerm_first")
+	second := WithResourceAddress(context.TODO(), "azurerm_second")
+	if err := m.LockWithContext(first, "a"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	if err := m.LockWithContext(second, "b"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	// `azurerm_first` waits on `b` until `azurerm_second` fails to acquire `a` and releases `b`
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- m.LockWithContext(first, "b")
+	}()
+	for {
+		m.lock.Lock()
+		waiting := lockContextFrom(first).waiting
+		m.lock.Unlock()
+		if waiting == "b" {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(second, 5*time.Second)
+	defer cancel()
+	err := m.LockWithContext(ctx, "a")
+	if err == nil || !strings.Contains(err.Error(), "deadlock") || ctx.Err() != nil {
+		t.Fatalf("expected acquiring `a` to return a deadlock error without waiting but got: %+v", err)
+	}
+	m.Unlock("b")
+
+	if err := <-acquired; err != nil {
+		t.Fatalf("expected `azurerm_first` to acquire `b` once released but got: %+v", err)
+	}
+	m.Unlock("b")
+	m.Unlock("a")
+}
+
+func TestMutexKV_Warnings(t *testing.T) {
+	m := newMutexKV()
+	m.warnAfter = 10 * time.Millisecond
+
+	if err := m.LockWithContext(WithResourceAddress(context.TODO(), "azurerm_holder"), "a"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		m.Unlock("a")
+	}()
+
+	waiter := WithResourceAddress(context.TODO(), "azurerm_waiter")
+	if err := m.LockWithContext(waiter, "a"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	m.Unlock("a")
+
+	warnings := Warnings(waiter)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "azurerm_holder") {
+		t.Fatalf("expected a warning naming `azurerm_holder` but got %+v", warnings)
+	}
+}
+
+func TestWaitWarningFromEnvironment(t *testing.T) {
+	t.Setenv(EnvWaitWarning, "")
+	if v := waitWarningFromEnvironment(); v != defaultWaitWarning {
+		t.Fatalf("expected %s but got %s", defaultWaitWarning, v)
+	}
+
+	t.Setenv(EnvWaitWarning, "2m")
+	if v := waitWarningFromEnvironment(); v != 2*time.Minute {
+		t.Fatalf("expected 2m but got %s", v)
+	}
+
+	t.Setenv(EnvWaitWarning, "invalid")
+	if v := waitWarningFromEnvironment(); v != defaultWaitWarning {
+		t.Fatalf("expected an invalid value to use %s but got %s", defaultWaitWarning, v)
+	}
+}
+
+func TestMutexKV_LockOrderingIsBounded(t *testing.T) {
+	m := newMutexKV()
+
+	// e.g. a Subnet locking its Virtual Network, with the Subnets created one after the other
+	for i := 0; i < 100; i++ {
+		ctx := WithResourceAddress(context.TODO(), fmt.Sprintf("azurerm_subnet.example%d", i))
+		if err := m.LockWithContext(ctx, "network"); err != nil {
+			t.Fatalf("acquiring: %+v", err)
+		}
+		if err := m.LockWithContext(ctx, fmt.Sprintf("subnet%d", i)); err != nil {
+			t.Fatalf("acquiring: %+v", err)
+		}
+		if len(m.ordering.acquiredBefore) != 1 {
+			t.Fatalf("expected only the order of the locks currently held to be recorded but got %+v", m.ordering.acquiredBefore)
+		}
+		m.Unlock(fmt.Sprintf("subnet%d", i))
+		m.Unlock("network")
+	}
+
+	if len(m.ordering.acquiredBefore) != 0 {
+		t.Fatalf("expected the lock ordering to be forgotten once the locks were released but got %+v", m.ordering.acquiredBefore)
+	}
+}
+
+func TestMultipleByNameWithContext_ConsistentOrdering(t *testing.T) {
+	names := [][]string{
+		{"subnet1", "subnet2", "subnet3"},
+		{"subnet3", "subnet2", "subnet1"},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		for _, v := range names {
+			wg.Add(1)
+			go func(names []string) {
+				defer wg.Done()
+				if err := MultipleByNameWithContext(context.TODO(), &names, "azurerm_subnet"); err != nil {
+					t.Errorf("acquiring %+v: %+v", names, err)
+					return
+				}
+				UnlockMultipleByName(&names, "azurerm_subnet")
+			}(append([]string{}, v...))
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatalf("deadlocked acquiring multiple locks in a different order")
+	}
+}
+
+func TestMultipleByNameWithContext_ReleasesOnTimeout(t *testing.T) {
+	if err := ByNameWithContext(context.TODO(), "subnet2", "azurerm_example"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	names := []string{"subnet2", "subnet1"}
+	if err := MultipleByNameWithContext(ctx, &names, "azurerm_example"); err == nil {
+		t.Fatalf("expected acquiring a held lock to time out")
+	}
+	UnlockByName("subnet2", "azurerm_example")
+
+	// `subnet1` was acquired first, so must have been released
+	if err := ByNameWithContext(context.TODO(), "subnet1", "azurerm_example"); err != nil {
+		t.Fatalf("acquiring: %+v", err)
+	}
+	UnlockByName("subnet1", "azurerm_example")
+}
+
+func TestMultipleByIDWithContext_OppositeOrder(t *testing.T) {
+	// e.g. moving one App Service Slot from Plan A to Plan B, and another from Plan B to Plan A
+	for _, ids := range [][]string{{"planA", "planB"}, {"planB", "planA"}} {
+		ctx := WithResourceAddress(context.TODO(), "azurerm_linux_web_app_slot")
+		if err := MultipleByIDWithContext(ctx, &ids); err != nil {
+			t.Fatalf("acquiring %+v: %+v", ids, err)
+		}
+		if held := lockContextFrom(ctx).currentlyHeld(); held[0] != "planA" {
+			t.Fatalf("expected the locks to be acquired in a consistent order but got %+v", held)
+		}
+		UnlockMultipleByID(&ids)
+	}
+}
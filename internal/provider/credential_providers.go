@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaClientCertificateProvider() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:          pluginsdk.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"client_certificate", "client_certificate_path", "client_secret", "client_secret_file_path", "client_secret_provider"},
+		Description:   "Signs the Client Assertion used when authenticating as a Service Principal using a Client Certificate held in Key Vault or a PKCS#11 token, rather than loading the private key.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"key_vault": {
+					Type:         pluginsdk.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: []string{"client_certificate_provider.0.key_vault", "client_certificate_provider.0.pkcs11"},
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"certificate_id": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.IsURLWithHTTPS,
+								Description:  "The ID of the Key Vault Certificate associated with the Service Principal.",
+							},
+
+							"bootstrap_client_id": {
+								Type:         pluginsdk.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.IsUUID,
+								Description:  "The Client ID of the identity used to access Key Vault, which authenticates using Managed Identity, OIDC or Azure CLI.",
+							},
+						},
+					},
+				},
+
+				"pkcs11": {
+					Type:         pluginsdk.TypeList,
+					Optional:     true,
+					MaxItems:     1,
+					ExactlyOneOf: []string{"client_certificate_provider.0.key_vault", "client_certificate_provider.0.pkcs11"},
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"module_path": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+								Description:  "The path to the PKCS#11 module for the token containing the Client Certificate.",
+							},
+
+							"slot_id": {
+								Type:         pluginsdk.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntAtLeast(0),
+								Description:  "The ID of the slot containing the token.",
+							},
+
+							"key_label": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+								Description:  "The label of the private key and Client Certificate within the token.",
+							},
+
+							"pin": {
+								Type:        pluginsdk.TypeString,
+								Optional:    true,
+								Sensitive:   true,
+								DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE_PKCS11_PIN", nil),
+								Description: "The PIN used to log in to the token.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaClientSecretProvider() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:          pluginsdk.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"client_certificate", "client_certificate_path", "client_secret", "client_secret_file_path", "client_certificate_provider"},
+		Description:   "Retrieves the Client Secret used when authenticating as a Service Principal from Key Vault.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"key_vault": {
+					Type:     pluginsdk.TypeList,
+					Required: true,
+					MaxItems: 1,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"secret_id": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.IsURLWithHTTPS,
+								Description:  "The ID of the Key Vault Secret containing the Client Secret.",
+							},
+
+							"bootstrap_client_id": {
+								Type:         pluginsdk.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.IsUUID,
+								Description:  "The Client ID of the identity used to access Key Vault, which authenticates using Managed Identity, OIDC or Azure CLI.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandClientCertificateProvider(input []interface{}) *clients.ClientCertificateProvider {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	val := input[0].(map[string]interface{})
+	output := clients.ClientCertificateProvider{}
+
+	if v := val["key_vault"].([]interface{}); len(v) > 0 && v[0] != nil {
+		keyVault := v[0].(map[string]interface{})
+		output.KeyVault = &clients.KeyVaultCertificateProvider{
+			CertificateId:     keyVault["certificate_id"].(string),
+			BootstrapClientId: keyVault["bootstrap_client_id"].(string),
+		}
+	}
+
+	if v := val["pkcs11"].([]interface{}); len(v) > 0 && v[0] != nil {
+		pkcs11 := v[0].(map[string]interface{})
+		output.PKCS11 = &clients.PKCS11CertificateProvider{
+			ModulePath: pkcs11["module_path"].(string),
+			SlotId:     int64(pkcs11["slot_id"].(int)),
+			KeyLabel:   pkcs11["key_label"].(string),
+			Pin:        pkcs11["pin"].(string),
+		}
+	}
+
+	return &output
+}
+
+func expandClientSecretProvider(input []interface{}) *clients.ClientSecretProvider {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	val := input[0].(map[string]interface{})
+	output := clients.ClientSecretProvider{}
+
+	if v := val["key_vault"].([]interface{}); len(v) > 0 && v[0] != nil {
+		keyVault := v[0].(map[string]interface{})
+		output.KeyVault = &clients.KeyVaultSecretProvider{
+			SecretId:          keyVault["secret_id"].(string),
+			BootstrapClientId: keyVault["bootstrap_client_id"].(string),
+		}
+	}
+
+	return &output
+}
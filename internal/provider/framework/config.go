@@ -108,6 +108,11 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 		EnableAuthenticatingUsingManagedIdentity:   getEnvBoolOrDefault(data.UseMSI, "ARM_USE_MSI", false),
 	}
 
+	p.clientBuilder.DeveloperTools = clients.DeveloperToolCredentials{
+		EnableAuthenticatingUsingAzureDeveloperCLI: getEnvBoolOrDefault(data.UseAzd, "ARM_USE_AZD", false),
+		EnableAuthenticatingUsingAzurePowerShell:   getEnvBoolOrDefault(data.UsePowerShell, "ARM_USE_POWERSHELL", false),
+	}
+
 	p.clientBuilder.SubscriptionID = getEnvStringIfValueAbsent(data.SubscriptionId, "ARM_SUBSCRIPTION_ID")
 
 	partnerId := getEnvStringIfValueAbsent(data.PartnerId, "ARM_PARTNER_ID")
@@ -120,6 +125,20 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 	p.clientBuilder.DisableTerraformPartnerID = getEnvBoolOrDefault(data.DisableTerraformPartnerId, "ARM_DISABLE_TERRAFORM_PARTNER_ID", false)
 	p.clientBuilder.StorageUseAzureAD = getEnvBoolOrDefault(data.StorageUseAzureAD, "ARM_STORAGE_USE_AZUREAD", false)
 
+	armRequestsPerSecond, err := getEnvFloat64OrDefault(data.ARMRequestsPerSecond, "ARM_REQUESTS_PER_SECOND", 0)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("parsing ARM_REQUESTS_PER_SECOND", err.Error()))
+		return
+	}
+	p.clientBuilder.ARMRequestsPerSecond = armRequestsPerSecond
+
+	maxConcurrentRequests, err := getEnvInt64OrDefault(data.MaxConcurrentRequests, "ARM_MAX_CONCURRENT_REQUESTS", 0)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("parsing ARM_MAX_CONCURRENT_REQUESTS", err.Error()))
+		return
+	}
+	p.clientBuilder.MaxConcurrentRequests = int(maxConcurrentRequests)
+
 	f := providerfeatures.UserFeatures{}
 
 	// features is required, but we'll play safe here
@@ -465,6 +484,78 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 	}
 
 	p.clientBuilder.Features = f
+
+	defaultTags := make(map[string]string)
+	if !data.DefaultTags.IsNull() && !data.DefaultTags.IsUnknown() {
+		var defaultTagsList []DefaultTags
+		d := data.DefaultTags.ElementsAs(ctx, &defaultTagsList, true)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+
+		if len(defaultTagsList) > 0 && !defaultTagsList[0].Tags.IsNull() && !defaultTagsList[0].Tags.IsUnknown() {
+			diags.Append(defaultTagsList[0].Tags.ElementsAs(ctx, &defaultTags, false)...)
+			if diags.HasError() {
+				return
+			}
+		}
+	}
+	p.clientBuilder.DefaultTags = defaultTags
+
+	ignoreTagKeys := make([]string, 0)
+	ignoreTagKeyPrefixes := make([]string, 0)
+	if !data.IgnoreTags.IsNull() && !data.IgnoreTags.IsUnknown() {
+		var ignoreTagsList []IgnoreTags
+		d := data.IgnoreTags.ElementsAs(ctx, &ignoreTagsList, true)
+		diags.Append(d...)
+		if diags.HasError() {
+			return
+		}
+
+		if len(ignoreTagsList) > 0 {
+			if v := ignoreTagsList[0].Keys; !v.IsNull() && !v.IsUnknown() {
+				diags.Append(v.ElementsAs(ctx, &ignoreTagKeys, false)...)
+			}
+			if v := ignoreTagsList[0].KeyPrefixes; !v.IsNull() && !v.IsUnknown() {
+				diags.Append(v.ElementsAs(ctx, &ignoreTagKeyPrefixes, false)...)
+			}
+			if diags.HasError() {
+				return
+			}
+		}
+	}
+	defaultTimeouts, err := expandDefaultTimeouts(ctx, data.DefaultTimeouts)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("parsing `default_timeouts`", err.Error()))
+		return
+	}
+	p.clientBuilder.DefaultTimeouts = defaultTimeouts
+
+	retryPolicyOverrides, err := expandRetry(ctx, data.Retry)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("parsing `retry`", err.Error()))
+		return
+	}
+	p.clientBuilder.RetryPolicyOverrides = *retryPolicyOverrides
+	p.clientBuilder.RetryRules = provider.RetryRulesForServices()
+
+	clientCertificateProvider, err := expandClientCertificateProvider(ctx, data.ClientCertificateProvider)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("parsing `client_certificate_provider`", err.Error()))
+		return
+	}
+	p.clientBuilder.ClientCertificateProvider = clientCertificateProvider
+
+	clientSecretProvider, err := expandClientSecretProvider(ctx, data.ClientSecretProvider)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("parsing `client_secret_provider`", err.Error()))
+		return
+	}
+	p.clientBuilder.ClientSecretProvider = clientSecretProvider
+
+	p.clientBuilder.IgnoreTagKeys = ignoreTagKeys
+	p.clientBuilder.IgnoreTagKeyPrefixes = ignoreTagKeyPrefixes
 	p.clientBuilder.AuthConfig = authConfig
 	p.clientBuilder.CustomCorrelationRequestID = os.Getenv("ARM_CORRELATION_REQUEST_ID")
 	p.clientBuilder.TerraformVersion = tfVersion
@@ -515,10 +606,14 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 	ctx2, cancel := context.WithTimeout(ctx, 30*time.Minute)
 	defer cancel()
 
-	if err = resourceproviders.EnsureRegistered(ctx2, client.Resource.ResourceProvidersClient, subscriptionId, requiredResourceProviders); err != nil {
+	if err = resourceproviders.EnsureRegistered(ctx2, client.Resource.ResourceProvidersClient, subscriptionId, requiredResourceProviders, client.ResourceProviderCache); err != nil {
 		diags.AddError("registering resource providers", err.Error())
 		return
 	}
 
+	if resourceProviderRegistrationSet == resourceproviders.ProviderRegistrationsLazy {
+		client.ResourceProviderRegistration = resourceproviders.NewLazyRegistration(client.Resource.ResourceProvidersClient, subscriptionId, client.ResourceProviderCache)
+	}
+
 	p.Client = client
 }
@@ -8,9 +8,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 )
 
 func decodeCertificate(clientCertificate string) ([]byte, error) {
@@ -172,6 +177,44 @@ func getEnvBoolOrDefault(val types.Bool, envVar string, def bool) bool {
 	return val.ValueBool()
 }
 
+// getEnvFloat64OrDefault returns the value of the Float64Value if this is not Null / Unknown, otherwise the value of
+// the Environment Variable `envVar` if set, else the default value `def`.
+func getEnvFloat64OrDefault(val types.Float64, envVar string, def float64) (float64, error) {
+	if val.IsNull() || val.IsUnknown() {
+		v := os.Getenv(envVar)
+		if v == "" {
+			return def, nil
+		}
+
+		out, err := strconv.ParseFloat(v, 64)
+		if err != nil || out < 0 {
+			return 0, fmt.Errorf("expected %s to be a number greater than or equal to 0, got %q", envVar, v)
+		}
+		return out, nil
+	}
+
+	return val.ValueFloat64(), nil
+}
+
+// getEnvInt64OrDefault returns the value of the Int64Value if this is not Null / Unknown, otherwise the value of
+// the Environment Variable `envVar` if set, else the default value `def`.
+func getEnvInt64OrDefault(val types.Int64, envVar string, def int64) (int64, error) {
+	if val.IsNull() || val.IsUnknown() {
+		v := os.Getenv(envVar)
+		if v == "" {
+			return def, nil
+		}
+
+		out, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || out < 0 {
+			return 0, fmt.Errorf("expected %s to be a whole number greater than or equal to 0, got %q", envVar, v)
+		}
+		return out, nil
+	}
+
+	return val.ValueInt64(), nil
+}
+
 // getEnvListOfStringsIfAbsent returns a []string for the types.List, or the contents of the supplied Environment
 // Variable `envVar` if set. If the separator is an empty string, then "," will be used as a default.
 func getEnvListOfStringsIfAbsent(val types.List, envVar string, separator string) []string {
@@ -191,3 +234,201 @@ func getEnvListOfStringsIfAbsent(val types.List, envVar string, separator string
 
 	return result
 }
+
+// expandDefaultTimeouts returns the timeouts specified within the `default_timeouts` block, or nil if this isn't set
+func expandDefaultTimeouts(ctx context.Context, input types.List) (*timeouts.Defaults, error) {
+	if input.IsNull() || input.IsUnknown() {
+		return nil, nil
+	}
+
+	var blocks []DefaultTimeouts
+	if diags := input.ElementsAs(ctx, &blocks, true); diags.HasError() {
+		return nil, fmt.Errorf("decoding `default_timeouts`")
+	}
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	operations, err := expandTimeoutOperations(blocks[0].Create, blocks[0].Read, blocks[0].Update, blocks[0].Delete)
+	if err != nil {
+		return nil, err
+	}
+
+	output := timeouts.Defaults{
+		Operations:    *operations,
+		ResourceTypes: make(map[string]timeouts.Operations),
+	}
+
+	if v := blocks[0].Resource; !v.IsNull() && !v.IsUnknown() {
+		var resources []DefaultTimeoutsResource
+		if diags := v.ElementsAs(ctx, &resources, true); diags.HasError() {
+			return nil, fmt.Errorf("decoding `default_timeouts.resource`")
+		}
+
+		for _, resource := range resources {
+			resourceType := resource.Type.ValueString()
+			if _, ok := output.ResourceTypes[resourceType]; ok {
+				return nil, fmt.Errorf("the resource type %q is specified more than once", resourceType)
+			}
+
+			operations, err := expandTimeoutOperations(resource.Create, resource.Read, resource.Update, resource.Delete)
+			if err != nil {
+				return nil, fmt.Errorf("%q: %+v", resourceType, err)
+			}
+			output.ResourceTypes[resourceType] = *operations
+		}
+	}
+
+	return &output, nil
+}
+
+func expandTimeoutOperations(createTimeout, readTimeout, updateTimeout, deleteTimeout types.String) (*timeouts.Operations, error) {
+	output := timeouts.Operations{}
+	for operation, v := range map[string]struct {
+		value types.String
+		field **time.Duration
+	}{
+		"create": {createTimeout, &output.Create},
+		"read":   {readTimeout, &output.Read},
+		"update": {updateTimeout, &output.Update},
+		"delete": {deleteTimeout, &output.Delete},
+	} {
+		if v.value.IsNull() || v.value.IsUnknown() || v.value.ValueString() == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(v.value.ValueString())
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("expected `%s` to be a positive duration (e.g. `30m` or `2h`) but got %q", operation, v.value.ValueString())
+		}
+		*v.field = &duration
+	}
+
+	return &output, nil
+}
+
+// expandRetry returns the overrides for the retry policy specified within the `retry` block
+func expandRetry(ctx context.Context, input types.List) (*common.RetryPolicyOverrides, error) {
+	output := common.RetryPolicyOverrides{}
+	if input.IsNull() || input.IsUnknown() {
+		return &output, nil
+	}
+
+	var blocks []Retry
+	if diags := input.ElementsAs(ctx, &blocks, true); diags.HasError() {
+		return nil, fmt.Errorf("decoding `retry`")
+	}
+	if len(blocks) == 0 {
+		return &output, nil
+	}
+
+	if v := blocks[0].MaxAttempts; !v.IsNull() && !v.IsUnknown() {
+		output.MaxAttempts = int(v.ValueInt64())
+	}
+
+	if v := blocks[0].MaxBackoff; !v.IsNull() && !v.IsUnknown() && v.ValueString() != "" {
+		duration, err := time.ParseDuration(v.ValueString())
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("expected `max_backoff` to be a positive duration (e.g. `30s` or `2m`) but got %q", v.ValueString())
+		}
+		output.MaxBackoff = duration
+	}
+
+	if v := blocks[0].AdditionalErrorCodes; !v.IsNull() && !v.IsUnknown() {
+		if diags := v.ElementsAs(ctx, &output.AdditionalErrorCodes, false); diags.HasError() {
+			return nil, fmt.Errorf("decoding `additional_error_codes`")
+		}
+	}
+	if v := blocks[0].DisabledErrorCodes; !v.IsNull() && !v.IsUnknown() {
+		if diags := v.ElementsAs(ctx, &output.DisabledErrorCodes, false); diags.HasError() {
+			return nil, fmt.Errorf("decoding `disabled_error_codes`")
+		}
+	}
+
+	return &output, nil
+}
+
+// expandClientCertificateProvider returns the provider specified within the `client_certificate_provider` block, or
+// nil if this isn't set
+func expandClientCertificateProvider(ctx context.Context, input types.List) (*clients.ClientCertificateProvider, error) {
+	if input.IsNull() || input.IsUnknown() {
+		return nil, nil
+	}
+
+	var blocks []ClientCertificateProvider
+	if diags := input.ElementsAs(ctx, &blocks, true); diags.HasError() {
+		return nil, fmt.Errorf("decoding `client_certificate_provider`")
+	}
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	output := clients.ClientCertificateProvider{}
+
+	if v := blocks[0].KeyVault; !v.IsNull() && !v.IsUnknown() {
+		var keyVault []KeyVaultCertificateProvider
+		if diags := v.ElementsAs(ctx, &keyVault, true); diags.HasError() {
+			return nil, fmt.Errorf("decoding `client_certificate_provider.key_vault`")
+		}
+		if len(keyVault) > 0 {
+			output.KeyVault = &clients.KeyVaultCertificateProvider{
+				CertificateId:     keyVault[0].CertificateId.ValueString(),
+				BootstrapClientId: keyVault[0].BootstrapClientId.ValueString(),
+			}
+		}
+	}
+
+	if v := blocks[0].PKCS11; !v.IsNull() && !v.IsUnknown() {
+		var pkcs11 []PKCS11CertificateProvider
+		if diags := v.ElementsAs(ctx, &pkcs11, true); diags.HasError() {
+			return nil, fmt.Errorf("decoding `client_certificate_provider.pkcs11`")
+		}
+		if len(pkcs11) > 0 {
+			output.PKCS11 = &clients.PKCS11CertificateProvider{
+				ModulePath: pkcs11[0].ModulePath.ValueString(),
+				SlotId:     pkcs11[0].SlotId.ValueInt64(),
+				KeyLabel:   pkcs11[0].KeyLabel.ValueString(),
+				Pin:        getEnvStringOrDefault(pkcs11[0].Pin, "ARM_CLIENT_CERTIFICATE_PKCS11_PIN", ""),
+			}
+		}
+	}
+
+	if (output.KeyVault == nil) == (output.PKCS11 == nil) {
+		return nil, fmt.Errorf("exactly one of the `key_vault` or `pkcs11` blocks must be specified within `client_certificate_provider`")
+	}
+
+	return &output, nil
+}
+
+// expandClientSecretProvider returns the provider specified within the `client_secret_provider` block, or nil if
+// this isn't set
+func expandClientSecretProvider(ctx context.Context, input types.List) (*clients.ClientSecretProvider, error) {
+	if input.IsNull() || input.IsUnknown() {
+		return nil, nil
+	}
+
+	var blocks []ClientSecretProvider
+	if diags := input.ElementsAs(ctx, &blocks, true); diags.HasError() {
+		return nil, fmt.Errorf("decoding `client_secret_provider`")
+	}
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	output := clients.ClientSecretProvider{}
+
+	if v := blocks[0].KeyVault; !v.IsNull() && !v.IsUnknown() {
+		var keyVault []KeyVaultSecretProvider
+		if diags := v.ElementsAs(ctx, &keyVault, true); diags.HasError() {
+			return nil, fmt.Errorf("decoding `client_secret_provider.key_vault`")
+		}
+		if len(keyVault) > 0 {
+			output.KeyVault = &clients.KeyVaultSecretProvider{
+				SecretId:          keyVault[0].SecretId.ValueString(),
+				BootstrapClientId: keyVault[0].BootstrapClientId.ValueString(),
+			}
+		}
+	}
+
+	return &output, nil
+}
@@ -9,35 +9,45 @@ import (
 )
 
 type ProviderModel struct {
-	SubscriptionId                types.String `tfsdk:"subscription_id"`
-	ClientId                      types.String `tfsdk:"client_id"`
-	ClientIdFilePath              types.String `tfsdk:"client_id_file_path"`
-	TenantId                      types.String `tfsdk:"tenant_id"`
-	AuxiliaryTenantIds            types.List   `tfsdk:"auxiliary_tenant_ids"`
-	Environment                   types.String `tfsdk:"environment"`
-	MetaDataHost                  types.String `tfsdk:"metadata_host"`
-	ClientCertificate             types.String `tfsdk:"client_certificate"`
-	ClientCertificatePath         types.String `tfsdk:"client_certificate_path"`
-	ClientCertificatePassword     types.String `tfsdk:"client_certificate_password"`
-	ClientSecret                  types.String `tfsdk:"client_secret"`
-	ClientSecretFilePath          types.String `tfsdk:"client_secret_file_path"`
-	OIDCRequestToken              types.String `tfsdk:"oidc_request_token"`
-	OIDCRequestURL                types.String `tfsdk:"oidc_request_url"`
-	OIDCToken                     types.String `tfsdk:"oidc_token"`
-	OIDCTokenFilePath             types.String `tfsdk:"oidc_token_file_path"`
-	UseOIDC                       types.Bool   `tfsdk:"use_oidc"`
-	UseMSI                        types.Bool   `tfsdk:"use_msi"`
-	MSIEndpoint                   types.String `tfsdk:"msi_endpoint"`
-	UseCLI                        types.Bool   `tfsdk:"use_cli"`
-	UseAKSWorkloadIdentity        types.Bool   `tfsdk:"use_aks_workload_identity"`
-	PartnerId                     types.String `tfsdk:"partner_id"`
-	DisableCorrelationRequestId   types.Bool   `tfsdk:"disable_correlation_request_id"`
-	DisableTerraformPartnerId     types.Bool   `tfsdk:"disable_terraform_partner_id"`
-	StorageUseAzureAD             types.Bool   `tfsdk:"storage_use_azuread"`
-	Features                      types.List   `tfsdk:"features"`
-	SkipProviderRegistration      types.Bool   `tfsdk:"skip_provider_registration"` // TODO - Remove in 5.0
-	ResourceProviderRegistrations types.String `tfsdk:"resource_provider_registrations"`
-	ResourceProvidersToRegister   types.List   `tfsdk:"resource_providers_to_register"`
+	SubscriptionId                types.String  `tfsdk:"subscription_id"`
+	ClientId                      types.String  `tfsdk:"client_id"`
+	ClientIdFilePath              types.String  `tfsdk:"client_id_file_path"`
+	TenantId                      types.String  `tfsdk:"tenant_id"`
+	AuxiliaryTenantIds            types.List    `tfsdk:"auxiliary_tenant_ids"`
+	Environment                   types.String  `tfsdk:"environment"`
+	MetaDataHost                  types.String  `tfsdk:"metadata_host"`
+	ClientCertificate             types.String  `tfsdk:"client_certificate"`
+	ClientCertificatePath         types.String  `tfsdk:"client_certificate_path"`
+	ClientCertificatePassword     types.String  `tfsdk:"client_certificate_password"`
+	ClientSecret                  types.String  `tfsdk:"client_secret"`
+	ClientSecretFilePath          types.String  `tfsdk:"client_secret_file_path"`
+	ClientCertificateProvider     types.List    `tfsdk:"client_certificate_provider"`
+	ClientSecretProvider          types.List    `tfsdk:"client_secret_provider"`
+	OIDCRequestToken              types.String  `tfsdk:"oidc_request_token"`
+	OIDCRequestURL                types.String  `tfsdk:"oidc_request_url"`
+	OIDCToken                     types.String  `tfsdk:"oidc_token"`
+	OIDCTokenFilePath             types.String  `tfsdk:"oidc_token_file_path"`
+	UseOIDC                       types.Bool    `tfsdk:"use_oidc"`
+	UseMSI                        types.Bool    `tfsdk:"use_msi"`
+	MSIEndpoint                   types.String  `tfsdk:"msi_endpoint"`
+	UseCLI                        types.Bool    `tfsdk:"use_cli"`
+	UseAzd                        types.Bool    `tfsdk:"use_azd"`
+	UsePowerShell                 types.Bool    `tfsdk:"use_powershell"`
+	UseAKSWorkloadIdentity        types.Bool    `tfsdk:"use_aks_workload_identity"`
+	PartnerId                     types.String  `tfsdk:"partner_id"`
+	DisableCorrelationRequestId   types.Bool    `tfsdk:"disable_correlation_request_id"`
+	DisableTerraformPartnerId     types.Bool    `tfsdk:"disable_terraform_partner_id"`
+	StorageUseAzureAD             types.Bool    `tfsdk:"storage_use_azuread"`
+	ARMRequestsPerSecond          types.Float64 `tfsdk:"arm_requests_per_second"`
+	MaxConcurrentRequests         types.Int64   `tfsdk:"max_concurrent_requests"`
+	Features                      types.List    `tfsdk:"features"`
+	DefaultTags                   types.List    `tfsdk:"default_tags"`
+	IgnoreTags                    types.List    `tfsdk:"ignore_tags"`
+	DefaultTimeouts               types.List    `tfsdk:"default_timeouts"`
+	Retry                         types.List    `tfsdk:"retry"`
+	SkipProviderRegistration      types.Bool    `tfsdk:"skip_provider_registration"` // TODO - Remove in 5.0
+	ResourceProviderRegistrations types.String  `tfsdk:"resource_provider_registrations"`
+	ResourceProvidersToRegister   types.List    `tfsdk:"resource_providers_to_register"`
 }
 
 type Features struct {
@@ -245,3 +255,119 @@ type RecoveryServiceVaults struct {
 var RecoveryServiceVaultsAttributes = map[string]attr.Type{
 	"recover_soft_deleted_backup_protected_vm": types.BoolType,
 }
+
+type DefaultTags struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+var DefaultTagsAttributes = map[string]attr.Type{
+	"tags": types.MapType{}.WithElementType(types.StringType),
+}
+
+type IgnoreTags struct {
+	Keys        types.Set `tfsdk:"keys"`
+	KeyPrefixes types.Set `tfsdk:"key_prefixes"`
+}
+
+var IgnoreTagsAttributes = map[string]attr.Type{
+	"keys":         types.SetType{}.WithElementType(types.StringType),
+	"key_prefixes": types.SetType{}.WithElementType(types.StringType),
+}
+
+type DefaultTimeouts struct {
+	Create   types.String `tfsdk:"create"`
+	Read     types.String `tfsdk:"read"`
+	Update   types.String `tfsdk:"update"`
+	Delete   types.String `tfsdk:"delete"`
+	Resource types.List   `tfsdk:"resource"`
+}
+
+var DefaultTimeoutsAttributes = map[string]attr.Type{
+	"create":   types.StringType,
+	"read":     types.StringType,
+	"update":   types.StringType,
+	"delete":   types.StringType,
+	"resource": types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(DefaultTimeoutsResourceAttributes)),
+}
+
+type DefaultTimeoutsResource struct {
+	Type   types.String `tfsdk:"type"`
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+var DefaultTimeoutsResourceAttributes = map[string]attr.Type{
+	"type":   types.StringType,
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
+
+type Retry struct {
+	MaxAttempts          types.Int64  `tfsdk:"max_attempts"`
+	MaxBackoff           types.String `tfsdk:"max_backoff"`
+	AdditionalErrorCodes types.Set    `tfsdk:"additional_error_codes"`
+	DisabledErrorCodes   types.Set    `tfsdk:"disabled_error_codes"`
+}
+
+var RetryAttributes = map[string]attr.Type{
+	"max_attempts":           types.Int64Type,
+	"max_backoff":            types.StringType,
+	"additional_error_codes": types.SetType{}.WithElementType(types.StringType),
+	"disabled_error_codes":   types.SetType{}.WithElementType(types.StringType),
+}
+
+type ClientCertificateProvider struct {
+	KeyVault types.List `tfsdk:"key_vault"`
+	PKCS11   types.List `tfsdk:"pkcs11"`
+}
+
+var ClientCertificateProviderAttributes = map[string]attr.Type{
+	"key_vault": types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(KeyVaultCertificateProviderAttributes)),
+	"pkcs11":    types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(PKCS11CertificateProviderAttributes)),
+}
+
+type KeyVaultCertificateProvider struct {
+	CertificateId     types.String `tfsdk:"certificate_id"`
+	BootstrapClientId types.String `tfsdk:"bootstrap_client_id"`
+}
+
+var KeyVaultCertificateProviderAttributes = map[string]attr.Type{
+	"certificate_id":      types.StringType,
+	"bootstrap_client_id": types.StringType,
+}
+
+type PKCS11CertificateProvider struct {
+	ModulePath types.String `tfsdk:"module_path"`
+	SlotId     types.Int64  `tfsdk:"slot_id"`
+	KeyLabel   types.String `tfsdk:"key_label"`
+	Pin        types.String `tfsdk:"pin"`
+}
+
+var PKCS11CertificateProviderAttributes = map[string]attr.Type{
+	"module_path": types.StringType,
+	"slot_id":     types.Int64Type,
+	"key_label":   types.StringType,
+	"pin":         types.StringType,
+}
+
+type ClientSecretProvider struct {
+	KeyVault types.List `tfsdk:"key_vault"`
+}
+
+var ClientSecretProviderAttributes = map[string]attr.Type{
+	"key_vault": types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(KeyVaultSecretProviderAttributes)),
+}
+
+type KeyVaultSecretProvider struct {
+	SecretId          types.String `tfsdk:"secret_id"`
+	BootstrapClientId types.String `tfsdk:"bootstrap_client_id"`
+}
+
+var KeyVaultSecretProviderAttributes = map[string]attr.Type{
+	"secret_id":           types.StringType,
+	"bootstrap_client_id": types.StringType,
+}
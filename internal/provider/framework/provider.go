@@ -6,17 +6,26 @@ package framework
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	pluginsdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	azurermprovider "github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
 )
 
@@ -29,10 +38,19 @@ var _ provider.Provider = &azureRmFrameworkProvider{}
 
 var _ provider.ProviderWithFunctions = &azureRmFrameworkProvider{}
 
+var _ provider.ProviderWithEphemeralResources = &azureRmFrameworkProvider{}
+
+var _ provider.ProviderWithListResources = &azureRmFrameworkProvider{}
+
 func (p *azureRmFrameworkProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
+		providerfunction.NewBuildResourceIDFunction,
+		providerfunction.NewCidrSubnetsForVnetFunction,
+		providerfunction.NewIsValidResourceIDFunction,
+		providerfunction.NewLocationNormalizeFunction,
 		providerfunction.NewNormaliseResourceIDFunction,
 		providerfunction.NewParseResourceIDFunction,
+		providerfunction.NewStorageAccountNameFunction,
 	}
 }
 
@@ -162,6 +180,17 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 				Description: "Allow Azure CLI to be used for Authentication.",
 			},
 
+			// Azure Developer CLI and Azure PowerShell specific fields
+			"use_azd": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow Azure Developer CLI to be used for Authentication.",
+			},
+
+			"use_powershell": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow Azure PowerShell to be used for Authentication.",
+			},
+
 			// Azure AKS Workload Identity fields
 			"use_aks_workload_identity": schema.BoolAttribute{
 				Optional:    true,
@@ -196,6 +225,22 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 				Description: "Should the AzureRM Provider use Azure AD Authentication when accessing the Storage Data Plane APIs?",
 			},
 
+			"arm_requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests per second which should be sent to Azure Resource Manager for each Subscription. Defaults to `0`, meaning there's no limit.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of concurrent requests which should be sent to Azure Resource Manager for each Subscription. Defaults to `0`, meaning there's no limit.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
 			"resource_provider_registrations": schema.StringAttribute{
 				Optional:    true,
 				Description: "The set of Resource Providers which should be automatically registered for the subscription.",
@@ -206,6 +251,7 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 						resourceproviders.ProviderRegistrationsCore,
 						resourceproviders.ProviderRegistrationsExtended,
 						resourceproviders.ProviderRegistrationsAll,
+						resourceproviders.ProviderRegistrationsLazy,
 					),
 				},
 			},
@@ -225,6 +271,246 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 		},
 
 		Blocks: map[string]schema.Block{
+			"default_tags": schema.ListNestedBlock{
+				Description: "A set of Tags which should be applied to all resources which support Tags.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"tags": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							// these match the limits enforced by `tags.Validate` within the Plugin SDKv2 Provider
+							Validators: []validator.Map{
+								mapvalidator.SizeAtMost(50),
+								mapvalidator.KeysAre(stringvalidator.LengthAtMost(512)),
+								mapvalidator.ValueStringsAre(stringvalidator.LengthAtMost(256)),
+							},
+						},
+					},
+				},
+			},
+
+			"ignore_tags": schema.ListNestedBlock{
+				Description: "A set of Tags which are managed outside of Terraform and should be ignored by all resources which support Tags.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"keys": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+							},
+						},
+
+						"key_prefixes": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+							},
+						},
+					},
+				},
+			},
+
+			"default_timeouts": schema.ListNestedBlock{
+				Description: "The default timeouts used by all resources, which can be overridden for specific resource types and within a resource's `timeouts` block.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"create": schema.StringAttribute{
+							Optional: true,
+						},
+						"read": schema.StringAttribute{
+							Optional: true,
+						},
+						"update": schema.StringAttribute{
+							Optional: true,
+						},
+						"delete": schema.StringAttribute{
+							Optional: true,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"resource": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										Required: true,
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+									"create": schema.StringAttribute{
+										Optional: true,
+									},
+									"read": schema.StringAttribute{
+										Optional: true,
+									},
+									"update": schema.StringAttribute{
+										Optional: true,
+									},
+									"delete": schema.StringAttribute{
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"client_certificate_provider": schema.ListNestedBlock{
+				Description: "Signs the Client Assertion used when authenticating as a Service Principal using a Client Certificate held in Key Vault or a PKCS#11 token, rather than loading the private key.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Blocks: map[string]schema.Block{
+						"key_vault": schema.ListNestedBlock{
+							Validators: []validator.List{
+								listvalidator.SizeAtMost(1),
+							},
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"certificate_id": schema.StringAttribute{
+										Required:    true,
+										Description: "The ID of the Key Vault Certificate associated with the Service Principal.",
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+
+									"bootstrap_client_id": schema.StringAttribute{
+										Optional:    true,
+										Description: "The Client ID of the identity used to access Key Vault, which authenticates using Managed Identity, OIDC or Azure CLI.",
+									},
+								},
+							},
+						},
+
+						"pkcs11": schema.ListNestedBlock{
+							Validators: []validator.List{
+								listvalidator.SizeAtMost(1),
+							},
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"module_path": schema.StringAttribute{
+										Required:    true,
+										Description: "The path to the PKCS#11 module for the token containing the Client Certificate.",
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+
+									"slot_id": schema.Int64Attribute{
+										Required:    true,
+										Description: "The ID of the slot containing the token.",
+										Validators: []validator.Int64{
+											int64validator.AtLeast(0),
+										},
+									},
+
+									"key_label": schema.StringAttribute{
+										Required:    true,
+										Description: "The label of the private key and Client Certificate within the token.",
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+
+									"pin": schema.StringAttribute{
+										Optional:    true,
+										Sensitive:   true,
+										Description: "The PIN used to log in to the token.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"client_secret_provider": schema.ListNestedBlock{
+				Description: "Retrieves the Client Secret used when authenticating as a Service Principal from Key Vault.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Blocks: map[string]schema.Block{
+						"key_vault": schema.ListNestedBlock{
+							Validators: []validator.List{
+								listvalidator.SizeBetween(1, 1),
+							},
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"secret_id": schema.StringAttribute{
+										Required:    true,
+										Description: "The ID of the Key Vault Secret containing the Client Secret.",
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+
+									"bootstrap_client_id": schema.StringAttribute{
+										Optional:    true,
+										Description: "The Client ID of the identity used to access Key Vault, which authenticates using Managed Identity, OIDC or Azure CLI.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"retry": schema.ListNestedBlock{
+				Description: "Configures how transient errors returned by Azure Resource Manager are retried.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"max_attempts": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The maximum number of times a request which fails with a transient error is sent.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+
+						"max_backoff": schema.StringAttribute{
+							Optional:    true,
+							Description: "The maximum delay between retries of a request which fails with a transient error.",
+						},
+
+						"additional_error_codes": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "A set of error codes returned by Azure which should also be retried.",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+							},
+						},
+
+						"disabled_error_codes": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "A set of error codes returned by Azure which shouldn't be retried, even when these are retried for the Resource Provider.",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+							},
+						},
+					},
+				},
+			},
+
 			"features": schema.ListNestedBlock{
 				Validators: []validator.List{
 					listvalidator.SizeBetween(1, 1),
@@ -475,20 +761,61 @@ func (p *azureRmFrameworkProvider) Configure(ctx context.Context, request provid
 
 		response.ResourceData = v
 		response.DataSourceData = v
+		response.EphemeralResourceData = v
+		response.ListResourceData = v
 	} else {
 		p.Load(ctx, &data, request.TerraformVersion, &response.Diagnostics)
 
-		response.DataSourceData = &p.ProviderConfig
-		response.ResourceData = &p.ProviderConfig
+		response.DataSourceData = p.ProviderConfig.Client
+		response.ResourceData = p.ProviderConfig.Client
+		response.EphemeralResourceData = p.ProviderConfig.Client
+		response.ListResourceData = p.ProviderConfig.Client
 	}
 }
 
 func (p *azureRmFrameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	// We do not currently support any Native framework Data Sources
-	return nil
+	output := make([]func() datasource.DataSource, 0)
+	for _, service := range azurermprovider.SupportedFrameworkServices() {
+		output = append(output, service.FrameworkDataSources()...)
+	}
+
+	return output
 }
 
 func (p *azureRmFrameworkProvider) Resources(_ context.Context) []func() resource.Resource {
-	// We do not currently support any Native framework Resources
-	return nil
+	output := make([]func() resource.Resource, 0)
+	for _, service := range azurermprovider.SupportedFrameworkServices() {
+		output = append(output, service.FrameworkResources()...)
+	}
+
+	return output
+}
+
+func (p *azureRmFrameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	output := make([]func() ephemeral.EphemeralResource, 0)
+	for _, service := range azurermprovider.SupportedFrameworkServices() {
+		output = append(output, service.EphemeralResources()...)
+	}
+
+	return output
+}
+
+func (p *azureRmFrameworkProvider) ListResources(_ context.Context) []func() list.ListResource {
+	output := make([]func() list.ListResource, 0)
+
+	// List Resources list the instances of Resources served by the Plugin SDKv2 Provider, so aren't available without it
+	v2Provider, ok := p.V2Provider.(*pluginsdkschema.Provider)
+	if !ok {
+		return output
+	}
+
+	for _, service := range azurermprovider.SupportedListResourceServices() {
+		for _, r := range service.ListResources() {
+			if resource, ok := v2Provider.ResourcesMap[r.ResourceType()]; ok {
+				output = append(output, sdk.NewListResourceWrapper(r, resource))
+			}
+		}
+	}
+
+	return output
 }
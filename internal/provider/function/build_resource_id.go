@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/recaser"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type BuildResourceIDFunction struct{}
+
+var _ function.Function = BuildResourceIDFunction{}
+
+type buildResourceIdInput struct {
+	ResourceName      *string   `tfsdk:"resource_name"`
+	ResourceProvider  *string   `tfsdk:"resource_provider"`
+	ResourceGroupName *string   `tfsdk:"resource_group_name"`
+	ResourceType      *string   `tfsdk:"resource_type"`
+	ResourceScope     *string   `tfsdk:"resource_scope"`
+	FullResourceType  *string   `tfsdk:"full_resource_type"`
+	SubscriptionId    *string   `tfsdk:"subscription_id"`
+	ParentResources   types.Map `tfsdk:"parent_resources"`
+}
+
+func NewBuildResourceIDFunction() function.Function {
+	return &BuildResourceIDFunction{}
+}
+
+func (b BuildResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "build_resource_id"
+}
+
+func (b BuildResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "build_resource_id",
+		Description:         "Builds an Azure Resource Manager ID from its component parts, as returned from `parse_resource_id`",
+		MarkdownDescription: "Builds an Azure Resource Manager ID from its component parts, as returned from `parse_resource_id`",
+		Parameters: []function.Parameter{
+			function.ObjectParameter{
+				Name:                "components",
+				Description:         "The components of the Resource ID, in the format returned from `parse_resource_id`",
+				MarkdownDescription: "The components of the Resource ID, in the format returned from `parse_resource_id`",
+				AttributeTypes:      idParseResultTypes,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (b BuildResourceIDFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var input buildResourceIdInput
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &input))
+
+	if response.Error != nil {
+		return
+	}
+
+	parentResources := make(map[string]string)
+	if !input.ParentResources.IsNull() {
+		response.Error = function.FuncErrorFromDiags(ctx, input.ParentResources.ElementsAs(ctx, &parentResources, false))
+		if response.Error != nil {
+			return
+		}
+	}
+
+	id, err := buildResourceId(input, parentResources)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	idType := recaser.ResourceIdTypeFromResourceId(id)
+	if idType == nil {
+		response.Error = function.NewFuncError(fmt.Sprintf("could not determine resource ID type from %s, the components may be incorrect or the ID is currently not supported in the provider", id))
+		return
+	}
+
+	parser := resourceids.NewParserFromResourceIdType(idType)
+	parsed, err := parser.Parse(id, true)
+	if err != nil {
+		response.Error = function.NewFuncError(fmt.Sprintf("Parsing Resource ID Error: %s", err))
+		return
+	}
+
+	err = idType.FromParseResult(*parsed)
+	if err != nil {
+		response.Error = function.NewFuncError(fmt.Sprintf("Expanding Parsed Resource ID Error: %s", err))
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, idType.ID()))
+}
+
+// buildResourceId builds the (unvalidated) Resource ID from the components returned from `parse_resource_id`
+func buildResourceId(input buildResourceIdInput, parentResources map[string]string) (string, error) {
+	id := ""
+	switch {
+	case pointer.From(input.ResourceScope) != "":
+		id = "/" + strings.Trim(pointer.From(input.ResourceScope), "/")
+
+	case pointer.From(input.SubscriptionId) != "":
+		id = fmt.Sprintf("/subscriptions/%s", pointer.From(input.SubscriptionId))
+		if pointer.From(input.ResourceGroupName) != "" {
+			id = fmt.Sprintf("%s/resourceGroups/%s", id, pointer.From(input.ResourceGroupName))
+		}
+
+	case pointer.From(input.ResourceGroupName) != "":
+		return "", fmt.Errorf("`subscription_id` must be specified when `resource_group_name` is specified")
+	}
+
+	fullResourceType := strings.Trim(pointer.From(input.FullResourceType), "/")
+	if fullResourceType == "" {
+		if id == "" {
+			return "", fmt.Errorf("at least one of `full_resource_type`, `resource_scope` or `subscription_id` must be specified")
+		}
+		return id, nil
+	}
+
+	// the Full Resource Type is in the format `{Provider}/{Type}/{NestedType}`
+	segments := strings.Split(fullResourceType, "/")
+	if len(segments) < 2 {
+		return "", fmt.Errorf("`full_resource_type` must be in the format `{Provider}/{Type}`, got %q", fullResourceType)
+	}
+	if pointer.From(input.ResourceName) == "" {
+		return "", fmt.Errorf("`resource_name` must be specified when `full_resource_type` is specified")
+	}
+
+	id = fmt.Sprintf("%s/providers/%s", id, segments[0])
+	resourceTypes := segments[1:]
+	for i, resourceType := range resourceTypes {
+		name := pointer.From(input.ResourceName)
+		if i < len(resourceTypes)-1 {
+			v, ok := parentResources[resourceType]
+			if !ok || v == "" {
+				return "", fmt.Errorf("a value for the parent resource %q must be specified within `parent_resources`", resourceType)
+			}
+			name = v
+		}
+
+		id = fmt.Sprintf("%s/%s/%s", id, resourceType, name)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionBuildResourceID_roundTrip(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	ids := map[string]string{
+		"nested":             "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.ApiManagement/service/service1/gateways/gateway1/hostnameConfigurations/config1",
+		"resource_group":     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1",
+		"scoped_at_resource": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Storage/storageAccounts/mystorageaccount/providers/Microsoft.EventGrid/eventSubscriptions/event1",
+		"scoped_at_group":    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Chaos/targets/target1",
+		"virtual_network":    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1",
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testBuildResourceIdRoundTripOutput(ids),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("nested", ids["nested"]),
+					acceptance.TestCheckOutput("resource_group", ids["resource_group"]),
+					acceptance.TestCheckOutput("scoped_at_resource", ids["scoped_at_resource"]),
+					acceptance.TestCheckOutput("scoped_at_group", ids["scoped_at_group"]),
+					acceptance.TestCheckOutput("virtual_network", ids["virtual_network"]),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionBuildResourceID_components(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testBuildResourceIdOutput(`{ "virtualNetworks" = "network1" }`),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("id", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1"),
+				),
+			},
+			{
+				Config:      testBuildResourceIdOutput(`{}`),
+				ExpectError: regexp.MustCompile("a value for the parent resource \"virtualNetworks\" must be specified"),
+			},
+		},
+	})
+}
+
+func testBuildResourceIdRoundTripOutput(ids map[string]string) string {
+	outputs := ""
+	for k, v := range ids {
+		outputs += fmt.Sprintf(`
+
+output "%s" {
+  value = provider::azurerm::build_resource_id(provider::azurerm::parse_resource_id("%s"))
+}
+`, k, v)
+	}
+
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+`, outputs)
+}
+
+func testBuildResourceIdOutput(parentResources string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+output "id" {
+  value = provider::azurerm::build_resource_id({
+    subscription_id     = "12345678-1234-9876-4563-123456789012"
+    resource_group_name = "resGroup1"
+    resource_provider   = "Microsoft.Network"
+    resource_type       = "subnets"
+    full_resource_type  = "Microsoft.Network/virtualNetworks/subnets"
+    resource_name       = "subnet1"
+    resource_scope      = null
+    parent_resources    = %s
+  })
+}
+`, parentResources)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// azureMaximumIPv4SubnetPrefixLength is the smallest IPv4 Subnet supported by Azure - since Azure reserves
+	// 5 addresses within each Subnet (the first four addresses and the last address)
+	azureMaximumIPv4SubnetPrefixLength = 29
+
+	// azureIPv6SubnetPrefixLength is the only IPv6 Subnet size supported by Azure
+	azureIPv6SubnetPrefixLength = 64
+)
+
+type CidrSubnetsForVnetFunction struct{}
+
+var _ function.Function = CidrSubnetsForVnetFunction{}
+
+func NewCidrSubnetsForVnetFunction() function.Function {
+	return &CidrSubnetsForVnetFunction{}
+}
+
+func (c CidrSubnetsForVnetFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "cidr_subnets_for_vnet"
+}
+
+func (c CidrSubnetsForVnetFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "cidr_subnets_for_vnet",
+		Description:         "Calculates a sequence of consecutive Subnet address prefixes within a Virtual Network address space, which are valid for use in Azure",
+		MarkdownDescription: "Calculates a sequence of consecutive Subnet address prefixes within a Virtual Network address space, which are valid for use in Azure",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "address_space",
+				Description:         "The address space of the Virtual Network in CIDR notation",
+				MarkdownDescription: "The address space of the Virtual Network in CIDR notation",
+			},
+			function.ListParameter{
+				Name:                "newbits",
+				Description:         "The number of additional bits for the prefix of each Subnet",
+				MarkdownDescription: "The number of additional bits for the prefix of each Subnet",
+				ElementType:         types.Int64Type,
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (c CidrSubnetsForVnetFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var addressSpace string
+	var newBits []int64
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &addressSpace, &newBits))
+
+	if response.Error != nil {
+		return
+	}
+
+	result, err := cidrSubnetsForVnet(addressSpace, newBits)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
+
+// cidrSubnetsForVnet allocates consecutive Subnets of the specified sizes within the address space, in the
+// same manner as Terraform's `cidrsubnets` function - whilst ensuring each Subnet is a size supported by Azure.
+func cidrSubnetsForVnet(addressSpace string, newBits []int64) ([]string, error) {
+	prefix, err := netip.ParsePrefix(addressSpace)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", addressSpace, err)
+	}
+	if prefix != prefix.Masked() {
+		return nil, fmt.Errorf("%q must be the network address of the address space, for example %q", addressSpace, prefix.Masked().String())
+	}
+
+	addressLength := prefix.Addr().BitLen()
+	current := new(big.Int).SetBytes(prefix.Addr().AsSlice())
+	end := new(big.Int).Add(current, new(big.Int).Lsh(big.NewInt(1), uint(addressLength-prefix.Bits())))
+
+	result := make([]string, 0)
+	for i, v := range newBits {
+		if v < 1 {
+			return nil, fmt.Errorf("newbits[%d] must be at least 1, got %d", i, v)
+		}
+
+		// checked prior to converting to an int, since a large value would otherwise overflow the prefix length
+		if v > int64(addressLength-prefix.Bits()) {
+			return nil, fmt.Errorf("newbits[%d] must be at most %d, since the prefix cannot exceed the length of the address (%d bits), got %d", i, addressLength-prefix.Bits(), addressLength, v)
+		}
+
+		length := prefix.Bits() + int(v)
+		if prefix.Addr().Is4() && length > azureMaximumIPv4SubnetPrefixLength {
+			return nil, fmt.Errorf("newbits[%d] would result in a /%d Subnet - however Azure reserves 5 addresses within each Subnet, so the smallest supported IPv4 Subnet is a /%d", i, length, azureMaximumIPv4SubnetPrefixLength)
+		}
+		if prefix.Addr().Is6() && length != azureIPv6SubnetPrefixLength {
+			return nil, fmt.Errorf("newbits[%d] would result in a /%d Subnet - however Azure requires that IPv6 Subnets are a /%d", i, length, azureIPv6SubnetPrefixLength)
+		}
+
+		size := new(big.Int).Lsh(big.NewInt(1), uint(addressLength-length))
+
+		// each Subnet must be aligned to its own size, so skip ahead to the next boundary where required
+		if remainder := new(big.Int).Mod(current, size); remainder.Sign() != 0 {
+			current.Add(current, new(big.Int).Sub(size, remainder))
+		}
+
+		next := new(big.Int).Add(current, size)
+		if next.Cmp(end) > 0 {
+			return nil, fmt.Errorf("there is insufficient space within %q to allocate a /%d for newbits[%d]", addressSpace, length, i)
+		}
+
+		address, ok := netip.AddrFromSlice(current.FillBytes(make([]byte, addressLength/8)))
+		if !ok {
+			return nil, fmt.Errorf("building the address for newbits[%d]", i)
+		}
+		result = append(result, netip.PrefixFrom(address, length).String())
+
+		current = next
+	}
+
+	return result, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionCidrSubnetsForVnet_basic(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testCidrSubnetsForVnetOutput("10.0.0.0/16", "[8, 13, 8]"),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("first", "10.0.0.0/24"),
+					acceptance.TestCheckOutput("second", "10.0.1.0/29"),
+					// the third subnet is aligned to the next /24 boundary
+					acceptance.TestCheckOutput("third", "10.0.2.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionCidrSubnetsForVnet_ipv6(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testCidrSubnetsForVnetOutput("ace:cab:deca::/48", "[16, 16, 16]"),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("first", "ace:cab:deca::/64"),
+					acceptance.TestCheckOutput("second", "ace:cab:deca:1::/64"),
+					acceptance.TestCheckOutput("third", "ace:cab:deca:2::/64"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionCidrSubnetsForVnet_invalid(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				// a /30 is too small, since Azure reserves 5 addresses within each Subnet
+				Config:      testCidrSubnetsForVnetOutput("10.0.0.0/24", "[6, 6, 6]"),
+				ExpectError: regexp.MustCompile("the smallest supported IPv4 Subnet is a /29"),
+			},
+			{
+				Config:      testCidrSubnetsForVnetOutput("10.0.0.0/24", "[1, 1, 1]"),
+				ExpectError: regexp.MustCompile("there is insufficient space"),
+			},
+			{
+				Config:      testCidrSubnetsForVnetOutput("ace:cab:deca::/48", "[8, 16, 16]"),
+				ExpectError: regexp.MustCompile("Azure requires that IPv6 Subnets are a /64"),
+			},
+			{
+				// a value this large would overflow the prefix length if it wasn't rejected up front
+				Config:      testCidrSubnetsForVnetOutput("10.0.0.0/16", "[8, 9223372036854775807, 8]"),
+				ExpectError: regexp.MustCompile(`newbits\[1\] must be at most 16`),
+			},
+		},
+	})
+}
+
+func testCidrSubnetsForVnetOutput(addressSpace, newBits string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+locals {
+  subnets = provider::azurerm::cidr_subnets_for_vnet("%s", %s)
+}
+
+output "first" {
+  value = local.subnets[0]
+}
+
+output "second" {
+  value = local.subnets[1]
+}
+
+output "third" {
+  value = local.subnets[2]
+}
+`, addressSpace, newBits)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/recaser"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type IsValidResourceIDFunction struct{}
+
+var _ function.Function = IsValidResourceIDFunction{}
+
+func NewIsValidResourceIDFunction() function.Function {
+	return &IsValidResourceIDFunction{}
+}
+
+func (i IsValidResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "is_valid_resource_id"
+}
+
+func (i IsValidResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "is_valid_resource_id",
+		Description:         "Determines whether the specified value is a valid Azure Resource Manager ID for the specified Resource Type",
+		MarkdownDescription: "Determines whether the specified value is a valid Azure Resource Manager ID for the specified Resource Type",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				Description:         "Resource ID",
+				MarkdownDescription: "Resource ID",
+			},
+			function.StringParameter{
+				Name:                "type",
+				Description:         "The full Resource Type, as returned in the `full_resource_type` field from `parse_resource_id` (for example `Microsoft.Network/virtualNetworks/subnets`)",
+				MarkdownDescription: "The full Resource Type, as returned in the `full_resource_type` field from `parse_resource_id` (for example `Microsoft.Network/virtualNetworks/subnets`)",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (i IsValidResourceIDFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var id, resourceType string
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &id, &resourceType))
+
+	if response.Error != nil {
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, isValidResourceId(id, resourceType)))
+}
+
+func isValidResourceId(id, resourceType string) bool {
+	idType := recaser.ResourceIdTypeFromResourceId(id)
+	if idType == nil {
+		return false
+	}
+
+	// the Resource ID must be in the correct casing to be valid, as such this is parsed case-sensitively
+	parser := resourceids.NewParserFromResourceIdType(idType)
+	if _, err := parser.Parse(id, false); err != nil {
+		return false
+	}
+
+	return strings.EqualFold(fullResourceTypeFromSegments(idType.Segments()), strings.Trim(resourceType, "/"))
+}
+
+// fullResourceTypeFromSegments returns the full Resource Type (for example `Microsoft.Network/virtualNetworks/subnets`)
+// for the Segments of a Resource ID, in the same format as the `full_resource_type` returned from `parse_resource_id`
+func fullResourceTypeFromSegments(segments []resourceids.Segment) string {
+	components := make([]string, 0)
+	for _, v := range segments {
+		switch v.Type {
+		case resourceids.ResourceProviderSegmentType:
+			// a Resource ID can contain multiple Resource Providers when it's scoped to another Resource,
+			// however the Resource Type is defined by the final Resource Provider
+			components = []string{pointer.From(v.FixedValue)}
+
+		case resourceids.StaticSegmentType:
+			if len(components) > 0 && pointer.From(v.FixedValue) != "providers" {
+				components = append(components, pointer.From(v.FixedValue))
+			}
+		}
+	}
+
+	return strings.Join(components, "/")
+}
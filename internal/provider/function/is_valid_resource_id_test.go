@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionIsValidResourceID_basic(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testIsValidResourceIdOutput(map[string][]string{
+					"valid":         {"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1", "Microsoft.Network/virtualNetworks/subnets"},
+					"wrong_type":    {"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1", "Microsoft.Network/virtualNetworks/subnets"},
+					"wrong_casing":  {"/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1", "Microsoft.Network/virtualNetworks"},
+					"scoped":        {"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Chaos/targets/target1", "Microsoft.Chaos/targets"},
+					"not_an_id":     {"not-a-resource-id", "Microsoft.Network/virtualNetworks"},
+					"case_of_types": {"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1", "microsoft.network/virtualnetworks"},
+				}),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("valid", "true"),
+					acceptance.TestCheckOutput("wrong_type", "false"),
+					acceptance.TestCheckOutput("wrong_casing", "false"),
+					acceptance.TestCheckOutput("scoped", "true"),
+					acceptance.TestCheckOutput("not_an_id", "false"),
+					acceptance.TestCheckOutput("case_of_types", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testIsValidResourceIdOutput(cases map[string][]string) string {
+	outputs := ""
+	for k, v := range cases {
+		outputs += fmt.Sprintf(`
+
+output "%s" {
+  value = provider::azurerm::is_valid_resource_id("%s", "%s")
+}
+`, k, v[0], v[1])
+	}
+
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+`, outputs)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type LocationNormalizeFunction struct{}
+
+var _ function.Function = LocationNormalizeFunction{}
+
+func NewLocationNormalizeFunction() function.Function {
+	return &LocationNormalizeFunction{}
+}
+
+func (l LocationNormalizeFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "location_normalize"
+}
+
+func (l LocationNormalizeFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "location_normalize",
+		Description:         "Normalises an Azure Location (for example `West Europe`) into the format used by the Azure Resource Manager API (for example `westeurope`)",
+		MarkdownDescription: "Normalises an Azure Location (for example `West Europe`) into the format used by the Azure Resource Manager API (for example `westeurope`)",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "location",
+				Description:         "The Azure Location",
+				MarkdownDescription: "The Azure Location",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (l LocationNormalizeFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var input string
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &input))
+
+	if response.Error != nil {
+		return
+	}
+
+	if len(input) == 0 {
+		response.Error = function.NewArgumentFuncError(0, "Got empty Location")
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, location.Normalize(input)))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+)
+
+// storageAccountNameMaxLength is the maximum length of a Storage Account name
+const storageAccountNameMaxLength = 24
+
+var storageAccountNameInvalidCharacters = regexp.MustCompile(`[^a-z0-9]`)
+
+type StorageAccountNameFunction struct{}
+
+var _ function.Function = StorageAccountNameFunction{}
+
+func NewStorageAccountNameFunction() function.Function {
+	return &StorageAccountNameFunction{}
+}
+
+func (s StorageAccountNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "storage_account_name"
+}
+
+func (s StorageAccountNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "storage_account_name",
+		Description:         "Sanitises the input into a Storage Account name which is valid for Azure, by lower-casing it, removing any unsupported characters and truncating it to 24 characters",
+		MarkdownDescription: "Sanitises the input into a Storage Account name which is valid for Azure, by lower-casing it, removing any unsupported characters and truncating it to 24 characters",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				Description:         "The name to sanitise",
+				MarkdownDescription: "The name to sanitise",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (s StorageAccountNameFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var name string
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &name))
+
+	if response.Error != nil {
+		return
+	}
+
+	result := storageAccountNameInvalidCharacters.ReplaceAllString(strings.ToLower(name), "")
+	if len(result) > storageAccountNameMaxLength {
+		result = result[:storageAccountNameMaxLength]
+	}
+
+	if _, errs := validate.StorageAccountName(result, "name"); len(errs) > 0 {
+		funcErrors := make([]*function.FuncError, 0)
+		for _, err := range errs {
+			funcErrors = append(funcErrors, function.NewArgumentFuncError(0, err.Error()))
+		}
+		response.Error = function.ConcatFuncErrors(funcErrors...)
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
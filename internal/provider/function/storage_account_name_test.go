@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionStorageAccountName_basic(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: testStorageAccountNameOutput("Example-Storage_Account 01"),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("name", "examplestorageaccount01"),
+				),
+			},
+			{
+				Config: testStorageAccountNameOutput("a-really-long-storage-account-name-for-production"),
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("name", "areallylongstorageaccoun"),
+				),
+			},
+			{
+				Config:      testStorageAccountNameOutput("a-b"),
+				ExpectError: regexp.MustCompile("must be between 3 and 24 characters long"),
+			},
+		},
+	})
+}
+
+func testStorageAccountNameOutput(name string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+output "name" {
+  value = provider::azurerm::storage_account_name("%s")
+}
+`, name)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+// resourceProvidersForService returns the Resource Providers declared by the Service, if any
+func resourceProvidersForService(service interface{}) []string {
+	if v, ok := service.(sdk.ServiceRegistrationWithResourceProviders); ok {
+		return v.ResourceProviders()
+	}
+	return nil
+}
+
+// withLazyResourceProviderRegistration registers the Resource Providers required by the Resource (or Data Source)
+// prior to it being created, read or imported, when `resource_provider_registrations` is set to `lazy`.
+//
+// Reading a Resource also requires the Resource Providers to be registered, for example when the Resource was
+// created outside of Terraform, however since the Resource Providers which are registered are cached this is
+// only looked up once.
+func withLazyResourceProviderRegistration(resourceType string, resource *schema.Resource, namespaces []string) *schema.Resource {
+	if len(namespaces) == 0 {
+		return resource
+	}
+
+	ensureRegistered := func(ctx context.Context, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		if !ok || client.ResourceProviderRegistration == nil {
+			return nil
+		}
+		return client.ResourceProviderRegistration.EnsureRegistered(ctx, resourceType, namespaces)
+	}
+
+	wrapContext := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := ensureRegistered(ctx, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return f(ctx, d, meta)
+		}
+	}
+
+	// the Untyped Resources use the (deprecated) Create and Read functions which aren't context-aware
+	wrap := func(f func(*schema.ResourceData, interface{}) error, withTimeout func(context.Context, *schema.ResourceData) (context.Context, context.CancelFunc)) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			ctx := context.Background()
+			if client, ok := meta.(*clients.Client); ok && client.StopContext != nil {
+				ctx = client.StopContext
+			}
+			ctx, cancel := withTimeout(ctx, d)
+			defer cancel()
+
+			if err := ensureRegistered(ctx, meta); err != nil {
+				return err
+			}
+			return f(d, meta)
+		}
+	}
+
+	resource.CreateContext = wrapContext(resource.CreateContext)
+	resource.CreateWithoutTimeout = wrapContext(resource.CreateWithoutTimeout)
+	resource.ReadContext = wrapContext(resource.ReadContext)
+	resource.ReadWithoutTimeout = wrapContext(resource.ReadWithoutTimeout)
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	resource.Create = wrap(resource.Create, timeouts.ForCreate) //nolint:staticcheck
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	resource.Read = wrap(resource.Read, timeouts.ForRead) //nolint:staticcheck
+
+	if resource.Importer != nil {
+		// the Importer can be shared between Resources, so is copied rather than updated
+		importer := *resource.Importer
+		resource.Importer = &importer
+
+		if importState := importer.StateContext; importState != nil {
+			importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := ensureRegistered(ctx, meta); err != nil {
+					return nil, err
+				}
+				return importState(ctx, d, meta)
+			}
+		}
+
+		//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+		if importState := importer.State; importState != nil { //nolint:staticcheck
+			//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+			importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) { //nolint:staticcheck
+				ctx := context.Background()
+				if client, ok := meta.(*clients.Client); ok && client.StopContext != nil {
+					ctx = client.StopContext
+				}
+
+				if err := ensureRegistered(ctx, meta); err != nil {
+					return nil, err
+				}
+				return importState(d, meta)
+			}
+		}
+	}
+
+	return resource
+}
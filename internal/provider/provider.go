@@ -20,6 +20,8 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
@@ -99,7 +101,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Errorf("creating Wrapper for Data Source %q: %+v", key, err))
 			}
 
-			dataSources[key] = dataSource
+			dataSources[key] = withLazyResourceProviderRegistration(key, dataSource, resourceProvidersForService(service))
 		}
 
 		logEntry("[DEBUG] Registering Resources for %q..", service.Name())
@@ -114,7 +116,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 			if err != nil {
 				panic(fmt.Errorf("creating Wrapper for Resource %q: %+v", key, err))
 			}
-			resources[key] = resource
+			resources[key] = withLazyResourceProviderRegistration(key, resource, resourceProvidersForService(service))
 		}
 	}
 
@@ -126,7 +128,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Sprintf("An existing Data Source exists for %q", k))
 			}
 
-			dataSources[k] = v
+			dataSources[k] = withLazyResourceProviderRegistration(k, v, resourceProvidersForService(service))
 		}
 
 		logEntry("[DEBUG] Registering Resources for %q..", service.Name())
@@ -135,7 +137,16 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Sprintf("An existing Resource exists for %q", k))
 			}
 
-			resources[k] = v
+			resources[k] = withLazyResourceProviderRegistration(k, v, resourceProvidersForService(service))
+		}
+	}
+
+	// the instances of the Resources exposed as List Resources are imported using their Resource Identity
+	for _, service := range SupportedListResourceServices() {
+		for _, r := range service.ListResources() {
+			if resource, ok := resources[r.ResourceType()]; ok {
+				resources[r.ResourceType()] = pluginsdk.WithResourceIdentity(resource, r.IdentityType())
+			}
 		}
 	}
 
@@ -229,6 +240,10 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "The path to a file containing the Client Secret which should be used. For use When authenticating as a Service Principal using a Client Secret.",
 			},
 
+			"client_certificate_provider": schemaClientCertificateProvider(),
+
+			"client_secret_provider": schemaClientSecretProvider(),
+
 			// OIDC specific fields
 			"use_oidc": {
 				Type:        schema.TypeBool,
@@ -288,6 +303,20 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "Allow Azure CLI to be used for Authentication.",
 			},
 
+			"use_azd": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_AZD", false),
+				Description: "Allow Azure Developer CLI to be used for Authentication.",
+			},
+
+			"use_powershell": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_POWERSHELL", false),
+				Description: "Allow Azure PowerShell to be used for Authentication.",
+			},
+
 			// Azure AKS Workload Identity fields
 			"use_aks_workload_identity": {
 				Type:        schema.TypeBool,
@@ -321,6 +350,14 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 
 			"features": schemaFeatures(supportLegacyTestSuite),
 
+			"default_tags": schemaDefaultTags(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
+			"default_timeouts": schemaDefaultTimeouts(),
+
+			"retry": schemaRetry(),
+
 			// Advanced feature flags
 			"resource_provider_registrations": {
 				Type:        schema.TypeString,
@@ -333,6 +370,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 					resourceproviders.ProviderRegistrationsAll,
 					resourceproviders.ProviderRegistrationsNone,
 					resourceproviders.ProviderRegistrationsLegacy,
+					resourceproviders.ProviderRegistrationsLazy,
 				}, false),
 			},
 
@@ -361,6 +399,22 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("ARM_STORAGE_USE_AZUREAD", false),
 				Description: "Should the AzureRM Provider use Azure AD Authentication when accessing the Storage Data Plane APIs?",
 			},
+
+			"arm_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The maximum number of requests per second which should be sent to Azure Resource Manager for each Subscription. Defaults to `0`, meaning there's no limit.",
+			},
+
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of concurrent requests which should be sent to Azure Resource Manager for each Subscription. Defaults to `0`, meaning there's no limit.",
+			},
 		},
 
 		DataSourcesMap: dataSources,
@@ -375,6 +429,14 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 		delete(p.Schema, "resource_providers_to_register")
 	}
 
+	// the `default_tags`, `ignore_tags` and `default_timeouts` blocks are configured per instance of the Provider, so are retrieved from the Client
+	for k, v := range p.ResourcesMap {
+		p.ResourcesMap[k] = timeouts.WithDefaults(k, withProviderTags(v), defaultTimeoutsFromMeta)
+	}
+	for k, v := range p.DataSourcesMap {
+		p.DataSourcesMap[k] = timeouts.WithDefaults(k, withProviderIgnoredTags(v), defaultTimeoutsFromMeta)
+	}
+
 	p.ConfigureContextFunc = providerConfigure(p)
 
 	return p
@@ -511,14 +573,42 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		requiredResourceProviders.Merge(additionalProvidersToRegister)
 	}
 
+	ignoreTagKeys, ignoreTagKeyPrefixes := expandIgnoreTags(d.Get("ignore_tags").([]interface{}))
+
+	defaultTimeouts, err := expandDefaultTimeouts(d.Get("default_timeouts").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	retryPolicyOverrides, err := expandRetry(d.Get("retry").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	developerTools := clients.DeveloperToolCredentials{
+		EnableAuthenticatingUsingAzureDeveloperCLI: d.Get("use_azd").(bool),
+		EnableAuthenticatingUsingAzurePowerShell:   d.Get("use_powershell").(bool),
+	}
+
 	clientBuilder := clients.ClientBuilder{
+		ARMRequestsPerSecond:        d.Get("arm_requests_per_second").(float64),
 		AuthConfig:                  authConfig,
+		ClientCertificateProvider:   expandClientCertificateProvider(d.Get("client_certificate_provider").([]interface{})),
+		ClientSecretProvider:        expandClientSecretProvider(d.Get("client_secret_provider").([]interface{})),
+		DefaultTags:                 expandDefaultTags(d.Get("default_tags").([]interface{})),
+		DefaultTimeouts:             defaultTimeouts,
+		DeveloperTools:              developerTools,
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 		DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 		Features:                    expandFeatures(d.Get("features").([]interface{})),
+		IgnoreTagKeys:               ignoreTagKeys,
+		IgnoreTagKeyPrefixes:        ignoreTagKeyPrefixes,
+		MaxConcurrentRequests:       d.Get("max_concurrent_requests").(int),
 		MetadataHost:                d.Get("metadata_host").(string),
 		PartnerID:                   d.Get("partner_id").(string),
 		RegisteredResourceProviders: requiredResourceProviders,
+		RetryPolicyOverrides:        *retryPolicyOverrides,
+		RetryRules:                  RetryRulesForServices(),
 		StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
 		SubscriptionID:              d.Get("subscription_id").(string),
 		TerraformVersion:            p.TerraformVersion,
@@ -546,10 +636,14 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 	ctx2, cancel := context.WithTimeout(ctx, 30*time.Minute)
 	defer cancel()
 
-	if err = resourceproviders.EnsureRegistered(ctx2, client.Resource.ResourceProvidersClient, subscriptionId, requiredResourceProviders); err != nil {
+	if err = resourceproviders.EnsureRegistered(ctx2, client.Resource.ResourceProvidersClient, subscriptionId, requiredResourceProviders, client.ResourceProviderCache); err != nil {
 		return nil, diag.FromErr(err)
 
 	}
 
+	if providerRegistrations == resourceproviders.ProviderRegistrationsLazy {
+		client.ResourceProviderRegistration = resourceproviders.NewLazyRegistration(client.Resource.ResourceProvidersClient, subscriptionId, client.ResourceProviderCache)
+	}
+
 	return client, nil
 }
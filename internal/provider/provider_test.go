@@ -80,13 +80,14 @@ func TestResourcesSupportCustomTimeouts(t *testing.T) {
 				t.Fatalf("Resource %q defines a Default timeout when it shouldn't!", resourceName)
 			}
 
-			// every Resource has to have a Create, Read & Destroy timeout
+			// every Resource has to have a Create, Read & Destroy timeout - the Context methods are called using the
+			// WithoutTimeout methods, so that the `default_timeouts` block in the Provider block can be applied
 
 			//lint:ignore SA1019 SDKv2 migration  - staticcheck's own linter directives are currently being ignored under golanci-lint
-			if (resource.Timeouts.Create == nil) != (resource.Create == nil && resource.CreateContext == nil) { //nolint:staticcheck
+			if (resource.Timeouts.Create == nil) != (resource.Create == nil && resource.CreateContext == nil && resource.CreateWithoutTimeout == nil) { //nolint:staticcheck
 				t.Fatalf("Resource %q should define/not define the Create(Context) method and the Create Timeout at the same time", resourceName)
 			}
-			if (resource.Timeouts.Delete == nil) != (resource.Delete == nil && resource.DeleteContext == nil) { //nolint:staticcheck
+			if (resource.Timeouts.Delete == nil) != (resource.Delete == nil && resource.DeleteContext == nil && resource.DeleteWithoutTimeout == nil) { //nolint:staticcheck
 				t.Fatalf("Resource %q should define/not define the Delete(Context) method and the Delete Timeout at the same time", resourceName)
 			}
 			if resource.Timeouts.Read == nil {
@@ -104,7 +105,7 @@ func TestResourcesSupportCustomTimeouts(t *testing.T) {
 			}
 
 			// Optional
-			if (resource.Timeouts.Update == nil) != (resource.Update == nil && resource.UpdateContext == nil) { //nolint:staticcheck
+			if (resource.Timeouts.Update == nil) != (resource.Update == nil && resource.UpdateContext == nil && resource.UpdateWithoutTimeout == nil) { //nolint:staticcheck
 				t.Fatalf("Resource %q should define/not define the Update(Context) method and the Update Timeout at the same time", resourceName)
 			}
 		})
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaRetry() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Configures how transient errors returned by Azure Resource Manager are retried.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_attempts": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The maximum number of times a request which fails with a transient error is sent.",
+				},
+
+				"max_backoff": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validateTimeoutDuration,
+					Description:  "The maximum delay between retries of a request which fails with a transient error.",
+				},
+
+				"additional_error_codes": {
+					Type:        pluginsdk.TypeSet,
+					Optional:    true,
+					Description: "A set of error codes returned by Azure which should also be retried.",
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"disabled_error_codes": {
+					Type:        pluginsdk.TypeSet,
+					Optional:    true,
+					Description: "A set of error codes returned by Azure which shouldn't be retried, even when these are retried for the Resource Provider.",
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandRetry(input []interface{}) (*common.RetryPolicyOverrides, error) {
+	output := common.RetryPolicyOverrides{}
+	if len(input) == 0 || input[0] == nil {
+		return &output, nil
+	}
+
+	val := input[0].(map[string]interface{})
+	output.MaxAttempts = val["max_attempts"].(int)
+
+	if v := val["max_backoff"].(string); v != "" {
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `max_backoff` within the `retry` block: %+v", err)
+		}
+		output.MaxBackoff = duration
+	}
+
+	for _, v := range val["additional_error_codes"].(*pluginsdk.Set).List() {
+		output.AdditionalErrorCodes = append(output.AdditionalErrorCodes, v.(string))
+	}
+	for _, v := range val["disabled_error_codes"].(*pluginsdk.Set).List() {
+		output.DisabledErrorCodes = append(output.DisabledErrorCodes, v.(string))
+	}
+
+	return &output, nil
+}
+
+// RetryRulesForServices returns the transient errors declared by each Service, which are the only errors retried
+// by the RetryPolicy other than any `additional_error_codes` specified in the Provider block
+func RetryRulesForServices() []common.RetryRule {
+	output := make([]common.RetryRule, 0)
+	seen := make(map[common.RetryRule]struct{})
+	add := func(service interface{}) {
+		// Services which are both Typed and Untyped are returned from both lists
+		for _, rule := range retryRulesForService(service) {
+			if _, ok := seen[rule]; !ok {
+				seen[rule] = struct{}{}
+				output = append(output, rule)
+			}
+		}
+	}
+
+	for _, service := range SupportedTypedServices() {
+		add(service)
+	}
+	for _, service := range SupportedUntypedServices() {
+		add(service)
+	}
+	return output
+}
+
+// retryRulesForService returns the transient errors declared by the Service, if any
+func retryRulesForService(service interface{}) []common.RetryRule {
+	if v, ok := service.(sdk.ServiceRegistrationWithRetryRules); ok {
+		return v.RetryRules()
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExpandRetry(t *testing.T) {
+	if v, err := expandRetry([]interface{}{}); err != nil || v.MaxAttempts != 0 || v.MaxBackoff != 0 {
+		t.Fatalf("expected no overrides for an empty block but got %+v / %+v", v, err)
+	}
+
+	overrides, err := expandRetry([]interface{}{
+		map[string]interface{}{
+			"max_attempts":           5,
+			"max_backoff":            "30s",
+			"additional_error_codes": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"SubnetIsFull"}),
+			"disabled_error_codes":   pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"AnotherOperationInProgress"}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expanding: %+v", err)
+	}
+
+	if overrides.MaxAttempts != 5 || overrides.MaxBackoff != 30*time.Second {
+		t.Fatalf("expected `max_attempts` and `max_backoff` to be 5 and 30s but got %d and %s", overrides.MaxAttempts, overrides.MaxBackoff)
+	}
+	if len(overrides.AdditionalErrorCodes) != 1 || overrides.AdditionalErrorCodes[0] != "SubnetIsFull" {
+		t.Fatalf("expected `additional_error_codes` to be `SubnetIsFull` but got %+v", overrides.AdditionalErrorCodes)
+	}
+	if len(overrides.DisabledErrorCodes) != 1 || overrides.DisabledErrorCodes[0] != "AnotherOperationInProgress" {
+		t.Fatalf("expected `disabled_error_codes` to be `AnotherOperationInProgress` but got %+v", overrides.DisabledErrorCodes)
+	}
+}
+
+func TestRetryRulesForServices(t *testing.T) {
+	seen := make(map[string]struct{})
+	for _, rule := range RetryRulesForServices() {
+		if rule.ErrorCode == "" && rule.MessageContains == "" {
+			t.Fatalf("expected the retry rules for each Service to specify an error code or message but got %+v", rule)
+		}
+		if rule.Namespace == "" {
+			t.Fatalf("expected the retry rule for %q to be limited to a Resource Provider", rule.ErrorCode)
+		}
+
+		key := rule.Namespace + "/" + rule.ErrorCode
+		if _, ok := seen[key]; ok {
+			t.Fatalf("expected the retry rule for %q to be declared once but got duplicates", key)
+		}
+		seen[key] = struct{}{}
+	}
+}
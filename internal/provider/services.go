@@ -138,6 +138,7 @@ import (
 )
 
 //go:generate go run ../tools/generator-services/main.go -path=../../
+//go:generate go run ../tools/generator-resource-identities/main.go -path=../../
 
 func SupportedTypedServices() []sdk.TypedServiceRegistration {
 	services := []sdk.TypedServiceRegistration{
@@ -337,3 +338,21 @@ func SupportedUntypedServices() []sdk.UntypedServiceRegistration {
 		return out
 	}()
 }
+
+func SupportedFrameworkServices() []sdk.FrameworkServiceRegistration {
+	return []sdk.FrameworkServiceRegistration{
+		containers.Registration{},
+		keyvault.Registration{},
+		storage.Registration{},
+	}
+}
+
+func SupportedListResourceServices() []sdk.ServiceRegistrationWithListResources {
+	return []sdk.ServiceRegistrationWithListResources{
+		containers.Registration{},
+		keyvault.Registration{},
+		network.Registration{},
+		resource.Registration{},
+		storage.Registration{},
+	}
+}
@@ -4,12 +4,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -40,6 +46,185 @@ func TestTypedResourcesContainValidModelObjects(t *testing.T) {
 	}
 }
 
+// typedResourcesWithUnstructuredIdentities are Typed Resources whose Resource ID can't be split into fields (since it
+// comprises other Resource IDs, or is a Data Plane ID) - where the Resource Identity instead contains the `id`
+var typedResourcesWithUnstructuredIdentities = map[string]struct{}{
+	"azurerm_communication_service_email_domain_association":             {},
+	"azurerm_disk_pool_iscsi_target_lun":                                 {},
+	"azurerm_disk_pool_managed_disk_attachment":                          {},
+	"azurerm_key_vault_managed_hardware_security_module_key":             {},
+	"azurerm_key_vault_managed_hardware_security_module_role_assignment": {},
+	"azurerm_key_vault_managed_hardware_security_module_role_definition": {},
+	"azurerm_marketplace_role_assignment":                                {},
+	"azurerm_private_endpoint_application_security_group_association":    {},
+	"azurerm_resource_policy_assignment":                                 {},
+	"azurerm_role_definition":                                            {},
+	"azurerm_source_control_token":                                       {},
+	"azurerm_virtual_machine_gallery_application_assignment":             {},
+}
+
+func TestTypedResourcesContainValidIdentities(t *testing.T) {
+	for _, service := range SupportedTypedServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, resource := range service.Resources() {
+			t.Logf("- Resource %q..", resource.ResourceType())
+			identityType := sdk.ResourceIdentityTypeFor(resource)
+			fields := identityType.Fields()
+			if len(fields) == 0 {
+				t.Fatalf("the Resource Identity for %q contains no fields", resource.ResourceType())
+			}
+
+			_, unstructured := typedResourcesWithUnstructuredIdentities[resource.ResourceType()]
+			if isUnstructured := len(fields) == 1 && fields[0] == "id"; isUnstructured != unstructured {
+				if isUnstructured {
+					t.Fatalf("the Resource Identity for %q couldn't be derived from the IDValidationFunc - run `make generate` or implement `sdk.ResourceWithIdentity`", resource.ResourceType())
+				}
+				t.Fatalf("the Resource Identity for %q is now derived from the IDValidationFunc - remove it from `typedResourcesWithUnstructuredIdentities`", resource.ResourceType())
+			}
+
+			schema := pluginsdk.NewResourceIdentity(identityType).SchemaFunc()
+			if len(schema) != len(fields) {
+				t.Fatalf("expected the Resource Identity Schema for %q to contain %d fields but got %d", resource.ResourceType(), len(fields), len(schema))
+			}
+
+			v, ok := resource.(sdk.ResourceWithIdentity)
+			if !ok {
+				continue
+			}
+
+			id := v.Identity()
+
+			// the example Resource ID must round-trip via the Resource Identity
+			components := make([]string, 0)
+			scoped := false
+			for _, segment := range id.Segments() {
+				scoped = scoped || segment.Type == resourceids.ScopeSegmentType
+				if segment.FixedValue != nil {
+					components = append(components, *segment.FixedValue)
+					continue
+				}
+				components = append(components, strings.TrimPrefix(segment.ExampleValue, "/"))
+			}
+			exampleId := "/" + strings.Join(components, "/")
+
+			values, err := identityType.ValuesFromId(exampleId)
+			if err != nil {
+				t.Fatalf("parsing the Resource Identity for %q: %+v", exampleId, err)
+			}
+			actual, err := identityType.IdFromValues(values)
+			if err != nil {
+				t.Fatalf("building the Resource ID from the Resource Identity %+v: %+v", values, err)
+			}
+			if actual != exampleId {
+				t.Fatalf("expected the Resource ID to be %q but got %q", exampleId, actual)
+			}
+
+			// the IDValidationFunc for a Scoped Resource ID may further validate the scope, which the example doesn't match
+			if scoped {
+				continue
+			}
+			if _, errs := resource.IDValidationFunc()(exampleId, "id"); len(errs) > 0 {
+				t.Fatalf("the IDValidationFunc for %q doesn't match the Resource Identity: %+v", resource.ResourceType(), errs)
+			}
+		}
+	}
+}
+
+func TestFrameworkEphemeralResourcesContainValidSchemas(t *testing.T) {
+	ctx := context.TODO()
+	names := make(map[string]struct{})
+	for _, service := range SupportedFrameworkServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, f := range service.EphemeralResources() {
+			r := f()
+
+			metadata := ephemeral.MetadataResponse{}
+			r.Metadata(ctx, ephemeral.MetadataRequest{ProviderTypeName: "azurerm"}, &metadata)
+			t.Logf("- Ephemeral Resource %q..", metadata.TypeName)
+			if !strings.HasPrefix(metadata.TypeName, "azurerm_") {
+				t.Fatalf("Ephemeral Resource %q must be prefixed with `azurerm_`", metadata.TypeName)
+			}
+			if _, exists := names[metadata.TypeName]; exists {
+				t.Fatalf("Ephemeral Resource %q is registered multiple times", metadata.TypeName)
+			}
+			names[metadata.TypeName] = struct{}{}
+
+			schema := ephemeral.SchemaResponse{}
+			r.Schema(ctx, ephemeral.SchemaRequest{}, &schema)
+			if schema.Diagnostics.HasError() {
+				t.Fatalf("retrieving schema: %+v", schema.Diagnostics)
+			}
+			if diags := schema.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("validating schema: %+v", diags)
+			}
+		}
+	}
+}
+
+func TestFrameworkResourcesContainValidSchemas(t *testing.T) {
+	ctx := context.TODO()
+	provider := AzureProvider()
+	names := make(map[string]struct{})
+	for _, service := range SupportedFrameworkServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, f := range service.FrameworkResources() {
+			r := f()
+
+			metadata := resource.MetadataResponse{}
+			r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "azurerm"}, &metadata)
+			t.Logf("- Resource %q..", metadata.TypeName)
+			if _, exists := provider.ResourcesMap[metadata.TypeName]; exists {
+				t.Fatalf("Resource %q is registered in both the Plugin SDK and the Plugin Framework", metadata.TypeName)
+			}
+			if _, exists := names[metadata.TypeName]; exists {
+				t.Fatalf("Resource %q is registered multiple times", metadata.TypeName)
+			}
+			names[metadata.TypeName] = struct{}{}
+
+			schema := resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, &schema)
+			if schema.Diagnostics.HasError() {
+				t.Fatalf("retrieving schema: %+v", schema.Diagnostics)
+			}
+			if diags := schema.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("validating schema: %+v", diags)
+			}
+		}
+	}
+}
+
+func TestFrameworkDataSourcesContainValidSchemas(t *testing.T) {
+	ctx := context.TODO()
+	provider := AzureProvider()
+	names := make(map[string]struct{})
+	for _, service := range SupportedFrameworkServices() {
+		t.Logf("Service %q..", service.Name())
+		for _, f := range service.FrameworkDataSources() {
+			d := f()
+
+			metadata := datasource.MetadataResponse{}
+			d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "azurerm"}, &metadata)
+			t.Logf("- Data Source %q..", metadata.TypeName)
+			if _, exists := provider.DataSourcesMap[metadata.TypeName]; exists {
+				t.Fatalf("Data Source %q is registered in both the Plugin SDK and the Plugin Framework", metadata.TypeName)
+			}
+			if _, exists := names[metadata.TypeName]; exists {
+				t.Fatalf("Data Source %q is registered multiple times", metadata.TypeName)
+			}
+			names[metadata.TypeName] = struct{}{}
+
+			schema := datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, &schema)
+			if schema.Diagnostics.HasError() {
+				t.Fatalf("retrieving schema: %+v", schema.Diagnostics)
+			}
+			if diags := schema.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("validating schema: %+v", diags)
+			}
+		}
+	}
+}
+
 func TestTypedResourcesContainValidIDParsers(t *testing.T) {
 	// This test confirms that all of the Typed Resources return an ID Validation method
 	// which is used to ensure that each of the resources will validate the Resource ID
@@ -207,3 +392,86 @@ func TestTypedResourcesUsePointersForOptionalProperties(t *testing.T) {
 		t.Fatalf("schema properties found with incorrect types - `Optional` should be pointers, `Required` should not be pointers")
 	}
 }
+
+func TestServicesDeclareKnownResourceProviders(t *testing.T) {
+	// these Resource Providers are registered on demand when `resource_provider_registrations` is set to `lazy`
+	// but aren't part of any of the predefined sets, so aren't registered automatically
+	declaredOnly := resourceproviders.ResourceProviders{
+		"Microsoft.AAD":                     {},
+		"Microsoft.Advisor":                 {},
+		"Microsoft.AnalysisServices":        {},
+		"Microsoft.App":                     {},
+		"Microsoft.Attestation":             {},
+		"Microsoft.Automanage":              {},
+		"Microsoft.AzureActiveDirectory":    {},
+		"Microsoft.AzureStackHCI":           {},
+		"Microsoft.Batch":                   {},
+		"Microsoft.Billing":                 {},
+		"Microsoft.Chaos":                   {},
+		"Microsoft.Communication":           {},
+		"Microsoft.ConfidentialLedger":      {},
+		"Microsoft.Consumption":             {},
+		"Microsoft.DataBoxEdge":             {},
+		"Microsoft.DataShare":               {},
+		"Microsoft.Datadog":                 {},
+		"Microsoft.DevCenter":               {},
+		"Microsoft.DigitalTwins":            {},
+		"Microsoft.Elastic":                 {},
+		"Microsoft.ElasticSan":              {},
+		"Microsoft.ExtendedLocation":        {},
+		"Microsoft.FluidRelay":              {},
+		"Microsoft.GraphServices":           {},
+		"Microsoft.HardwareSecurityModules": {},
+		"Microsoft.HybridCompute":           {},
+		"Microsoft.Kubernetes":              {},
+		"Microsoft.KubernetesConfiguration": {},
+		"Microsoft.LabServices":             {},
+		"Microsoft.LoadTestService":         {},
+		"Microsoft.Logz":                    {},
+		"Microsoft.MobileNetwork":           {},
+		"Microsoft.NetApp":                  {},
+		"Microsoft.NetworkFunction":         {},
+		"Microsoft.Orbital":                 {},
+		"Microsoft.Portal":                  {},
+		"Microsoft.Purview":                 {},
+		"Microsoft.RedHatOpenShift":         {},
+		"Microsoft.ResourceConnector":       {},
+		"Microsoft.ScVmm":                   {},
+		"Microsoft.ServiceLinker":           {},
+		"Microsoft.ServiceNetworking":       {},
+		"Microsoft.Solutions":               {},
+		"Microsoft.StorageCache":            {},
+		"Microsoft.StorageMover":            {},
+		"Microsoft.StoragePool":             {},
+		"Microsoft.Subscription":            {},
+		"Microsoft.Synapse":                 {},
+		"Microsoft.VoiceServices":           {},
+		"Microsoft.Workloads":               {},
+		"NGINX.NGINXPLUS":                   {},
+		"NewRelic.Observability":            {},
+		"PaloAltoNetworks.Cloudngfw":        {},
+	}
+	known := resourceproviders.All().Merge(resourceproviders.Legacy()).Merge(declaredOnly)
+
+	services := make([]interface{}, 0)
+	for _, service := range SupportedTypedServices() {
+		services = append(services, service)
+	}
+	for _, service := range SupportedUntypedServices() {
+		services = append(services, service)
+	}
+
+	for _, service := range services {
+		namespaces := resourceProvidersForService(service)
+		if len(namespaces) == 0 {
+			t.Errorf("the Service %T doesn't declare the Resource Providers it requires", service)
+		}
+
+		for _, namespace := range namespaces {
+			// Resource Providers are case-sensitive
+			if _, ok := known[namespace]; !ok {
+				t.Errorf("the Service %T declares the Resource Provider %q which isn't a known Resource Provider", service, namespace)
+			}
+		}
+	}
+}
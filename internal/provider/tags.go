@@ -0,0 +1,302 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "A set of Tags which should be applied to all resources which support Tags.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Optional:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "A set of Tags which are managed outside of Terraform and should be ignored by all resources which support Tags.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandDefaultTags(input []interface{}) map[string]string {
+	output := make(map[string]string)
+
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	val := input[0].(map[string]interface{})
+	for k, v := range val["tags"].(map[string]interface{}) {
+		// Validate should have ignored this error already
+		value, _ := tags.TagValueToString(v)
+		output[k] = value
+	}
+
+	return output
+}
+
+func expandIgnoreTags(input []interface{}) (keys []string, keyPrefixes []string) {
+	keys = make([]string, 0)
+	keyPrefixes = make([]string, 0)
+
+	if len(input) == 0 || input[0] == nil {
+		return keys, keyPrefixes
+	}
+
+	val := input[0].(map[string]interface{})
+	if v, ok := val["keys"].(*pluginsdk.Set); ok {
+		for _, key := range v.List() {
+			keys = append(keys, key.(string))
+		}
+	}
+	if v, ok := val["key_prefixes"].(*pluginsdk.Set); ok {
+		for _, prefix := range v.List() {
+			keyPrefixes = append(keyPrefixes, prefix.(string))
+		}
+	}
+
+	return keys, keyPrefixes
+}
+
+// withProviderTags applies the `default_tags` and `ignore_tags` blocks of the Provider to a Resource with a top-level
+// `tags` field, which is done here rather than within each Resource since Resources use both this Provider's `tags`
+// package and the `tags` and `commonschema` packages from `go-azure-helpers` - the Tags themselves are merged and
+// filtered by the `tags.Configuration` for this instance of the Provider.
+//
+// The Tags assigned to the Resource (including the Default Tags and any ignored Tags) are exposed via `tags_all`,
+// which means that a change to only the Default Tags is shown in the plan and then applied - whilst `tags` only
+// contains the Tags defined on the Resource itself.
+func withProviderTags(resource *schema.Resource) *schema.Resource {
+	tagsSchema, ok := resource.Schema["tags"]
+	if !ok || tagsSchema.Type != schema.TypeMap || !(tagsSchema.Optional || tagsSchema.Required) {
+		return resource
+	}
+	if elem, ok := tagsSchema.Elem.(*schema.Schema); !ok || elem.Type != schema.TypeString {
+		return resource
+	}
+	if _, exists := resource.Schema["tags_all"]; exists {
+		return resource
+	}
+
+	resource.Schema["tags_all"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "All of the Tags assigned to this Resource, including those inherited from the `default_tags` block of the Provider.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	updatable := resource.Update != nil || resource.UpdateContext != nil || resource.UpdateWithoutTimeout != nil //nolint:staticcheck
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+
+		if !d.NewValueKnown("tags") {
+			return d.SetNewComputed("tags_all")
+		}
+
+		existing, _ := d.GetChange("tags_all")
+		configured, _ := d.Get("tags").(map[string]interface{})
+		allTags := tagsConfiguration(meta).AllTags(configured, existing.(map[string]interface{}))
+		if reflect.DeepEqual(existing, allTags) {
+			return nil
+		}
+
+		// the Tags for a Resource which can't be updated in-place can only be changed by replacing it - so rather than
+		// replacing the Resource when only the `default_tags` block changes, these are applied when it's next replaced
+		if d.Id() != "" && (tagsSchema.ForceNew || !updatable) && !d.HasChange("tags") {
+			log.Printf("[WARN] the Tags for %q can't be updated in-place, so the changes to the `default_tags` block of the Provider will be applied when it's next replaced", d.Id())
+			return nil
+		}
+
+		if err := d.SetNew("tags_all", allTags); err != nil {
+			return fmt.Errorf("setting `tags_all`: %+v", err)
+		}
+
+		return nil
+	}
+
+	// the Tags sent to Azure are all of the Tags, which are merged into `tags` prior to Create/Update - and once the
+	// Resource has been read `tags` is set to only those configured on the Resource, which for a Read are in the state
+	wrap := func(f func(*schema.ResourceData, interface{}) error, expandTags bool) func(*schema.ResourceData, interface{}) error {
+		return func(d *schema.ResourceData, meta interface{}) error {
+			configuration := tagsConfiguration(meta)
+			configured, _ := d.Get("tags").(map[string]interface{})
+			if expandTags {
+				var err error
+				if configured, err = configuration.ExpandResourceTags(d); err != nil {
+					return err
+				}
+			}
+			if err := f(d, meta); err != nil {
+				return err
+			}
+			return configuration.FlattenResourceTags(d, configured)
+		}
+	}
+	wrapContext := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, expandTags bool) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			configuration := tagsConfiguration(meta)
+			configured, _ := d.Get("tags").(map[string]interface{})
+			if expandTags {
+				var err error
+				if configured, err = configuration.ExpandResourceTags(d); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			diags := f(ctx, d, meta)
+			if diags.HasError() {
+				return diags
+			}
+			if err := configuration.FlattenResourceTags(d, configured); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+			return diags
+		}
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if resource.Create != nil { //nolint:staticcheck
+		resource.Create = wrap(resource.Create, true) //nolint:staticcheck
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if resource.Read != nil { //nolint:staticcheck
+		resource.Read = wrap(resource.Read, false) //nolint:staticcheck
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if resource.Update != nil { //nolint:staticcheck
+		resource.Update = wrap(resource.Update, true) //nolint:staticcheck
+	}
+	if resource.CreateContext != nil {
+		resource.CreateContext = wrapContext(resource.CreateContext, true)
+	}
+	if resource.ReadContext != nil {
+		resource.ReadContext = wrapContext(resource.ReadContext, false)
+	}
+	if resource.UpdateContext != nil {
+		resource.UpdateContext = wrapContext(resource.UpdateContext, true)
+	}
+	if resource.CreateWithoutTimeout != nil {
+		resource.CreateWithoutTimeout = wrapContext(resource.CreateWithoutTimeout, true)
+	}
+	if resource.ReadWithoutTimeout != nil {
+		resource.ReadWithoutTimeout = wrapContext(resource.ReadWithoutTimeout, false)
+	}
+	if resource.UpdateWithoutTimeout != nil {
+		resource.UpdateWithoutTimeout = wrapContext(resource.UpdateWithoutTimeout, true)
+	}
+
+	return resource
+}
+
+// withProviderIgnoredTags applies the `ignore_tags` block of the Provider to a Data Source with a top-level `tags`
+// field, so that Tags managed outside of Terraform are omitted when reading the Data Source in the same manner as
+// when reading a Resource.
+func withProviderIgnoredTags(dataSource *schema.Resource) *schema.Resource {
+	tagsSchema, ok := dataSource.Schema["tags"]
+	if !ok || tagsSchema.Type != schema.TypeMap || !tagsSchema.Computed || tagsSchema.Optional || tagsSchema.Required {
+		return dataSource
+	}
+	if elem, ok := tagsSchema.Elem.(*schema.Schema); !ok || elem.Type != schema.TypeString {
+		return dataSource
+	}
+
+	flatten := func(d *schema.ResourceData, meta interface{}) error {
+		return tagsConfiguration(meta).FlattenDataSourceTags(d)
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if read := dataSource.Read; read != nil { //nolint:staticcheck
+		dataSource.Read = func(d *schema.ResourceData, meta interface{}) error { //nolint:staticcheck
+			if err := read(d, meta); err != nil {
+				return err
+			}
+			return flatten(d, meta)
+		}
+	}
+
+	wrapContext := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := f(ctx, d, meta)
+			if diags.HasError() {
+				return diags
+			}
+			if err := flatten(d, meta); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+			return diags
+		}
+	}
+	if dataSource.ReadContext != nil {
+		dataSource.ReadContext = wrapContext(dataSource.ReadContext)
+	}
+	if dataSource.ReadWithoutTimeout != nil {
+		dataSource.ReadWithoutTimeout = wrapContext(dataSource.ReadWithoutTimeout)
+	}
+
+	return dataSource
+}
+
+// tagsConfiguration returns the `default_tags` and `ignore_tags` configured for this instance of the Provider
+func tagsConfiguration(meta interface{}) tags.Configuration {
+	if client, ok := meta.(*clients.Client); ok {
+		return client.Tags
+	}
+	return tags.Configuration{}
+}
@@ -0,0 +1,368 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestExpandDefaultTags(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Expected map[string]string
+	}{
+		{
+			Name:     "Empty Block",
+			Input:    []interface{}{},
+			Expected: map[string]string{},
+		},
+		{
+			Name: "No Tags",
+			Input: []interface{}{
+				map[string]interface{}{
+					"tags": map[string]interface{}{},
+				},
+			},
+			Expected: map[string]string{},
+		},
+		{
+			Name: "Tags",
+			Input: []interface{}{
+				map[string]interface{}{
+					"tags": map[string]interface{}{
+						"environment": "production",
+						"cost-centre": 1234,
+					},
+				},
+			},
+			Expected: map[string]string{
+				"environment": "production",
+				"cost-centre": "1234",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := expandDefaultTags(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestExpandIgnoreTags(t *testing.T) {
+	testData := []struct {
+		Name                string
+		Input               []interface{}
+		ExpectedKeys        []string
+		ExpectedKeyPrefixes []string
+	}{
+		{
+			Name:                "Empty Block",
+			Input:               []interface{}{},
+			ExpectedKeys:        []string{},
+			ExpectedKeyPrefixes: []string{},
+		},
+		{
+			Name: "Keys and Key Prefixes",
+			Input: []interface{}{
+				map[string]interface{}{
+					"keys":         pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"CreatedBy"}),
+					"key_prefixes": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"hidden-link:"}),
+				},
+			},
+			ExpectedKeys:        []string{"CreatedBy"},
+			ExpectedKeyPrefixes: []string{"hidden-link:"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		keys, keyPrefixes := expandIgnoreTags(v.Input)
+		if !reflect.DeepEqual(keys, v.ExpectedKeys) {
+			t.Fatalf("Expected keys %+v but got %+v", v.ExpectedKeys, keys)
+		}
+		if !reflect.DeepEqual(keyPrefixes, v.ExpectedKeyPrefixes) {
+			t.Fatalf("Expected key prefixes %+v but got %+v", v.ExpectedKeyPrefixes, keyPrefixes)
+		}
+	}
+}
+
+func TestWithProviderTags_UnknownTags(t *testing.T) {
+	var sent map[string]interface{}
+	resource := withProviderTags(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			sent = d.Get("tags").(map[string]interface{})
+			d.SetId("example")
+			return nil
+		},
+	})
+
+	meta := &clients.Client{
+		Tags: tags.Configuration{
+			DefaultTags: map[string]string{
+				"environment": "production",
+			},
+		},
+	}
+
+	// when `tags` is unknown at plan time `tags_all` is unknown too, so is unset when the Resource is created
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"tags": map[string]interface{}{
+			"name": "example",
+		},
+	})
+	if diags := resource.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"environment": "production",
+		"name":        "example",
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Fatalf("expected the Tags sent to be %+v but got %+v", expected, sent)
+	}
+	if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags_all` to be %+v but got %+v", expected, actual)
+	}
+
+	expected = map[string]interface{}{
+		"name": "example",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expected, actual)
+	}
+}
+
+func TestWithProviderTags_Read(t *testing.T) {
+	assigned := map[string]interface{}{
+		"name":        "example",
+		"environment": "production",
+		"team":        "networking",
+		"CreatedBy":   "Azure Policy",
+	}
+	resource := withProviderTags(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(d.Set("tags", assigned))
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+	})
+
+	meta := &clients.Client{
+		Tags: tags.Configuration{
+			DefaultTags: map[string]string{
+				"environment": "production",
+				"team":        "platform",
+			},
+			IgnoredTags: tags.NewIgnoredTags([]string{"createdby"}, nil),
+		},
+	}
+
+	state := &terraform.InstanceState{
+		ID: "example",
+		Attributes: map[string]string{
+			"id":        "example",
+			"tags.%":    "1",
+			"tags.name": "example",
+		},
+	}
+	d := resource.Data(state)
+	if diags := resource.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+
+	// the Default Tag `environment` is omitted from `tags`, whilst `team` has been changed outside of Terraform
+	expected := map[string]interface{}{
+		"name": "example",
+		"team": "networking",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expected, actual)
+	}
+	if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, assigned) {
+		t.Fatalf("expected `tags_all` to be %+v but got %+v", assigned, actual)
+	}
+}
+
+func TestWithProviderTags_DefaultTagsChanged(t *testing.T) {
+	testData := []struct {
+		Name            string
+		Updatable       bool
+		ForceNew        bool
+		Tags            map[string]interface{}
+		ExpectTagsAll   bool
+		ExpectRecreated bool
+	}{
+		{
+			Name:          "Updatable",
+			Updatable:     true,
+			ExpectTagsAll: true,
+		},
+		{
+			Name:      "Tags are ForceNew",
+			Updatable: true,
+			ForceNew:  true,
+		},
+		{
+			Name:     "Not Updatable",
+			ForceNew: true,
+		},
+		{
+			Name:     "Not Updatable with changed Tags",
+			ForceNew: true,
+			Tags: map[string]interface{}{
+				"name": "updated",
+			},
+			ExpectTagsAll:   true,
+			ExpectRecreated: true,
+		},
+	}
+
+	meta := &clients.Client{
+		Tags: tags.Configuration{
+			DefaultTags: map[string]string{
+				"environment": "production",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		resource := &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					ForceNew: v.ForceNew,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				return nil
+			},
+		}
+		if v.Updatable {
+			resource.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				return nil
+			}
+		}
+		resource = withProviderTags(resource)
+
+		configured := v.Tags
+		if configured == nil {
+			configured = map[string]interface{}{
+				"name": "example",
+			}
+		}
+
+		// the `default_tags` block has changed since the Resource was created, so `tags_all` differs
+		state := &terraform.InstanceState{
+			ID: "example",
+			Attributes: map[string]string{
+				"id":            "example",
+				"tags.%":        "1",
+				"tags.name":     "example",
+				"tags_all.%":    "1",
+				"tags_all.name": "example",
+			},
+		}
+		diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"tags": configured,
+		}), meta)
+		if err != nil {
+			t.Fatalf("diffing: %+v", err)
+		}
+
+		hasTagsAll := false
+		if diff != nil {
+			_, hasTagsAll = diff.GetAttribute("tags_all.environment")
+		}
+		if hasTagsAll != v.ExpectTagsAll {
+			t.Fatalf("expected a diff for `tags_all` to be %t but got %t", v.ExpectTagsAll, hasTagsAll)
+		}
+		if recreated := diff.RequiresNew(); recreated != v.ExpectRecreated {
+			t.Fatalf("expected the Resource to be recreated to be %t but got %t", v.ExpectRecreated, recreated)
+		}
+	}
+}
+
+func TestWithProviderIgnoredTags(t *testing.T) {
+	dataSource := withProviderIgnoredTags(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("example")
+			if err := d.Set("tags", map[string]interface{}{
+				"name":           "example",
+				"hidden-managed": "true",
+				"Owner":          "someone",
+			}); err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	})
+
+	meta := &clients.Client{
+		Tags: tags.Configuration{
+			IgnoredTags: tags.NewIgnoredTags([]string{"owner"}, []string{"hidden-"}),
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{})
+	if diags := dataSource.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"name": "example",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expected, actual)
+	}
+}
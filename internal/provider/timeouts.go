@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func schemaDefaultTimeouts() *pluginsdk.Schema {
+	operations := func() map[string]*pluginsdk.Schema {
+		out := make(map[string]*pluginsdk.Schema)
+		for _, operation := range []string{pluginsdk.TimeoutCreate, pluginsdk.TimeoutRead, pluginsdk.TimeoutUpdate, pluginsdk.TimeoutDelete} {
+			out[operation] = &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTimeoutDuration,
+			}
+		}
+		return out
+	}
+
+	// the timeouts for specific resource types are specified using a `resource` block (with a `type`) rather than
+	// a map of objects (e.g. `azurerm_kubernetes_cluster = { create = "3h" }`), since the Plugin SDK can't express a
+	// map whose values are objects - and the schema of the Framework Provider must match this schema, since these
+	// are muxed together
+	resourceSchema := operations()
+	resourceSchema["type"] = &pluginsdk.Schema{
+		Type:         pluginsdk.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+	}
+
+	blockSchema := operations()
+	blockSchema["resource"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		Elem: &pluginsdk.Resource{
+			Schema: resourceSchema,
+		},
+	}
+
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The default timeouts used by all resources, which can be overridden for specific resource types and within a resource's `timeouts` block.",
+		Elem: &pluginsdk.Resource{
+			Schema: blockSchema,
+		},
+	}
+}
+
+func validateTimeoutDuration(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if duration, err := time.ParseDuration(v); err != nil || duration <= 0 {
+		errors = append(errors, fmt.Errorf("expected %q to be a positive duration (e.g. `30m` or `2h`) but got %q", k, v))
+	}
+
+	return
+}
+
+func expandDefaultTimeouts(input []interface{}) (*timeouts.Defaults, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	expandOperations := func(val map[string]interface{}) (timeouts.Operations, error) {
+		out := timeouts.Operations{}
+		for operation, field := range map[string]**time.Duration{
+			pluginsdk.TimeoutCreate: &out.Create,
+			pluginsdk.TimeoutRead:   &out.Read,
+			pluginsdk.TimeoutUpdate: &out.Update,
+			pluginsdk.TimeoutDelete: &out.Delete,
+		} {
+			v, ok := val[operation].(string)
+			if !ok || v == "" {
+				continue
+			}
+
+			duration, err := time.ParseDuration(v)
+			if err != nil {
+				return out, fmt.Errorf("parsing `%s`: %+v", operation, err)
+			}
+			*field = &duration
+		}
+		return out, nil
+	}
+
+	val := input[0].(map[string]interface{})
+	operations, err := expandOperations(val)
+	if err != nil {
+		return nil, fmt.Errorf("expanding `default_timeouts`: %+v", err)
+	}
+
+	output := timeouts.Defaults{
+		Operations:    operations,
+		ResourceTypes: make(map[string]timeouts.Operations),
+	}
+
+	for _, raw := range val["resource"].([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		v := raw.(map[string]interface{})
+		resourceType := v["type"].(string)
+
+		if _, ok := output.ResourceTypes[resourceType]; ok {
+			return nil, fmt.Errorf("expanding `default_timeouts`: the resource type %q is specified more than once", resourceType)
+		}
+
+		operations, err := expandOperations(v)
+		if err != nil {
+			return nil, fmt.Errorf("expanding `default_timeouts` for %q: %+v", resourceType, err)
+		}
+		output.ResourceTypes[resourceType] = operations
+	}
+
+	return &output, nil
+}
+
+// defaultTimeoutsFromMeta returns the timeouts specified in the `default_timeouts` block for this instance of the Provider
+func defaultTimeoutsFromMeta(meta interface{}) *timeouts.Defaults {
+	if client, ok := meta.(*clients.Client); ok {
+		return client.DefaultTimeouts
+	}
+	return nil
+}
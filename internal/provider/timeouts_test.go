@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandDefaultTimeouts(t *testing.T) {
+	if v, err := expandDefaultTimeouts([]interface{}{}); err != nil || v != nil {
+		t.Fatalf("expected no default timeouts for an empty block but got %+v / %+v", v, err)
+	}
+
+	defaults, err := expandDefaultTimeouts([]interface{}{
+		map[string]interface{}{
+			"create": "1h",
+			"read":   "",
+			"update": "",
+			"delete": "45m",
+			"resource": []interface{}{
+				map[string]interface{}{
+					"type":   "azurerm_kubernetes_cluster",
+					"create": "3h",
+					"read":   "",
+					"update": "2h",
+					"delete": "",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expanding: %+v", err)
+	}
+
+	if defaults.Create == nil || *defaults.Create != time.Hour || defaults.Read != nil || defaults.Delete == nil || *defaults.Delete != 45*time.Minute {
+		t.Fatalf("unexpected default timeouts: %+v", defaults.Operations)
+	}
+	cluster, ok := defaults.ResourceTypes["azurerm_kubernetes_cluster"]
+	if !ok || cluster.Create == nil || *cluster.Create != 3*time.Hour || cluster.Update == nil || *cluster.Update != 2*time.Hour || cluster.Delete != nil {
+		t.Fatalf("unexpected timeouts for `azurerm_kubernetes_cluster`: %+v", cluster)
+	}
+
+	_, err = expandDefaultTimeouts([]interface{}{
+		map[string]interface{}{
+			"resource": []interface{}{
+				map[string]interface{}{"type": "azurerm_resource_group"},
+				map[string]interface{}{"type": "azurerm_resource_group"},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("expected an error when a resource type is specified more than once")
+	}
+}
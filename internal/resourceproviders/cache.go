@@ -6,6 +6,7 @@ package resourceproviders
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 	"sync"
 
@@ -22,13 +23,13 @@ var cacheLock = &sync.Mutex{}
 
 // CacheSupportedProviders attempts to retrieve the supported Resource Providers from the Resource Manager API
 // and caches them, for used in enhanced validation
-func CacheSupportedProviders(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) error {
+func CacheSupportedProviders(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, diskCache *DiskCache) error {
 	// already populated
 	if cachedResourceProviders != nil {
 		return nil
 	}
 
-	if err := populateCache(ctx, client, subscriptionId); err != nil {
+	if err := populateCache(ctx, client, subscriptionId, diskCache); err != nil {
 		return fmt.Errorf("populating cache: %+v", err)
 	}
 
@@ -43,32 +44,93 @@ func ClearCache() {
 	cacheLock.Unlock()
 }
 
-func populateCache(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) error {
+// populateCache populates the Resource Provider cache, from the DiskCache when this is configured (and not stale)
+func populateCache(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, diskCache *DiskCache) error {
 	cacheLock.Lock()
 	defer cacheLock.Unlock()
 
+	if diskCache != nil {
+		if cached := diskCache.load(subscriptionId.SubscriptionId); cached != nil {
+			log.Printf("[DEBUG] Populating the Resource Provider cache from %q", diskCache.path(subscriptionId.SubscriptionId))
+			setCache(cached)
+			return nil
+		}
+	}
+
 	providers, err := client.ListComplete(ctx, subscriptionId, providers.DefaultListOperationOptions())
 	if err != nil {
 		return fmt.Errorf("listing Resource Providers: %+v", err)
 	}
 
-	providerNames := make([]string, 0)
-	registeredResourceProviders = make(map[string]struct{})
-	unregisteredResourceProviders = make(map[string]struct{})
+	items := make([]diskCacheProvider, 0)
 	for _, provider := range providers.Items {
 		if provider.Namespace == nil {
 			continue
 		}
 
-		providerNames = append(providerNames, *provider.Namespace)
-		registered := provider.RegistrationState != nil && strings.EqualFold(*provider.RegistrationState, "registered")
-		if registered {
-			registeredResourceProviders[*provider.Namespace] = struct{}{}
+		items = append(items, diskCacheProvider{
+			Namespace:  *provider.Namespace,
+			Registered: provider.RegistrationState != nil && strings.EqualFold(*provider.RegistrationState, "registered"),
+		})
+	}
+	setCache(items)
+
+	if diskCache != nil {
+		if err := diskCache.save(subscriptionId.SubscriptionId, items); err != nil {
+			log.Printf("[WARN] Unable to write the Resource Provider cache %q: %+v", diskCache.path(subscriptionId.SubscriptionId), err)
+		}
+	}
+
+	return nil
+}
+
+func setCache(items []diskCacheProvider) {
+	providerNames := make([]string, 0)
+	registeredResourceProviders = make(map[string]struct{})
+	unregisteredResourceProviders = make(map[string]struct{})
+	for _, provider := range items {
+		providerNames = append(providerNames, provider.Namespace)
+		if provider.Registered {
+			registeredResourceProviders[provider.Namespace] = struct{}{}
 		} else {
-			unregisteredResourceProviders[*provider.Namespace] = struct{}{}
+			unregisteredResourceProviders[provider.Namespace] = struct{}{}
 		}
 	}
 
 	cachedResourceProviders = &providerNames
-	return nil
+}
+
+// updateCacheAfterRegistration marks the Resource Providers as registered once they've been registered, or
+// invalidates the on-disk cache when registration fails since the registration state is no longer known
+func updateCacheAfterRegistration(subscriptionId commonids.SubscriptionId, diskCache *DiskCache, providersToRegister []string, registrationErr error) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if registrationErr != nil {
+		if diskCache != nil {
+			diskCache.invalidate(subscriptionId.SubscriptionId)
+		}
+		return
+	}
+
+	items := make([]diskCacheProvider, 0)
+	for _, namespace := range *cachedResourceProviders {
+		_, registered := registeredResourceProviders[namespace]
+		for _, p := range providersToRegister {
+			if p == namespace {
+				registered = true
+			}
+		}
+		items = append(items, diskCacheProvider{
+			Namespace:  namespace,
+			Registered: registered,
+		})
+	}
+	setCache(items)
+
+	if diskCache != nil {
+		if err := diskCache.save(subscriptionId.SubscriptionId, items); err != nil {
+			log.Printf("[WARN] Unable to write the Resource Provider cache %q: %+v", diskCache.path(subscriptionId.SubscriptionId), err)
+		}
+	}
 }
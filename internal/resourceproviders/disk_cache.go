@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvCacheTTL enables caching the Resource Providers available within the Subscription on disk, for the
+	// specified duration (for example `1h`) - caching on disk is disabled when unset
+	EnvCacheTTL = "ARM_RESOURCE_PROVIDER_CACHE_TTL"
+
+	// EnvCacheDirectory is the directory which the Resource Providers are cached within - defaults to the `azurerm`
+	// directory within `TF_DATA_DIR` (or `.terraform` when unset)
+	EnvCacheDirectory = "ARM_RESOURCE_PROVIDER_CACHE_DIR"
+
+	// missingSubscriptionRegistrationCode is the error code returned from Resource Manager when a Resource Provider
+	// isn't registered within the Subscription
+	missingSubscriptionRegistrationCode = "MissingSubscriptionRegistration"
+)
+
+// DiskCache caches the Resource Providers available within a Subscription on disk, so that these don't need
+// to be retrieved from Resource Manager on every run. Each instance of the Provider (e.g. an alias) holds its own
+// DiskCache, which is keyed by the Tenant and Subscription that instance is configured for.
+type DiskCache struct {
+	// Directory is the directory containing the cache files
+	Directory string
+
+	// TenantId is the Tenant ID which the cache files are keyed by, in addition to the Subscription ID
+	TenantId string
+
+	// TTL is the duration after which a cache file is considered stale
+	TTL time.Duration
+}
+
+type diskCacheFile struct {
+	CreatedAt time.Time           `json:"created_at"`
+	Providers []diskCacheProvider `json:"providers"`
+}
+
+type diskCacheProvider struct {
+	Namespace  string `json:"namespace"`
+	Registered bool   `json:"registered"`
+}
+
+// DiskCacheFromEnvironment returns the DiskCache configured using the `ARM_RESOURCE_PROVIDER_CACHE_TTL` and
+// `ARM_RESOURCE_PROVIDER_CACHE_DIR` Environment Variables, or nil when caching on disk isn't enabled.
+func DiskCacheFromEnvironment(tenantId string) (*DiskCache, error) {
+	v := os.Getenv(EnvCacheTTL)
+	if v == "" {
+		return nil, nil
+	}
+
+	ttl, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the Environment Variable `%s`: %+v", EnvCacheTTL, err)
+	}
+	if ttl <= 0 {
+		return nil, nil
+	}
+
+	directory := os.Getenv(EnvCacheDirectory)
+	if directory == "" {
+		dataDirectory := os.Getenv("TF_DATA_DIR")
+		if dataDirectory == "" {
+			dataDirectory = ".terraform"
+		}
+		directory = filepath.Join(dataDirectory, "azurerm")
+	}
+
+	return &DiskCache{
+		Directory: directory,
+		TenantId:  tenantId,
+		TTL:       ttl,
+	}, nil
+}
+
+func (c DiskCache) path(subscriptionId string) string {
+	fileName := fmt.Sprintf("resource-providers-%s-%s.json", strings.ToLower(c.TenantId), strings.ToLower(subscriptionId))
+	return filepath.Join(c.Directory, fileName)
+}
+
+// load returns the cached Resource Providers for the Subscription, or nil if these aren't cached or are stale
+func (c DiskCache) load(subscriptionId string) []diskCacheProvider {
+	path := c.path(subscriptionId)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[DEBUG] Unable to read the Resource Provider cache %q: %+v", path, err)
+		}
+		return nil
+	}
+
+	var file diskCacheFile
+	if err := json.Unmarshal(contents, &file); err != nil {
+		log.Printf("[DEBUG] Ignoring the Resource Provider cache %q since it couldn't be parsed: %+v", path, err)
+		return nil
+	}
+	if time.Since(file.CreatedAt) > c.TTL {
+		log.Printf("[DEBUG] Ignoring the Resource Provider cache %q since it was created at %s", path, file.CreatedAt.Format(time.RFC3339))
+		return nil
+	}
+
+	return file.Providers
+}
+
+// save writes the Resource Providers to the cache for the Subscription, replacing the cache file atomically since
+// this can be shared between multiple processes
+func (c DiskCache) save(subscriptionId string, providers []diskCacheProvider) error {
+	contents, err := json.Marshal(diskCacheFile{
+		CreatedAt: time.Now().UTC(),
+		Providers: providers,
+	})
+	if err != nil {
+		return fmt.Errorf("serializing: %+v", err)
+	}
+
+	if err := os.MkdirAll(c.Directory, 0o700); err != nil {
+		return fmt.Errorf("creating directory %q: %+v", c.Directory, err)
+	}
+
+	file, err := os.CreateTemp(c.Directory, ".resource-providers-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %+v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		return fmt.Errorf("writing %q: %+v", file.Name(), err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing %q: %+v", file.Name(), err)
+	}
+
+	path := c.path(subscriptionId)
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("renaming %q to %q: %+v", file.Name(), path, err)
+	}
+
+	return nil
+}
+
+// invalidate removes the cache file for the Subscription, so that the Resource Providers are retrieved from
+// Resource Manager on the next run
+func (c DiskCache) invalidate(subscriptionId string) {
+	path := c.path(subscriptionId)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("[WARN] Unable to remove the Resource Provider cache %q: %+v", path, err)
+		return
+	}
+	log.Printf("[DEBUG] Invalidated the Resource Provider cache %q", path)
+}
+
+// InvalidateResponseMiddleware invalidates the on-disk Resource Provider cache for the Subscription when Resource
+// Manager returns a `MissingSubscriptionRegistration` error, since this means the cache is out of date.
+func (c *DiskCache) InvalidateResponseMiddleware(req *http.Request, resp *http.Response) (*http.Response, error) {
+	if c == nil || resp == nil || resp.StatusCode != http.StatusConflict || req == nil || req.URL == nil {
+		return resp, nil
+	}
+
+	// /subscriptions/{subscriptionId}/...
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return resp, nil
+	}
+
+	if resp.Body == nil {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, nil
+	}
+
+	var errorResponse struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &errorResponse) == nil && strings.EqualFold(errorResponse.Error.Code, missingSubscriptionRegistrationCode) {
+		c.invalidate(segments[1])
+	}
+
+	return resp, nil
+}
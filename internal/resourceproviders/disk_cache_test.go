@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+)
+
+const (
+	testSubscriptionId = "11111111-1111-1111-1111-111111111111"
+	testTenantId       = "22222222-2222-2222-2222-222222222222"
+)
+
+func TestDiskCacheFromEnvironment(t *testing.T) {
+	t.Setenv(EnvCacheTTL, "")
+	if cache, err := DiskCacheFromEnvironment(testTenantId); err != nil || cache != nil {
+		t.Fatalf("expected the disk cache to be disabled by default but got %+v / %+v", cache, err)
+	}
+
+	t.Setenv(EnvCacheTTL, "an hour")
+	if _, err := DiskCacheFromEnvironment(testTenantId); err == nil {
+		t.Fatalf("expected an error for an invalid TTL")
+	}
+
+	t.Setenv(EnvCacheTTL, "1h")
+	t.Setenv(EnvCacheDirectory, "")
+	t.Setenv("TF_DATA_DIR", filepath.Join("example", "data"))
+	cache, err := DiskCacheFromEnvironment(testTenantId)
+	if err != nil {
+		t.Fatalf("building the disk cache: %+v", err)
+	}
+	if expected := filepath.Join("example", "data", "azurerm"); cache.Directory != expected || cache.TTL != time.Hour {
+		t.Fatalf("expected the disk cache to use %q for 1h but got %q for %s", expected, cache.Directory, cache.TTL)
+	}
+
+	t.Setenv(EnvCacheDirectory, "shared")
+	if cache, _ = DiskCacheFromEnvironment(testTenantId); cache.Directory != "shared" {
+		t.Fatalf("expected the disk cache to use %q but got %q", "shared", cache.Directory)
+	}
+}
+
+func TestDiskCache_SaveAndLoad(t *testing.T) {
+	cache := DiskCache{
+		Directory: filepath.Join(t.TempDir(), "azurerm"),
+		TenantId:  testTenantId,
+		TTL:       time.Hour,
+	}
+
+	if v := cache.load(testSubscriptionId); v != nil {
+		t.Fatalf("expected nothing to be cached but got %+v", v)
+	}
+
+	providers := []diskCacheProvider{
+		{Namespace: "Microsoft.Compute", Registered: true},
+		{Namespace: "Microsoft.Storage", Registered: false},
+	}
+	if err := cache.save(testSubscriptionId, providers); err != nil {
+		t.Fatalf("saving: %+v", err)
+	}
+
+	if v := cache.load(testSubscriptionId); len(v) != 2 || !v[0].Registered || v[1].Registered {
+		t.Fatalf("expected the cached providers to be loaded but got %+v", v)
+	}
+
+	// the cache is keyed by both Tenant and Subscription
+	other := cache
+	other.TenantId = "33333333-3333-3333-3333-333333333333"
+	if v := other.load(testSubscriptionId); v != nil {
+		t.Fatalf("expected nothing to be cached for another Tenant but got %+v", v)
+	}
+
+	// stale entries are ignored
+	contents, _ := json.Marshal(diskCacheFile{
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+		Providers: providers,
+	})
+	if err := os.WriteFile(cache.path(testSubscriptionId), contents, 0o600); err != nil {
+		t.Fatalf("writing: %+v", err)
+	}
+	if v := cache.load(testSubscriptionId); v != nil {
+		t.Fatalf("expected a stale cache to be ignored but got %+v", v)
+	}
+}
+
+func TestUpdateCacheAfterRegistration(t *testing.T) {
+	cache := &DiskCache{
+		Directory: t.TempDir(),
+		TenantId:  testTenantId,
+		TTL:       time.Hour,
+	}
+	defer ClearCache()
+
+	subscriptionId := commonids.NewSubscriptionID(testSubscriptionId)
+	setCache([]diskCacheProvider{
+		{Namespace: "Microsoft.Compute", Registered: true},
+		{Namespace: "Microsoft.Storage", Registered: false},
+	})
+
+	updateCacheAfterRegistration(subscriptionId, cache, []string{"Microsoft.Storage"}, nil)
+	if _, ok := registeredResourceProviders["Microsoft.Storage"]; !ok {
+		t.Fatalf("expected `Microsoft.Storage` to be marked as registered")
+	}
+	if v := cache.load(testSubscriptionId); len(v) != 2 || !v[1].Registered {
+		t.Fatalf("expected the registration to be written to the disk cache but got %+v", v)
+	}
+
+	updateCacheAfterRegistration(subscriptionId, cache, []string{"Microsoft.Web"}, fmt.Errorf("registering"))
+	if _, err := os.Stat(cache.path(testSubscriptionId)); !os.IsNotExist(err) {
+		t.Fatalf("expected the disk cache to be invalidated when registration fails")
+	}
+}
+
+func TestDiskCache_InvalidateResponseMiddleware(t *testing.T) {
+	directory := t.TempDir()
+	cache := &DiskCache{
+		Directory: directory,
+		TenantId:  testTenantId,
+		TTL:       time.Hour,
+	}
+
+	// another instance of the Provider (e.g. an alias) configured for another Tenant holds its own cache
+	otherTenant := &DiskCache{
+		Directory: directory,
+		TenantId:  "11111111-1111-1111-1111-111111111111",
+		TTL:       time.Hour,
+	}
+	if err := otherTenant.save(testSubscriptionId, []diskCacheProvider{{Namespace: "Microsoft.Web", Registered: true}}); err != nil {
+		t.Fatalf("saving: %+v", err)
+	}
+
+	uri := fmt.Sprintf("https://management.azure.com/subscriptions/%s/resourceGroups/example/providers/Microsoft.Web/sites/example?api-version=2023-01-01", testSubscriptionId)
+	testCases := []struct {
+		statusCode  int
+		body        string
+		invalidated bool
+	}{
+		{
+			statusCode: http.StatusOK,
+			body:       `{}`,
+		},
+		{
+			statusCode: http.StatusConflict,
+			body:       `{"error":{"code":"Conflict","message":"The resource is being updated"}}`,
+		},
+		{
+			statusCode:  http.StatusConflict,
+			body:        `{"error":{"code":"MissingSubscriptionRegistration","message":"The subscription is not registered to use namespace 'Microsoft.Web'."}}`,
+			invalidated: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		if err := cache.save(testSubscriptionId, []diskCacheProvider{{Namespace: "Microsoft.Web", Registered: true}}); err != nil {
+			t.Fatalf("saving: %+v", err)
+		}
+
+		req, _ := http.NewRequest(http.MethodPut, uri, nil)
+		resp := &http.Response{
+			StatusCode: testCase.statusCode,
+			Body:       io.NopCloser(strings.NewReader(testCase.body)),
+		}
+		resp, err := cache.InvalidateResponseMiddleware(req, resp)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		// the body must remain readable
+		if body, _ := io.ReadAll(resp.Body); string(body) != testCase.body {
+			t.Fatalf("expected the body to be %q but got %q", testCase.body, string(body))
+		}
+
+		invalidated := cache.load(testSubscriptionId) == nil
+		if invalidated != testCase.invalidated {
+			t.Fatalf("expected invalidated to be %t for %d %s but got %t", testCase.invalidated, testCase.statusCode, testCase.body, invalidated)
+		}
+	}
+
+	if otherTenant.load(testSubscriptionId) == nil {
+		t.Fatalf("expected the cache for another Tenant not to be invalidated")
+	}
+
+	// a nil DiskCache means caching on disk is disabled
+	var disabled *DiskCache
+	resp := &http.Response{StatusCode: http.StatusConflict}
+	if v, err := disabled.InvalidateResponseMiddleware(nil, resp); err != nil || v != resp {
+		t.Fatalf("expected the response to be returned as-is when caching on disk is disabled but got %+v / %+v", v, err)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
+)
+
+const lazyRegistrationErrorFmt = `%s.
+
+The Resource Provider(s) %s are required by the resource %q and are not registered
+within the Subscription %q.
+
+Since "resource_provider_registrations" is set to "lazy", Terraform attempts to register the
+Resource Providers required by a resource when it is first used. If you don't have
+permission to register these Resource Providers, they can instead be registered by someone
+with the "<namespace>/register/action" permission, for example using the Azure CLI:
+
+%s
+
+Encountered the following errors:
+
+%v`
+
+// LazyRegistration registers the Resource Providers required by a resource when it's first used, rather than
+// registering a set of Resource Providers up-front when the Provider is configured.
+type LazyRegistration struct {
+	client         *providers.ProvidersClient
+	subscriptionId commonids.SubscriptionId
+	diskCache      *DiskCache
+
+	// lock ensures that each Resource Provider is only registered once, even when resources are created concurrently
+	lock sync.Mutex
+}
+
+func NewLazyRegistration(client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, diskCache *DiskCache) *LazyRegistration {
+	return &LazyRegistration{
+		client:         client,
+		subscriptionId: subscriptionId,
+		diskCache:      diskCache,
+	}
+}
+
+// EnsureRegistered registers any of the specified Resource Providers which aren't registered within the Subscription,
+// returning an error naming the Resource Providers which couldn't be registered for the specified resource type.
+func (l *LazyRegistration) EnsureRegistered(ctx context.Context, resourceType string, namespaces []string) error {
+	if len(namespaces) == 0 {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	cacheLock.Lock()
+	populated := cachedResourceProviders != nil && registeredResourceProviders != nil && unregisteredResourceProviders != nil
+	cacheLock.Unlock()
+	if !populated {
+		if err := populateCache(ctx, l.client, l.subscriptionId, l.diskCache); err != nil {
+			return fmt.Errorf("populating Resource Provider cache: %+v", err)
+		}
+	}
+
+	required := make(ResourceProviders)
+	required.Add(namespaces...)
+
+	cacheLock.Lock()
+	providersToRegister, err := DetermineWhichRequiredResourceProvidersRequireRegistration(required)
+	cacheLock.Unlock()
+	if err != nil {
+		return fmt.Errorf("determining which Resource Providers require registration: %+v", err)
+	}
+	if len(*providersToRegister) == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Registering the Resource Providers %s required by %q", strings.Join(*providersToRegister, ", "), resourceType)
+	err = registerForSubscription(ctx, l.client, l.subscriptionId, *providersToRegister)
+	updateCacheAfterRegistration(l.subscriptionId, l.diskCache, *providersToRegister, err)
+	if err != nil {
+		return l.userError(resourceType, *providersToRegister, err)
+	}
+
+	return nil
+}
+
+func (l *LazyRegistration) userError(resourceType string, namespaces []string, err error) error {
+	summary := "Encountered an error whilst registering the Resource Providers required by this resource"
+	if errors.Is(err, ErrNoAuthorization) {
+		summary = "Terraform does not have the necessary permissions to register the Resource Providers required by this resource"
+	}
+
+	quoted := make([]string, 0, len(namespaces))
+	commands := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		quoted = append(quoted, fmt.Sprintf("%q", namespace))
+		commands = append(commands, fmt.Sprintf("  az provider register --namespace %s --subscription %s", namespace, l.subscriptionId.SubscriptionId))
+	}
+
+	return fmt.Errorf(lazyRegistrationErrorFmt, summary, strings.Join(quoted, ", "), resourceType, l.subscriptionId.SubscriptionId, strings.Join(commands, "\n"), err)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+)
+
+func TestLazyRegistration_AlreadyRegistered(t *testing.T) {
+	defer ClearCache()
+	setCache([]diskCacheProvider{
+		{Namespace: "Microsoft.Compute", Registered: true},
+		{Namespace: "Microsoft.Network", Registered: true},
+	})
+
+	// the client isn't used when all of the Resource Providers are registered
+	registration := NewLazyRegistration(nil, commonids.NewSubscriptionID(testSubscriptionId), nil)
+	if err := registration.EnsureRegistered(context.TODO(), "azurerm_linux_virtual_machine", []string{"Microsoft.Compute", "Microsoft.Network"}); err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+
+	// Resource Providers which aren't available in this environment are skipped
+	if err := registration.EnsureRegistered(context.TODO(), "azurerm_example", []string{"Microsoft.Unavailable"}); err != nil {
+		t.Fatalf("expected no error for an unavailable Resource Provider but got: %+v", err)
+	}
+}
+
+func TestLazyRegistration_UserError(t *testing.T) {
+	registration := NewLazyRegistration(nil, commonids.NewSubscriptionID(testSubscriptionId), nil)
+
+	testCases := []struct {
+		err     error
+		summary string
+	}{
+		{
+			err:     fmt.Errorf("%w: registering resource provider %q", ErrNoAuthorization, "Microsoft.Web"),
+			summary: "Terraform does not have the necessary permissions",
+		},
+		{
+			err:     fmt.Errorf("registering resource provider %q: timed out", "Microsoft.Web"),
+			summary: "Encountered an error whilst registering",
+		},
+	}
+
+	for _, testCase := range testCases {
+		message := registration.userError("azurerm_linux_web_app", []string{"Microsoft.Web"}, testCase.err).Error()
+
+		expected := []string{
+			testCase.summary,
+			`"Microsoft.Web"`,
+			`"azurerm_linux_web_app"`,
+			fmt.Sprintf("az provider register --namespace Microsoft.Web --subscription %s", testSubscriptionId),
+		}
+		for _, v := range expected {
+			if !strings.Contains(message, v) {
+				t.Fatalf("expected the error to contain %q but got:\n\n%s", v, message)
+			}
+		}
+	}
+}
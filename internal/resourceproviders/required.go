@@ -27,6 +27,10 @@ const (
 	ProviderRegistrationsCore     = "core"
 	ProviderRegistrationsExtended = "extended"
 	ProviderRegistrationsAll      = "all"
+
+	// ProviderRegistrationsLazy registers the Resource Providers declared by each Service when a resource (or data
+	// source) within that Service is first used, rather than registering a set of Resource Providers up-front
+	ProviderRegistrationsLazy = "lazy"
 )
 
 func (r ResourceProviders) Add(providers ...string) {
@@ -216,7 +220,7 @@ func GetResourceProvidersSet(input string) (ResourceProviders, error) {
 		return All(), nil
 	case ProviderRegistrationsExtended:
 		return Extended(), nil
-	case ProviderRegistrationsNone:
+	case ProviderRegistrationsNone, ProviderRegistrationsLazy:
 		return empty, nil
 	}
 
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// EphemeralResourceMetadata is intended to be embedded within Ephemeral Resources, providing
+// access to the configured Client once the Provider has been configured.
+type EphemeralResourceMetadata struct {
+	Client *clients.Client
+}
+
+// Defaults configures the Client from the Provider Data passed to the Ephemeral Resource
+func (m *EphemeralResourceMetadata) Defaults(request ephemeral.ConfigureRequest, response *ephemeral.ConfigureResponse) {
+	// the Provider Data is nil when the Ephemeral Resource is validated prior to the Provider being configured
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*clients.Client)
+	if !ok {
+		response.Diagnostics.AddError("Client Provider Data Error", fmt.Sprintf("expected `*clients.Client` but got %T", request.ProviderData))
+		return
+	}
+
+	m.Client = client
+}
+
+// ClientConfigured returns whether the Client has been configured, adding an error to diags when it hasn't been - which
+// happens when the Ephemeral Resource is opened before the Provider has been configured (e.g. when the Provider
+// configuration depends on values which are unknown during the plan)
+func (m *EphemeralResourceMetadata) ClientConfigured(diags *diag.Diagnostics) bool {
+	if m.Client == nil {
+		diags.AddError("Provider Not Configured", "the Provider has not been configured, which is required to open this Ephemeral Resource")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,377 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package frameworkhelpers
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// DecodeModel decodes the Plugin Framework value (for example the Plan or State of a Resource) into the model, which
+// must be a pointer to a struct using `tfschema` struct tags - the same models used by Typed Resources.
+//
+// Null and Unknown values leave the field as it's zero value, as such Optional fields should be pointers so that an
+// omitted value (nil) can be differentiated from a zero value. Fields with the `writeOnly` struct tag are skipped,
+// since these are never present in the Plan or State - see DecodeWriteOnlyModel.
+func DecodeModel(input tftypes.Value, model interface{}) error {
+	return decodeModel(input, model, false)
+}
+
+// DecodeWriteOnlyModel decodes the top-level fields with the `writeOnly` struct tag from the Config into the model,
+// leaving any other fields as-is
+func DecodeWriteOnlyModel(config tftypes.Value, model interface{}) error {
+	return decodeModel(config, model, true)
+}
+
+// EncodeModel encodes the model (a pointer to a struct using `tfschema` struct tags) into a Plugin Framework value
+// of the specified Object type.
+//
+// A nil pointer, map or slice is encoded as a null value - other than a slice of nested blocks, which is encoded as
+// an empty List/Set, since Terraform represents an omitted block that way. Attributes which aren't present in the
+// model (for example `id` or `timeouts`) are taken from source where known, otherwise these are null. Fields with the
+// `writeOnly` struct tag are always null, since Write-Only values are never persisted.
+func EncodeModel(model interface{}, ty tftypes.Object, source tftypes.Value) (tftypes.Value, error) {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return tftypes.Value{}, fmt.Errorf("the model must be a pointer to a struct but got %T", model)
+	}
+
+	return encodeStruct(v.Elem(), ty, source)
+}
+
+type modelField struct {
+	index     int
+	writeOnly bool
+}
+
+// modelFields returns the fields within the struct which have a `tfschema` struct tag, keyed by the attribute name
+func modelFields(input reflect.Type) (map[string]modelField, error) {
+	output := make(map[string]modelField)
+	for i := 0; i < input.NumField(); i++ {
+		field := input.Field(i)
+		tag, ok := field.Tag.Lookup("tfschema")
+		if !ok {
+			continue
+		}
+
+		// NOTE: the attribute name has to be the first item in the struct tag
+		components := strings.Split(tag, ",")
+		name := strings.TrimSpace(components[0])
+		if name == "" {
+			return nil, fmt.Errorf("the `tfschema` struct tag for the field %q was empty", field.Name)
+		}
+
+		item := modelField{
+			index: i,
+		}
+		for _, v := range components[1:] {
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "writeonly":
+				item.writeOnly = true
+			case "removedinnextmajorversion", "addedinnextmajorversion":
+				// the Schema only contains the attributes available in this major version, which is used to
+				// determine which fields are decoded/encoded - so these require no special handling here
+			default:
+				return nil, fmt.Errorf("the struct tag %q for the field %q is not implemented", v, field.Name)
+			}
+		}
+
+		output[name] = item
+	}
+
+	return output, nil
+}
+
+func decodeModel(input tftypes.Value, model interface{}, writeOnly bool) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("the model must be a pointer to a struct but got %T", model)
+	}
+
+	return decodeStruct(input, v.Elem(), &writeOnly)
+}
+
+// decodeStruct decodes the Object into the struct - when writeOnly is specified only the fields which are (or aren't)
+// Write-Only are decoded, which only applies to the top-level fields since Write-Only blocks aren't supported
+func decodeStruct(input tftypes.Value, target reflect.Value, writeOnly *bool) error {
+	if input.IsNull() || !input.IsKnown() {
+		return nil
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := input.As(&attributes); err != nil {
+		return fmt.Errorf("expected an object: %+v", err)
+	}
+
+	fields, err := modelFields(target.Type())
+	if err != nil {
+		return err
+	}
+
+	for name, field := range fields {
+		if writeOnly != nil && field.writeOnly != *writeOnly {
+			continue
+		}
+
+		value, ok := attributes[name]
+		if !ok {
+			continue
+		}
+
+		if err := decodeValue(value, target.Field(field.index)); err != nil {
+			return fmt.Errorf("decoding %q: %+v", name, err)
+		}
+	}
+
+	return nil
+}
+
+func decodeValue(input tftypes.Value, target reflect.Value) error {
+	if input.IsNull() || !input.IsKnown() {
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Pointer:
+		v := reflect.New(target.Type().Elem())
+		if err := decodeValue(input, v.Elem()); err != nil {
+			return err
+		}
+		target.Set(v)
+
+	case reflect.Interface:
+		v, err := decodeInterface(input)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(v))
+
+	case reflect.String:
+		var v string
+		if err := input.As(&v); err != nil {
+			return err
+		}
+		target.SetString(v)
+
+	case reflect.Bool:
+		var v bool
+		if err := input.As(&v); err != nil {
+			return err
+		}
+		target.SetBool(v)
+
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		v := new(big.Float)
+		if err := input.As(&v); err != nil {
+			return err
+		}
+		i, accuracy := v.Int64()
+		if accuracy != big.Exact {
+			return fmt.Errorf("the value %s cannot be represented as an integer", v.String())
+		}
+		target.SetInt(i)
+
+	case reflect.Float32, reflect.Float64:
+		v := new(big.Float)
+		if err := input.As(&v); err != nil {
+			return err
+		}
+		f, _ := v.Float64()
+		target.SetFloat(f)
+
+	case reflect.Slice:
+		var elements []tftypes.Value
+		if err := input.As(&elements); err != nil {
+			return err
+		}
+		output := reflect.MakeSlice(target.Type(), len(elements), len(elements))
+		for i, element := range elements {
+			if err := decodeValue(element, output.Index(i)); err != nil {
+				return fmt.Errorf("decoding item %d: %+v", i, err)
+			}
+		}
+		target.Set(output)
+
+	case reflect.Map:
+		var elements map[string]tftypes.Value
+		if err := input.As(&elements); err != nil {
+			return err
+		}
+		output := reflect.MakeMapWithSize(target.Type(), len(elements))
+		for k, element := range elements {
+			v := reflect.New(target.Type().Elem()).Elem()
+			if err := decodeValue(element, v); err != nil {
+				return fmt.Errorf("decoding key %q: %+v", k, err)
+			}
+			output.SetMapIndex(reflect.ValueOf(k).Convert(target.Type().Key()), v)
+		}
+		target.Set(output)
+
+	case reflect.Struct:
+		return decodeStruct(input, target, nil)
+
+	default:
+		return fmt.Errorf("the field type %s is not supported", target.Type())
+	}
+
+	return nil
+}
+
+// decodeInterface returns the value in the same format as the Plugin SDK, which is used for fields such as
+// `map[string]interface{}` - where numbers are returned as an `int` when these are whole numbers
+func decodeInterface(input tftypes.Value) (interface{}, error) {
+	switch {
+	case input.Type().Is(tftypes.String):
+		var v string
+		err := input.As(&v)
+		return v, err
+
+	case input.Type().Is(tftypes.Bool):
+		var v bool
+		err := input.As(&v)
+		return v, err
+
+	case input.Type().Is(tftypes.Number):
+		v := new(big.Float)
+		if err := input.As(&v); err != nil {
+			return nil, err
+		}
+		if i, accuracy := v.Int64(); accuracy == big.Exact {
+			return int(i), nil
+		}
+		f, _ := v.Float64()
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("decoding a %s into an interface{} is not supported", input.Type())
+}
+
+func encodeStruct(input reflect.Value, ty tftypes.Object, source tftypes.Value) (tftypes.Value, error) {
+	fields, err := modelFields(input.Type())
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	var sourceAttributes map[string]tftypes.Value
+	if source.Type() != nil && source.IsKnown() && !source.IsNull() {
+		if err := source.As(&sourceAttributes); err != nil {
+			return tftypes.Value{}, fmt.Errorf("expected the source to be an object: %+v", err)
+		}
+	}
+
+	attributes := make(map[string]tftypes.Value, len(ty.AttributeTypes))
+	for name, attributeType := range ty.AttributeTypes {
+		field, ok := fields[name]
+		if !ok {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+			if v, ok := sourceAttributes[name]; ok && v.IsFullyKnown() {
+				attributes[name] = v
+			}
+			continue
+		}
+
+		if field.writeOnly {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+			continue
+		}
+
+		value, err := encodeValue(input.Field(field.index), attributeType)
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("encoding %q: %+v", name, err)
+		}
+		attributes[name] = value
+	}
+
+	return tftypes.NewValue(ty, attributes), nil
+}
+
+func encodeValue(input reflect.Value, ty tftypes.Type) (tftypes.Value, error) {
+	if input.Kind() == reflect.Pointer || input.Kind() == reflect.Interface {
+		if input.IsNil() {
+			return tftypes.NewValue(ty, nil), nil
+		}
+		return encodeValue(input.Elem(), ty)
+	}
+
+	switch {
+	case ty.Is(tftypes.String):
+		if input.Kind() != reflect.String {
+			return tftypes.Value{}, fmt.Errorf("expected a string but got %s", input.Type())
+		}
+		return tftypes.NewValue(ty, input.String()), nil
+
+	case ty.Is(tftypes.Bool):
+		if input.Kind() != reflect.Bool {
+			return tftypes.Value{}, fmt.Errorf("expected a bool but got %s", input.Type())
+		}
+		return tftypes.NewValue(ty, input.Bool()), nil
+
+	case ty.Is(tftypes.Number):
+		switch input.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			return tftypes.NewValue(ty, new(big.Float).SetInt64(input.Int())), nil
+		case reflect.Float32, reflect.Float64:
+			return tftypes.NewValue(ty, big.NewFloat(input.Float())), nil
+		}
+		return tftypes.Value{}, fmt.Errorf("expected a number but got %s", input.Type())
+
+	case ty.Is(tftypes.List{}), ty.Is(tftypes.Set{}):
+		if input.Kind() != reflect.Slice {
+			return tftypes.Value{}, fmt.Errorf("expected a slice but got %s", input.Type())
+		}
+
+		var elementType tftypes.Type
+		if v, ok := ty.(tftypes.List); ok {
+			elementType = v.ElementType
+		} else {
+			elementType = ty.(tftypes.Set).ElementType
+		}
+
+		_, isBlock := elementType.(tftypes.Object)
+		if input.IsNil() && !isBlock {
+			return tftypes.NewValue(ty, nil), nil
+		}
+
+		elements := make([]tftypes.Value, 0, input.Len())
+		for i := 0; i < input.Len(); i++ {
+			v, err := encodeValue(input.Index(i), elementType)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("encoding item %d: %+v", i, err)
+			}
+			elements = append(elements, v)
+		}
+		return tftypes.NewValue(ty, elements), nil
+
+	case ty.Is(tftypes.Map{}):
+		if input.Kind() != reflect.Map || input.Type().Key().Kind() != reflect.String {
+			return tftypes.Value{}, fmt.Errorf("expected a map with string keys but got %s", input.Type())
+		}
+		if input.IsNil() {
+			return tftypes.NewValue(ty, nil), nil
+		}
+
+		elements := make(map[string]tftypes.Value, input.Len())
+		iter := input.MapRange()
+		for iter.Next() {
+			v, err := encodeValue(iter.Value(), ty.(tftypes.Map).ElementType)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("encoding key %q: %+v", iter.Key().String(), err)
+			}
+			elements[iter.Key().String()] = v
+		}
+		return tftypes.NewValue(ty, elements), nil
+
+	case ty.Is(tftypes.Object{}):
+		if input.Kind() != reflect.Struct {
+			return tftypes.Value{}, fmt.Errorf("expected a struct but got %s", input.Type())
+		}
+		return encodeStruct(input, ty.(tftypes.Object), tftypes.Value{})
+	}
+
+	return tftypes.Value{}, fmt.Errorf("encoding the type %s is not supported", ty)
+}
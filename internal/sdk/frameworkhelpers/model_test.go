@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package frameworkhelpers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type modelTestNested struct {
+	Email string  `tfschema:"email"`
+	Phone *string `tfschema:"phone"`
+}
+
+type modelTestModel struct {
+	Name     string            `tfschema:"name"`
+	Count    *int64            `tfschema:"count"`
+	Enabled  bool              `tfschema:"enabled"`
+	Tags     map[string]string `tfschema:"tags"`
+	Contacts []modelTestNested `tfschema:"contact"`
+	Password string            `tfschema:"password,writeOnly"`
+}
+
+var modelTestNestedType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"email": tftypes.String,
+		"phone": tftypes.String,
+	},
+}
+
+var modelTestType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id":       tftypes.String,
+		"name":     tftypes.String,
+		"count":    tftypes.Number,
+		"enabled":  tftypes.Bool,
+		"tags":     tftypes.Map{ElementType: tftypes.String},
+		"contact":  tftypes.Set{ElementType: modelTestNestedType},
+		"password": tftypes.String,
+	},
+}
+
+func TestDecodeModel(t *testing.T) {
+	input := tftypes.NewValue(modelTestType, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "/things/example"),
+		"name":    tftypes.NewValue(tftypes.String, "example"),
+		"count":   tftypes.NewValue(tftypes.Number, nil),
+		"enabled": tftypes.NewValue(tftypes.Bool, true),
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "test"),
+		}),
+		"contact": tftypes.NewValue(tftypes.Set{ElementType: modelTestNestedType}, []tftypes.Value{
+			tftypes.NewValue(modelTestNestedType, map[string]tftypes.Value{
+				"email": tftypes.NewValue(tftypes.String, "someone@example.com"),
+				"phone": tftypes.NewValue(tftypes.String, nil),
+			}),
+		}),
+		"password": tftypes.NewValue(tftypes.String, nil),
+	})
+	config := tftypes.NewValue(modelTestType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, nil),
+		"name":     tftypes.NewValue(tftypes.String, "example"),
+		"count":    tftypes.NewValue(tftypes.Number, nil),
+		"enabled":  tftypes.NewValue(tftypes.Bool, true),
+		"tags":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		"contact":  tftypes.NewValue(tftypes.Set{ElementType: modelTestNestedType}, nil),
+		"password": tftypes.NewValue(tftypes.String, "secret"),
+	})
+
+	var model modelTestModel
+	if err := DecodeModel(input, &model); err != nil {
+		t.Fatalf("decoding: %+v", err)
+	}
+	if model.Name != "example" || !model.Enabled || model.Tags["env"] != "test" {
+		t.Fatalf("unexpected model: %+v", model)
+	}
+	if model.Count != nil {
+		t.Fatalf("expected `count` to be nil since it's null but got %d", *model.Count)
+	}
+	if len(model.Contacts) != 1 || model.Contacts[0].Email != "someone@example.com" || model.Contacts[0].Phone != nil {
+		t.Fatalf("unexpected contacts: %+v", model.Contacts)
+	}
+	if model.Password != "" {
+		t.Fatalf("expected the Write-Only `password` not to be decoded from the state but got %q", model.Password)
+	}
+
+	if err := DecodeWriteOnlyModel(config, &model); err != nil {
+		t.Fatalf("decoding the Write-Only fields: %+v", err)
+	}
+	if model.Password != "secret" {
+		t.Fatalf("expected `password` to be `secret` but got %q", model.Password)
+	}
+	if model.Tags["env"] != "test" {
+		t.Fatalf("expected the other fields to be retained but got %+v", model)
+	}
+}
+
+func TestEncodeModel(t *testing.T) {
+	source := tftypes.NewValue(modelTestType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "/things/example"),
+		"name":     tftypes.NewValue(tftypes.String, "example"),
+		"count":    tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+		"enabled":  tftypes.NewValue(tftypes.Bool, false),
+		"tags":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		"contact":  tftypes.NewValue(tftypes.Set{ElementType: modelTestNestedType}, nil),
+		"password": tftypes.NewValue(tftypes.String, nil),
+	})
+
+	count := int64(3)
+	model := modelTestModel{
+		Name:     "example",
+		Count:    &count,
+		Password: "secret",
+	}
+	output, err := EncodeModel(&model, modelTestType, source)
+	if err != nil {
+		t.Fatalf("encoding: %+v", err)
+	}
+
+	expected := tftypes.NewValue(modelTestType, map[string]tftypes.Value{
+		// `id` isn't present in the model, so is taken from the source
+		"id":      tftypes.NewValue(tftypes.String, "/things/example"),
+		"name":    tftypes.NewValue(tftypes.String, "example"),
+		"count":   tftypes.NewValue(tftypes.Number, big.NewFloat(3)),
+		"enabled": tftypes.NewValue(tftypes.Bool, false),
+		"tags":    tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		// an omitted block is an empty set, rather than null
+		"contact": tftypes.NewValue(tftypes.Set{ElementType: modelTestNestedType}, []tftypes.Value{}),
+		// Write-Only values are never persisted
+		"password": tftypes.NewValue(tftypes.String, nil),
+	})
+	if !output.Equal(expected) {
+		diffs, _ := output.Diff(expected)
+		t.Fatalf("unexpected output: %+v", diffs)
+	}
+}
+
+func TestModelInvalidStructTag(t *testing.T) {
+	var model struct {
+		Name string `tfschema:"name,computed"`
+	}
+	input := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "example"),
+	})
+	if err := DecodeModel(input, &model); err == nil {
+		t.Fatalf("expected an error for an unsupported struct tag but didn't get one")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package frameworkhelpers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// WrappedSetValidator provides a wrapper for legacy SDKv2 type validations to ease migration to Framework Native
+// The provided function is tested against each element in the set, this simulates the SDKv2 behaviour of defining the
+// valiation inside the `Elem:` property.
+type WrappedSetValidator struct {
+	Func         func(v interface{}, k string) (warnings []string, errors []error)
+	Desc         string
+	MarkdownDesc string
+}
+
+func (w WrappedSetValidator) Description(_ context.Context) string {
+	return w.Desc
+}
+
+func (w WrappedSetValidator) MarkdownDescription(_ context.Context) string {
+	return w.MarkdownDesc
+}
+
+func (w WrappedSetValidator) ValidateSet(ctx context.Context, request validator.SetRequest, response *validator.SetResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// the elements are validated in the same manner as a List, since ordering is irrelevant here
+	list, diags := basetypes.NewListValue(request.ConfigValue.ElementType(ctx), request.ConfigValue.Elements())
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	listRequest := validator.ListRequest{
+		Path:           request.Path,
+		PathExpression: request.PathExpression,
+		Config:         request.Config,
+		ConfigValue:    list,
+	}
+	listResponse := validator.ListResponse{}
+	WrappedListValidator{
+		Func:         w.Func,
+		Desc:         w.Desc,
+		MarkdownDesc: w.MarkdownDesc,
+	}.ValidateList(ctx, listRequest, &listResponse)
+	response.Diagnostics.Append(listResponse.Diagnostics...)
+}
+
+var _ validator.Set = &WrappedSetValidator{}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// ListResource lists the existing instances of a Resource, which is exposed as a List Resource via the
+// Framework Provider - allowing these to be discovered using `terraform query` and imported using the
+// Resource Identity of each instance.
+type ListResource interface {
+	// ResourceType is the type of the Resource being listed, for example `azurerm_resource_group`
+	ResourceType() string
+
+	// IdentityType returns the ResourceIdentityType used to derive the Resource Identity of each instance
+	// from its Resource ID
+	IdentityType() pluginsdk.ResourceIdentityType
+
+	// SupportsResourceGroupScope returns whether the instances can be listed within a single Resource Group,
+	// rather than only across the Subscription
+	SupportsResourceGroupScope() bool
+
+	// List returns the instances of the Resource which exist within the specified scope
+	List(ctx context.Context, client *clients.Client, scope ListResourceScope) ([]ListResourceResult, error)
+}
+
+// ListResourceScope is the scope within which the instances of a Resource are listed
+type ListResourceScope struct {
+	// SubscriptionId is the Subscription in which the instances should be listed
+	SubscriptionId commonids.SubscriptionId
+
+	// ResourceGroupId is the Resource Group in which the instances should be listed, when specified
+	ResourceGroupId *commonids.ResourceGroupId
+}
+
+// ListResourceResult is an instance of a Resource returned from a ListResource
+type ListResourceResult struct {
+	// ID is the normalized Resource ID of this instance
+	ID string
+
+	// DisplayName is the human-readable name of this instance, typically the name of the Resource
+	DisplayName string
+}
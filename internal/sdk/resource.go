@@ -108,6 +108,21 @@ type ResourceWithCustomImporter interface {
 	CustomImporter() ResourceRunFunc
 }
 
+// ResourceWithIdentity is an optional interface
+//
+// The Resource Identity for each Resource is derived from the Resource ID validated by the
+// IDValidationFunc - see ResourceIdentityTypeFor. Resources implementing this interface
+// instead expose a Resource Identity comprised of the non-static segments (for example
+// `subscription_id`, `resource_group_name` and `name`) of the Resource ID returned from
+// Identity, which is only necessary when the IDValidationFunc isn't a generated
+// validation function (for example a closure).
+type ResourceWithIdentity interface {
+	Resource
+
+	// Identity returns the Resource ID type for this Resource, used to derive the Resource Identity
+	Identity() resourceids.ResourceId
+}
+
 // ResourceWithUpdate is an optional interface
 //
 // Notably the Arguments for Resources implementing this interface
@@ -190,11 +205,27 @@ type ResourceMetaData struct {
 
 	// serializationDebugLogger is used for testing purposes
 	serializationDebugLogger Logger
+
+	// framework holds the Plugin Framework values for this Resource, and is used in place of the ResourceData
+	// when the Resource is exposed via the FrameworkResourceWrapper (or FrameworkDataSourceWrapper)
+	framework *frameworkResourceData
+}
+
+// ID returns the ID of this Resource, which is empty when the Resource hasn't been created (or no longer exists)
+func (rmd ResourceMetaData) ID() string {
+	if rmd.framework != nil {
+		return rmd.framework.id
+	}
+	return rmd.ResourceData.Id()
 }
 
 // MarkAsGone marks this resource as removed in the Remote API, so this is no longer available
 func (rmd ResourceMetaData) MarkAsGone(idFormatter resourceids.Id) error {
 	rmd.Logger.Infof("[DEBUG] %s was not found - removing from state", idFormatter)
+	if rmd.framework != nil {
+		rmd.framework.id = ""
+		return nil
+	}
 	rmd.ResourceData.SetId("")
 	return nil
 }
@@ -5,8 +5,11 @@ package sdk
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -24,6 +27,9 @@ import (
 // var person Person
 // if err := metadata.Decode(&person); err != nil { .. }
 func (rmd ResourceMetaData) Decode(input interface{}) error {
+	if rmd.framework != nil {
+		return rmd.framework.decode(input)
+	}
 	if rmd.ResourceData == nil {
 		return fmt.Errorf("ResourceData was nil")
 	}
@@ -45,6 +51,8 @@ type stateRetriever interface {
 	Get(key string) interface{}
 	GetOk(key string) (interface{}, bool)
 	GetOkExists(key string) (interface{}, bool)
+	GetRawConfig() cty.Value
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
 }
 
 func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debugLogger Logger) error {
@@ -64,6 +72,13 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 
 		if structTags != nil {
 			tfschemaValue, valExists := stateRetriever.GetOkExists(structTags.hclPath)
+			if structTags.writeOnly {
+				// Write-Only values are never persisted into the State, so can only be retrieved from the Config
+				tfschemaValue, valExists, err = decodeWriteOnlyValue(stateRetriever, structTags.hclPath, field.Type)
+				if err != nil {
+					return fmt.Errorf("retrieving write-only value for %q: %+v", field.Name, err)
+				}
+			}
 			if !valExists {
 				continue
 			}
@@ -79,6 +94,54 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	return nil
 }
 
+// decodeWriteOnlyValue retrieves the value for the top-level Write-Only attribute at hclPath from the Raw Config,
+// returning it in the same format as the Plugin SDK would for a value in the State so that it can be passed to setValue.
+func decodeWriteOnlyValue(stateRetriever stateRetriever, hclPath string, fieldType reflect.Type) (interface{}, bool, error) {
+	// the Raw Config is only available during Create/Update and Plan operations (e.g. not during a Read)
+	if stateRetriever.GetRawConfig().IsNull() {
+		return nil, false, nil
+	}
+
+	raw, diags := stateRetriever.GetRawConfigAt(cty.GetAttrPath(hclPath))
+	if diags.HasError() {
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				return nil, false, fmt.Errorf("%s: %s", d.Summary, d.Detail)
+			}
+		}
+	}
+
+	if !raw.IsKnown() || raw.IsNull() {
+		return nil, false, nil
+	}
+
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	switch raw.Type() {
+	case cty.String:
+		return raw.AsString(), true, nil
+
+	case cty.Bool:
+		return raw.True(), true, nil
+
+	case cty.Number:
+		if fieldType.Kind() == reflect.Float64 {
+			v, _ := raw.AsBigFloat().Float64()
+			return v, true, nil
+		}
+
+		v, accuracy := raw.AsBigFloat().Int64()
+		if accuracy != big.Exact {
+			return nil, false, fmt.Errorf("the value %s cannot be represented as an integer", raw.AsBigFloat().String())
+		}
+		return int(v), true, nil
+	}
+
+	return nil, false, fmt.Errorf("write-only attributes of the type %q are not supported", raw.Type().FriendlyName())
+}
+
 func setValue(input, tfschemaValue interface{}, index int, fieldName string, debugLogger Logger) (errOut error) {
 	debugLogger.Infof("setting value for %q..", fieldName)
 	defer func() {
@@ -8,10 +8,13 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type decodeTestData struct {
 	State       map[string]interface{}
+	RawConfig   map[string]cty.Value
 	Input       interface{}
 	Expected    interface{}
 	ExpectError bool
@@ -1035,6 +1038,76 @@ func TestResourceDecode_NestedThreeLevelsDeepMultipleOptionalItems(t *testing.T)
 	}.test(t)
 }
 
+func TestDecode_WriteOnlyFields(t *testing.T) {
+	type WriteOnly struct {
+		Name          string   `tfschema:"name"`
+		Secret        string   `tfschema:"secret,writeOnly"`
+		SecretVersion int64    `tfschema:"secret_version"`
+		Port          *int64   `tfschema:"port,writeOnly"`
+		Ratio         *float64 `tfschema:"ratio,writeOnly"`
+		Enabled       bool     `tfschema:"enabled,writeOnly"`
+		Unset         *string  `tfschema:"unset,writeOnly"`
+	}
+
+	decodeTestData{
+		State: map[string]interface{}{
+			"name":           "bingo",
+			"secret_version": 2,
+			// Write-Only values are never returned from the State, so should always be taken from the Config
+			"secret": "",
+		},
+		RawConfig: map[string]cty.Value{
+			"name":           cty.StringVal("bingo"),
+			"secret":         cty.StringVal("bongo"),
+			"secret_version": cty.NumberIntVal(2),
+			"port":           cty.NumberIntVal(8080),
+			"ratio":          cty.NumberFloatVal(0.5),
+			"enabled":        cty.True,
+			"unset":          cty.NullVal(cty.String),
+		},
+		Input: &WriteOnly{},
+		Expected: &WriteOnly{
+			Name:          "bingo",
+			Secret:        "bongo",
+			SecretVersion: 2,
+			Port:          pointer.To(int64(8080)),
+			Ratio:         pointer.To(0.5),
+			Enabled:       true,
+		},
+	}.test(t)
+}
+
+func TestDecode_WriteOnlyFieldsWithoutConfig(t *testing.T) {
+	type WriteOnly struct {
+		Name   string  `tfschema:"name"`
+		Secret *string `tfschema:"secret,writeOnly"`
+	}
+
+	decodeTestData{
+		State: map[string]interface{}{
+			"name": "bingo",
+		},
+		Input: &WriteOnly{},
+		Expected: &WriteOnly{
+			Name: "bingo",
+		},
+	}.test(t)
+}
+
+func TestDecode_WriteOnlyFieldsInvalidNumber(t *testing.T) {
+	type WriteOnly struct {
+		Count int64 `tfschema:"count,writeOnly"`
+	}
+
+	decodeTestData{
+		RawConfig: map[string]cty.Value{
+			"count": cty.NumberFloatVal(1.5),
+		},
+		Input:       &WriteOnly{},
+		ExpectError: true,
+	}.test(t)
+}
+
 func (testData decodeTestData) test(t *testing.T) {
 	debugLogger := ConsoleLogger{}
 	state := testData.stateWrapper()
@@ -1056,13 +1129,20 @@ func (testData decodeTestData) test(t *testing.T) {
 }
 
 func (testData decodeTestData) stateWrapper() testDataGetter {
+	rawConfig := cty.NullVal(cty.DynamicPseudoType)
+	if testData.RawConfig != nil {
+		rawConfig = cty.ObjectVal(testData.RawConfig)
+	}
+
 	return testDataGetter{
-		values: testData.State,
+		values:    testData.State,
+		rawConfig: rawConfig,
 	}
 }
 
 type testDataGetter struct {
-	values map[string]interface{}
+	values    map[string]interface{}
+	rawConfig cty.Value
 }
 
 func (td testDataGetter) Get(key string) interface{} {
@@ -1079,3 +1159,15 @@ func (td testDataGetter) GetOkExists(key string) (interface{}, bool) {
 	val, ok := td.values[key]
 	return val, ok
 }
+
+func (td testDataGetter) GetRawConfig() cty.Value {
+	return td.rawConfig
+}
+
+func (td testDataGetter) GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics) {
+	val, err := valPath.Apply(td.rawConfig)
+	if err != nil {
+		return cty.DynamicVal, diag.FromErr(err)
+	}
+	return val, nil
+}
@@ -18,6 +18,10 @@ func (rmd ResourceMetaData) Encode(input interface{}) error {
 		return fmt.Errorf("need a pointer")
 	}
 
+	if rmd.framework != nil {
+		return rmd.framework.encode(input)
+	}
+
 	objType := reflect.TypeOf(input).Elem()
 	objVal := reflect.ValueOf(input).Elem()
 
@@ -70,6 +74,11 @@ func recurse(objType reflect.Type, objVal reflect.Value, debugLogger Logger) (ou
 				continue
 			}
 
+			if structTags.writeOnly {
+				debugLogger.Infof("The HCL Path %q is marked as write-only - skipping", structTags.hclPath)
+				continue
+			}
+
 			switch field.Type.Kind() {
 			case reflect.Int64:
 				iv := fieldVal.Int()
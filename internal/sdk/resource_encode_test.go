@@ -454,6 +454,27 @@ func TestResourceEncode_TopLevelComputed(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceEncode_TopLevelWriteOnly(t *testing.T) {
+	type SimpleType struct {
+		String        string  `tfschema:"string"`
+		Secret        string  `tfschema:"secret,writeOnly"`
+		SecretVersion int64   `tfschema:"secret_version"`
+		OtherSecret   *string `tfschema:"other_secret,writeOnly"`
+	}
+	encodeTestData{
+		Input: &SimpleType{
+			String:        "world",
+			Secret:        "bingo",
+			SecretVersion: 1,
+			OtherSecret:   pointer.To("bango"),
+		},
+		Expected: map[string]interface{}{
+			"string":         "world",
+			"secret_version": int64(1),
+		},
+	}.test(t)
+}
+
 func TestResourceEncode_NestedOneLevelDeepEmpty(t *testing.T) {
 	type Inner struct {
 		Value string `tfschema:"value"`
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+)
+
+// frameworkResourceData holds the Plugin Framework values for a Resource or Data Source exposed via the
+// FrameworkResourceWrapper or FrameworkDataSourceWrapper, which is used in place of the Plugin SDKv2 ResourceData
+// by Decode, Encode, SetID, ID and MarkAsGone
+type frameworkResourceData struct {
+	// id is the ID of this Resource, which is empty when the Resource doesn't exist
+	id string
+
+	// source is the value decoded into the model - that is the Plan during a Create/Update, the State during a
+	// Read/Delete, or the Config for a Data Source
+	source tftypes.Value
+
+	// config is the Config for this Resource, which is used to retrieve the values for any Write-Only fields,
+	// and is null when the Config isn't available (e.g. during a Read or Delete)
+	config tftypes.Value
+
+	// output is the value to be persisted into the State, which is populated by Encode
+	output tftypes.Value
+
+	// outputType is the type of the Plugin Framework Schema for this Resource
+	outputType tftypes.Object
+}
+
+// state returns the value to be persisted into the State, or a null value when the Resource doesn't exist
+func (d *frameworkResourceData) state() (tftypes.Value, error) {
+	if d.id == "" {
+		return tftypes.NewValue(d.outputType, nil), nil
+	}
+
+	value := d.output
+	if value.Type() == nil {
+		// Encode hasn't been called, so persist the (known) values from the source as-is
+		var err error
+		if value, err = frameworkhelpers.EncodeModel(&struct{}{}, d.outputType, d.source); err != nil {
+			return tftypes.Value{}, err
+		}
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		return tftypes.Value{}, fmt.Errorf("expected an object: %+v", err)
+	}
+	attributes["id"] = tftypes.NewValue(tftypes.String, d.id)
+
+	return tftypes.NewValue(d.outputType, attributes), nil
+}
+
+func (d *frameworkResourceData) decode(input interface{}) error {
+	if err := frameworkhelpers.DecodeModel(d.source, input); err != nil {
+		return err
+	}
+
+	if d.config.Type() != nil {
+		if err := frameworkhelpers.DecodeWriteOnlyModel(d.config, input); err != nil {
+			return fmt.Errorf("decoding the Write-Only fields: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *frameworkResourceData) encode(input interface{}) error {
+	source := d.output
+	if source.Type() == nil {
+		source = d.source
+	}
+
+	output, err := frameworkhelpers.EncodeModel(input, d.outputType, source)
+	if err != nil {
+		return err
+	}
+
+	d.output = output
+	return nil
+}
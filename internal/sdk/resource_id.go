@@ -7,5 +7,9 @@ import "github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 
 // SetID uses the specified ID Formatter to set the Resource ID
 func (rmd ResourceMetaData) SetID(formatter resourceids.Id) {
+	if rmd.framework != nil {
+		rmd.framework.id = formatter.ID()
+		return
+	}
 	rmd.ResourceData.SetId(formatter.ID())
 }
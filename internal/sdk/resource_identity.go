@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var (
+	// resourceIdentityTypes contains the ResourceIdentityType for the Resource ID validated by each IDValidationFunc,
+	// keyed by the name of the validation function
+	resourceIdentityTypes     = map[string]pluginsdk.ResourceIdentityType{}
+	resourceIdentityTypesLock sync.RWMutex
+)
+
+// RegisterResourceIdentityType registers the ResourceIdentityType for the Resource ID validated by validateFunc, which
+// is used to derive the Resource Identity for each Typed Resource returning validateFunc from its IDValidationFunc.
+//
+// This is called from the `resource_identities_gen.go` file generated within each Service Package.
+func RegisterResourceIdentityType(validateFunc pluginsdk.SchemaValidateFunc, identityType pluginsdk.ResourceIdentityType) {
+	resourceIdentityTypesLock.Lock()
+	defer resourceIdentityTypesLock.Unlock()
+
+	resourceIdentityTypes[functionName(validateFunc)] = identityType
+}
+
+// RegisterLegacyResourceIdentityType registers the ResourceIdentityType for a Resource ID generated by
+// `generator-resource-id`, where parseFunc is the function used by validateFunc to parse the Resource ID.
+func RegisterLegacyResourceIdentityType(validateFunc pluginsdk.SchemaValidateFunc, parseFunc interface{}) {
+	identityType, err := pluginsdk.NewLegacyResourceIdentityType(parseFunc)
+	if err != nil {
+		panic(fmt.Sprintf("building the Resource Identity Type for %q: %+v", functionName(validateFunc), err))
+	}
+
+	RegisterResourceIdentityType(validateFunc, identityType)
+}
+
+// ResourceIdentityTypeFor returns the ResourceIdentityType used to derive the Resource Identity for the specified
+// Resource - either from the Resource ID type returned from Identity, or the Resource ID validated by the
+// IDValidationFunc.
+//
+// Where the Resource ID validated by the IDValidationFunc can't be split into fields (for example as it comprises
+// other Resource IDs) the Resource Identity contains the `id` field, validated using the IDValidationFunc.
+func ResourceIdentityTypeFor(resource Resource) pluginsdk.ResourceIdentityType {
+	if v, ok := resource.(ResourceWithIdentity); ok {
+		return pluginsdk.NewResourceIdentityType(v.Identity())
+	}
+
+	validateFunc := resource.IDValidationFunc()
+
+	resourceIdentityTypesLock.RLock()
+	defer resourceIdentityTypesLock.RUnlock()
+
+	if v, ok := resourceIdentityTypes[functionName(validateFunc)]; ok {
+		return v
+	}
+
+	return pluginsdk.NewValidatedResourceIdentityType(validateFunc)
+}
+
+// functionName returns the fully qualified name of the specified function
+func functionName(input interface{}) string {
+	v := reflect.ValueOf(input)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+
+	if fn := runtime.FuncForPC(v.Pointer()); fn != nil {
+		return fn.Name()
+	}
+
+	return ""
+}
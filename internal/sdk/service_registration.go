@@ -4,6 +4,10 @@
 package sdk
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -41,6 +45,27 @@ type UntypedServiceRegistration interface {
 	SupportedResources() map[string]*pluginsdk.Resource
 }
 
+// FrameworkServiceRegistration is the interface used for Services which expose
+// native Plugin Framework types, which are served by the Framework Provider.
+type FrameworkServiceRegistration interface {
+	// Name is the name of this Service
+	Name() string
+
+	// WebsiteCategories returns a list of categories which can be used for the sidebar
+	WebsiteCategories() []string
+
+	// EphemeralResources returns a list of Ephemeral Resources supported by this Service
+	EphemeralResources() []func() ephemeral.EphemeralResource
+
+	// FrameworkDataSources returns a list of native Plugin Framework Data Sources supported by this Service
+	// Typed Data Sources can be exposed here using NewFrameworkDataSourceWrapper
+	FrameworkDataSources() []func() datasource.DataSource
+
+	// FrameworkResources returns a list of native Plugin Framework Resources supported by this Service
+	// Typed Resources can be exposed here using NewFrameworkResourceWrapper
+	FrameworkResources() []func() resource.Resource
+}
+
 // TypedServiceRegistrationWithAGitHubLabel is a superset of TypedServiceRegistration allowing
 // a single GitHub Label to be specified that will be automatically applied to any Pull Requests
 // making changes to this package.
@@ -64,3 +89,34 @@ type UntypedServiceRegistrationWithAGitHubLabel interface {
 
 	AssociatedGitHubLabel() string
 }
+
+// ServiceRegistrationWithResourceProviders is an optional interface which a Typed or Untyped Service Registration
+// can implement to declare the Resource Providers which the Resources within this Service require. When the
+// Provider is configured with `resource_provider_registrations` set to `lazy`, these Resource Providers are
+// registered when a Resource or Data Source within this Service is first used.
+//
+// NOTE: this is intentionally an optional interface since Services which don't declare any Resource Providers
+// can still be used when the Resource Providers have been registered outside of Terraform
+type ServiceRegistrationWithResourceProviders interface {
+	// ResourceProviders returns the namespaces of the Resource Providers required by this Service,
+	// for example `Microsoft.Compute`
+	ResourceProviders() []string
+}
+
+// ServiceRegistrationWithRetryRules is an optional interface which a Typed or Untyped Service Registration can
+// implement to declare the transient errors returned by its Resource Providers (for example due to eventual
+// consistency), which are then retried automatically for all Resources. No errors are retried by default, a Service
+// can opt into the common errors for its Resource Provider via `common.OperationInProgressRetryRules`.
+type ServiceRegistrationWithRetryRules interface {
+	// RetryRules returns the transient errors which should be retried for this Service, which should be limited
+	// to the Resource Provider returning these errors via `Namespace`
+	RetryRules() []common.RetryRule
+}
+
+// ServiceRegistrationWithListResources is an optional interface which a Service Registration can implement to
+// expose List Resources for its Resources, which are served by the Framework Provider and allow the existing
+// instances of these Resources to be discovered using `terraform query`.
+type ServiceRegistrationWithListResources interface {
+	// ListResources returns a list of List Resources supported by this Service
+	ListResources() []ListResource
+}
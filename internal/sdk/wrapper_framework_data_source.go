@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ datasource.DataSourceWithConfigure = &FrameworkDataSourceWrapper{}
+
+// FrameworkDataSourceWrapper is a wrapper for converting a DataSource implementation
+// into a native Plugin Framework Data Source, which is served by the Framework Provider
+//
+// As with the FrameworkResourceWrapper, the Config is decoded into (and the State encoded from) the Data Source's
+// model using the frameworkhelpers package, rather than the Plugin SDKv2 ResourceData.
+type FrameworkDataSourceWrapper struct {
+	client     *clients.Client
+	dataSource DataSource
+}
+
+// NewFrameworkDataSourceWrapper returns a function which returns a native Plugin Framework Data Source for this
+// DataSource implementation, for use in a FrameworkServiceRegistration
+func NewFrameworkDataSourceWrapper(dataSource DataSource) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &FrameworkDataSourceWrapper{
+			dataSource: dataSource,
+		}
+	}
+}
+
+func (w *FrameworkDataSourceWrapper) Metadata(_ context.Context, _ datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = w.dataSource.ResourceType()
+}
+
+func (w *FrameworkDataSourceWrapper) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	if modelObj := w.dataSource.ModelObject(); modelObj != nil {
+		if err := ValidateModelObject(modelObj); err != nil {
+			response.Diagnostics.AddError(fmt.Sprintf("Error building the Schema for %q", w.dataSource.ResourceType()), fmt.Sprintf("validating model: %+v", err))
+			return
+		}
+	}
+
+	dataSourceSchema, err := frameworkDataSourceSchema(w.dataSource.Arguments(), w.dataSource.Attributes())
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error building the Schema for %q", w.dataSource.ResourceType()), err.Error())
+		return
+	}
+
+	dataSourceSchema.Blocks["timeouts"] = frameworkDataSourceTimeoutsBlock()
+
+	if v, ok := w.dataSource.(DataSourceWithDeprecationReplacedBy); ok {
+		dataSourceSchema.DeprecationMessage = fmt.Sprintf(`The %[1]q datasource has been deprecated and replaced by the %[2]q datasource.
+
+The existing %[1]q datasource will remain available until the next
+major version of the Azure Provider however the existing datasource is feature-frozen
+and we recommend using the %[2]q datasource instead.
+`, w.dataSource.ResourceType(), v.DeprecatedInFavourOfDataSource())
+	}
+
+	response.Schema = *dataSourceSchema
+}
+
+func (w *FrameworkDataSourceWrapper) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// the Provider Data is nil when the Data Source is validated prior to the Provider being configured
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*clients.Client)
+	if !ok {
+		response.Diagnostics.AddError("Client Provider Data Error", fmt.Sprintf("expected `*clients.Client` but got %T", request.ProviderData))
+		return
+	}
+
+	w.client = client
+}
+
+func (w *FrameworkDataSourceWrapper) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	timeout, err := frameworkTimeout(request.Config.Raw, pluginsdk.TimeoutRead)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error reading %q", w.dataSource.ResourceType()), err.Error())
+		return
+	}
+	if timeout == nil {
+		timeout = pointer.To(w.dataSource.Read().Timeout)
+	}
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	// a Data Source has no prior State, so the model is decoded from the Config alone
+	data := &frameworkResourceData{
+		source:     request.Config.Raw,
+		outputType: response.State.Schema.Type().TerraformType(ctx).(tftypes.Object),
+	}
+
+	ctx = locks.WithResourceAddress(ctx, w.dataSource.ResourceType())
+	logger := &DiagnosticsLogger{}
+	metaData := ResourceMetaData{
+		Client:                   w.client,
+		Logger:                   logger,
+		serializationDebugLogger: NullLogger{},
+		framework:                data,
+	}
+	err = w.dataSource.Read().Func(ctx, metaData)
+	appendPluginSdkDiagnostics(&response.Diagnostics, err, logger)
+	for _, warning := range locks.Warnings(ctx) {
+		response.Diagnostics.AddWarning(fmt.Sprintf("Lock warning whilst reading %s", w.dataSource.ResourceType()), warning)
+	}
+	if err != nil {
+		return
+	}
+
+	value, err := data.state()
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error setting the State for %q", w.dataSource.ResourceType()), err.Error())
+		return
+	}
+	if value.IsNull() {
+		response.Diagnostics.AddError(fmt.Sprintf("Error reading %q", w.dataSource.ResourceType()), "the ID was not set")
+		return
+	}
+
+	value, err = normalizeFrameworkNullValues(ctx, response.State, request.Config.Raw, value)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error setting the State for %q", w.dataSource.ResourceType()), err.Error())
+		return
+	}
+
+	response.State.Raw = value
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	pschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ provider.Provider = frameworkTestProvider{}
+
+// frameworkTestProvider is a Plugin Framework Provider serving the test-only frameworkTestResource via the
+// FrameworkResourceWrapper, so that the bridge can be exercised over the protocol in the same way as Terraform
+type frameworkTestProvider struct {
+	resource frameworkTestResource
+}
+
+func (p frameworkTestProvider) Metadata(_ context.Context, _ provider.MetadataRequest, response *provider.MetadataResponse) {
+	response.TypeName = "azurerm"
+}
+
+func (p frameworkTestProvider) Schema(_ context.Context, _ provider.SchemaRequest, response *provider.SchemaResponse) {
+	response.Schema = pschema.Schema{}
+}
+
+func (p frameworkTestProvider) Configure(_ context.Context, _ provider.ConfigureRequest, _ *provider.ConfigureResponse) {
+}
+
+func (p frameworkTestProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p frameworkTestProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		NewFrameworkResourceWrapper(p.resource),
+	}
+}
+
+func TestFrameworkResourceWrapper_ServedByProvider(t *testing.T) {
+	ctx := context.TODO()
+	typed := frameworkTestResource{
+		items: map[string]frameworkTestModel{},
+	}
+	server, err := providerserver.NewProtocol5WithError(frameworkTestProvider{resource: typed})()
+	if err != nil {
+		t.Fatalf("building the provider server: %+v", err)
+	}
+
+	schemaResponse, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the provider schema: %+v", err)
+	}
+	frameworkTestAssertNoProtoDiagnostics(t, schemaResponse.Diagnostics)
+	resourceSchema, ok := schemaResponse.ResourceSchemas["azurerm_framework_test"]
+	if !ok {
+		t.Fatalf("expected `azurerm_framework_test` to be served by the provider")
+	}
+	ty := resourceSchema.ValueType()
+	if _, ok := ty.(tftypes.Object).AttributeTypes["timeouts"]; !ok {
+		t.Fatalf("expected the schema to contain the `timeouts` block")
+	}
+
+	t.Log("Plan..")
+	config := frameworkTestObject(ty, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "example"),
+	})
+	nullState := tftypes.NewValue(ty, nil)
+	planResponse, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         "azurerm_framework_test",
+		PriorState:       frameworkTestDynamicValue(t, ty, nullState),
+		ProposedNewState: frameworkTestDynamicValue(t, ty, config),
+		Config:           frameworkTestDynamicValue(t, ty, config),
+	})
+	if err != nil {
+		t.Fatalf("planning: %+v", err)
+	}
+	frameworkTestAssertNoProtoDiagnostics(t, planResponse.Diagnostics)
+
+	t.Log("Apply..")
+	applyResponse, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:     "azurerm_framework_test",
+		PriorState:   frameworkTestDynamicValue(t, ty, nullState),
+		PlannedState: planResponse.PlannedState,
+		Config:       frameworkTestDynamicValue(t, ty, config),
+	})
+	if err != nil {
+		t.Fatalf("applying: %+v", err)
+	}
+	frameworkTestAssertNoProtoDiagnostics(t, applyResponse.Diagnostics)
+	state, err := applyResponse.NewState.Unmarshal(ty)
+	if err != nil {
+		t.Fatalf("unmarshaling the new state: %+v", err)
+	}
+	expected := frameworkTestObject(ty, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "/things/example"),
+		"name":   tftypes.NewValue(tftypes.String, "example"),
+		"output": tftypes.NewValue(tftypes.String, "output-example"),
+	})
+	if !state.Equal(expected) {
+		diffs, _ := state.Diff(expected)
+		t.Fatalf("unexpected state after apply: %+v", diffs)
+	}
+
+	t.Log("Import..")
+	importResponse, err := server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: "azurerm_framework_test",
+		ID:       "/things/example",
+	})
+	if err != nil {
+		t.Fatalf("importing: %+v", err)
+	}
+	frameworkTestAssertNoProtoDiagnostics(t, importResponse.Diagnostics)
+	if len(importResponse.ImportedResources) != 1 {
+		t.Fatalf("expected a single imported resource but got %d", len(importResponse.ImportedResources))
+	}
+
+	t.Log("Read imported resource..")
+	readResponse, err := server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     "azurerm_framework_test",
+		CurrentState: importResponse.ImportedResources[0].State,
+	})
+	if err != nil {
+		t.Fatalf("reading: %+v", err)
+	}
+	frameworkTestAssertNoProtoDiagnostics(t, readResponse.Diagnostics)
+	imported, err := readResponse.NewState.Unmarshal(ty)
+	if err != nil {
+		t.Fatalf("unmarshaling the imported state: %+v", err)
+	}
+	if !imported.Equal(expected) {
+		diffs, _ := imported.Diff(expected)
+		t.Fatalf("expected the imported state to match the applied state but got: %+v", diffs)
+	}
+}
+
+func frameworkTestDynamicValue(t *testing.T, ty tftypes.Type, value tftypes.Value) *tfprotov5.DynamicValue {
+	v, err := tfprotov5.NewDynamicValue(ty, value)
+	if err != nil {
+		t.Fatalf("building the dynamic value: %+v", err)
+	}
+	return &v
+}
+
+func frameworkTestAssertNoProtoDiagnostics(t *testing.T, diags []*tfprotov5.Diagnostic) {
+	for _, v := range diags {
+		if v != nil && v.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", v.Summary, v.Detail)
+		}
+	}
+}
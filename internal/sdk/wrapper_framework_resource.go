@@ -0,0 +1,365 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var (
+	_ resource.ResourceWithConfigure   = &FrameworkResourceWrapper{}
+	_ resource.ResourceWithImportState = &FrameworkResourceWrapper{}
+)
+
+// FrameworkResourceWrapper is a wrapper for converting a Resource implementation
+// into a native Plugin Framework Resource, which is served by the Framework Provider
+//
+// The Resource's Arguments and Attributes are cross-compiled into a Plugin Framework Schema - and the Plan/State
+// are decoded into (and encoded from) the Resource's model using the frameworkhelpers package, rather than the
+// Plugin SDKv2 ResourceData. As such the Resource must use Decode/Encode/ID/SetID rather than the ResourceData
+// - and a Typed Resource can be served by either Provider, but not both.
+type FrameworkResourceWrapper struct {
+	client   *clients.Client
+	resource Resource
+}
+
+// NewFrameworkResourceWrapper returns a function which returns a native Plugin Framework Resource for this
+// Resource implementation, for use in a FrameworkServiceRegistration
+func NewFrameworkResourceWrapper(r Resource) func() resource.Resource {
+	return func() resource.Resource {
+		return &FrameworkResourceWrapper{
+			resource: r,
+		}
+	}
+}
+
+func (w *FrameworkResourceWrapper) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = w.resource.ResourceType()
+}
+
+func (w *FrameworkResourceWrapper) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	if err := w.validate(); err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error building the Schema for %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+
+	resourceSchema, err := frameworkResourceSchema(w.resource.Arguments(), w.resource.Attributes())
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error building the Schema for %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+
+	_, supportsUpdate := w.resource.(ResourceWithUpdate)
+	resourceSchema.Blocks["timeouts"] = frameworkResourceTimeoutsBlock(supportsUpdate)
+
+	if v, ok := w.resource.(ResourceWithDeprecationAndNoReplacement); ok {
+		resourceSchema.DeprecationMessage = v.DeprecationMessage()
+	}
+	if v, ok := w.resource.(ResourceWithDeprecationReplacedBy); ok {
+		resourceSchema.DeprecationMessage = fmt.Sprintf(`The %[1]q resource has been deprecated and replaced by the %[2]q resource.
+
+The existing %[1]q resource will remain available until the next
+major version of the Azure Provider however the existing resource is feature-frozen
+and we recommend using the %[2]q resource instead.
+`, w.resource.ResourceType(), v.DeprecatedInFavourOfResource())
+	}
+
+	response.Schema = *resourceSchema
+}
+
+func (w *FrameworkResourceWrapper) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	// the Provider Data is nil when the Resource is validated prior to the Provider being configured
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*clients.Client)
+	if !ok {
+		response.Diagnostics.AddError("Client Provider Data Error", fmt.Sprintf("expected `*clients.Client` but got %T", request.ProviderData))
+		return
+	}
+
+	w.client = client
+}
+
+// timeout returns the timeout for the operation - which is the value within the `timeouts` block in the Plan/State
+// where specified, else the `default_timeouts` block in the Provider block, else the timeout defined by the Resource
+func (w *FrameworkResourceWrapper) timeout(operation string, source tftypes.Value, resourceTimeout time.Duration) (time.Duration, error) {
+	configured, err := frameworkTimeout(source, operation)
+	if err != nil {
+		return 0, err
+	}
+	if configured != nil {
+		return *configured, nil
+	}
+
+	if w.client == nil {
+		return resourceTimeout, nil
+	}
+	return w.client.DefaultTimeouts.For(w.resource.ResourceType(), operation, resourceTimeout), nil
+}
+
+func (w *FrameworkResourceWrapper) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	timeout, err := w.timeout(pluginsdk.TimeoutCreate, request.Plan.Raw, w.resource.Create().Timeout)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error creating %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data := &frameworkResourceData{
+		source:     request.Plan.Raw,
+		config:     request.Config.Raw,
+		outputType: frameworkObjectType(ctx, response.State),
+	}
+
+	w.run(ctx, data, &response.Diagnostics, func(ctx context.Context, metaData ResourceMetaData) error {
+		if err := w.resource.Create().Func(ctx, metaData); err != nil {
+			return err
+		}
+		// NOTE: whilst this may look like we should use the Read
+		// functions timeout here, we're still /technically/ in the
+		// Create function so reusing that timeout should be sufficient
+		return w.resource.Read().Func(ctx, metaData)
+	})
+
+	// the State is persisted even when the Create fails, provided the ID has been set, so that the resource is tainted
+	w.setState(ctx, data, request.Plan.Raw, &response.State, &response.Diagnostics)
+}
+
+func (w *FrameworkResourceWrapper) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	timeout, err := w.timeout(pluginsdk.TimeoutRead, request.State.Raw, w.resource.Read().Timeout)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error reading %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data := &frameworkResourceData{
+		id:         frameworkResourceID(request.State.Raw),
+		source:     request.State.Raw,
+		outputType: frameworkObjectType(ctx, response.State),
+	}
+
+	if failed := w.run(ctx, data, &response.Diagnostics, w.resource.Read().Func); failed {
+		return
+	}
+
+	// a Read which calls MarkAsGone clears the ID, which removes the Resource from the State
+	w.setState(ctx, data, request.State.Raw, &response.State, &response.Diagnostics)
+}
+
+func (w *FrameworkResourceWrapper) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	v, ok := w.resource.(ResourceWithUpdate)
+	if !ok {
+		// this shouldn't happen since all of the arguments are ForceNew, however we'll be safe
+		response.Diagnostics.AddError(fmt.Sprintf("Error updating %q", w.resource.ResourceType()), "this resource doesn't support being updated")
+		return
+	}
+
+	timeout, err := w.timeout(pluginsdk.TimeoutUpdate, request.Plan.Raw, v.Update().Timeout)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error updating %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data := &frameworkResourceData{
+		id:         frameworkResourceID(request.State.Raw),
+		source:     request.Plan.Raw,
+		config:     request.Config.Raw,
+		outputType: frameworkObjectType(ctx, response.State),
+	}
+
+	w.run(ctx, data, &response.Diagnostics, func(ctx context.Context, metaData ResourceMetaData) error {
+		if err := v.Update().Func(ctx, metaData); err != nil {
+			return err
+		}
+		// whilst this may look like we should use the Update timeout here
+		// we're still "technically" in the update method, so reusing the
+		// Update's timeout should be fine
+		return w.resource.Read().Func(ctx, metaData)
+	})
+
+	w.setState(ctx, data, request.Plan.Raw, &response.State, &response.Diagnostics)
+}
+
+func (w *FrameworkResourceWrapper) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	timeout, err := w.timeout(pluginsdk.TimeoutDelete, request.State.Raw, w.resource.Delete().Timeout)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error deleting %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data := &frameworkResourceData{
+		id:         frameworkResourceID(request.State.Raw),
+		source:     request.State.Raw,
+		outputType: frameworkObjectType(ctx, response.State),
+	}
+
+	w.run(ctx, data, &response.Diagnostics, w.resource.Delete().Func)
+}
+
+func (w *FrameworkResourceWrapper) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	warnings, errors := w.resource.IDValidationFunc()(request.ID, "id")
+	for _, warning := range warnings {
+		response.Diagnostics.AddWarning("Resource ID Warning", warning)
+	}
+	if len(errors) > 0 {
+		out := make([]string, 0)
+		for _, err := range errors {
+			out = append(out, err.Error())
+		}
+		response.Diagnostics.AddError("Error validating the Resource ID", strings.Join(out, "\n"))
+		return
+	}
+
+	v, ok := w.resource.(ResourceWithCustomImporter)
+	if !ok {
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), request.ID)...)
+		return
+	}
+
+	// there's no `timeouts` block available during an import, so this is either the default or the Resource's timeout
+	timeout, err := w.timeout(pluginsdk.TimeoutRead, tftypes.Value{}, w.resource.Read().Timeout)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error importing %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	outputType := frameworkObjectType(ctx, response.State)
+	data := &frameworkResourceData{
+		id:         request.ID,
+		source:     tftypes.NewValue(outputType, nil),
+		outputType: outputType,
+	}
+
+	if failed := w.run(ctx, data, &response.Diagnostics, v.CustomImporter()); failed {
+		return
+	}
+
+	w.setState(ctx, data, tftypes.Value{}, &response.State, &response.Diagnostics)
+}
+
+// validate confirms the Resource implementation can be exposed as a native Plugin Framework Resource
+func (w *FrameworkResourceWrapper) validate() error {
+	if modelObj := w.resource.ModelObject(); modelObj != nil {
+		if err := ValidateModelObject(modelObj); err != nil {
+			return fmt.Errorf("validating model for %q: %+v", w.resource.ResourceType(), err)
+		}
+	}
+
+	if _, ok := w.resource.(ResourceWithCustomizeDiff); ok {
+		return fmt.Errorf("ResourceWithCustomizeDiff is not supported when exposed as a Plugin Framework Resource - a Plan Modifier should be used instead")
+	}
+	if _, ok := w.resource.(ResourceWithStateMigration); ok {
+		return fmt.Errorf("ResourceWithStateMigration is not supported when exposed as a Plugin Framework Resource")
+	}
+
+	return nil
+}
+
+// run runs the function for this Resource, appending any errors, warnings and lock warnings into the Diagnostics
+// and returning whether the function failed
+func (w *FrameworkResourceWrapper) run(ctx context.Context, data *frameworkResourceData, diags *diag.Diagnostics, f ResourceRunFunc) bool {
+	// record the resource acquiring any locks, so that the holder of a lock can be identified
+	address := w.resource.ResourceType()
+	if data.id != "" {
+		address = fmt.Sprintf("%s (%s)", address, data.id)
+	}
+	ctx = locks.WithResourceAddress(ctx, address)
+
+	logger := &DiagnosticsLogger{}
+	metaData := ResourceMetaData{
+		Client:                   w.client,
+		Logger:                   logger,
+		serializationDebugLogger: NullLogger{},
+		framework:                data,
+	}
+	err := f(ctx, metaData)
+	appendPluginSdkDiagnostics(diags, err, logger)
+
+	for _, warning := range locks.Warnings(ctx) {
+		diags.AddWarning(fmt.Sprintf("Lock warning whilst modifying %s", w.resource.ResourceType()), warning)
+	}
+
+	return err != nil
+}
+
+// setState populates the Plugin Framework State from the values encoded by the Resource
+func (w *FrameworkResourceWrapper) setState(ctx context.Context, data *frameworkResourceData, source tftypes.Value, state *tfsdk.State, diags *diag.Diagnostics) {
+	value, err := data.state()
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error setting the State for %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+
+	if value.IsNull() {
+		state.RemoveResource(ctx)
+		return
+	}
+
+	value, err = normalizeFrameworkNullValues(ctx, *state, source, value)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error setting the State for %q", w.resource.ResourceType()), err.Error())
+		return
+	}
+
+	state.Raw = value
+}
+
+// frameworkObjectType returns the type of the Plugin Framework Schema for this State
+func frameworkObjectType(ctx context.Context, state tfsdk.State) tftypes.Object {
+	return state.Schema.Type().TerraformType(ctx).(tftypes.Object)
+}
+
+// frameworkResourceID returns the value of the `id` attribute within the State, or an empty string when unset
+func frameworkResourceID(state tftypes.Value) string {
+	raw, _, err := tftypes.WalkAttributePath(state, tftypes.NewAttributePath().WithAttributeName("id"))
+	if err != nil {
+		return ""
+	}
+
+	var id string
+	if value, ok := raw.(tftypes.Value); ok && value.IsKnown() && !value.IsNull() {
+		_ = value.As(&id)
+	}
+	return id
+}
+
+// appendPluginSdkDiagnostics appends the error and any warnings logged by the Plugin SDKv2 function
+// into the Plugin Framework Diagnostics
+func appendPluginSdkDiagnostics(diags *diag.Diagnostics, err error, logger *DiagnosticsLogger) {
+	if err != nil {
+		diags.AddError(err.Error(), err.Error())
+	}
+
+	for _, v := range logger.diagnostics {
+		if v.Severity == sdkdiag.Error {
+			diags.AddError(v.Summary, v.Detail)
+			continue
+		}
+		diags.AddWarning(v.Summary, v.Detail)
+	}
+}
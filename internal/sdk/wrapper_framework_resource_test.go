@@ -0,0 +1,357 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type frameworkTestModel struct {
+	Name        string            `tfschema:"name"`
+	Description *string           `tfschema:"description"`
+	Enabled     bool              `tfschema:"enabled"`
+	Tags        map[string]string `tfschema:"tags"`
+	Output      string            `tfschema:"output"`
+}
+
+var _ ResourceWithUpdate = frameworkTestResource{}
+
+// frameworkTestResource is a Typed Resource which stores the model in-memory
+type frameworkTestResource struct {
+	items map[string]frameworkTestModel
+}
+
+func (r frameworkTestResource) Arguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func (r frameworkTestResource) Attributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"output": {
+			Type: schema.TypeString,
+		},
+	}
+}
+
+func (r frameworkTestResource) ModelObject() interface{} {
+	return &frameworkTestModel{}
+}
+
+func (r frameworkTestResource) ResourceType() string {
+	return "azurerm_framework_test"
+}
+
+func (r frameworkTestResource) Create() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var model frameworkTestModel
+			if err := metadata.Decode(&model); err != nil {
+				return err
+			}
+
+			id := fmt.Sprintf("/things/%s", model.Name)
+			model.Output = fmt.Sprintf("output-%s", model.Name)
+			r.items[id] = model
+
+			metadata.SetID(frameworkTestId(id))
+			return nil
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func (r frameworkTestResource) Read() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			model, ok := r.items[metadata.ID()]
+			if !ok {
+				return metadata.MarkAsGone(frameworkTestId(metadata.ID()))
+			}
+
+			return metadata.Encode(&model)
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func (r frameworkTestResource) Update() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			var model frameworkTestModel
+			if err := metadata.Decode(&model); err != nil {
+				return err
+			}
+
+			existing := r.items[metadata.ID()]
+			existing.Description = model.Description
+			r.items[metadata.ID()] = existing
+
+			return nil
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func (r frameworkTestResource) Delete() ResourceFunc {
+	return ResourceFunc{
+		Func: func(ctx context.Context, metadata ResourceMetaData) error {
+			delete(r.items, metadata.ID())
+			return nil
+		},
+		Timeout: time.Minute,
+	}
+}
+
+func (r frameworkTestResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validation.StringIsNotEmpty
+}
+
+type frameworkTestId string
+
+func (id frameworkTestId) ID() string {
+	return string(id)
+}
+
+func (id frameworkTestId) String() string {
+	return fmt.Sprintf("Test Thing %q", string(id))
+}
+
+func TestFrameworkResourceWrapper_Lifecycle(t *testing.T) {
+	ctx := context.TODO()
+	typed := frameworkTestResource{
+		items: map[string]frameworkTestModel{},
+	}
+	wrapper := NewFrameworkResourceWrapper(typed)()
+
+	metadata := resource.MetadataResponse{}
+	wrapper.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "azurerm"}, &metadata)
+	if metadata.TypeName != "azurerm_framework_test" {
+		t.Fatalf("expected the type name to be `azurerm_framework_test` but got %q", metadata.TypeName)
+	}
+
+	schemaResponse := resource.SchemaResponse{}
+	wrapper.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("retrieving schema: %+v", schemaResponse.Diagnostics)
+	}
+	resourceSchema := schemaResponse.Schema
+	frameworkType := resourceSchema.Type().TerraformType(ctx)
+
+	t.Log("Create..")
+	config := frameworkTestObject(frameworkType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "example"),
+	})
+	plan := frameworkTestObject(frameworkType, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":   tftypes.NewValue(tftypes.String, "example"),
+		"output": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	createResponse := resource.CreateResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(frameworkType, nil)},
+	}
+	wrapper.Create(ctx, resource.CreateRequest{
+		Config: tfsdk.Config{Schema: resourceSchema, Raw: config},
+		Plan:   tfsdk.Plan{Schema: resourceSchema, Raw: plan},
+	}, &createResponse)
+	if createResponse.Diagnostics.HasError() {
+		t.Fatalf("creating: %+v", createResponse.Diagnostics)
+	}
+	frameworkTestAssertString(t, createResponse.State, "id", types.StringValue("/things/example"))
+	frameworkTestAssertString(t, createResponse.State, "output", types.StringValue("output-example"))
+	// an omitted value is null, rather than the zero value - where the model uses a pointer this is retained
+	// and otherwise the zero value must be normalized to match the Plan
+	frameworkTestAssertString(t, createResponse.State, "description", types.StringNull())
+	var enabled types.Bool
+	createResponse.State.GetAttribute(ctx, path.Root("enabled"), &enabled)
+	if !enabled.IsNull() {
+		t.Fatalf("expected `enabled` to be null but got %s", enabled)
+	}
+
+	t.Log("Update..")
+	config = frameworkTestObject(frameworkType, map[string]tftypes.Value{
+		"name":        tftypes.NewValue(tftypes.String, "example"),
+		"description": tftypes.NewValue(tftypes.String, "updated"),
+	})
+	plan = frameworkTestObject(frameworkType, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "/things/example"),
+		"name":        tftypes.NewValue(tftypes.String, "example"),
+		"description": tftypes.NewValue(tftypes.String, "updated"),
+		"output":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	updateResponse := resource.UpdateResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: createResponse.State.Raw},
+	}
+	wrapper.Update(ctx, resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: resourceSchema, Raw: config},
+		Plan:   tfsdk.Plan{Schema: resourceSchema, Raw: plan},
+		State:  createResponse.State,
+	}, &updateResponse)
+	if updateResponse.Diagnostics.HasError() {
+		t.Fatalf("updating: %+v", updateResponse.Diagnostics)
+	}
+	frameworkTestAssertString(t, updateResponse.State, "description", types.StringValue("updated"))
+	if v := typed.items["/things/example"].Description; v == nil || *v != "updated" {
+		t.Fatalf("expected the stored description to be `updated` but got %v", v)
+	}
+
+	t.Log("Read..")
+	readResponse := resource.ReadResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: updateResponse.State.Raw},
+	}
+	wrapper.Read(ctx, resource.ReadRequest{State: updateResponse.State}, &readResponse)
+	if readResponse.Diagnostics.HasError() {
+		t.Fatalf("reading: %+v", readResponse.Diagnostics)
+	}
+	if !readResponse.State.Raw.Equal(updateResponse.State.Raw) {
+		t.Fatalf("expected the State to be unchanged after a Read but got %s", readResponse.State.Raw)
+	}
+
+	t.Log("Delete..")
+	deleteResponse := resource.DeleteResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: readResponse.State.Raw},
+	}
+	wrapper.Delete(ctx, resource.DeleteRequest{State: readResponse.State}, &deleteResponse)
+	if deleteResponse.Diagnostics.HasError() {
+		t.Fatalf("deleting: %+v", deleteResponse.Diagnostics)
+	}
+	if len(typed.items) != 0 {
+		t.Fatalf("expected the item to be deleted but got %+v", typed.items)
+	}
+
+	t.Log("Read after Delete..")
+	goneResponse := resource.ReadResponse{
+		State: tfsdk.State{Schema: resourceSchema, Raw: readResponse.State.Raw},
+	}
+	wrapper.Read(ctx, resource.ReadRequest{State: readResponse.State}, &goneResponse)
+	if goneResponse.Diagnostics.HasError() {
+		t.Fatalf("reading: %+v", goneResponse.Diagnostics)
+	}
+	if !goneResponse.State.Raw.IsNull() {
+		t.Fatalf("expected the resource to be removed from the State but got %s", goneResponse.State.Raw)
+	}
+}
+
+func TestFrameworkResourceWrapper_Timeouts(t *testing.T) {
+	ctx := context.TODO()
+	wrapper := NewFrameworkResourceWrapper(frameworkTestResource{})()
+
+	schemaResponse := resource.SchemaResponse{}
+	wrapper.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("retrieving schema: %+v", schemaResponse.Diagnostics)
+	}
+	frameworkType := schemaResponse.Schema.Type().TerraformType(ctx)
+	timeoutsType := frameworkType.(tftypes.Object).AttributeTypes["timeouts"].(tftypes.Object)
+	if _, ok := timeoutsType.AttributeTypes["update"]; !ok {
+		t.Fatalf("expected the `timeouts` block to contain `update` since the resource supports being updated")
+	}
+
+	value := frameworkTestObject(frameworkType, map[string]tftypes.Value{
+		"timeouts": frameworkTestObject(timeoutsType, map[string]tftypes.Value{
+			"create": tftypes.NewValue(tftypes.String, "2h"),
+		}),
+	})
+	w := wrapper.(*FrameworkResourceWrapper)
+
+	// the value within the `timeouts` block takes precedence
+	actual, err := w.timeout(pluginsdk.TimeoutCreate, value, time.Minute)
+	if err != nil {
+		t.Fatalf("retrieving the create timeout: %+v", err)
+	}
+	if actual != 2*time.Hour {
+		t.Fatalf("expected the create timeout to be 2h but got %s", actual)
+	}
+
+	// otherwise the Resource's timeout is used
+	actual, err = w.timeout(pluginsdk.TimeoutDelete, value, time.Minute)
+	if err != nil {
+		t.Fatalf("retrieving the delete timeout: %+v", err)
+	}
+	if actual != time.Minute {
+		t.Fatalf("expected the delete timeout to be 1m but got %s", actual)
+	}
+}
+
+func TestFrameworkResourceWrapper_ImportValidatesId(t *testing.T) {
+	ctx := context.TODO()
+	wrapper := NewFrameworkResourceWrapper(frameworkTestResource{})().(resource.ResourceWithImportState)
+
+	schemaResponse := resource.SchemaResponse{}
+	wrapper.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+	frameworkType := schemaResponse.Schema.Type().TerraformType(ctx)
+
+	response := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(frameworkType, nil)},
+	}
+	wrapper.ImportState(ctx, resource.ImportStateRequest{ID: ""}, &response)
+	if !response.Diagnostics.HasError() {
+		t.Fatalf("expected an error when importing an invalid ID but didn't get one")
+	}
+
+	response = resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(frameworkType, nil)},
+	}
+	wrapper.ImportState(ctx, resource.ImportStateRequest{ID: "/things/example"}, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("importing: %+v", response.Diagnostics)
+	}
+	frameworkTestAssertString(t, response.State, "id", types.StringValue("/things/example"))
+}
+
+// frameworkTestObject returns an object of the specified type, where any unspecified attributes are null
+func frameworkTestObject(frameworkType tftypes.Type, values map[string]tftypes.Value) tftypes.Value {
+	attributes := make(map[string]tftypes.Value)
+	for k, v := range frameworkType.(tftypes.Object).AttributeTypes {
+		attributes[k] = tftypes.NewValue(v, nil)
+		if value, ok := values[k]; ok {
+			attributes[k] = value
+		}
+	}
+
+	return tftypes.NewValue(frameworkType, attributes)
+}
+
+func frameworkTestAssertString(t *testing.T, state tfsdk.State, name string, expected types.String) {
+	var actual types.String
+	if diags := state.GetAttribute(context.TODO(), path.Root(name), &actual); diags.HasError() {
+		t.Fatalf("retrieving %q: %+v", name, diags)
+	}
+	if !actual.Equal(expected) {
+		t.Fatalf("expected %q to be %s but got %s", name, expected, actual)
+	}
+}
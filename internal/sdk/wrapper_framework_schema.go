@@ -0,0 +1,698 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+)
+
+// The Plugin Framework Schemas built here are cross-compiled from the Plugin SDKv2 Arguments and Attributes
+// and intentionally retain the same implied type - which allows the Typed Resources models to be decoded/encoded
+// (see frameworkhelpers.DecodeModel) and existing State to be used as-is when served as a native Framework Resource.
+//
+// Since the Provider is served over Protocol v5 (where Nested Attributes are unavailable), user-configurable
+// nested items are exposed as Blocks and Computed-only nested items as a List/Set of Objects - as in Plugin SDKv2.
+
+// frameworkResourceSchema returns the Plugin Framework Resource Schema for these Arguments and Attributes
+func frameworkResourceSchema(arguments map[string]*schema.Schema, attributes map[string]*schema.Schema) (*rschema.Schema, error) {
+	fields, err := combineSchema(arguments, attributes)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := (*fields)["id"]; exists {
+		return nil, fmt.Errorf("`id` is a reserved field and cannot be specified in the schema")
+	}
+
+	out := rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"id": rschema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]rschema.Block{},
+	}
+	for name, field := range *fields {
+		if err := frameworkResourceSchemaField(name, name, field, out.Attributes, out.Blocks); err != nil {
+			return nil, err
+		}
+	}
+
+	return &out, nil
+}
+
+func frameworkResourceSchemaField(name, sdkPath string, input *schema.Schema, attributes map[string]rschema.Attribute, blocks map[string]rschema.Block) error {
+	if err := validateFrameworkSchemaField(sdkPath, input); err != nil {
+		return err
+	}
+
+	// NOTE: Computed values are intentionally left as Unknown when the resource changes (rather than using
+	// the UseStateForUnknown plan modifier) since the Read following a Create/Update may change these
+	computed := input.Computed || input.Default != nil
+
+	switch input.Type {
+	case schema.TypeString:
+		out := rschema.StringAttribute{
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			WriteOnly:          input.WriteOnly,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         stringRelationalValidators.build(sdkPath, input),
+		}
+		if input.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedStringValidator{Func: input.ValidateFunc})
+		}
+		if input.ForceNew {
+			out.PlanModifiers = append(out.PlanModifiers, stringplanmodifier.RequiresReplace())
+		}
+		if input.Default != nil {
+			v, ok := input.Default.(string)
+			if !ok {
+				return fmt.Errorf("%q: expected a string Default but got %T", sdkPath, input.Default)
+			}
+			out.Default = stringdefault.StaticString(v)
+		}
+		attributes[name] = out
+
+	case schema.TypeInt:
+		out := rschema.Int64Attribute{
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			WriteOnly:          input.WriteOnly,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         int64RelationalValidators.build(sdkPath, input),
+		}
+		if input.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedInt64Validator{Func: input.ValidateFunc})
+		}
+		if input.ForceNew {
+			out.PlanModifiers = append(out.PlanModifiers, int64planmodifier.RequiresReplace())
+		}
+		if input.Default != nil {
+			v, ok := input.Default.(int)
+			if !ok {
+				return fmt.Errorf("%q: expected an int Default but got %T", sdkPath, input.Default)
+			}
+			out.Default = int64default.StaticInt64(int64(v))
+		}
+		attributes[name] = out
+
+	case schema.TypeFloat:
+		out := rschema.Float64Attribute{
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			WriteOnly:          input.WriteOnly,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         float64RelationalValidators.build(sdkPath, input),
+		}
+		if input.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedFloat64Validator{Func: input.ValidateFunc})
+		}
+		if input.ForceNew {
+			out.PlanModifiers = append(out.PlanModifiers, float64planmodifier.RequiresReplace())
+		}
+		if input.Default != nil {
+			v, ok := input.Default.(float64)
+			if !ok {
+				return fmt.Errorf("%q: expected a float64 Default but got %T", sdkPath, input.Default)
+			}
+			out.Default = float64default.StaticFloat64(v)
+		}
+		attributes[name] = out
+
+	case schema.TypeBool:
+		out := rschema.BoolAttribute{
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			WriteOnly:          input.WriteOnly,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         boolRelationalValidators.build(sdkPath, input),
+		}
+		if input.ValidateFunc != nil {
+			return fmt.Errorf("%q: a ValidateFunc is not supported for a TypeBool", sdkPath)
+		}
+		if input.ForceNew {
+			out.PlanModifiers = append(out.PlanModifiers, boolplanmodifier.RequiresReplace())
+		}
+		if input.Default != nil {
+			v, ok := input.Default.(bool)
+			if !ok {
+				return fmt.Errorf("%q: expected a bool Default but got %T", sdkPath, input.Default)
+			}
+			out.Default = booldefault.StaticBool(v)
+		}
+		attributes[name] = out
+
+	case schema.TypeList:
+		if nested, ok := input.Elem.(*schema.Resource); ok && !input.Computed {
+			out := rschema.ListNestedBlock{
+				Description:        input.Description,
+				DeprecationMessage: input.Deprecated,
+				Validators:         listRelationalValidators.build(sdkPath, input),
+				NestedObject: rschema.NestedBlockObject{
+					Attributes: map[string]rschema.Attribute{},
+					Blocks:     map[string]rschema.Block{},
+				},
+			}
+			out.Validators = append(out.Validators, frameworkBlockSizeValidators(input, listvalidator.IsRequired, listvalidator.SizeAtLeast, listvalidator.SizeAtMost)...)
+			if input.ForceNew {
+				out.PlanModifiers = append(out.PlanModifiers, listplanmodifier.RequiresReplace())
+			}
+			for k, v := range nested.SchemaMap() {
+				if err := frameworkResourceSchemaField(k, fmt.Sprintf("%s.0.%s", sdkPath, k), v, out.NestedObject.Attributes, out.NestedObject.Blocks); err != nil {
+					return err
+				}
+			}
+			blocks[name] = out
+			return nil
+		}
+
+		elemType, err := frameworkElementType(sdkPath, input)
+		if err != nil {
+			return err
+		}
+		out := rschema.ListAttribute{
+			ElementType:        elemType,
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			WriteOnly:          input.WriteOnly,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         listRelationalValidators.build(sdkPath, input),
+		}
+		out.Validators = append(out.Validators, frameworkAttributeSizeValidators(input, listvalidator.SizeAtLeast, listvalidator.SizeAtMost)...)
+		if v, ok := input.Elem.(*schema.Schema); ok && v.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedListValidator{Func: v.ValidateFunc})
+		}
+		if input.ForceNew {
+			out.PlanModifiers = append(out.PlanModifiers, listplanmodifier.RequiresReplace())
+		}
+		attributes[name] = out
+
+	case schema.TypeSet:
+		if nested, ok := input.Elem.(*schema.Resource); ok && !input.Computed {
+			out := rschema.SetNestedBlock{
+				Description:        input.Description,
+				DeprecationMessage: input.Deprecated,
+				Validators:         setRelationalValidators.build(sdkPath, input),
+				NestedObject: rschema.NestedBlockObject{
+					Attributes: map[string]rschema.Attribute{},
+					Blocks:     map[string]rschema.Block{},
+				},
+			}
+			out.Validators = append(out.Validators, frameworkBlockSizeValidators(input, setvalidator.IsRequired, setvalidator.SizeAtLeast, setvalidator.SizeAtMost)...)
+			if input.ForceNew {
+				out.PlanModifiers = append(out.PlanModifiers, setplanmodifier.RequiresReplace())
+			}
+			for k, v := range nested.SchemaMap() {
+				// items within a Set can't be referenced by index, however the path is only used to build error messages
+				if err := frameworkResourceSchemaField(k, fmt.Sprintf("%s.0.%s", sdkPath, k), v, out.NestedObject.Attributes, out.NestedObject.Blocks); err != nil {
+					return err
+				}
+			}
+			blocks[name] = out
+			return nil
+		}
+
+		elemType, err := frameworkElementType(sdkPath, input)
+		if err != nil {
+			return err
+		}
+		out := rschema.SetAttribute{
+			ElementType:        elemType,
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         setRelationalValidators.build(sdkPath, input),
+		}
+		out.Validators = append(out.Validators, frameworkAttributeSizeValidators(input, setvalidator.SizeAtLeast, setvalidator.SizeAtMost)...)
+		if v, ok := input.Elem.(*schema.Schema); ok && v.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedSetValidator{Func: v.ValidateFunc})
+		}
+		if input.ForceNew {
+			out.PlanModifiers = append(out.PlanModifiers, setplanmodifier.RequiresReplace())
+		}
+		attributes[name] = out
+
+	case schema.TypeMap:
+		elemType, err := frameworkElementType(sdkPath, input)
+		if err != nil {
+			return err
+		}
+		if input.ValidateFunc != nil {
+			return fmt.Errorf("%q: a ValidateFunc is not supported for a TypeMap", sdkPath)
+		}
+		out := rschema.MapAttribute{
+			ElementType:        elemType,
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			WriteOnly:          input.WriteOnly,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         mapRelationalValidators.build(sdkPath, input),
+		}
+		out.Validators = append(out.Validators, frameworkAttributeSizeValidators(input, mapvalidator.SizeAtLeast, mapvalidator.SizeAtMost)...)
+		if input.ForceNew {
+			out.PlanModifiers = append(out.PlanModifiers, mapplanmodifier.RequiresReplace())
+		}
+		attributes[name] = out
+
+	default:
+		return fmt.Errorf("%q: unsupported type %s", sdkPath, input.Type.String())
+	}
+
+	return nil
+}
+
+// frameworkDataSourceSchema returns the Plugin Framework Data Source Schema for these Arguments and Attributes
+func frameworkDataSourceSchema(arguments map[string]*schema.Schema, attributes map[string]*schema.Schema) (*dschema.Schema, error) {
+	fields, err := combineSchema(arguments, attributes)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := (*fields)["id"]; exists {
+		return nil, fmt.Errorf("`id` is a reserved field and cannot be specified in the schema")
+	}
+
+	out := dschema.Schema{
+		Attributes: map[string]dschema.Attribute{
+			"id": dschema.StringAttribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]dschema.Block{},
+	}
+	for name, field := range *fields {
+		if err := frameworkDataSourceSchemaField(name, name, field, out.Attributes, out.Blocks); err != nil {
+			return nil, err
+		}
+	}
+
+	return &out, nil
+}
+
+func frameworkDataSourceSchemaField(name, sdkPath string, input *schema.Schema, attributes map[string]dschema.Attribute, blocks map[string]dschema.Block) error {
+	if err := validateFrameworkSchemaField(sdkPath, input); err != nil {
+		return err
+	}
+	if input.ForceNew {
+		return fmt.Errorf("%q: ForceNew is not supported within a Data Source", sdkPath)
+	}
+
+	// Data Sources don't support Defaults, however since the value is populated through the
+	// Plugin SDKv2 ResourceData it's sufficient to mark these as Computed
+	computed := input.Computed || input.Default != nil
+
+	switch input.Type {
+	case schema.TypeString:
+		out := dschema.StringAttribute{
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         stringRelationalValidators.build(sdkPath, input),
+		}
+		if input.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedStringValidator{Func: input.ValidateFunc})
+		}
+		attributes[name] = out
+
+	case schema.TypeInt:
+		out := dschema.Int64Attribute{
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         int64RelationalValidators.build(sdkPath, input),
+		}
+		if input.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedInt64Validator{Func: input.ValidateFunc})
+		}
+		attributes[name] = out
+
+	case schema.TypeFloat:
+		out := dschema.Float64Attribute{
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         float64RelationalValidators.build(sdkPath, input),
+		}
+		if input.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedFloat64Validator{Func: input.ValidateFunc})
+		}
+		attributes[name] = out
+
+	case schema.TypeBool:
+		if input.ValidateFunc != nil {
+			return fmt.Errorf("%q: a ValidateFunc is not supported for a TypeBool", sdkPath)
+		}
+		attributes[name] = dschema.BoolAttribute{
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         boolRelationalValidators.build(sdkPath, input),
+		}
+
+	case schema.TypeList:
+		if nested, ok := input.Elem.(*schema.Resource); ok && !input.Computed {
+			out := dschema.ListNestedBlock{
+				Description:        input.Description,
+				DeprecationMessage: input.Deprecated,
+				Validators:         listRelationalValidators.build(sdkPath, input),
+				NestedObject: dschema.NestedBlockObject{
+					Attributes: map[string]dschema.Attribute{},
+					Blocks:     map[string]dschema.Block{},
+				},
+			}
+			out.Validators = append(out.Validators, frameworkBlockSizeValidators(input, listvalidator.IsRequired, listvalidator.SizeAtLeast, listvalidator.SizeAtMost)...)
+			for k, v := range nested.SchemaMap() {
+				if err := frameworkDataSourceSchemaField(k, fmt.Sprintf("%s.0.%s", sdkPath, k), v, out.NestedObject.Attributes, out.NestedObject.Blocks); err != nil {
+					return err
+				}
+			}
+			blocks[name] = out
+			return nil
+		}
+
+		elemType, err := frameworkElementType(sdkPath, input)
+		if err != nil {
+			return err
+		}
+		out := dschema.ListAttribute{
+			ElementType:        elemType,
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         listRelationalValidators.build(sdkPath, input),
+		}
+		out.Validators = append(out.Validators, frameworkAttributeSizeValidators(input, listvalidator.SizeAtLeast, listvalidator.SizeAtMost)...)
+		if v, ok := input.Elem.(*schema.Schema); ok && v.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedListValidator{Func: v.ValidateFunc})
+		}
+		attributes[name] = out
+
+	case schema.TypeSet:
+		if nested, ok := input.Elem.(*schema.Resource); ok && !input.Computed {
+			out := dschema.SetNestedBlock{
+				Description:        input.Description,
+				DeprecationMessage: input.Deprecated,
+				Validators:         setRelationalValidators.build(sdkPath, input),
+				NestedObject: dschema.NestedBlockObject{
+					Attributes: map[string]dschema.Attribute{},
+					Blocks:     map[string]dschema.Block{},
+				},
+			}
+			out.Validators = append(out.Validators, frameworkBlockSizeValidators(input, setvalidator.IsRequired, setvalidator.SizeAtLeast, setvalidator.SizeAtMost)...)
+			for k, v := range nested.SchemaMap() {
+				if err := frameworkDataSourceSchemaField(k, fmt.Sprintf("%s.0.%s", sdkPath, k), v, out.NestedObject.Attributes, out.NestedObject.Blocks); err != nil {
+					return err
+				}
+			}
+			blocks[name] = out
+			return nil
+		}
+
+		elemType, err := frameworkElementType(sdkPath, input)
+		if err != nil {
+			return err
+		}
+		out := dschema.SetAttribute{
+			ElementType:        elemType,
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         setRelationalValidators.build(sdkPath, input),
+		}
+		out.Validators = append(out.Validators, frameworkAttributeSizeValidators(input, setvalidator.SizeAtLeast, setvalidator.SizeAtMost)...)
+		if v, ok := input.Elem.(*schema.Schema); ok && v.ValidateFunc != nil {
+			out.Validators = append(out.Validators, frameworkhelpers.WrappedSetValidator{Func: v.ValidateFunc})
+		}
+		attributes[name] = out
+
+	case schema.TypeMap:
+		elemType, err := frameworkElementType(sdkPath, input)
+		if err != nil {
+			return err
+		}
+		if input.ValidateFunc != nil {
+			return fmt.Errorf("%q: a ValidateFunc is not supported for a TypeMap", sdkPath)
+		}
+		out := dschema.MapAttribute{
+			ElementType:        elemType,
+			Required:           input.Required,
+			Optional:           input.Optional,
+			Computed:           computed,
+			Sensitive:          input.Sensitive,
+			Description:        input.Description,
+			DeprecationMessage: input.Deprecated,
+			Validators:         mapRelationalValidators.build(sdkPath, input),
+		}
+		out.Validators = append(out.Validators, frameworkAttributeSizeValidators(input, mapvalidator.SizeAtLeast, mapvalidator.SizeAtMost)...)
+		attributes[name] = out
+
+	default:
+		return fmt.Errorf("%q: unsupported type %s", sdkPath, input.Type.String())
+	}
+
+	return nil
+}
+
+// validateFrameworkSchemaField ensures that the Plugin SDKv2 Schema doesn't make use of functionality which
+// has no equivalent in the Plugin Framework, rather than silently dropping this behaviour
+func validateFrameworkSchemaField(sdkPath string, input *schema.Schema) error {
+	unsupported := map[string]bool{
+		"ComputedWhen":     len(input.ComputedWhen) > 0,
+		"ConfigMode":       input.ConfigMode != schema.SchemaConfigModeAuto,
+		"DefaultFunc":      input.DefaultFunc != nil,
+		"DiffSuppressFunc": input.DiffSuppressFunc != nil,
+		"StateFunc":        input.StateFunc != nil,
+		"ValidateDiagFunc": input.ValidateDiagFunc != nil,
+	}
+	for name, used := range unsupported {
+		if used {
+			return fmt.Errorf("%q: %s is not supported when exposed as a Plugin Framework type", sdkPath, name)
+		}
+	}
+
+	if _, ok := input.Elem.(*schema.Resource); ok && input.Computed && (input.Optional || input.Required) {
+		// Blocks can't be Computed in the Plugin Framework, so the Plan would never match the State
+		return fmt.Errorf("%q: nested items which are both Optional and Computed are not supported when exposed as a Plugin Framework type", sdkPath)
+	}
+
+	return nil
+}
+
+// frameworkElementType returns the Element Type for the Plugin SDKv2 List, Map or Set
+func frameworkElementType(sdkPath string, input *schema.Schema) (attr.Type, error) {
+	switch v := input.Elem.(type) {
+	case nil:
+		if input.Type == schema.TypeMap {
+			return types.StringType, nil
+		}
+	case schema.ValueType:
+		return frameworkAttributeType(sdkPath, &schema.Schema{Type: v})
+	case *schema.Schema:
+		return frameworkAttributeType(sdkPath, v)
+	case *schema.Resource:
+		if input.Type == schema.TypeMap {
+			// Plugin SDKv2 treats a Map containing a Resource as a Map of Strings
+			return types.StringType, nil
+		}
+
+		attrTypes := make(map[string]attr.Type)
+		for k, nested := range v.SchemaMap() {
+			attrType, err := frameworkAttributeType(fmt.Sprintf("%s.0.%s", sdkPath, k), nested)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[k] = attrType
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+	}
+
+	return nil, fmt.Errorf("%q: unsupported Elem %T", sdkPath, input.Elem)
+}
+
+// frameworkAttributeType returns the Attribute Type for the Plugin SDKv2 Schema
+func frameworkAttributeType(sdkPath string, input *schema.Schema) (attr.Type, error) {
+	switch input.Type {
+	case schema.TypeString:
+		return types.StringType, nil
+	case schema.TypeInt:
+		return types.Int64Type, nil
+	case schema.TypeFloat:
+		return types.Float64Type, nil
+	case schema.TypeBool:
+		return types.BoolType, nil
+	}
+
+	elemType, err := frameworkElementType(sdkPath, input)
+	if err != nil {
+		return nil, err
+	}
+	switch input.Type {
+	case schema.TypeList:
+		return types.ListType{ElemType: elemType}, nil
+	case schema.TypeSet:
+		return types.SetType{ElemType: elemType}, nil
+	case schema.TypeMap:
+		return types.MapType{ElemType: elemType}, nil
+	}
+
+	return nil, fmt.Errorf("%q: unsupported type %s", sdkPath, input.Type.String())
+}
+
+// frameworkBlockSizeValidators returns the Validators equivalent to Required/MinItems/MaxItems for a Block
+func frameworkBlockSizeValidators[T any](input *schema.Schema, isRequired func() T, sizeAtLeast func(int) T, sizeAtMost func(int) T) []T {
+	out := make([]T, 0)
+	if input.Required {
+		out = append(out, isRequired())
+		if input.MinItems == 0 {
+			out = append(out, sizeAtLeast(1))
+		}
+	}
+
+	return append(out, frameworkAttributeSizeValidators(input, sizeAtLeast, sizeAtMost)...)
+}
+
+// frameworkAttributeSizeValidators returns the Validators equivalent to MinItems/MaxItems for a collection
+func frameworkAttributeSizeValidators[T any](input *schema.Schema, sizeAtLeast func(int) T, sizeAtMost func(int) T) []T {
+	out := make([]T, 0)
+	if input.MinItems > 0 {
+		out = append(out, sizeAtLeast(input.MinItems))
+	}
+	if input.MaxItems > 0 {
+		out = append(out, sizeAtMost(input.MaxItems))
+	}
+
+	return out
+}
+
+// frameworkRelationalValidators builds the Validators equivalent to the Plugin SDKv2 fields
+// AtLeastOneOf, ConflictsWith, ExactlyOneOf and RequiredWith for a given type
+type frameworkRelationalValidators[T any] struct {
+	alsoRequires  func(...path.Expression) T
+	atLeastOneOf  func(...path.Expression) T
+	conflictsWith func(...path.Expression) T
+	exactlyOneOf  func(...path.Expression) T
+}
+
+var (
+	boolRelationalValidators    = frameworkRelationalValidators[validator.Bool]{boolvalidator.AlsoRequires, boolvalidator.AtLeastOneOf, boolvalidator.ConflictsWith, boolvalidator.ExactlyOneOf}
+	float64RelationalValidators = frameworkRelationalValidators[validator.Float64]{float64validator.AlsoRequires, float64validator.AtLeastOneOf, float64validator.ConflictsWith, float64validator.ExactlyOneOf}
+	int64RelationalValidators   = frameworkRelationalValidators[validator.Int64]{int64validator.AlsoRequires, int64validator.AtLeastOneOf, int64validator.ConflictsWith, int64validator.ExactlyOneOf}
+	listRelationalValidators    = frameworkRelationalValidators[validator.List]{listvalidator.AlsoRequires, listvalidator.AtLeastOneOf, listvalidator.ConflictsWith, listvalidator.ExactlyOneOf}
+	mapRelationalValidators     = frameworkRelationalValidators[validator.Map]{mapvalidator.AlsoRequires, mapvalidator.AtLeastOneOf, mapvalidator.ConflictsWith, mapvalidator.ExactlyOneOf}
+	setRelationalValidators     = frameworkRelationalValidators[validator.Set]{setvalidator.AlsoRequires, setvalidator.AtLeastOneOf, setvalidator.ConflictsWith, setvalidator.ExactlyOneOf}
+	stringRelationalValidators  = frameworkRelationalValidators[validator.String]{stringvalidator.AlsoRequires, stringvalidator.AtLeastOneOf, stringvalidator.ConflictsWith, stringvalidator.ExactlyOneOf}
+)
+
+func (v frameworkRelationalValidators[T]) build(sdkPath string, input *schema.Schema) []T {
+	out := make([]T, 0)
+	if expressions := frameworkPathExpressions(sdkPath, input.RequiredWith); len(expressions) > 0 {
+		out = append(out, v.alsoRequires(expressions...))
+	}
+	if expressions := frameworkPathExpressions(sdkPath, input.AtLeastOneOf); len(expressions) > 0 {
+		out = append(out, v.atLeastOneOf(expressions...))
+	}
+	if expressions := frameworkPathExpressions(sdkPath, input.ConflictsWith); len(expressions) > 0 {
+		out = append(out, v.conflictsWith(expressions...))
+	}
+	if expressions := frameworkPathExpressions(sdkPath, input.ExactlyOneOf); len(expressions) > 0 {
+		out = append(out, v.exactlyOneOf(expressions...))
+	}
+
+	return out
+}
+
+// frameworkPathExpressions converts the Plugin SDKv2 keys (e.g. `block.0.field`) into Path Expressions,
+// omitting the current field since the Plugin Framework Validators implicitly include this
+func frameworkPathExpressions(sdkPath string, keys []string) []path.Expression {
+	out := make([]path.Expression, 0)
+	for _, key := range keys {
+		if key == sdkPath {
+			continue
+		}
+
+		segments := strings.Split(key, ".")
+		expression := path.MatchRoot(segments[0])
+		for _, segment := range segments[1:] {
+			if index, err := strconv.Atoi(segment); err == nil {
+				expression = expression.AtListIndex(index)
+				continue
+			}
+			expression = expression.AtName(segment)
+		}
+		out = append(out, expression)
+	}
+
+	return out
+}
@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func frameworkSchemaTestArguments() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"number": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      5,
+			ValidateFunc: validation.IntBetween(1, 10),
+		},
+		"float": {
+			Type:          schema.TypeFloat,
+			Optional:      true,
+			ConflictsWith: []string{"number"},
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"networks": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+		"int_set": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"list": {
+			Type:     schema.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ExactlyOneOf: []string{"list.0.name", "list.0.value"},
+					},
+					"value": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"inner": {
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"key": {
+									Type:     schema.TypeString,
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func frameworkSchemaTestAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"output": {
+			Type: schema.TypeString,
+		},
+		"computed_list": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"values": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeInt,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestFrameworkResourceSchema_ImpliedTypeMatchesPluginSdk(t *testing.T) {
+	ctx := context.TODO()
+	frameworkSchema, err := frameworkResourceSchema(frameworkSchemaTestArguments(), frameworkSchemaTestAttributes())
+	if err != nil {
+		t.Fatalf("building schema: %+v", err)
+	}
+	if diags := frameworkSchema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("validating schema: %+v", diags)
+	}
+
+	sdkSchema, _ := combineSchema(frameworkSchemaTestArguments(), frameworkSchemaTestAttributes())
+	expected := pluginSdkImpliedFrameworkType(t, &schema.Resource{Schema: *sdkSchema})
+	if actual := frameworkSchema.Type().TerraformType(ctx); !actual.Equal(expected) {
+		t.Fatalf("expected the implied type to be %s but got %s", expected, actual)
+	}
+
+	if _, ok := frameworkSchema.Blocks["list"]; !ok {
+		t.Fatalf("expected `list` to be a Block")
+	}
+	if _, ok := frameworkSchema.Attributes["computed_list"]; !ok {
+		t.Fatalf("expected `computed_list` to be an Attribute")
+	}
+	if !frameworkSchema.Attributes["number"].IsComputed() {
+		t.Fatalf("expected `number` to be Computed since it has a Default")
+	}
+}
+
+func TestFrameworkDataSourceSchema_ImpliedTypeMatchesPluginSdk(t *testing.T) {
+	ctx := context.TODO()
+	arguments := frameworkSchemaTestArguments()
+	arguments["name"].ForceNew = false
+
+	frameworkSchema, err := frameworkDataSourceSchema(arguments, frameworkSchemaTestAttributes())
+	if err != nil {
+		t.Fatalf("building schema: %+v", err)
+	}
+	if diags := frameworkSchema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("validating schema: %+v", diags)
+	}
+
+	sdkSchema, _ := combineSchema(arguments, frameworkSchemaTestAttributes())
+	expected := pluginSdkImpliedFrameworkType(t, &schema.Resource{Schema: *sdkSchema})
+	if actual := frameworkSchema.Type().TerraformType(ctx); !actual.Equal(expected) {
+		t.Fatalf("expected the implied type to be %s but got %s", expected, actual)
+	}
+}
+
+func TestFrameworkResourceSchema_Unsupported(t *testing.T) {
+	testData := map[string]map[string]*schema.Schema{
+		"reserved id": {
+			"id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		"diff suppress func": {
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				DiffSuppressFunc: func(_, _, _ string, _ *schema.ResourceData) bool {
+					return true
+				},
+			},
+		},
+		"optional and computed block": {
+			"block": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		"mismatched default": {
+			"number": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  "5",
+			},
+		},
+	}
+	for name, arguments := range testData {
+		t.Logf("[DEBUG] Testing %q..", name)
+		if _, err := frameworkResourceSchema(arguments, map[string]*schema.Schema{}); err == nil {
+			t.Fatalf("expected an error for %q but didn't get one", name)
+		}
+	}
+}
+
+func TestFrameworkPathExpressions(t *testing.T) {
+	actual := frameworkPathExpressions("list.0.name", []string{"list.0.name", "list.0.value", "other"})
+	expected := []string{"list[0].value", "other"}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d expressions but got %d", len(expected), len(actual))
+	}
+	for i, v := range expected {
+		if actual[i].String() != v {
+			t.Fatalf("expected expression %d to be %q but got %q", i, v, actual[i].String())
+		}
+	}
+}
+
+func pluginSdkImpliedFrameworkType(t *testing.T, resource *schema.Resource) tftypes.Type {
+	b, err := ctyjson.MarshalType(resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("marshaling type: %+v", err)
+	}
+
+	//nolint:staticcheck
+	out, err := tftypes.ParseJSONType(b)
+	if err != nil {
+		t.Fatalf("parsing type: %+v", err)
+	}
+
+	return out
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"time"
+
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// the `timeouts` block is exposed in the same manner as Plugin SDKv2, so that existing configurations
+// (and the State) remain compatible when a Typed Resource is exposed via the Plugin Framework
+
+// frameworkResourceTimeoutsBlock returns the `timeouts` block for a Resource, where `update` is only
+// available when the Resource supports being updated
+func frameworkResourceTimeoutsBlock(supportsUpdate bool) rschema.Block {
+	operations := []string{pluginsdk.TimeoutCreate, pluginsdk.TimeoutRead, pluginsdk.TimeoutDelete}
+	if supportsUpdate {
+		operations = append(operations, pluginsdk.TimeoutUpdate)
+	}
+
+	attributes := make(map[string]rschema.Attribute)
+	for _, operation := range operations {
+		attributes[operation] = rschema.StringAttribute{
+			Optional:   true,
+			Validators: []validator.String{frameworkTimeoutValidator()},
+		}
+	}
+
+	return rschema.SingleNestedBlock{
+		Attributes: attributes,
+	}
+}
+
+// frameworkDataSourceTimeoutsBlock returns the `timeouts` block for a Data Source, which only supports `read`
+func frameworkDataSourceTimeoutsBlock() dschema.Block {
+	return dschema.SingleNestedBlock{
+		Attributes: map[string]dschema.Attribute{
+			pluginsdk.TimeoutRead: dschema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{frameworkTimeoutValidator()},
+			},
+		},
+	}
+}
+
+func frameworkTimeoutValidator() validator.String {
+	return frameworkhelpers.WrappedStringValidator{
+		Func: func(v interface{}, k string) (warnings []string, errors []error) {
+			if _, err := time.ParseDuration(v.(string)); err != nil {
+				errors = append(errors, fmt.Errorf("%q must be a duration (e.g. `30m`) but got %q: %+v", k, v, err))
+			}
+			return
+		},
+		Desc:         "must be a duration, for example `30m`",
+		MarkdownDesc: "must be a duration, for example `30m`",
+	}
+}
+
+// frameworkTimeout returns the timeout for the operation specified within the `timeouts` block in the value
+// (that is the Plan, State or Config), or nil when this isn't specified
+func frameworkTimeout(input tftypes.Value, operation string) (*time.Duration, error) {
+	if input.Type() == nil || input.IsNull() || !input.IsKnown() {
+		return nil, nil
+	}
+
+	raw, _, err := tftypes.WalkAttributePath(input, tftypes.NewAttributePath().WithAttributeName("timeouts").WithAttributeName(operation))
+	if err != nil {
+		// either the `timeouts` block or this operation isn't present
+		return nil, nil
+	}
+
+	value, ok := raw.(tftypes.Value)
+	if !ok || value.IsNull() || !value.IsKnown() {
+		return nil, nil
+	}
+
+	var v string
+	if err := value.As(&v); err != nil {
+		return nil, err
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the %s timeout %q: %+v", operation, v, err)
+	}
+
+	return &duration, nil
+}
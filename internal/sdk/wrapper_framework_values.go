@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// normalizeFrameworkNullValues replaces the zero values encoded from the model with a null value, where the
+// source (that is, the Plan or the prior State) contains a null value.
+//
+// A model using a non-pointer field for an Optional value can't differentiate between a zero and null value - whilst
+// the Plugin Framework requires that the State matches the Plan, so this would otherwise raise an error from Terraform.
+// Computed attributes are left as-is, since the zero value may be the value returned from the API.
+func normalizeFrameworkNullValues(ctx context.Context, state tfsdk.State, source tftypes.Value, output tftypes.Value) (tftypes.Value, error) {
+	if source.Type() == nil || source.IsNull() {
+		return output, nil
+	}
+
+	return tftypes.Transform(output, func(attributePath *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if len(attributePath.Steps()) == 0 || value.IsNull() || !value.IsKnown() || !isFrameworkZeroValue(value) {
+			return value, nil
+		}
+
+		if attribute, err := state.Schema.AttributeAtTerraformPath(ctx, attributePath); err == nil && attribute.IsComputed() {
+			return value, nil
+		}
+
+		raw, _, err := tftypes.WalkAttributePath(source, attributePath)
+		if err != nil {
+			// the value isn't present in the source, so there's nothing to compare against
+			return value, nil
+		}
+		if sourceValue, ok := raw.(tftypes.Value); ok && sourceValue.IsNull() {
+			return tftypes.NewValue(value.Type(), nil), nil
+		}
+
+		return value, nil
+	})
+}
+
+func isFrameworkZeroValue(value tftypes.Value) bool {
+	switch {
+	case value.Type().Is(tftypes.String):
+		var v string
+		return value.As(&v) == nil && v == ""
+
+	case value.Type().Is(tftypes.Number):
+		v := new(big.Float)
+		return value.As(&v) == nil && v.Sign() == 0
+
+	case value.Type().Is(tftypes.Bool):
+		var v bool
+		return value.As(&v) == nil && !v
+
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Set{}):
+		var v []tftypes.Value
+		return value.As(&v) == nil && len(v) == 0
+
+	case value.Type().Is(tftypes.Map{}):
+		var v map[string]tftypes.Value
+		return value.As(&v) == nil && len(v) == 0
+	}
+
+	return false
+}
@@ -0,0 +1,334 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourcegroups"
+	"github.com/hashicorp/go-cty/cty"
+	ctymsgpack "github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var (
+	_ list.ListResourceWithConfigure    = &ListResourceWrapper{}
+	_ list.ListResourceWithRawV5Schemas = &ListResourceWrapper{}
+)
+
+// ListResourceWrapper is a wrapper for exposing a ListResource as a native Plugin Framework List Resource,
+// which is served by the Framework Provider for a Resource served by the Plugin SDKv2 Provider.
+//
+// Each instance is returned with its Resource Identity - and when requested, the instance is read using the
+// Plugin SDKv2 Resource, so that the returned values match those which would be imported.
+type ListResourceWrapper struct {
+	client       *clients.Client
+	listResource ListResource
+	resource     *schema.Resource
+}
+
+// NewListResourceWrapper returns a function which returns a native Plugin Framework List Resource for the
+// ListResource, where resource is the Plugin SDKv2 Resource being listed.
+func NewListResourceWrapper(listResource ListResource, resource *schema.Resource) func() list.ListResource {
+	return func() list.ListResource {
+		return &ListResourceWrapper{
+			listResource: listResource,
+			resource:     resource,
+		}
+	}
+}
+
+func (w *ListResourceWrapper) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = w.listResource.ResourceType()
+}
+
+func (w *ListResourceWrapper) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, response *list.ListResourceSchemaResponse) {
+	attributes := map[string]listschema.Attribute{
+		"subscription_id": listschema.StringAttribute{
+			Optional:    true,
+			Description: "The ID of the Subscription in which the resources should be listed. Defaults to the Subscription configured in the Provider block.",
+			Validators: []validator.String{
+				frameworkhelpers.WrappedStringValidator{
+					Func: validation.IsUUID,
+				},
+			},
+		},
+	}
+
+	if w.listResource.SupportsResourceGroupScope() {
+		attributes["resource_group_name"] = listschema.StringAttribute{
+			Optional:    true,
+			Description: "The name of the Resource Group in which the resources should be listed. When omitted the resources are listed across the Subscription.",
+			Validators: []validator.String{
+				frameworkhelpers.WrappedStringValidator{
+					Func: resourcegroups.ValidateName,
+				},
+			},
+		}
+	}
+
+	response.Schema = listschema.Schema{
+		Attributes: attributes,
+	}
+
+	// RawV5Schemas is unable to return Diagnostics, so any error building the schemas is surfaced here
+	if _, _, err := w.rawV5Schemas(ctx); err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Error building the Schema for %q", w.listResource.ResourceType()), err.Error())
+	}
+}
+
+func (w *ListResourceWrapper) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	// the Provider Data is nil when the List Resource is validated prior to the Provider being configured
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*clients.Client)
+	if !ok {
+		response.Diagnostics.AddError("Client Provider Data Error", fmt.Sprintf("expected `*clients.Client` but got %T", request.ProviderData))
+		return
+	}
+
+	w.client = client
+}
+
+// RawV5Schemas returns the Schema and Resource Identity Schema of the Plugin SDKv2 Resource being listed, since
+// the Resource isn't defined within the Framework Provider
+func (w *ListResourceWrapper) RawV5Schemas(ctx context.Context, _ list.RawV5SchemaRequest, response *list.RawV5SchemaResponse) {
+	// the response doesn't contain Diagnostics, so any error is surfaced from ListResourceConfigSchema instead
+	resourceSchema, identitySchema, err := w.rawV5Schemas(ctx)
+	if err != nil {
+		log.Printf("[ERROR] %+v", err)
+		return
+	}
+
+	response.ProtoV5Schema = resourceSchema
+	response.ProtoV5IdentitySchema = identitySchema
+}
+
+// rawV5Schemas returns the ProtoV5 Schema and Resource Identity Schema of the Plugin SDKv2 Resource being listed
+func (w *ListResourceWrapper) rawV5Schemas(ctx context.Context) (*tfprotov5.Schema, *tfprotov5.ResourceIdentitySchema, error) {
+	resourceType := w.listResource.ResourceType()
+	server := schema.NewGRPCProviderServer(&schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			resourceType: w.resource,
+		},
+	})
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving the Schema for %q: %+v", resourceType, err)
+	}
+	if err := protoDiagnosticsError(schemas.Diagnostics); err != nil {
+		return nil, nil, fmt.Errorf("retrieving the Schema for %q: %+v", resourceType, err)
+	}
+
+	identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving the Resource Identity Schema for %q: %+v", resourceType, err)
+	}
+	if err := protoDiagnosticsError(identitySchemas.Diagnostics); err != nil {
+		return nil, nil, fmt.Errorf("retrieving the Resource Identity Schema for %q: %+v", resourceType, err)
+	}
+
+	resourceSchema, ok := schemas.ResourceSchemas[resourceType]
+	if !ok || resourceSchema == nil {
+		return nil, nil, fmt.Errorf("the Schema for %q was not found", resourceType)
+	}
+	identitySchema, ok := identitySchemas.IdentitySchemas[resourceType]
+	if !ok || identitySchema == nil {
+		return nil, nil, fmt.Errorf("the Resource Identity Schema for %q was not found", resourceType)
+	}
+
+	return resourceSchema, identitySchema, nil
+}
+
+func (w *ListResourceWrapper) List(ctx context.Context, request list.ListRequest, stream *list.ListResultsStream) {
+	resourceType := w.listResource.ResourceType()
+	summary := fmt.Sprintf("Error listing %q", resourceType)
+
+	if w.client == nil {
+		stream.Results = list.ListResultsStreamDiagnostics(errorDiagnostics(summary, "the Provider has not been configured"))
+		return
+	}
+
+	scope, diags := w.scope(ctx, request)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	results, err := w.listResource.List(ctx, w.client, *scope)
+	if err != nil {
+		stream.Results = list.ListResultsStreamDiagnostics(errorDiagnostics(summary, err.Error()))
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		pushed := int64(0)
+		for _, item := range results {
+			if request.Limit > 0 && pushed >= request.Limit {
+				return
+			}
+
+			// instances whose ID can't be parsed (e.g. as it uses a different casing) can't be imported using their
+			// Resource Identity, so are skipped rather than ending the stream
+			values, err := w.listResource.IdentityType().ValuesFromId(item.ID)
+			if err != nil {
+				log.Printf("[WARN] parsing the Resource Identity from %q, skipping this %s: %+v", item.ID, resourceType, err)
+				continue
+			}
+
+			result := request.NewListResult(ctx)
+			result.DisplayName = item.DisplayName
+			for field, value := range values {
+				result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(field), value)...)
+			}
+
+			// instances deleted since they were listed are skipped, since these can no longer be imported
+			if request.IncludeResource && !w.read(ctx, item.ID, &result) {
+				continue
+			}
+
+			if !push(result) {
+				return
+			}
+			pushed++
+		}
+	}
+}
+
+// scope returns the scope in which the instances should be listed, from the `subscription_id` and
+// `resource_group_name` specified in the `list` block
+func (w *ListResourceWrapper) scope(ctx context.Context, request list.ListRequest) (*ListResourceScope, diag.Diagnostics) {
+	var subscriptionId types.String
+	diags := request.Config.GetAttribute(ctx, path.Root("subscription_id"), &subscriptionId)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	scope := ListResourceScope{
+		SubscriptionId: commonids.NewSubscriptionID(w.client.Account.SubscriptionId),
+	}
+	if v := subscriptionId.ValueString(); v != "" {
+		scope.SubscriptionId = commonids.NewSubscriptionID(v)
+	}
+
+	if w.listResource.SupportsResourceGroupScope() {
+		var resourceGroupName types.String
+		diags.Append(request.Config.GetAttribute(ctx, path.Root("resource_group_name"), &resourceGroupName)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if v := resourceGroupName.ValueString(); v != "" {
+			resourceGroupId := commonids.NewResourceGroupID(scope.SubscriptionId.SubscriptionId, v)
+			scope.ResourceGroupId = &resourceGroupId
+		}
+	}
+
+	return &scope, diags
+}
+
+// read populates the Resource within the result by reading the instance using the Plugin SDKv2 Resource, returning
+// false when the instance no longer exists
+func (w *ListResourceWrapper) read(ctx context.Context, id string, result *list.ListResult) bool {
+	summary := fmt.Sprintf("Error reading %q", w.listResource.ResourceType())
+
+	state, sdkDiags := w.resource.RefreshWithoutUpgrade(ctx, &terraform.InstanceState{
+		ID: id,
+		Attributes: map[string]string{
+			"id": id,
+		},
+	}, w.client)
+	for _, v := range sdkDiags {
+		if v.Severity == sdkdiag.Error {
+			result.Diagnostics.AddError(v.Summary, v.Detail)
+			continue
+		}
+		result.Diagnostics.AddWarning(v.Summary, v.Detail)
+	}
+	if sdkDiags.HasError() {
+		return true
+	}
+
+	// the instance has been deleted since it was listed
+	if state == nil || state.ID == "" {
+		log.Printf("[DEBUG] %q was not found, skipping this %s", id, w.listResource.ResourceType())
+		return false
+	}
+
+	ty := w.resource.CoreConfigSchema().ImpliedType()
+	value, err := state.AttrsAsObjectValue(ty)
+	if err != nil {
+		result.Diagnostics.AddError(summary, fmt.Sprintf("building the state for %q: %+v", id, err))
+		return true
+	}
+
+	raw, err := ctyValueToFramework(value, ty, result.Resource.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		result.Diagnostics.AddError(summary, fmt.Sprintf("converting the state for %q: %+v", id, err))
+		return true
+	}
+
+	result.Resource.Raw = raw
+	return true
+}
+
+// ctyValueToFramework converts the cty value used by Plugin SDKv2 into the Plugin Framework (tftypes) value
+//
+// Both representations share the same wire format (MessagePack) - so this is used to convert between the two.
+func ctyValueToFramework(input cty.Value, ty cty.Type, frameworkType tftypes.Type) (tftypes.Value, error) {
+	b, err := ctymsgpack.Marshal(input, ty)
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("marshaling the Plugin SDK value: %+v", err)
+	}
+
+	output, err := (&tfprotov5.DynamicValue{MsgPack: b}).Unmarshal(frameworkType)
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("unmarshaling the Plugin SDK value: %+v", err)
+	}
+
+	return output, nil
+}
+
+// protoDiagnosticsError returns an error containing any error Diagnostics returned from the Plugin SDKv2 Provider
+func protoDiagnosticsError(diags []*tfprotov5.Diagnostic) error {
+	errs := make([]string, 0)
+	for _, v := range diags {
+		if v != nil && v.Severity == tfprotov5.DiagnosticSeverityError {
+			errs = append(errs, fmt.Sprintf("%s: %s", v.Summary, v.Detail))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(errs, "; "))
+}
+
+func errorDiagnostics(summary, detail string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	diags.AddError(summary, detail)
+	return diags
+}
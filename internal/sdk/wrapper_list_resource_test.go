@@ -0,0 +1,314 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	pschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+const listTestSubscriptionId = "11111111-1111-1111-1111-111111111111"
+
+var (
+	_ provider.Provider                  = listTestProvider{}
+	_ provider.ProviderWithListResources = listTestProvider{}
+	_ ListResource                       = &listTestResource{}
+)
+
+// listTestProvider is a Plugin Framework Provider serving the test-only listTestResource via the
+// ListResourceWrapper, where the Resource being listed is a Plugin SDKv2 Resource which isn't served by this
+// Provider - so that the schemas are bridged using RawV5Schemas in the same way as for Terraform
+type listTestProvider struct {
+	listResource *listTestResource
+}
+
+func (p listTestProvider) Metadata(_ context.Context, _ provider.MetadataRequest, response *provider.MetadataResponse) {
+	response.TypeName = "azurerm"
+}
+
+func (p listTestProvider) Schema(_ context.Context, _ provider.SchemaRequest, response *provider.SchemaResponse) {
+	response.Schema = pschema.Schema{}
+}
+
+func (p listTestProvider) Configure(_ context.Context, _ provider.ConfigureRequest, response *provider.ConfigureResponse) {
+	response.ListResourceData = &clients.Client{
+		Account: &clients.ResourceManagerAccount{
+			SubscriptionId: listTestSubscriptionId,
+		},
+	}
+}
+
+func (p listTestProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p listTestProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{}
+}
+
+func (p listTestProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewListResourceWrapper(p.listResource, listTestSDKResource()),
+	}
+}
+
+// listTestResource lists the Resource Groups in results, recording the scope each listing was requested for
+type listTestResource struct {
+	resourceGroupScope bool
+	results            []ListResourceResult
+	scopes             []ListResourceScope
+}
+
+func (r *listTestResource) ResourceType() string {
+	return "azurerm_list_test"
+}
+
+func (r *listTestResource) IdentityType() pluginsdk.ResourceIdentityType {
+	return pluginsdk.NewResourceIdentityType(&commonids.ResourceGroupId{})
+}
+
+func (r *listTestResource) SupportsResourceGroupScope() bool {
+	return r.resourceGroupScope
+}
+
+func (r *listTestResource) List(_ context.Context, _ *clients.Client, scope ListResourceScope) ([]ListResourceResult, error) {
+	r.scopes = append(r.scopes, scope)
+	return r.results, nil
+}
+
+// listTestSDKResource returns the Plugin SDKv2 Resource being listed, whose Read populates `name` from the
+// Resource ID - and removes the Resource Group named `deleted` from the state
+func listTestSDKResource() *schema.Resource {
+	identityType := pluginsdk.NewResourceIdentityType(&commonids.ResourceGroupId{})
+	return &schema.Resource{
+		Identity: pluginsdk.NewResourceIdentity(identityType),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		ReadContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) sdkdiag.Diagnostics {
+			id, err := commonids.ParseResourceGroupID(d.Id())
+			if err != nil {
+				return sdkdiag.FromErr(err)
+			}
+			if id.ResourceGroupName == "deleted" {
+				d.SetId("")
+				return nil
+			}
+			return sdkdiag.FromErr(d.Set("name", id.ResourceGroupName))
+		},
+	}
+}
+
+func TestListResourceWrapper_List(t *testing.T) {
+	listResource := &listTestResource{
+		resourceGroupScope: true,
+		results: []ListResourceResult{
+			{
+				ID:          commonids.NewResourceGroupID(listTestSubscriptionId, "first").ID(),
+				DisplayName: "first",
+			},
+			{
+				// the casing of the segments differs, so the Resource Identity can't be parsed from this ID
+				ID:          fmt.Sprintf("/SUBSCRIPTIONS/%s/RESOURCEGROUPS/unparsable", listTestSubscriptionId),
+				DisplayName: "unparsable",
+			},
+			{
+				ID:          commonids.NewResourceGroupID(listTestSubscriptionId, "second").ID(),
+				DisplayName: "second",
+			},
+		},
+	}
+	server := listTestServer(t, listResource)
+	configType := listTestConfigType(t, server)
+
+	t.Log("Subscription scope..")
+	results := listTestList(t, server, configType, map[string]tftypes.Value{}, false)
+	if len(listResource.scopes) != 1 || listResource.scopes[0].SubscriptionId.SubscriptionId != listTestSubscriptionId || listResource.scopes[0].ResourceGroupId != nil {
+		t.Fatalf("expected the Resource Groups to be listed within the Subscription configured in the Provider but got %+v", listResource.scopes)
+	}
+	if len(results) != 2 || results[0].DisplayName != "first" || results[1].DisplayName != "second" {
+		t.Fatalf("expected the unparsable instance to be skipped but got %+v", results)
+	}
+
+	resourceType, identityType := listTestRawV5Types(t)
+	for _, result := range results {
+		frameworkTestAssertNoProtoDiagnostics(t, result.Diagnostics)
+		if result.Resource != nil {
+			t.Fatalf("expected the Resource not to be returned when it's not requested")
+		}
+		identity := listTestDecode(t, result.Identity.IdentityData, identityType)
+		if identity["subscription_id"] != listTestSubscriptionId || identity["resource_group_name"] != result.DisplayName {
+			t.Fatalf("expected the Resource Identity for %q but got %+v", result.DisplayName, identity)
+		}
+	}
+
+	t.Log("Resource Group scope..")
+	otherSubscriptionId := "22222222-2222-2222-2222-222222222222"
+	listTestList(t, server, configType, map[string]tftypes.Value{
+		"subscription_id":     tftypes.NewValue(tftypes.String, otherSubscriptionId),
+		"resource_group_name": tftypes.NewValue(tftypes.String, "example"),
+	}, false)
+	expected := commonids.NewResourceGroupID(otherSubscriptionId, "example")
+	if scope := listResource.scopes[1]; scope.SubscriptionId.SubscriptionId != otherSubscriptionId || scope.ResourceGroupId == nil || *scope.ResourceGroupId != expected {
+		t.Fatalf("expected the Resource Groups to be listed within %s but got %+v", expected, scope)
+	}
+
+	t.Log("Include Resource..")
+	listResource.results = append(listResource.results, ListResourceResult{
+		ID:          commonids.NewResourceGroupID(listTestSubscriptionId, "deleted").ID(),
+		DisplayName: "deleted",
+	})
+	results = listTestList(t, server, configType, map[string]tftypes.Value{}, true)
+	if len(results) != 2 {
+		t.Fatalf("expected the instance deleted since it was listed to be skipped but got %+v", results)
+	}
+	for _, result := range results {
+		frameworkTestAssertNoProtoDiagnostics(t, result.Diagnostics)
+		if result.Resource == nil {
+			t.Fatalf("expected the Resource to be returned for %q", result.DisplayName)
+		}
+		state := listTestDecode(t, result.Resource, resourceType)
+		if state["id"] != commonids.NewResourceGroupID(listTestSubscriptionId, result.DisplayName).ID() || state["name"] != result.DisplayName {
+			t.Fatalf("expected the Resource read using the Plugin SDKv2 Resource for %q but got %+v", result.DisplayName, state)
+		}
+	}
+
+	t.Log("Limit..")
+	if results := listTestList(t, server, configType, map[string]tftypes.Value{}, false, 1); len(results) != 1 {
+		t.Fatalf("expected the results to be limited to 1 but got %d", len(results))
+	}
+}
+
+func TestListResourceWrapper_SubscriptionScopeOnly(t *testing.T) {
+	server := listTestServer(t, &listTestResource{})
+	configType := listTestConfigType(t, server).(tftypes.Object)
+
+	if _, ok := configType.AttributeTypes["subscription_id"]; !ok {
+		t.Fatalf("expected the `subscription_id` attribute to be defined")
+	}
+	if _, ok := configType.AttributeTypes["resource_group_name"]; ok {
+		t.Fatalf("expected the `resource_group_name` attribute not to be defined when the Resource Group scope isn't supported")
+	}
+}
+
+func listTestServer(t *testing.T, listResource *listTestResource) tfprotov5.ProviderServerWithListResource {
+	providerServer, err := providerserver.NewProtocol5WithError(listTestProvider{listResource: listResource})()
+	if err != nil {
+		t.Fatalf("building the provider server: %+v", err)
+	}
+	server, ok := providerServer.(tfprotov5.ProviderServerWithListResource)
+	if !ok {
+		t.Fatalf("expected the provider server to serve List Resources")
+	}
+
+	configType := pschema.Schema{}.Type().TerraformType(context.TODO())
+	configureResponse, err := server.ConfigureProvider(context.TODO(), &tfprotov5.ConfigureProviderRequest{
+		Config: frameworkTestDynamicValue(t, configType, tftypes.NewValue(configType, map[string]tftypes.Value{})),
+	})
+	if err != nil {
+		t.Fatalf("configuring the provider: %+v", err)
+	}
+	frameworkTestAssertNoProtoDiagnostics(t, configureResponse.Diagnostics)
+
+	return server
+}
+
+func listTestConfigType(t *testing.T, server tfprotov5.ProviderServerWithListResource) tftypes.Type {
+	schemas, err := server.GetProviderSchema(context.TODO(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the provider schema: %+v", err)
+	}
+	frameworkTestAssertNoProtoDiagnostics(t, schemas.Diagnostics)
+
+	listSchema, ok := schemas.ListResourceSchemas["azurerm_list_test"]
+	if !ok {
+		t.Fatalf("expected `azurerm_list_test` to be served as a List Resource")
+	}
+	return listSchema.ValueType()
+}
+
+// listTestRawV5Types returns the types of the Plugin SDKv2 Resource and its Resource Identity, which are bridged
+// using RawV5Schemas since the Resource isn't served by the Provider
+func listTestRawV5Types(t *testing.T) (tftypes.Type, tftypes.Type) {
+	response := &list.RawV5SchemaResponse{}
+	(&ListResourceWrapper{
+		listResource: &listTestResource{},
+		resource:     listTestSDKResource(),
+	}).RawV5Schemas(context.TODO(), list.RawV5SchemaRequest{}, response)
+	if response.ProtoV5Schema == nil || response.ProtoV5IdentitySchema == nil {
+		t.Fatalf("expected the Schema and Resource Identity Schema to be returned")
+	}
+	return response.ProtoV5Schema.ValueType(), response.ProtoV5IdentitySchema.ValueType()
+}
+
+func listTestList(t *testing.T, server tfprotov5.ProviderServerWithListResource, configType tftypes.Type, config map[string]tftypes.Value, includeResource bool, limit ...int64) []tfprotov5.ListResourceResult {
+	request := &tfprotov5.ListResourceRequest{
+		TypeName:        "azurerm_list_test",
+		Config:          frameworkTestDynamicValue(t, configType, frameworkTestObject(configType, config)),
+		IncludeResource: includeResource,
+		Limit:           100,
+	}
+	if len(limit) > 0 {
+		request.Limit = limit[0]
+	}
+
+	stream, err := server.ListResource(context.TODO(), request)
+	if err != nil {
+		t.Fatalf("listing: %+v", err)
+	}
+
+	results := make([]tfprotov5.ListResourceResult, 0)
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+	return results
+}
+
+// listTestDecode decodes the (object) value, returning the value of each known String attribute
+func listTestDecode(t *testing.T, input *tfprotov5.DynamicValue, ty tftypes.Type) map[string]string {
+	if input == nil {
+		t.Fatalf("expected a value but got nil")
+	}
+
+	value, err := input.Unmarshal(ty)
+	if err != nil {
+		t.Fatalf("unmarshaling the value: %+v", err)
+	}
+
+	attributes := make(map[string]tftypes.Value)
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("converting the value: %+v", err)
+	}
+
+	out := make(map[string]string)
+	for k, v := range attributes {
+		if !v.Type().Is(tftypes.String) || !v.IsKnown() || v.IsNull() {
+			continue
+		}
+		var s string
+		if err := v.As(&s); err != nil {
+			t.Fatalf("converting %q: %+v", k, err)
+		}
+		out[k] = s
+	}
+	return out
+}
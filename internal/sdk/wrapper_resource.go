@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -47,6 +48,20 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		return &duration
 	}
 
+	identityType := ResourceIdentityTypeFor(rw.resource)
+	read := func(ctx context.Context, metaData ResourceMetaData) error {
+		if err := rw.resource.Read().Func(ctx, metaData); err != nil {
+			return err
+		}
+
+		// the Resource Identity is derived from the Resource ID, so is set once the Resource has been read
+		if metaData.ResourceData.Id() != "" {
+			return pluginsdk.SetResourceIdentity(metaData.ResourceData, identityType)
+		}
+
+		return nil
+	}
+
 	resource := schema.Resource{
 		Schema: *resourceSchema,
 
@@ -59,25 +74,27 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			// NOTE: whilst this may look like we should use the Read
 			// functions timeout here, we're still /technically/ in the
 			// Create function so reusing that timeout should be sufficient
-			return rw.resource.Read().Func(ctx, metaData)
+			return read(ctx, metaData)
 		}),
 
 		// looks like these could be reused, easiest if they're not
 		ReadContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
-			return rw.resource.Read().Func(ctx, metaData)
+			return read(ctx, metaData)
 		}),
 		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
 			return rw.resource.Delete().Func(ctx, metaData)
 		}),
 
+		Identity: pluginsdk.NewResourceIdentity(identityType),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: d(rw.resource.Create().Timeout),
 			Read:   d(rw.resource.Read().Timeout),
 			Delete: d(rw.resource.Delete().Timeout),
 		},
-		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
+		Importer: pluginsdk.ImporterValidatingResourceIdOrIdentityThen(identityType, func(id string) error {
 			fn := rw.resource.IDValidationFunc()
 			warnings, errors := fn(id, "id")
 			if len(warnings) > 0 {
@@ -90,7 +107,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 				for _, err := range errors {
 					out += err.Error()
 				}
-				return fmt.Errorf(out)
+				return fmt.Errorf("%s", out)
 			}
 
 			return nil
@@ -98,7 +115,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			if v, ok := rw.resource.(ResourceWithCustomImporter); ok {
 				metaData := runArgs(d, meta, rw.logger)
 
-				ctx, cancel := context.WithTimeout(ctx, rw.resource.Read().Timeout)
+				ctx, cancel := context.WithTimeout(ctx, metaData.Client.DefaultTimeouts.For(rw.resource.ResourceType(), pluginsdk.TimeoutRead, rw.resource.Read().Timeout))
 				defer cancel()
 				err := v.CustomImporter()(ctx, metaData)
 				if err != nil {
@@ -125,7 +142,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 			// whilst this may look like we should use the Update timeout here
 			// we're still "technically" in the update method, so reusing the
 			// Update's timeout should be fine
-			return rw.resource.Read().Func(ctx, metaData)
+			return read(ctx, metaData)
 		})
 		resource.Timeouts.Update = d(v.Update().Timeout)
 	}
@@ -183,7 +200,25 @@ and we recommend using the %[2]q resource instead.
 }
 
 func (rw *ResourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diagnosticsWrapper(in, rw.logger)
+	wrapped := diagnosticsWrapper(in, rw.logger)
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		// record the resource acquiring any locks, so that the holder of a lock can be identified
+		address := rw.resource.ResourceType()
+		if id := d.Id(); id != "" {
+			address = fmt.Sprintf("%s (%s)", address, id)
+		}
+		ctx = locks.WithResourceAddress(ctx, address)
+
+		out := wrapped(ctx, d, meta)
+		for _, warning := range locks.Warnings(ctx) {
+			out = append(out, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Lock warning whilst modifying %s", rw.resource.ResourceType()),
+				Detail:   warning,
+			})
+		}
+		return out
+	}
 }
 
 func diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error, logger Logger) func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -21,6 +21,13 @@ func (r Registration) Name() string {
 	return "Advisor"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Advisor",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
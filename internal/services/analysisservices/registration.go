@@ -21,6 +21,13 @@ func (r Registration) Name() string {
 	return "Analysis Services"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.AnalysisServices",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apimanagement
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/apimanagement/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/apimanagement/validate"
+)
+
+func init() {
+	sdk.RegisterLegacyResourceIdentityType(validate.NotificationRecipientEmailID, parse.NotificationRecipientEmailID)
+	sdk.RegisterLegacyResourceIdentityType(validate.NotificationRecipientUserID, parse.NotificationRecipientUserID)
+}
@@ -38,6 +38,13 @@ func (r Registration) Name() string {
 	return "App Configuration"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.AppConfiguration",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package applicationinsights
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	workbooktemplates "github.com/hashicorp/go-azure-sdk/resource-manager/applicationinsights/2020-11-20/workbooktemplatesapis"
+	workbooks "github.com/hashicorp/go-azure-sdk/resource-manager/applicationinsights/2022-04-01/workbooksapis"
+	webtests "github.com/hashicorp/go-azure-sdk/resource-manager/applicationinsights/2022-06-15/webtestsapis"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(workbooktemplates.ValidateWorkbookTemplateID, pluginsdk.NewResourceIdentityType(&workbooktemplates.WorkbookTemplateId{}))
+	sdk.RegisterResourceIdentityType(workbooks.ValidateWorkbookID, pluginsdk.NewResourceIdentityType(&workbooks.WorkbookId{}))
+	sdk.RegisterResourceIdentityType(webtests.ValidateWebTestID, pluginsdk.NewResourceIdentityType(&webtests.WebTestId{}))
+}
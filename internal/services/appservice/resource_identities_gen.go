@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package appservice
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/web/2023-01-01/staticsites"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/web/2023-01-01/webapps"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/web/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/web/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(commonids.ValidateAppServiceID, pluginsdk.NewResourceIdentityType(&commonids.AppServiceId{}))
+	sdk.RegisterResourceIdentityType(commonids.ValidateAppServicePlanID, pluginsdk.NewResourceIdentityType(&commonids.AppServicePlanId{}))
+	sdk.RegisterResourceIdentityType(commonids.ValidateFunctionAppID, pluginsdk.NewResourceIdentityType(&commonids.FunctionAppId{}))
+	sdk.RegisterResourceIdentityType(staticsites.ValidateCustomDomainID, pluginsdk.NewResourceIdentityType(&staticsites.CustomDomainId{}))
+	sdk.RegisterResourceIdentityType(staticsites.ValidateStaticSiteID, pluginsdk.NewResourceIdentityType(&staticsites.StaticSiteId{}))
+	sdk.RegisterResourceIdentityType(staticsites.ValidateUserProvidedFunctionAppID, pluginsdk.NewResourceIdentityType(&staticsites.UserProvidedFunctionAppId{}))
+	sdk.RegisterResourceIdentityType(webapps.ValidateFunctionID, pluginsdk.NewResourceIdentityType(&webapps.FunctionId{}))
+	sdk.RegisterResourceIdentityType(webapps.ValidateRelayID, pluginsdk.NewResourceIdentityType(&webapps.RelayId{}))
+	sdk.RegisterResourceIdentityType(webapps.ValidateSlotID, pluginsdk.NewResourceIdentityType(&webapps.SlotId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.AppServiceEnvironmentID, parse.AppServiceEnvironmentID)
+}
@@ -905,10 +905,12 @@ func (r WindowsFunctionAppSlotResource) Update() sdk.ResourceFunc {
 				if err != nil {
 					return err
 				}
-				locks.ByID(oldPlan.ID())
-				defer locks.UnlockByID(oldPlan.ID())
-				locks.ByID(newPlan.ID())
-				defer locks.UnlockByID(newPlan.ID())
+				// the Service Plans are locked in a consistent order, so that moving Slots between the same Service Plans in each direction can't deadlock
+				planIds := []string{oldPlan.ID(), newPlan.ID()}
+				if err := locks.MultipleByIDWithContext(ctx, &planIds); err != nil {
+					return err
+				}
+				defer locks.UnlockMultipleByID(&planIds)
 				if model.Properties == nil {
 					return fmt.Errorf("updating Service Plan for Windows %s: Slot SiteProperties was nil", *id)
 				}
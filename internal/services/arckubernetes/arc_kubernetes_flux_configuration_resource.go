@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	arckubernetes "github.com/hashicorp/go-azure-sdk/resource-manager/hybridkubernetes/2024-01-01/connectedclusters"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/fluxconfiguration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
@@ -100,7 +101,8 @@ type KustomizationDefinitionModel struct {
 type ArcKubernetesFluxConfigurationResource struct{}
 
 var (
-	_ sdk.ResourceWithUpdate = ArcKubernetesFluxConfigurationResource{}
+	_ sdk.ResourceWithUpdate   = ArcKubernetesFluxConfigurationResource{}
+	_ sdk.ResourceWithIdentity = ArcKubernetesFluxConfigurationResource{}
 )
 
 func (r ArcKubernetesFluxConfigurationResource) ResourceType() string {
@@ -135,6 +137,10 @@ func (r ArcKubernetesFluxConfigurationResource) IDValidationFunc() pluginsdk.Sch
 	}
 }
 
+func (r ArcKubernetesFluxConfigurationResource) Identity() resourceids.ResourceId {
+	return &fluxconfiguration.ScopedFluxConfigurationId{}
+}
+
 func (r ArcKubernetesFluxConfigurationResource) Arguments() map[string]*pluginsdk.Schema {
 	return map[string]*pluginsdk.Schema{
 		"name": {
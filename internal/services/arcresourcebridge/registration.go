@@ -19,6 +19,13 @@ func (r Registration) Name() string {
 	return "Arc Resource Bridge"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ResourceConnector",
+	}
+}
+
 func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{
 		ArcResourceBridgeApplianceDataSource{},
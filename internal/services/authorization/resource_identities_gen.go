@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authorization
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/authorization/2020-10-01/rolemanagementpolicies"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/authorization/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/authorization/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(rolemanagementpolicies.ValidateScopedRoleManagementPolicyID, pluginsdk.NewResourceIdentityType(&rolemanagementpolicies.ScopedRoleManagementPolicyId{}))
+	sdk.RegisterLegacyResourceIdentityType(parse.ValidateRoleManagementPolicyId, parse.RoleManagementPolicyID)
+	sdk.RegisterLegacyResourceIdentityType(validate.PimRoleAssignmentID, parse.PimRoleAssignmentID)
+}
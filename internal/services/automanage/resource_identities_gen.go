@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package automanage
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofileassignments"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofiles"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(configurationprofileassignments.ValidateVirtualMachineProviders2ConfigurationProfileAssignmentID, pluginsdk.NewResourceIdentityType(&configurationprofileassignments.VirtualMachineProviders2ConfigurationProfileAssignmentId{}))
+	sdk.RegisterResourceIdentityType(configurationprofiles.ValidateConfigurationProfileID, pluginsdk.NewResourceIdentityType(&configurationprofiles.ConfigurationProfileId{}))
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package automation
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2019-06-01/softwareupdateconfiguration"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2020-01-13-preview/watcher"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2023-11-01/connectiontype"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2023-11-01/hybridrunbookworker"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2023-11-01/hybridrunbookworkergroup"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2023-11-01/module"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2023-11-01/python3package"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2023-11-01/sourcecontrol"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2023-11-01/variable"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(softwareupdateconfiguration.ValidateSoftwareUpdateConfigurationID, pluginsdk.NewResourceIdentityType(&softwareupdateconfiguration.SoftwareUpdateConfigurationId{}))
+	sdk.RegisterResourceIdentityType(watcher.ValidateWatcherID, pluginsdk.NewResourceIdentityType(&watcher.WatcherId{}))
+	sdk.RegisterResourceIdentityType(connectiontype.ValidateConnectionTypeID, pluginsdk.NewResourceIdentityType(&connectiontype.ConnectionTypeId{}))
+	sdk.RegisterResourceIdentityType(hybridrunbookworker.ValidateHybridRunbookWorkerID, pluginsdk.NewResourceIdentityType(&hybridrunbookworker.HybridRunbookWorkerId{}))
+	sdk.RegisterResourceIdentityType(hybridrunbookworkergroup.ValidateHybridRunbookWorkerGroupID, pluginsdk.NewResourceIdentityType(&hybridrunbookworkergroup.HybridRunbookWorkerGroupId{}))
+	sdk.RegisterResourceIdentityType(module.ValidatePowerShell72ModuleID, pluginsdk.NewResourceIdentityType(&module.PowerShell72ModuleId{}))
+	sdk.RegisterResourceIdentityType(python3package.ValidatePython3PackageID, pluginsdk.NewResourceIdentityType(&python3package.Python3PackageId{}))
+	sdk.RegisterResourceIdentityType(sourcecontrol.ValidateSourceControlID, pluginsdk.NewResourceIdentityType(&sourcecontrol.SourceControlId{}))
+	sdk.RegisterResourceIdentityType(variable.ValidateAutomationAccountID, pluginsdk.NewResourceIdentityType(&variable.AutomationAccountId{}))
+}
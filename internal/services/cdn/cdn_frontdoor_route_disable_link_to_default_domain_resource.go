@@ -86,7 +86,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainCreate(d *pluginsdk.Reso
 
 	id := parse.NewFrontDoorRouteDisableLinkToDefaultDomainID(routeId.SubscriptionId, routeId.ResourceGroup, routeId.ProfileName, routeId.AfdEndpointName, routeId.RouteName, uuid)
 
-	locks.ByName(routeId.RouteName, cdnFrontDoorRouteResourceName)
+	if err := locks.ByNameWithContext(routeCtx, routeId.RouteName, cdnFrontDoorRouteResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(routeId.RouteName, cdnFrontDoorRouteResourceName)
 
 	for _, v := range customDomains {
@@ -95,7 +97,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainCreate(d *pluginsdk.Reso
 			return fmt.Errorf("creating %s: %+v", id, err)
 		}
 
-		locks.ByName(customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName)
+		if err := locks.ByNameWithContext(routeCtx, customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName)
 	}
 
@@ -228,7 +232,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainUpdate(d *pluginsdk.Reso
 			return err
 		}
 
-		locks.ByName(routeId.RouteName, cdnFrontDoorRouteResourceName)
+		if err := locks.ByNameWithContext(routeCtx, routeId.RouteName, cdnFrontDoorRouteResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(routeId.RouteName, cdnFrontDoorRouteResourceName)
 
 		for _, v := range customDomains {
@@ -237,7 +243,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainUpdate(d *pluginsdk.Reso
 				return fmt.Errorf("updating %s: %+v", id, err)
 			}
 
-			locks.ByName(customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName)
+			if err := locks.ByNameWithContext(routeCtx, customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(customDomainId.CustomDomainName, cdnFrontDoorCustomDomainResourceName)
 		}
 
@@ -324,7 +332,9 @@ func resourceCdnFrontDoorRouteDisableLinkToDefaultDomainDelete(d *pluginsdk.Reso
 		return err
 	}
 
-	locks.ByName(route.RouteName, cdnFrontDoorRouteResourceName)
+	if err := locks.ByNameWithContext(ctx, route.RouteName, cdnFrontDoorRouteResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(route.RouteName, cdnFrontDoorRouteResourceName)
 
 	resp, err := client.Get(ctx, route.ResourceGroup, route.ProfileName, route.AfdEndpointName, route.RouteName)
@@ -414,7 +414,9 @@ func resourceCdnFrontDoorRouteUpdate(d *pluginsdk.ResourceData, meta interface{}
 
 	// we need to lock the route for update because the custom domain
 	// association may also be trying to update the route as well...
-	locks.ByName(id.RouteName, cdnFrontDoorRouteResourceName)
+	if err := locks.ByNameWithContext(ctx, id.RouteName, cdnFrontDoorRouteResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.RouteName, cdnFrontDoorRouteResourceName)
 
 	httpsRedirect := d.Get("https_redirect_enabled").(bool)
@@ -16,6 +16,13 @@ func (r Registration) Name() string {
 	return r.autoRegistration.Name()
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Chaos",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return r.autoRegistration.WebsiteCategories()
@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package chaosstudio
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/chaosstudio/2023-11-01/experiments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(commonids.ValidateChaosStudioCapabilityID, pluginsdk.NewResourceIdentityType(&commonids.ChaosStudioCapabilityId{}))
+	sdk.RegisterResourceIdentityType(commonids.ValidateChaosStudioTargetID, pluginsdk.NewResourceIdentityType(&commonids.ChaosStudioTargetId{}))
+	sdk.RegisterResourceIdentityType(experiments.ValidateExperimentID, pluginsdk.NewResourceIdentityType(&experiments.ExperimentId{}))
+}
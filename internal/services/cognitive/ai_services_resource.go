@@ -318,7 +318,9 @@ func (AzureAIServicesResource) Create() sdk.ResourceFunc {
 				}
 			}
 
-			locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+			if err := locks.MultipleByNameWithContext(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 			props := cognitiveservicesaccounts.Account{
@@ -472,7 +474,9 @@ func (AzureAIServicesResource) Update() sdk.ResourceFunc {
 			props := resp.Model
 			if metadata.ResourceData.HasChange("network_acls") {
 				networkACLs, subnetIds := expandAzureAIServicesNetworkACLs(model.NetworkACLs)
-				locks.MultipleByName(&subnetIds, network.VirtualNetworkResourceName)
+				if err := locks.MultipleByNameWithContext(ctx, &subnetIds, network.VirtualNetworkResourceName); err != nil {
+					return err
+				}
 				defer locks.UnlockMultipleByName(&subnetIds, network.VirtualNetworkResourceName)
 
 				// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
@@ -487,7 +491,9 @@ func (AzureAIServicesResource) Update() sdk.ResourceFunc {
 					}
 				}
 
-				locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+				if err := locks.MultipleByNameWithContext(ctx, &virtualNetworkNames, network.VirtualNetworkResourceName); err != nil {
+					return err
+				}
 				defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
 
 				props.Properties.NetworkAcls = networkACLs
@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cognitive
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/cognitive/2023-05-01/cognitiveservicesaccounts"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/cognitive/2023-05-01/deployments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(cognitiveservicesaccounts.ValidateAccountID, pluginsdk.NewResourceIdentityType(&cognitiveservicesaccounts.AccountId{}))
+	sdk.RegisterResourceIdentityType(deployments.ValidateDeploymentID, pluginsdk.NewResourceIdentityType(&deployments.DeploymentId{}))
+}
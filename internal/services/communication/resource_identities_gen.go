@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package communication
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/communication/2023-03-31/communicationservices"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/communication/2023-03-31/domains"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/communication/2023-03-31/emailservices"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(communicationservices.ValidateCommunicationServiceID, pluginsdk.NewResourceIdentityType(&communicationservices.CommunicationServiceId{}))
+	sdk.RegisterResourceIdentityType(domains.ValidateDomainID, pluginsdk.NewResourceIdentityType(&domains.DomainId{}))
+	sdk.RegisterResourceIdentityType(emailservices.ValidateEmailServiceID, pluginsdk.NewResourceIdentityType(&emailservices.EmailServiceId{}))
+}
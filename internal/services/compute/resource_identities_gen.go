@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-03/galleryapplications"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-03/galleryapplicationversions"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2023-03-01/virtualmachineruncommands"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/restorepointcollections"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/restorepoints"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(galleryapplications.ValidateApplicationID, pluginsdk.NewResourceIdentityType(&galleryapplications.ApplicationId{}))
+	sdk.RegisterResourceIdentityType(galleryapplicationversions.ValidateApplicationVersionID, pluginsdk.NewResourceIdentityType(&galleryapplicationversions.ApplicationVersionId{}))
+	sdk.RegisterResourceIdentityType(virtualmachineruncommands.ValidateVirtualMachineRunCommandID, pluginsdk.NewResourceIdentityType(&virtualmachineruncommands.VirtualMachineRunCommandId{}))
+	sdk.RegisterResourceIdentityType(restorepointcollections.ValidateRestorePointCollectionID, pluginsdk.NewResourceIdentityType(&restorepointcollections.RestorePointCollectionId{}))
+	sdk.RegisterResourceIdentityType(restorepoints.ValidateRestorePointID, pluginsdk.NewResourceIdentityType(&restorepoints.RestorePointId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.DataDiskID, parse.DataDiskID)
+}
@@ -20,6 +20,13 @@ func (r Registration) Name() string {
 	return "Connections"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Web",
+	}
+}
+
 func (r Registration) WebsiteCategories() []string {
 	return []string{
 		"Connections",
@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containerapps
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2023-05-01/certificates"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2023-05-01/containerapps"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2023-05-01/daprcomponents"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2023-05-01/jobs"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2023-05-01/managedenvironmentsstorages"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerapps/2024-03-01/managedenvironments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(certificates.ValidateCertificateID, pluginsdk.NewResourceIdentityType(&certificates.CertificateId{}))
+	sdk.RegisterResourceIdentityType(containerapps.ValidateContainerAppID, pluginsdk.NewResourceIdentityType(&containerapps.ContainerAppId{}))
+	sdk.RegisterResourceIdentityType(daprcomponents.ValidateDaprComponentID, pluginsdk.NewResourceIdentityType(&daprcomponents.DaprComponentId{}))
+	sdk.RegisterResourceIdentityType(jobs.ValidateJobID, pluginsdk.NewResourceIdentityType(&jobs.JobId{}))
+	sdk.RegisterResourceIdentityType(managedenvironmentsstorages.ValidateStorageID, pluginsdk.NewResourceIdentityType(&managedenvironmentsstorages.StorageId{}))
+	sdk.RegisterResourceIdentityType(managedenvironments.ValidateManagedEnvironmentID, pluginsdk.NewResourceIdentityType(&managedenvironments.ManagedEnvironmentId{}))
+	sdk.RegisterResourceIdentityType(validate.ContainerAppCustomDomainId, pluginsdk.NewResourceIdentityType(&parse.ContainerAppCustomDomainId{}))
+}
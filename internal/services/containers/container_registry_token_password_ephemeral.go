@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2021-08-01-preview/registries"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2021-08-01-preview/tokens"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ContainerRegistryTokenPasswordEphemeralResource{}
+
+func NewContainerRegistryTokenPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &ContainerRegistryTokenPasswordEphemeralResource{}
+}
+
+// ContainerRegistryTokenPasswordEphemeralResource generates a new password for a Container Registry Token each time
+// it's opened, which (unlike the `azurerm_container_registry_token_password` Resource) isn't persisted to the State.
+//
+// Container Registry only returns the value of a password when it's generated, so there's no way to retrieve an
+// existing password - as such opening this rotates the password, which must be acknowledged using `rotate_password`.
+type ContainerRegistryTokenPasswordEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type ContainerRegistryTokenPasswordEphemeralResourceModel struct {
+	TokenId types.String `tfsdk:"container_registry_token_id"`
+	Name    types.String `tfsdk:"name"`
+	Expiry  types.String `tfsdk:"expiry"`
+	Value   types.String `tfsdk:"value"`
+	Rotate  types.Bool   `tfsdk:"rotate_password"`
+}
+
+func (e *ContainerRegistryTokenPasswordEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = "azurerm_container_registry_token_password"
+}
+
+func (e *ContainerRegistryTokenPasswordEphemeralResource) Configure(_ context.Context, request ephemeral.ConfigureRequest, response *ephemeral.ConfigureResponse) {
+	e.Defaults(request, response)
+}
+
+func (e *ContainerRegistryTokenPasswordEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"container_registry_token_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: tokens.ValidateTokenID,
+					},
+				},
+			},
+
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Defaults to `password1`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(registries.PossibleValuesForTokenPasswordName()...),
+				},
+			},
+
+			"rotate_password": schema.BoolAttribute{
+				Required:    true,
+				Description: "Must be `true`, acknowledging that the existing password with this `name` is replaced each time this is opened.",
+			},
+
+			"expiry": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validation.IsRFC3339Time,
+					},
+				},
+			},
+
+			"value": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (e *ContainerRegistryTokenPasswordEphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	if !e.ClientConfigured(&response.Diagnostics) {
+		return
+	}
+
+	client := e.Client.Containers.ContainerRegistryClient_v2021_08_01_preview.Registries
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+
+	var data ContainerRegistryTokenPasswordEphemeralResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// the value of an existing password can't be retrieved, so a new password is generated - which replaces the
+	// existing password and as such must be explicitly opted into
+	if !data.Rotate.ValueBool() {
+		response.Diagnostics.AddAttributeError(path.Root("rotate_password"), "Password Rotation Not Enabled", "Container Registry only returns the value of a password when it's generated, so opening this Ephemeral Resource replaces the existing password with the same `name` - set `rotate_password` to `true` to acknowledge this")
+		return
+	}
+
+	tokenId, err := tokens.ParseTokenID(data.TokenId.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Error parsing Container Registry Token ID", err.Error())
+		return
+	}
+
+	name := registries.TokenPasswordNamePasswordOne
+	if v := data.Name.ValueString(); v != "" {
+		name = registries.TokenPasswordName(v)
+	}
+
+	param := registries.GenerateCredentialsParameters{
+		TokenId: pointer.To(tokenId.ID()),
+		Name:    pointer.To(name),
+	}
+	if v := data.Expiry.ValueString(); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			response.Diagnostics.AddError("Error parsing `expiry`", err.Error())
+			return
+		}
+		param.Expiry = pointer.To(date.Time{Time: t}.String())
+	}
+
+	if err := locks.ByIDWithContext(ctx, tokenId.ID()); err != nil {
+		response.Diagnostics.AddError("Error generating Container Registry Token Password", fmt.Sprintf("locking %s: %+v", *tokenId, err))
+		return
+	}
+	defer locks.UnlockByID(tokenId.ID())
+
+	registryId := registries.NewRegistryID(tokenId.SubscriptionId, tokenId.ResourceGroupName, tokenId.RegistryName)
+	result, err := client.GenerateCredentials(ctx, registryId, param)
+	if err != nil {
+		response.Diagnostics.AddError("Error generating Container Registry Token Password", fmt.Sprintf("generating password credential %s for %s: %+v", string(name), *tokenId, err))
+		return
+	}
+
+	if err := result.Poller.PollUntilDone(ctx); err != nil {
+		response.Diagnostics.AddError("Error generating Container Registry Token Password", fmt.Sprintf("polling generation of password credential %s for %s: %+v", string(name), *tokenId, err))
+		return
+	}
+
+	var res registries.GenerateCredentialsResult
+	if err := json.NewDecoder(result.HttpResponse.Body).Decode(&res); err != nil {
+		response.Diagnostics.AddError("Error generating Container Registry Token Password", fmt.Sprintf("decoding generated password credentials: %+v", err))
+		return
+	}
+
+	data.Value = types.StringNull()
+	if res.Passwords != nil {
+		for _, password := range *res.Passwords {
+			if pointer.From(password.Name) == name {
+				data.Value = types.StringPointerValue(password.Value)
+			}
+		}
+	}
+	if data.Value.IsNull() {
+		response.Diagnostics.AddError("Error generating Container Registry Token Password", fmt.Sprintf("the password credential %s for %s was not returned", string(name), *tokenId))
+		return
+	}
+
+	response.Diagnostics.Append(response.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+)
+
+type ContainerRegistryTokenPasswordEphemeralResource struct{}
+
+func TestAccEphemeralContainerRegistryTokenPassword_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_container_registry_token_password", "test")
+	r := ContainerRegistryTokenPasswordEphemeralResource{}
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+		},
+	})
+}
+
+func TestAccEphemeralContainerRegistryTokenPassword_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_container_registry_token_password", "test")
+	r := ContainerRegistryTokenPasswordEphemeralResource{}
+	expiry := time.Now().UTC().Add(time.Hour).Format(time.RFC3339)
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.complete(data, expiry),
+		},
+	})
+}
+
+func (ContainerRegistryTokenPasswordEphemeralResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_container_registry_token_password" "test" {
+  container_registry_token_id = azurerm_container_registry_token.test.id
+  rotate_password             = true
+
+  lifecycle {
+    postcondition {
+      condition     = self.value != ""
+      error_message = "no password was generated"
+    }
+  }
+}
+`, ContainerRegistryTokenPasswordResource{}.template(data))
+}
+
+func (ContainerRegistryTokenPasswordEphemeralResource) complete(data acceptance.TestData, expiry string) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_container_registry_token_password" "test" {
+  container_registry_token_id = azurerm_container_registry_token.test.id
+  rotate_password             = true
+  name                        = "password2"
+  expiry                      = "%s"
+
+  lifecycle {
+    postcondition {
+      condition     = self.value != ""
+      error_message = "no password was generated"
+    }
+  }
+}
+`, ContainerRegistryTokenPasswordResource{}.template(data), expiry)
+}
@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourcegroups"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &KubernetesClusterEphemeralResource{}
+
+func NewKubernetesClusterEphemeralResource() ephemeral.EphemeralResource {
+	return &KubernetesClusterEphemeralResource{}
+}
+
+// KubernetesClusterEphemeralResource retrieves the credentials for a Kubernetes Cluster, which (unlike
+// the `kube_config` attributes within the `azurerm_kubernetes_cluster` Data Source) aren't persisted to the State.
+type KubernetesClusterEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type KubernetesClusterEphemeralResourceModel struct {
+	Name               types.String                           `tfsdk:"name"`
+	ResourceGroupName  types.String                           `tfsdk:"resource_group_name"`
+	KubeConfig         []KubernetesClusterEphemeralKubeConfig `tfsdk:"kube_config"`
+	KubeConfigRaw      types.String                           `tfsdk:"kube_config_raw"`
+	KubeAdminConfig    []KubernetesClusterEphemeralKubeConfig `tfsdk:"kube_admin_config"`
+	KubeAdminConfigRaw types.String                           `tfsdk:"kube_admin_config_raw"`
+}
+
+type KubernetesClusterEphemeralKubeConfig struct {
+	Host                 string `tfsdk:"host"`
+	Username             string `tfsdk:"username"`
+	Password             string `tfsdk:"password"`
+	ClientCertificate    string `tfsdk:"client_certificate"`
+	ClientKey            string `tfsdk:"client_key"`
+	ClusterCaCertificate string `tfsdk:"cluster_ca_certificate"`
+}
+
+func (e *KubernetesClusterEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = "azurerm_kubernetes_cluster"
+}
+
+func (e *KubernetesClusterEphemeralResource) Configure(_ context.Context, request ephemeral.ConfigureRequest, response *ephemeral.ConfigureResponse) {
+	e.Defaults(request, response)
+}
+
+func (e *KubernetesClusterEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	kubeConfigSchema := func() schema.ListNestedAttribute {
+		return schema.ListNestedAttribute{
+			Computed:  true,
+			Sensitive: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"username": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"password": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"client_certificate": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"client_key": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"cluster_ca_certificate": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		}
+	}
+
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"resource_group_name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: resourcegroups.ValidateName,
+					},
+				},
+			},
+
+			"kube_config": kubeConfigSchema(),
+
+			"kube_config_raw": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"kube_admin_config": kubeConfigSchema(),
+
+			"kube_admin_config_raw": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (e *KubernetesClusterEphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if !e.ClientConfigured(&resp.Diagnostics) {
+		return
+	}
+
+	client := e.Client.Containers.KubernetesClustersClient
+	subscriptionId := e.Client.Account.SubscriptionId
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	var data KubernetesClusterEphemeralResourceModel
+	resp.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := commonids.NewKubernetesClusterID(subscriptionId, data.ResourceGroupName.ValueString(), data.Name.ValueString())
+	existing, err := client.Get(ctx, id)
+	if err != nil {
+		if response.WasNotFound(existing.HttpResponse) {
+			resp.Diagnostics.AddError("Error retrieving Kubernetes Cluster", fmt.Sprintf("%s was not found", id))
+			return
+		}
+		resp.Diagnostics.AddError("Error retrieving Kubernetes Cluster", fmt.Sprintf("retrieving %s: %+v", id, err))
+		return
+	}
+
+	userCredentialsResp, err := client.ListClusterUserCredentials(ctx, id, managedclusters.ListClusterUserCredentialsOperationOptions{})
+	if err != nil {
+		resp.Diagnostics.AddError("Error retrieving Kubernetes Cluster Credentials", fmt.Sprintf("retrieving User Credentials for %s: %+v", id, err))
+		return
+	}
+
+	kubeConfigRaw, kubeConfig := flattenKubernetesClusterCredentials(userCredentialsResp.Model, "clusterUser")
+	data.KubeConfigRaw = types.StringPointerValue(kubeConfigRaw)
+	data.KubeConfig = expandKubernetesClusterEphemeralKubeConfig(kubeConfig)
+
+	data.KubeAdminConfigRaw = types.StringNull()
+	data.KubeAdminConfig = make([]KubernetesClusterEphemeralKubeConfig, 0)
+	if model := existing.Model; model != nil && model.Properties != nil {
+		props := model.Properties
+		// adminProfile is only available for RBAC enabled clusters with AAD and without local accounts disabled
+		if props.AadProfile != nil && (props.DisableLocalAccounts == nil || !*props.DisableLocalAccounts) {
+			adminCredentialsResp, err := client.ListClusterAdminCredentials(ctx, id, managedclusters.ListClusterAdminCredentialsOperationOptions{})
+			// only raise the error if it's not a limited permissions error, since the Admin Credentials are optional
+			if err != nil && !response.WasStatusCode(adminCredentialsResp.HttpResponse, http.StatusForbidden) {
+				resp.Diagnostics.AddError("Error retrieving Kubernetes Cluster Credentials", fmt.Sprintf("retrieving Admin Credentials for %s: %+v", id, err))
+				return
+			}
+
+			adminKubeConfigRaw, adminKubeConfig := flattenKubernetesClusterCredentials(adminCredentialsResp.Model, "clusterAdmin")
+			data.KubeAdminConfigRaw = types.StringPointerValue(adminKubeConfigRaw)
+			data.KubeAdminConfig = expandKubernetesClusterEphemeralKubeConfig(adminKubeConfig)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// expandKubernetesClusterEphemeralKubeConfig converts the flattened Kube Config used by the Data Source into the
+// typed model used by the Ephemeral Resource
+func expandKubernetesClusterEphemeralKubeConfig(input []interface{}) []KubernetesClusterEphemeralKubeConfig {
+	output := make([]KubernetesClusterEphemeralKubeConfig, 0)
+	for _, item := range input {
+		v, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		config := KubernetesClusterEphemeralKubeConfig{}
+		config.Host, _ = v["host"].(string)
+		config.Username, _ = v["username"].(string)
+		config.Password, _ = v["password"].(string)
+		config.ClientCertificate, _ = v["client_certificate"].(string)
+		config.ClientKey, _ = v["client_key"].(string)
+		config.ClusterCaCertificate, _ = v["cluster_ca_certificate"].(string)
+		output = append(output, config)
+	}
+
+	return output
+}
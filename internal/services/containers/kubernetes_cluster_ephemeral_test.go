@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+)
+
+type KubernetesClusterEphemeralResource struct{}
+
+func TestAccEphemeralKubernetesCluster_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterEphemeralResource{}
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+		},
+	})
+}
+
+func (KubernetesClusterEphemeralResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_kubernetes_cluster" "test" {
+  name                = azurerm_kubernetes_cluster.test.name
+  resource_group_name = azurerm_kubernetes_cluster.test.resource_group_name
+
+  lifecycle {
+    postcondition {
+      condition     = length(self.kube_config) == 1 && self.kube_config[0].host == azurerm_kubernetes_cluster.test.kube_config[0].host && length(self.kube_admin_config) == 0
+      error_message = "the Kube Config didn't match"
+    }
+  }
+}
+`, KubernetesClusterResource{}.basicVMSSConfig(data))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/extensions"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -44,7 +45,10 @@ type PlanModel struct {
 
 type KubernetesClusterExtensionResource struct{}
 
-var _ sdk.ResourceWithUpdate = KubernetesClusterExtensionResource{}
+var (
+	_ sdk.ResourceWithUpdate   = KubernetesClusterExtensionResource{}
+	_ sdk.ResourceWithIdentity = KubernetesClusterExtensionResource{}
+)
 
 func (r KubernetesClusterExtensionResource) ResourceType() string {
 	return "azurerm_kubernetes_cluster_extension"
@@ -78,6 +82,10 @@ func (r KubernetesClusterExtensionResource) IDValidationFunc() pluginsdk.SchemaV
 	}
 }
 
+func (r KubernetesClusterExtensionResource) Identity() resourceids.ResourceId {
+	return &extensions.ScopedExtensionId{}
+}
+
 func (r KubernetesClusterExtensionResource) Arguments() map[string]*pluginsdk.Schema {
 	return map[string]*pluginsdk.Schema{
 		"name": {
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.ListResource = KubernetesClusterListResource{}
+
+type KubernetesClusterListResource struct{}
+
+func (r KubernetesClusterListResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster"
+}
+
+func (r KubernetesClusterListResource) IdentityType() pluginsdk.ResourceIdentityType {
+	return pluginsdk.NewResourceIdentityType(&commonids.KubernetesClusterId{})
+}
+
+func (r KubernetesClusterListResource) SupportsResourceGroupScope() bool {
+	return true
+}
+
+func (r KubernetesClusterListResource) List(ctx context.Context, client *clients.Client, scope sdk.ListResourceScope) ([]sdk.ListResourceResult, error) {
+	var items []managedclusters.ManagedCluster
+	if scope.ResourceGroupId != nil {
+		resp, err := client.Containers.KubernetesClustersClient.ListByResourceGroupComplete(ctx, *scope.ResourceGroupId)
+		if err != nil {
+			return nil, fmt.Errorf("listing Kubernetes Clusters within %s: %+v", scope.ResourceGroupId, err)
+		}
+		items = resp.Items
+	} else {
+		resp, err := client.Containers.KubernetesClustersClient.ListComplete(ctx, scope.SubscriptionId)
+		if err != nil {
+			return nil, fmt.Errorf("listing Kubernetes Clusters within %s: %+v", scope.SubscriptionId, err)
+		}
+		items = resp.Items
+	}
+
+	results := make([]sdk.ListResourceResult, 0)
+	for _, item := range items {
+		id, err := commonids.ParseKubernetesClusterIDInsensitively(pointer.From(item.Id))
+		if err != nil {
+			// skipped rather than failing the whole listing, since the remaining instances can still be imported
+			log.Printf("[WARN] parsing %q, skipping this Kubernetes Cluster: %+v", pointer.From(item.Id), err)
+			continue
+		}
+
+		results = append(results, sdk.ListResourceResult{
+			ID:          id.ID(),
+			DisplayName: pointer.From(item.Name),
+		})
+	}
+
+	return results, nil
+}
@@ -483,10 +483,14 @@ func resourceKubernetesClusterNodePoolCreate(d *pluginsdk.ResourceData, meta int
 			return err
 		}
 
-		locks.ByName(subnetID.VirtualNetworkName, network.VirtualNetworkResourceName)
+		if err := locks.ByNameWithContext(ctx, subnetID.VirtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(subnetID.VirtualNetworkName, network.VirtualNetworkResourceName)
 
-		locks.ByName(subnetID.SubnetName, network.SubnetResourceName)
+		if err := locks.ByNameWithContext(ctx, subnetID.SubnetName, network.SubnetResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(subnetID.SubnetName, network.SubnetResourceName)
 	}
 
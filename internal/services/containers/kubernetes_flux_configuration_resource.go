@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/kubernetesconfiguration/2022-11-01/fluxconfiguration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
@@ -105,7 +106,8 @@ type ManagedIdentityDefinitionModel struct {
 type KubernetesFluxConfigurationResource struct{}
 
 var (
-	_ sdk.ResourceWithUpdate = KubernetesFluxConfigurationResource{}
+	_ sdk.ResourceWithUpdate   = KubernetesFluxConfigurationResource{}
+	_ sdk.ResourceWithIdentity = KubernetesFluxConfigurationResource{}
 )
 
 func (r KubernetesFluxConfigurationResource) ResourceType() string {
@@ -140,6 +142,10 @@ func (r KubernetesFluxConfigurationResource) IDValidationFunc() pluginsdk.Schema
 	}
 }
 
+func (r KubernetesFluxConfigurationResource) Identity() resourceids.ResourceId {
+	return &fluxconfiguration.ScopedFluxConfigurationId{}
+}
+
 func (r KubernetesFluxConfigurationResource) Arguments() map[string]*pluginsdk.Schema {
 	return map[string]*pluginsdk.Schema{
 		"name": {
@@ -4,6 +4,9 @@
 package containers
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,8 +16,10 @@ type Registration struct {
 }
 
 var (
-	_ sdk.TypedServiceRegistration   = Registration{}
-	_ sdk.UntypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration             = Registration{}
+	_ sdk.UntypedServiceRegistration           = Registration{}
+	_ sdk.FrameworkServiceRegistration         = Registration{}
+	_ sdk.ServiceRegistrationWithListResources = Registration{}
 )
 
 // Name is the name of this Service
@@ -22,6 +27,15 @@ func (r Registration) Name() string {
 	return "Container Services"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ContainerInstance",
+		"Microsoft.ContainerRegistry",
+		"Microsoft.ContainerService",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	categories := []string{
@@ -83,3 +97,28 @@ func (r Registration) Resources() []sdk.Resource {
 	resources = append(resources, r.autoRegistration.Resources()...)
 	return resources
 }
+
+// EphemeralResources returns the Ephemeral Resources supported by this Service
+func (r Registration) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewContainerRegistryTokenPasswordEphemeralResource,
+		NewKubernetesClusterEphemeralResource,
+	}
+}
+
+// FrameworkDataSources returns the native Plugin Framework Data Sources supported by this Service
+func (r Registration) FrameworkDataSources() []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+// FrameworkResources returns the native Plugin Framework Resources supported by this Service
+func (r Registration) FrameworkResources() []func() resource.Resource {
+	return []func() resource.Resource{}
+}
+
+// ListResources returns the List Resources supported by this Service
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		KubernetesClusterListResource{},
+	}
+}
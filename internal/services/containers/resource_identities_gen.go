@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2019-06-01-preview/tasks"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2021-08-01-preview/connectedregistries"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2023-07-01/cacherules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-03-02-preview/trustedaccess"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/snapshots"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-04-01/fleetmembers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-04-01/fleets"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-04-01/fleetupdatestrategies"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-04-01/updateruns"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(tasks.ValidateTaskID, pluginsdk.NewResourceIdentityType(&tasks.TaskId{}))
+	sdk.RegisterResourceIdentityType(connectedregistries.ValidateConnectedRegistryID, pluginsdk.NewResourceIdentityType(&connectedregistries.ConnectedRegistryId{}))
+	sdk.RegisterResourceIdentityType(cacherules.ValidateCacheRuleID, pluginsdk.NewResourceIdentityType(&cacherules.CacheRuleId{}))
+	sdk.RegisterResourceIdentityType(trustedaccess.ValidateTrustedAccessRoleBindingID, pluginsdk.NewResourceIdentityType(&trustedaccess.TrustedAccessRoleBindingId{}))
+	sdk.RegisterResourceIdentityType(snapshots.ValidateSnapshotID, pluginsdk.NewResourceIdentityType(&snapshots.SnapshotId{}))
+	sdk.RegisterResourceIdentityType(fleetmembers.ValidateMemberID, pluginsdk.NewResourceIdentityType(&fleetmembers.MemberId{}))
+	sdk.RegisterResourceIdentityType(fleets.ValidateFleetID, pluginsdk.NewResourceIdentityType(&fleets.FleetId{}))
+	sdk.RegisterResourceIdentityType(fleetupdatestrategies.ValidateUpdateStrategyID, pluginsdk.NewResourceIdentityType(&fleetupdatestrategies.UpdateStrategyId{}))
+	sdk.RegisterResourceIdentityType(updateruns.ValidateUpdateRunID, pluginsdk.NewResourceIdentityType(&updateruns.UpdateRunId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.ContainerRegistryTaskScheduleID, parse.ContainerRegistryTaskScheduleID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ContainerRegistryTokenPasswordID, parse.ContainerRegistryTokenPasswordID)
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cosmos
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/cosmosdb/2022-05-15/sqldedicatedgateway"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/cosmosdb/2022-11-15/mongorbacs"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/postgresqlhsc/2022-11-08/clusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/postgresqlhsc/2022-11-08/configurations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/postgresqlhsc/2022-11-08/firewallrules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/postgresqlhsc/2022-11-08/roles"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(sqldedicatedgateway.ValidateServiceID, pluginsdk.NewResourceIdentityType(&sqldedicatedgateway.ServiceId{}))
+	sdk.RegisterResourceIdentityType(mongorbacs.ValidateMongodbRoleDefinitionID, pluginsdk.NewResourceIdentityType(&mongorbacs.MongodbRoleDefinitionId{}))
+	sdk.RegisterResourceIdentityType(mongorbacs.ValidateMongodbUserDefinitionID, pluginsdk.NewResourceIdentityType(&mongorbacs.MongodbUserDefinitionId{}))
+	sdk.RegisterResourceIdentityType(clusters.ValidateServerGroupsv2ID, pluginsdk.NewResourceIdentityType(&clusters.ServerGroupsv2Id{}))
+	sdk.RegisterResourceIdentityType(configurations.ValidateCoordinatorConfigurationID, pluginsdk.NewResourceIdentityType(&configurations.CoordinatorConfigurationId{}))
+	sdk.RegisterResourceIdentityType(configurations.ValidateNodeConfigurationID, pluginsdk.NewResourceIdentityType(&configurations.NodeConfigurationId{}))
+	sdk.RegisterResourceIdentityType(firewallrules.ValidateFirewallRuleID, pluginsdk.NewResourceIdentityType(&firewallrules.FirewallRuleId{}))
+	sdk.RegisterResourceIdentityType(roles.ValidateRoleID, pluginsdk.NewResourceIdentityType(&roles.RoleId{}))
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package costmanagement
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/costmanagement/2022-06-01-preview/scheduledactions"
+	scheduledactions2 "github.com/hashicorp/go-azure-sdk/resource-manager/costmanagement/2022-10-01/scheduledactions"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/costmanagement/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/costmanagement/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(scheduledactions.ValidateScopedScheduledActionID, pluginsdk.NewResourceIdentityType(&scheduledactions.ScopedScheduledActionId{}))
+	sdk.RegisterResourceIdentityType(scheduledactions2.ValidateScopedScheduledActionID, pluginsdk.NewResourceIdentityType(&scheduledactions2.ScopedScheduledActionId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.BillingAccountCostManagementExportID, parse.BillingAccountCostManagementExportID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ResourceGroupCostManagementExportID, parse.ResourceGroupCostManagementExportID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ResourceGroupCostManagementViewID, parse.ResourceGroupCostManagementViewID)
+	sdk.RegisterLegacyResourceIdentityType(validate.SubscriptionCostManagementExportID, parse.SubscriptionCostManagementExportID)
+	sdk.RegisterLegacyResourceIdentityType(validate.SubscriptionCostManagementViewID, parse.SubscriptionCostManagementViewID)
+}
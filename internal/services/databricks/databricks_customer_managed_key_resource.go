@@ -98,7 +98,9 @@ func databricksWorkspaceCustomerManagedKeyCreateUpdate(d *pluginsdk.ResourceData
 
 	// Not sure if I should also lock the key vault here too
 	// or at the very least the key?
-	locks.ByName(id.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByNameWithContext(ctx, id.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkspaceName, "azurerm_databricks_workspace")
 	var encryptionEnabled bool
 
@@ -242,7 +244,9 @@ func databricksWorkspaceCustomerManagedKeyDelete(d *pluginsdk.ResourceData, meta
 	}
 
 	// Not sure if I should also lock the key vault here too
-	locks.ByName(id.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByNameWithContext(ctx, id.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkspaceName, "azurerm_databricks_workspace")
 
 	workspace, err := client.Get(ctx, *id)
@@ -106,7 +106,9 @@ func databricksWorkspaceRootDbfsCustomerManagedKeyCreate(d *pluginsdk.ResourceDa
 
 	// Not sure if I should also lock the key vault here too
 	// or at the very least the key?
-	locks.ByName(id.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByNameWithContext(ctx, id.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkspaceName, "azurerm_databricks_workspace")
 	var encryptionEnabled bool
 
@@ -274,7 +276,9 @@ func databricksWorkspaceRootDbfsCustomerManagedKeyUpdate(d *pluginsdk.ResourceDa
 
 	// Not sure if I should also lock the key vault here too
 	// or at the very least the key?
-	locks.ByName(id.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByNameWithContext(ctx, id.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkspaceName, "azurerm_databricks_workspace")
 	var encryptionEnabled bool
 
@@ -358,7 +362,9 @@ func databricksWorkspaceRootDbfsCustomerManagedKeyDelete(d *pluginsdk.ResourceDa
 	}
 
 	// Not sure if I should also lock the key vault here too
-	locks.ByName(id.WorkspaceName, "azurerm_databricks_workspace")
+	if err := locks.ByNameWithContext(ctx, id.WorkspaceName, "azurerm_databricks_workspace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkspaceName, "azurerm_databricks_workspace")
 
 	workspace, err := client.Get(ctx, *id)
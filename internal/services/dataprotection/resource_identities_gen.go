@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dataprotection
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dataprotection/2024-04-01/backupinstances"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dataprotection/2024-04-01/backuppolicies"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(backupinstances.ValidateBackupInstanceID, pluginsdk.NewResourceIdentityType(&backupinstances.BackupInstanceId{}))
+	sdk.RegisterResourceIdentityType(backuppolicies.ValidateBackupPolicyID, pluginsdk.NewResourceIdentityType(&backuppolicies.BackupPolicyId{}))
+}
@@ -191,11 +191,14 @@ func resourceArmDesktopVirtualizationWorkspaceDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.WorkspaceName, workspaceResourceType)
-	defer locks.UnlockByName(id.WorkspaceName, workspaceResourceType)
-
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
+
+	if err := locks.ByNameWithContext(ctx, id.WorkspaceName, workspaceResourceType); err != nil {
+		return err
+	}
+	defer locks.UnlockByName(id.WorkspaceName, workspaceResourceType)
+
 	if _, err = client.Delete(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
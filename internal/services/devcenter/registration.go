@@ -17,6 +17,13 @@ func (r Registration) Name() string {
 	return "Dev Center"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.DevCenter",
+	}
+}
+
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/dev-center"
 }
@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package devcenter
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/catalogs"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/devboxdefinitions"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/devcenters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/environmenttypes"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/galleries"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/networkconnections"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/projects"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(catalogs.ValidateCatalogID, pluginsdk.NewResourceIdentityType(&catalogs.CatalogId{}))
+	sdk.RegisterResourceIdentityType(devboxdefinitions.ValidateDevCenterDevBoxDefinitionID, pluginsdk.NewResourceIdentityType(&devboxdefinitions.DevCenterDevBoxDefinitionId{}))
+	sdk.RegisterResourceIdentityType(devcenters.ValidateDevCenterID, pluginsdk.NewResourceIdentityType(&devcenters.DevCenterId{}))
+	sdk.RegisterResourceIdentityType(environmenttypes.ValidateDevCenterEnvironmentTypeID, pluginsdk.NewResourceIdentityType(&environmenttypes.DevCenterEnvironmentTypeId{}))
+	sdk.RegisterResourceIdentityType(environmenttypes.ValidateEnvironmentTypeID, pluginsdk.NewResourceIdentityType(&environmenttypes.EnvironmentTypeId{}))
+	sdk.RegisterResourceIdentityType(galleries.ValidateGalleryID, pluginsdk.NewResourceIdentityType(&galleries.GalleryId{}))
+	sdk.RegisterResourceIdentityType(networkconnections.ValidateNetworkConnectionID, pluginsdk.NewResourceIdentityType(&networkconnections.NetworkConnectionId{}))
+	sdk.RegisterResourceIdentityType(projects.ValidateProjectID, pluginsdk.NewResourceIdentityType(&projects.ProjectId{}))
+}
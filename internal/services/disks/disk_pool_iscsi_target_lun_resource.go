@@ -100,7 +100,9 @@ func (d DiskPoolIscsiTargetLunModel) Create() sdk.ResourceFunc {
 			}
 			id := parse.NewDiskPoolIscsiTargetLunId(*iscsiTargetId, attachmentId.ManagedDiskId)
 
-			locks.ByID(iscsiTargetId.ID())
+			if err := locks.ByIDWithContext(ctx, iscsiTargetId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(iscsiTargetId.ID())
 
 			client := metadata.Client.Disks.DisksPoolIscsiTargetClient
@@ -220,7 +222,9 @@ func (d DiskPoolIscsiTargetLunModel) Delete() sdk.ResourceFunc {
 			}
 			id := parse.NewDiskPoolIscsiTargetLunId(*iscsiTargetId, attachmentId.ManagedDiskId)
 
-			locks.ByID(iscsiTargetId.ID())
+			if err := locks.ByIDWithContext(ctx, iscsiTargetId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(iscsiTargetId.ID())
 
 			client := metadata.Client.Disks.DisksPoolIscsiTargetClient
@@ -158,7 +158,9 @@ func (r DisksPoolIscsiTargetLunResource) Destroy(ctx context.Context, clients *c
 
 	iscsiTargetId := id.IscsiTargetId
 
-	locks.ByID(iscsiTargetId.ID())
+	if err := locks.ByIDWithContext(ctx, iscsiTargetId.ID()); err != nil {
+		return nil, err
+	}
 	defer locks.UnlockByID(iscsiTargetId.ID())
 
 	client := clients.Disks.DisksPoolIscsiTargetClient
@@ -123,7 +123,9 @@ func (d DisksPoolIscsiTargetResource) Create() sdk.ResourceFunc {
 
 			id := iscsitargets.NewIscsiTargetID(poolId.SubscriptionId, poolId.ResourceGroupName, poolId.DiskPoolName, m.Name)
 			client := metadata.Client.Disks.DisksPoolIscsiTargetClient
-			locks.ByID(poolId.ID())
+			if err := locks.ByIDWithContext(ctx, poolId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(poolId.ID())
 
 			existing, err := client.Get(ctx, id)
@@ -207,7 +209,9 @@ func (d DisksPoolIscsiTargetResource) Delete() sdk.ResourceFunc {
 			if err != nil {
 				return err
 			}
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			client := metadata.Client.Disks.DisksPoolIscsiTargetClient
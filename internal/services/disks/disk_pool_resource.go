@@ -186,7 +186,9 @@ func (r DiskPoolResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			future, err := client.Delete(ctx, *id)
@@ -221,7 +223,9 @@ func (r DiskPoolResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(metadata.ResourceData.Id())
+			if err := locks.ByIDWithContext(ctx, metadata.ResourceData.Id()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(metadata.ResourceData.Id())
 
 			patch := diskpools.DiskPoolUpdate{}
@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package disks
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagepool/2021-08-01/diskpools"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagepool/2021-08-01/iscsitargets"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(diskpools.ValidateDiskPoolID, pluginsdk.NewResourceIdentityType(&diskpools.DiskPoolId{}))
+	sdk.RegisterResourceIdentityType(iscsitargets.ValidateIscsiTargetID, pluginsdk.NewResourceIdentityType(&iscsitargets.IscsiTargetId{}))
+}
@@ -93,7 +93,9 @@ func resourceActiveDirectoryDomainServiceReplicaSetCreate(d *pluginsdk.ResourceD
 
 	idsdk := domainservices.NewDomainServiceID(domainServiceId.SubscriptionId, domainServiceId.ResourceGroup, domainServiceId.Name)
 
-	locks.ByName(domainServiceId.Name, DomainServiceResourceName)
+	if err := locks.ByNameWithContext(ctx, domainServiceId.Name, DomainServiceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(domainServiceId.Name, DomainServiceResourceName)
 
 	domainService, err := client.Get(ctx, idsdk)
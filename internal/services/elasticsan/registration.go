@@ -17,6 +17,13 @@ func (Registration) Name() string {
 	return "ElasticSan"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ElasticSan",
+	}
+}
+
 func (Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{
 		ElasticSANDataSource{},
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package elasticsan
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/elasticsan/2023-01-01/elasticsans"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/elasticsan/2023-01-01/volumegroups"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/elasticsan/2023-01-01/volumes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(elasticsans.ValidateElasticSanID, pluginsdk.NewResourceIdentityType(&elasticsans.ElasticSanId{}))
+	sdk.RegisterResourceIdentityType(volumegroups.ValidateVolumeGroupID, pluginsdk.NewResourceIdentityType(&volumegroups.VolumeGroupId{}))
+	sdk.RegisterResourceIdentityType(volumes.ValidateVolumeID, pluginsdk.NewResourceIdentityType(&volumes.VolumeId{}))
+}
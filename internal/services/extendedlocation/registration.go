@@ -32,3 +32,10 @@ func (r Registration) Resources() []sdk.Resource {
 func (r Registration) Name() string {
 	return "ExtendedLocation"
 }
+
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ExtendedLocation",
+	}
+}
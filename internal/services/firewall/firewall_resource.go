@@ -349,17 +349,25 @@ func resourceFirewallCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 
 	if policyId, ok := d.GetOk("firewall_policy_id"); ok {
 		id, _ := firewallpolicies.ParseFirewallPolicyID(policyId.(string))
-		locks.ByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
+		if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
 	}
 
-	locks.ByName(id.AzureFirewallName, AzureFirewallResourceName)
+	if err := locks.ByNameWithContext(ctx, id.AzureFirewallName, AzureFirewallResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AzureFirewallName, AzureFirewallResourceName)
 
-	locks.MultipleByName(vnetToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, vnetToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetToLock, SubnetResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, subnetToLock, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(subnetToLock, SubnetResourceName)
 
 	if !d.IsNewResource() {
@@ -541,17 +549,25 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 			if err != nil {
 				return err
 			}
-			locks.ByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
+			if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, AzureFirewallPolicyResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
 		}
 
-		locks.ByName(id.AzureFirewallName, AzureFirewallResourceName)
+		if err := locks.ByNameWithContext(ctx, id.AzureFirewallName, AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, AzureFirewallResourceName)
 
-		locks.MultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
+		if err := locks.MultipleByNameWithContext(ctx, &virtualNetworkNamesToLock, VirtualNetworkResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockMultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
 
-		locks.MultipleByName(&subnetNamesToLock, SubnetResourceName)
+		if err := locks.MultipleByNameWithContext(ctx, &subnetNamesToLock, SubnetResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockMultipleByName(&subnetNamesToLock, SubnetResourceName)
 
 		// todo see if this is still needed this way
@@ -227,7 +227,9 @@ type customHttpsConfigurationUpdateInput struct {
 func updateCustomHTTPSConfiguration(ctx context.Context, client *frontdoors.FrontDoorsClient, input customHttpsConfigurationUpdateInput) error {
 	// Locking to prevent parallel changes causing issues
 	frontendEndpointResourceId := input.frontendEndpointId.ID()
-	locks.ByID(frontendEndpointResourceId)
+	if err := locks.ByIDWithContext(ctx, frontendEndpointResourceId); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(frontendEndpointResourceId)
 
 	if input.provisioningState == "" {
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/machineextensions"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/machines"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/privatelinkscopes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(machineextensions.ValidateExtensionID, pluginsdk.NewResourceIdentityType(&machineextensions.ExtensionId{}))
+	sdk.RegisterResourceIdentityType(machines.ValidateMachineID, pluginsdk.NewResourceIdentityType(&machines.MachineId{}))
+	sdk.RegisterResourceIdentityType(privatelinkscopes.ValidateProviderPrivateLinkScopeID, pluginsdk.NewResourceIdentityType(&privatelinkscopes.ProviderPrivateLinkScopeId{}))
+}
@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iotcentral
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/iotcentral/2021-11-01-preview/apps"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/iotcentral/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/iotcentral/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(apps.ValidateIotAppID, pluginsdk.NewResourceIdentityType(&apps.IotAppId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.OrganizationID, parse.OrganizationID)
+}
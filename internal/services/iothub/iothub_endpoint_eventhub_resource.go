@@ -139,7 +139,9 @@ func resourceIotHubEndpointEventHubCreateUpdate(d *pluginsdk.ResourceData, meta
 
 	id := parse.NewEndpointEventhubID(subscriptionId, iotHubRG, iotHubName, d.Get("name").(string))
 
-	locks.ByName(iotHubName, IothubResourceName)
+	if err := locks.ByNameWithContext(ctx, iotHubName, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(iotHubName, IothubResourceName)
 
 	iothub, err := client.Get(ctx, iotHubRG, iotHubName)
@@ -320,7 +322,9 @@ func resourceIotHubEndpointEventHubDelete(d *pluginsdk.ResourceData, meta interf
 		return err
 	}
 
-	locks.ByName(id.IotHubName, IothubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.IotHubName, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.IotHubName, IothubResourceName)
 
 	iothub, err := client.Get(ctx, id.ResourceGroup, id.IotHubName)
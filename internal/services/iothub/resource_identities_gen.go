@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iothub
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/deviceupdate/2022-10-01/deviceupdates"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/iothub/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/iothub/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(deviceupdates.ValidateAccountID, pluginsdk.NewResourceIdentityType(&deviceupdates.AccountId{}))
+	sdk.RegisterResourceIdentityType(deviceupdates.ValidateInstanceID, pluginsdk.NewResourceIdentityType(&deviceupdates.InstanceId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.EndpointCosmosDBAccountID, parse.EndpointCosmosDBAccountID)
+	sdk.RegisterLegacyResourceIdentityType(validate.IotHubID, parse.IotHubID)
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &KeyVaultCertificateEphemeralResource{}
+
+func NewKeyVaultCertificateEphemeralResource() ephemeral.EphemeralResource {
+	return &KeyVaultCertificateEphemeralResource{}
+}
+
+type KeyVaultCertificateEphemeralResource struct {
+	sdk.EphemeralResourceMetadata
+}
+
+type KeyVaultCertificateEphemeralResourceModel struct {
+	Name              types.String `tfsdk:"name"`
+	KeyVaultId        types.String `tfsdk:"key_vault_id"`
+	Version           types.String `tfsdk:"version"`
+	Hex               types.String `tfsdk:"hex"`
+	Pem               types.String `tfsdk:"pem"`
+	Key               types.String `tfsdk:"key"`
+	Expires           types.String `tfsdk:"expires"`
+	NotBefore         types.String `tfsdk:"not_before"`
+	CertificatesCount types.Int64  `tfsdk:"certificates_count"`
+}
+
+func (e *KeyVaultCertificateEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = "azurerm_key_vault_certificate"
+}
+
+func (e *KeyVaultCertificateEphemeralResource) Configure(_ context.Context, request ephemeral.ConfigureRequest, response *ephemeral.ConfigureResponse) {
+	e.Defaults(request, response)
+}
+
+func (e *KeyVaultCertificateEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: keyVaultValidate.NestedItemName,
+					},
+				},
+			},
+
+			"key_vault_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: commonids.ValidateKeyVaultID,
+					},
+				},
+			},
+
+			"version": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+
+			"hex": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"pem": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"expires": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"not_before": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"certificates_count": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (e *KeyVaultCertificateEphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	if !e.ClientConfigured(&response.Diagnostics) {
+		return
+	}
+
+	keyVaultsClient := e.Client.KeyVault
+	client := e.Client.KeyVault.ManagementClient
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	var data KeyVaultCertificateEphemeralResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	keyVaultId, err := commonids.ParseKeyVaultID(data.KeyVaultId.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Error parsing Key Vault ID", err.Error())
+		return
+	}
+
+	keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+	if err != nil {
+		response.Diagnostics.AddError("Error looking up Key Vault URI", fmt.Sprintf("looking up Certificate %q vault url from id %q: %+v", name, *keyVaultId, err))
+		return
+	}
+
+	cert, err := client.GetCertificate(ctx, *keyVaultBaseUri, name, data.Version.ValueString())
+	if err != nil {
+		if utils.ResponseWasNotFound(cert.Response) {
+			response.Diagnostics.AddError("Error retrieving Key Vault Certificate", fmt.Sprintf("the Certificate %q was not found in Key Vault at URI %q", name, *keyVaultBaseUri))
+			return
+		}
+		response.Diagnostics.AddError("Error retrieving Key Vault Certificate", fmt.Sprintf("reading Key Vault Certificate: %+v", err))
+		return
+	}
+
+	if pointer.From(cert.ID) == "" {
+		response.Diagnostics.AddError("Error retrieving Key Vault Certificate", fmt.Sprintf("failure reading Key Vault Certificate ID for %q", name))
+		return
+	}
+
+	id, err := parse.ParseNestedItemID(*cert.ID)
+	if err != nil {
+		response.Diagnostics.AddError("Error parsing Key Vault Certificate ID", err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(id.Name)
+	data.Version = types.StringValue(id.Version)
+
+	certificateData := ""
+	if contents := cert.Cer; contents != nil {
+		certificateData = strings.ToUpper(hex.EncodeToString(*contents))
+	}
+	data.Hex = types.StringValue(certificateData)
+
+	data.Expires = types.StringNull()
+	data.NotBefore = types.StringNull()
+	if attributes := cert.Attributes; attributes != nil {
+		if expires := attributes.Expires; expires != nil {
+			data.Expires = types.StringValue(time.Time(*expires).Format(time.RFC3339))
+		}
+		if notBefore := attributes.NotBefore; notBefore != nil {
+			data.NotBefore = types.StringValue(time.Time(*notBefore).Format(time.RFC3339))
+		}
+	}
+
+	// the Private Key is only available from the Secret which backs the Certificate
+	pfx, err := client.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, id.Version)
+	if err != nil {
+		response.Diagnostics.AddError("Error retrieving Key Vault Certificate", fmt.Sprintf("retrieving certificate %q from keyvault: %+v", id.Name, err))
+		return
+	}
+
+	certs, key, certificatesCount, err := flattenKeyVaultCertificateSecretToPEM(id.Name, pfx)
+	if err != nil {
+		response.Diagnostics.AddError("Error converting Key Vault Certificate", err.Error())
+		return
+	}
+
+	data.Pem = types.StringValue(certs)
+	data.Key = types.StringValue(key)
+	data.CertificatesCount = types.Int64Value(int64(certificatesCount))
+
+	response.Diagnostics.Append(response.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.ListResource = KeyVaultListResource{}
+
+type KeyVaultListResource struct{}
+
+func (r KeyVaultListResource) ResourceType() string {
+	return "azurerm_key_vault"
+}
+
+func (r KeyVaultListResource) IdentityType() pluginsdk.ResourceIdentityType {
+	return pluginsdk.NewResourceIdentityType(&commonids.KeyVaultId{})
+}
+
+func (r KeyVaultListResource) SupportsResourceGroupScope() bool {
+	return true
+}
+
+func (r KeyVaultListResource) List(ctx context.Context, client *clients.Client, scope sdk.ListResourceScope) ([]sdk.ListResourceResult, error) {
+	var items []vaults.Vault
+	if scope.ResourceGroupId != nil {
+		resp, err := client.KeyVault.VaultsClient.ListByResourceGroupComplete(ctx, *scope.ResourceGroupId, vaults.DefaultListByResourceGroupOperationOptions())
+		if err != nil {
+			return nil, fmt.Errorf("listing Key Vaults within %s: %+v", scope.ResourceGroupId, err)
+		}
+		items = resp.Items
+	} else {
+		resp, err := client.KeyVault.VaultsClient.ListBySubscriptionComplete(ctx, scope.SubscriptionId, vaults.DefaultListBySubscriptionOperationOptions())
+		if err != nil {
+			return nil, fmt.Errorf("listing Key Vaults within %s: %+v", scope.SubscriptionId, err)
+		}
+		items = resp.Items
+	}
+
+	results := make([]sdk.ListResourceResult, 0)
+	for _, item := range items {
+		id, err := commonids.ParseKeyVaultIDInsensitively(pointer.From(item.Id))
+		if err != nil {
+			// skipped rather than failing the whole listing, since the remaining instances can still be imported
+			log.Printf("[WARN] parsing %q, skipping this Key Vault: %+v", pointer.From(item.Id), err)
+			continue
+		}
+
+		results = append(results, sdk.ListResourceResult{
+			ID:          id.ID(),
+			DisplayName: pointer.From(item.Name),
+		})
+	}
+
+	return results, nil
+}
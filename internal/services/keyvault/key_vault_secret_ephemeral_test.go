@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+)
+
+type KeyVaultSecretEphemeralResource struct{}
+
+func TestAccEphemeralKeyVaultSecret_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretEphemeralResource{}
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+		},
+	})
+}
+
+func TestAccEphemeralKeyVaultSecret_specifyVersion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretEphemeralResource{}
+
+	data.EphemeralResourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.specifyVersion(data),
+		},
+	})
+}
+
+func (KeyVaultSecretEphemeralResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_key_vault_secret" "test" {
+  name         = azurerm_key_vault_secret.test.name
+  key_vault_id = azurerm_key_vault.test.id
+
+  lifecycle {
+    postcondition {
+      condition     = self.value == "rick-and-morty"
+      error_message = "the Key Vault Secret value didn't match"
+    }
+  }
+}
+`, KeyVaultSecretResource{}.basic(data))
+}
+
+func (KeyVaultSecretEphemeralResource) specifyVersion(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azurerm_key_vault_secret" "test" {
+  name         = azurerm_key_vault_secret.test.name
+  key_vault_id = azurerm_key_vault.test.id
+  version      = azurerm_key_vault_secret.test.version
+
+  lifecycle {
+    postcondition {
+      condition     = self.version == azurerm_key_vault_secret.test.version
+      error_message = "the Key Vault Secret version didn't match"
+    }
+  }
+}
+`, KeyVaultSecretResource{}.basic(data))
+}
@@ -245,7 +245,9 @@ func resourceKustoAttachedDatabaseConfigurationDelete(d *pluginsdk.ResourceData,
 	}
 
 	// DELETE operation for attached configuration does not support running concurrently at cluster level
-	locks.ByName(id.ClusterName, "azurerm_kusto_cluster")
+	if err := locks.ByNameWithContext(ctx, id.ClusterName, "azurerm_kusto_cluster"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ClusterName, "azurerm_kusto_cluster")
 
 	err = client.DeleteThenPoll(ctx, *id)
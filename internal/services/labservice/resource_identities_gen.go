@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package labservice
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/labservices/2022-08-01/lab"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/labservices/2022-08-01/labplan"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/labservices/2022-08-01/schedule"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/labservices/2022-08-01/user"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(lab.ValidateLabID, pluginsdk.NewResourceIdentityType(&lab.LabId{}))
+	sdk.RegisterResourceIdentityType(labplan.ValidateLabPlanID, pluginsdk.NewResourceIdentityType(&labplan.LabPlanId{}))
+	sdk.RegisterResourceIdentityType(schedule.ValidateScheduleID, pluginsdk.NewResourceIdentityType(&schedule.ScheduleId{}))
+	sdk.RegisterResourceIdentityType(user.ValidateUserID, pluginsdk.NewResourceIdentityType(&user.UserId{}))
+}
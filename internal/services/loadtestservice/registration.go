@@ -25,6 +25,13 @@ func (r Registration) Name() string {
 	return "LoadTestService"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.LoadTestService",
+	}
+}
+
 func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{
 		LoadTestDataSource{},
@@ -38,6 +38,14 @@ func (r Registration) Name() string {
 	return "Log Analytics"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.OperationalInsights",
+		"Microsoft.OperationsManagement",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loganalytics
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2019-09-01/querypackqueries"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2019-09-01/querypacks"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2022-10-01/clusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2022-10-01/tables"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationsmanagement/2015-11-01-preview/solution"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(querypackqueries.ValidateQueryID, pluginsdk.NewResourceIdentityType(&querypackqueries.QueryId{}))
+	sdk.RegisterResourceIdentityType(querypacks.ValidateQueryPackID, pluginsdk.NewResourceIdentityType(&querypacks.QueryPackId{}))
+	sdk.RegisterResourceIdentityType(clusters.ValidateClusterID, pluginsdk.NewResourceIdentityType(&clusters.ClusterId{}))
+	sdk.RegisterResourceIdentityType(tables.ValidateTableID, pluginsdk.NewResourceIdentityType(&tables.TableId{}))
+	sdk.RegisterResourceIdentityType(solution.ValidateSolutionID, pluginsdk.NewResourceIdentityType(&solution.SolutionId{}))
+}
@@ -22,6 +22,13 @@ func (r Registration) Name() string {
 	return "Machine Learning"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.MachineLearningServices",
+	}
+}
+
 func (r Registration) WebsiteCategories() []string {
 	return []string{
 		"Machine Learning",
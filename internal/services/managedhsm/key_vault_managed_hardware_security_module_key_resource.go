@@ -199,7 +199,9 @@ func (r KeyVaultMHSMKeyResource) Create() sdk.ResourceFunc {
 
 			id := parse.NewManagedHSMDataPlaneVersionlessKeyID(endpoint.ManagedHSMName, endpoint.DomainSuffix, config.Name)
 
-			locks.ByName(managedHsmId.ID(), "azurerm_key_vault_managed_hardware_security_module")
+			if err := locks.ByNameWithContext(ctx, managedHsmId.ID(), "azurerm_key_vault_managed_hardware_security_module"); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(managedHsmId.ID(), "azurerm_key_vault_managed_hardware_security_module")
 
 			existing, err := client.GetKey(ctx, endpoint.BaseURI(), id.KeyName, "")
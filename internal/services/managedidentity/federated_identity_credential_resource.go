@@ -100,7 +100,9 @@ func (r FederatedIdentityCredentialResource) Create() sdk.ResourceFunc {
 				return fmt.Errorf("parsing parent resource ID: %+v", err)
 			}
 
-			locks.ByID(parentId.ID())
+			if err := locks.ByIDWithContext(ctx, parentId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(parentId.ID())
 
 			id := managedidentities.NewFederatedIdentityCredentialID(subscriptionId, config.ResourceGroupName, parentId.UserAssignedIdentityName, config.Name)
@@ -182,7 +184,9 @@ func (r FederatedIdentityCredentialResource) Delete() sdk.ResourceFunc {
 				return fmt.Errorf("parsing parent resource ID: %+v", err)
 			}
 
-			locks.ByID(parentId.ID())
+			if err := locks.ByIDWithContext(ctx, parentId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(parentId.ID())
 
 			id, err := managedidentities.ParseFederatedIdentityCredentialID(metadata.ResourceData.Id())
@@ -24,6 +24,13 @@ func (r Registration) Name() string {
 	return r.autoRegistration.Name()
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ManagedIdentity",
+	}
+}
+
 func (r Registration) DataSources() []sdk.DataSource {
 	dataSources := []sdk.DataSource{}
 	dataSources = append(dataSources, r.autoRegistration.DataSources()...)
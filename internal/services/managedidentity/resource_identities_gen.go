@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedidentity
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/managedidentity/2023-01-31/managedidentities"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(commonids.ValidateUserAssignedIdentityID, pluginsdk.NewResourceIdentityType(&commonids.UserAssignedIdentityId{}))
+	sdk.RegisterResourceIdentityType(managedidentities.ValidateFederatedIdentityCredentialID, pluginsdk.NewResourceIdentityType(&managedidentities.FederatedIdentityCredentialId{}))
+}
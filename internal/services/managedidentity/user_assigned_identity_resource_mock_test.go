@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedidentity_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedidentity"
+)
+
+func TestUserAssignedIdentityResource_mockResourceManager(t *testing.T) {
+	server := mockarm.NewServer(t)
+	client := server.Client(t)
+
+	resourceGroupId := commonids.NewResourceGroupID(server.SubscriptionId, "example-resources")
+	server.SetResource(resourceGroupId.ID(), map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	wrapper := sdk.NewResourceWrapper(managedidentity.UserAssignedIdentityResource{})
+	resource, err := wrapper.Resource()
+	if err != nil {
+		t.Fatalf("building the resource: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	config := map[string]interface{}{
+		"name":                "example-identity",
+		"resource_group_name": resourceGroupId.ResourceGroupName,
+		"location":            "westeurope",
+		"tags": map[string]interface{}{
+			"environment": "test",
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, config)
+	if diags := resource.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+
+	id := commonids.NewUserAssignedIdentityID(server.SubscriptionId, resourceGroupId.ResourceGroupName, "example-identity")
+	if d.Id() != id.ID() {
+		t.Fatalf("expected the ID to be %q but got %q", id.ID(), d.Id())
+	}
+	if tags := d.Get("tags").(map[string]interface{}); tags["environment"] != "test" {
+		t.Fatalf("expected the tags to be read back from the API but got %+v", tags)
+	}
+
+	// a second Create should detect the existing resource
+	if diags := resource.CreateContext(ctx, schema.TestResourceDataRaw(t, resource.Schema, config), client); !diags.HasError() {
+		t.Fatalf("expected an error when creating a resource which already exists")
+	}
+
+	config["tags"] = map[string]interface{}{
+		"environment": "production",
+	}
+	d = schema.TestResourceDataRaw(t, resource.Schema, config)
+	d.SetId(id.ID())
+	if diags := resource.UpdateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("updating: %+v", diags)
+	}
+	if tags := server.Resource(id.ID())["tags"].(map[string]interface{}); tags["environment"] != "production" {
+		t.Fatalf("expected the tags to be updated but got %+v", tags)
+	}
+
+	if diags := resource.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("deleting: %+v", diags)
+	}
+	if server.Resource(id.ID()) != nil {
+		t.Fatalf("expected the resource to be deleted")
+	}
+
+	// the resource should be removed from the state once it's gone
+	if diags := resource.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the resource to be removed from the state but got %q", d.Id())
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mobilenetwork
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/attacheddatanetwork"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/datanetwork"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/mobilenetwork"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/packetcorecontrolplane"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/packetcoredataplane"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/service"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/sim"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/simgroup"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/simpolicy"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/site"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mobilenetwork/2022-11-01/slice"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(attacheddatanetwork.ValidateAttachedDataNetworkID, pluginsdk.NewResourceIdentityType(&attacheddatanetwork.AttachedDataNetworkId{}))
+	sdk.RegisterResourceIdentityType(datanetwork.ValidateDataNetworkID, pluginsdk.NewResourceIdentityType(&datanetwork.DataNetworkId{}))
+	sdk.RegisterResourceIdentityType(mobilenetwork.ValidateMobileNetworkID, pluginsdk.NewResourceIdentityType(&mobilenetwork.MobileNetworkId{}))
+	sdk.RegisterResourceIdentityType(packetcorecontrolplane.ValidatePacketCoreControlPlaneID, pluginsdk.NewResourceIdentityType(&packetcorecontrolplane.PacketCoreControlPlaneId{}))
+	sdk.RegisterResourceIdentityType(packetcoredataplane.ValidatePacketCoreDataPlaneID, pluginsdk.NewResourceIdentityType(&packetcoredataplane.PacketCoreDataPlaneId{}))
+	sdk.RegisterResourceIdentityType(service.ValidateServiceID, pluginsdk.NewResourceIdentityType(&service.ServiceId{}))
+	sdk.RegisterResourceIdentityType(sim.ValidateSimID, pluginsdk.NewResourceIdentityType(&sim.SimId{}))
+	sdk.RegisterResourceIdentityType(simgroup.ValidateSimGroupID, pluginsdk.NewResourceIdentityType(&simgroup.SimGroupId{}))
+	sdk.RegisterResourceIdentityType(simpolicy.ValidateSimPolicyID, pluginsdk.NewResourceIdentityType(&simpolicy.SimPolicyId{}))
+	sdk.RegisterResourceIdentityType(site.ValidateSiteID, pluginsdk.NewResourceIdentityType(&site.SiteId{}))
+	sdk.RegisterResourceIdentityType(slice.ValidateSliceID, pluginsdk.NewResourceIdentityType(&slice.SliceId{}))
+}
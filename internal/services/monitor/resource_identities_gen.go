@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package monitor
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/alertsmanagement/2021-08-08/alertprocessingrules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/alertsmanagement/2023-03-01/prometheusrulegroups"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionendpoints"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionruleassociations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionrules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2023-03-15-preview/scheduledqueryrules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2023-04-03/azuremonitorworkspaces"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(alertprocessingrules.ValidateActionRuleID, pluginsdk.NewResourceIdentityType(&alertprocessingrules.ActionRuleId{}))
+	sdk.RegisterResourceIdentityType(prometheusrulegroups.ValidatePrometheusRuleGroupID, pluginsdk.NewResourceIdentityType(&prometheusrulegroups.PrometheusRuleGroupId{}))
+	sdk.RegisterResourceIdentityType(datacollectionendpoints.ValidateDataCollectionEndpointID, pluginsdk.NewResourceIdentityType(&datacollectionendpoints.DataCollectionEndpointId{}))
+	sdk.RegisterResourceIdentityType(datacollectionruleassociations.ValidateScopedDataCollectionRuleAssociationID, pluginsdk.NewResourceIdentityType(&datacollectionruleassociations.ScopedDataCollectionRuleAssociationId{}))
+	sdk.RegisterResourceIdentityType(datacollectionrules.ValidateDataCollectionRuleID, pluginsdk.NewResourceIdentityType(&datacollectionrules.DataCollectionRuleId{}))
+	sdk.RegisterResourceIdentityType(scheduledqueryrules.ValidateScheduledQueryRuleID, pluginsdk.NewResourceIdentityType(&scheduledqueryrules.ScheduledQueryRuleId{}))
+	sdk.RegisterResourceIdentityType(azuremonitorworkspaces.ValidateAccountID, pluginsdk.NewResourceIdentityType(&azuremonitorworkspaces.AccountId{}))
+}
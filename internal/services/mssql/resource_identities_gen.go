@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mssql
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/sqlvirtualmachine/2023-10-01/availabilitygrouplisteners"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(availabilitygrouplisteners.ValidateAvailabilityGroupListenerID, pluginsdk.NewResourceIdentityType(&availabilitygrouplisteners.AvailabilityGroupListenerId{}))
+	sdk.RegisterResourceIdentityType(availabilitygrouplisteners.ValidateSqlVirtualMachineGroupID, pluginsdk.NewResourceIdentityType(&availabilitygrouplisteners.SqlVirtualMachineGroupId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.FailoverGroupID, parse.FailoverGroupID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ServerDNSAliasID, parse.ServerDNSAliasID)
+}
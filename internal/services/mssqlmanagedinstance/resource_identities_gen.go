@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mssqlmanagedinstance
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssqlmanagedinstance/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mssqlmanagedinstance/validate"
+)
+
+func init() {
+	sdk.RegisterLegacyResourceIdentityType(validate.ManagedDatabaseID, parse.ManagedDatabaseID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ManagedInstanceAzureActiveDirectoryAdministratorID, parse.ManagedInstanceAzureActiveDirectoryAdministratorID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ManagedInstanceFailoverGroupID, parse.ManagedInstanceFailoverGroupID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ManagedInstanceID, parse.ManagedInstanceID)
+}
@@ -81,7 +81,9 @@ func resourceMySQLFlexibleServerConfigurationCreate(d *pluginsdk.ResourceData, m
 
 	id := configurations.NewConfigurationID(subscriptionId, d.Get("resource_group_name").(string), d.Get("server_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.FlexibleServerName, mysqlFlexibleServerResourceName)
+	if err := locks.ByNameWithContext(ctx, id.FlexibleServerName, mysqlFlexibleServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FlexibleServerName, mysqlFlexibleServerResourceName)
 
 	if err := client.UpdateThenPoll(ctx, id, payload); err != nil {
@@ -104,7 +106,9 @@ func resourceMySQLFlexibleServerConfigurationUpdate(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(id.FlexibleServerName, mysqlFlexibleServerResourceName)
+	if err := locks.ByNameWithContext(ctx, id.FlexibleServerName, mysqlFlexibleServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FlexibleServerName, mysqlFlexibleServerResourceName)
 
 	payload := configurations.Configuration{
@@ -166,7 +170,9 @@ func resourceMySQLFlexibleServerConfigurationDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(id.FlexibleServerName, mysqlFlexibleServerResourceName)
+	if err := locks.ByNameWithContext(ctx, id.FlexibleServerName, mysqlFlexibleServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FlexibleServerName, mysqlFlexibleServerResourceName)
 
 	// "delete" = resetting this to the default value
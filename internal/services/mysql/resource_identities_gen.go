@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mysql
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mysql/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/mysql/validate"
+)
+
+func init() {
+	sdk.RegisterLegacyResourceIdentityType(validate.FlexibleServerAzureActiveDirectoryAdministratorID, parse.FlexibleServerAzureActiveDirectoryAdministratorID)
+}
@@ -23,6 +23,13 @@ func (r Registration) Name() string {
 	return "NetApp"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.NetApp",
+	}
+}
+
 func (r Registration) WebsiteCategories() []string {
 	return []string{
 		"NetApp",
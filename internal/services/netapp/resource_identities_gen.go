@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package netapp
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/netapp/2023-05-01/netappaccounts"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/netapp/2023-05-01/volumegroups"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/netapp/2023-05-01/volumequotarules"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(netappaccounts.ValidateNetAppAccountID, pluginsdk.NewResourceIdentityType(&netappaccounts.NetAppAccountId{}))
+	sdk.RegisterResourceIdentityType(volumegroups.ValidateVolumeGroupID, pluginsdk.NewResourceIdentityType(&volumegroups.VolumeGroupId{}))
+	sdk.RegisterResourceIdentityType(volumequotarules.ValidateVolumeQuotaRuleID, pluginsdk.NewResourceIdentityType(&volumequotarules.VolumeQuotaRuleId{}))
+}
@@ -91,7 +91,9 @@ func resourceExpressRoutePortAuthorizationCreate(d *pluginsdk.ResourceData, meta
 
 	// can run only one create/update/delete operation of expressRoutePort at the same time
 	portID := expressrouteports.NewExpressRoutePortID(id.SubscriptionId, id.ResourceGroupName, id.ExpressRoutePortName)
-	locks.ByID(portID.ID())
+	if err := locks.ByIDWithContext(ctx, portID.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(portID.ID())
 
 	if err := client.CreateOrUpdateThenPoll(ctx, id, properties); err != nil {
@@ -147,7 +149,9 @@ func resourceExpressRoutePortAuthorizationDelete(d *pluginsdk.ResourceData, meta
 	}
 
 	portID := expressrouteports.NewExpressRoutePortID(id.SubscriptionId, id.ResourceGroupName, id.ExpressRoutePortName)
-	locks.ByID(portID.ID())
+	if err := locks.ByIDWithContext(ctx, portID.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(portID.ID())
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
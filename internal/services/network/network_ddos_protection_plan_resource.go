@@ -81,9 +81,13 @@ func resourceNetworkDDoSProtectionPlanCreate(d *pluginsdk.ResourceData, meta int
 
 	id := ddosprotectionplans.NewDdosProtectionPlanID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DdosProtectionPlanName, ddosProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
 	existing, err := client.Get(ctx, id)
@@ -126,9 +130,13 @@ func resourceNetworkDDoSProtectionPlanUpdate(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("retrieving %s: %+v", id, err)
 	}
 
-	locks.ByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DdosProtectionPlanName, ddosProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
 	existing, err := client.Get(ctx, *id)
@@ -227,10 +235,14 @@ func resourceNetworkDDoSProtectionPlanDelete(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DdosProtectionPlanName, ddosProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
 
-	locks.MultipleByName(virtualNetworksNamesToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, virtualNetworksNamesToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(virtualNetworksNamesToLock, VirtualNetworkResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
@@ -127,13 +127,19 @@ func resourceNetworkProfileCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("extracting names of Subnet and Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.NetworkProfileName, azureNetworkProfileResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkProfileName, azureNetworkProfileResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkProfileName, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, subnetsToLock, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
 
 	payload := networkprofiles.NetworkProfile{
@@ -183,13 +189,19 @@ func resourceNetworkProfileUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("extracting names of Subnet and Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.NetworkProfileName, azureNetworkProfileResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkProfileName, azureNetworkProfileResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkProfileName, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, subnetsToLock, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
 
 	if d.HasChange("container_network_interface") {
@@ -279,13 +291,19 @@ func resourceNetworkProfileDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("extracting names of Subnet and Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.NetworkProfileName, azureNetworkProfileResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkProfileName, azureNetworkProfileResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkProfileName, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, subnetsToLock, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
@@ -224,7 +224,9 @@ func resourceNetworkWatcherFlowLogCreate(d *pluginsdk.ResourceData, meta interfa
 		return tf.ImportAsExistsError("azurerm_network_watcher_flow_log", id.ID())
 	}
 
-	locks.ByID(nsgId.ID())
+	if err := locks.ByIDWithContext(ctx, nsgId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(nsgId.ID())
 
 	loc := d.Get("location").(string)
@@ -301,7 +303,9 @@ func resourceNetworkWatcherFlowLogUpdate(d *pluginsdk.ResourceData, meta interfa
 	if err != nil {
 		return err
 	}
-	locks.ByID(nsgId.ID())
+	if err := locks.ByIDWithContext(ctx, nsgId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(nsgId.ID())
 
 	if d.HasChange("storage_account_id") {
@@ -426,7 +430,9 @@ func resourceNetworkWatcherFlowLogDelete(d *pluginsdk.ResourceData, meta interfa
 		return fmt.Errorf("parsing %q as a Network Security Group ID: %+v", resp.Model.Properties.TargetResourceId, err)
 	}
 
-	locks.ByID(networkSecurityGroupId.ID())
+	if err := locks.ByIDWithContext(ctx, networkSecurityGroupId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(networkSecurityGroupId.ID())
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
@@ -73,7 +73,9 @@ func (p PrivateEndpointApplicationSecurityGroupAssociationResource) Create() sdk
 				return err
 			}
 
-			locks.ByName(privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint")
+			if err := locks.ByNameWithContext(ctx, privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint"); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint")
 
 			ASGClient := metadata.Client.Network.ApplicationSecurityGroups
@@ -82,7 +84,9 @@ func (p PrivateEndpointApplicationSecurityGroupAssociationResource) Create() sdk
 				return err
 			}
 
-			locks.ByName(ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group")
+			if err := locks.ByNameWithContext(ctx, ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group"); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group")
 
 			existingPrivateEndpoint, err := privateEndpointClient.Get(ctx, *privateEndpointId, privateendpoints.DefaultGetOperationOptions())
@@ -170,7 +174,9 @@ func (p PrivateEndpointApplicationSecurityGroupAssociationResource) Read() sdk.R
 				return err
 			}
 
-			locks.ByName(privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint")
+			if err := locks.ByNameWithContext(ctx, privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint"); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint")
 
 			ASGClient := metadata.Client.Network.ApplicationSecurityGroups
@@ -180,7 +186,9 @@ func (p PrivateEndpointApplicationSecurityGroupAssociationResource) Read() sdk.R
 				return err
 			}
 
-			locks.ByName(ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group")
+			if err := locks.ByNameWithContext(ctx, ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group"); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group")
 
 			existingPrivateEndpoint, err := privateEndpointClient.Get(ctx, *privateEndpointId, privateendpoints.DefaultGetOperationOptions())
@@ -248,7 +256,9 @@ func (p PrivateEndpointApplicationSecurityGroupAssociationResource) Delete() sdk
 				return err
 			}
 
-			locks.ByName(privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint")
+			if err := locks.ByNameWithContext(ctx, privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint"); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(privateEndpointId.PrivateEndpointName, "azurerm_private_endpoint")
 
 			ASGClient := metadata.Client.Network.ApplicationSecurityGroups
@@ -258,7 +268,9 @@ func (p PrivateEndpointApplicationSecurityGroupAssociationResource) Delete() sdk
 				return err
 			}
 
-			locks.ByName(ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group")
+			if err := locks.ByNameWithContext(ctx, ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group"); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(ASGId.ApplicationSecurityGroupName, "azurerm_application_security_group")
 
 			existingPrivateEndpoint, err := privateEndpointClient.Get(ctx, *privateEndpointId, privateendpoints.DefaultGetOperationOptions())
@@ -4,6 +4,7 @@
 package network
 
 import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -13,6 +14,8 @@ type Registration struct{}
 var (
 	_ sdk.TypedServiceRegistrationWithAGitHubLabel   = Registration{}
 	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithRetryRules          = Registration{}
+	_ sdk.ServiceRegistrationWithListResources       = Registration{}
 )
 
 // Name is the name of this Service
@@ -20,6 +23,23 @@ func (r Registration) Name() string {
 	return "Network"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Network",
+	}
+}
+
+// RetryRules returns the transient errors returned by the Resource Providers within this Service
+func (r Registration) RetryRules() []common.RetryRule {
+	// `ReferencedResourceNotProvisioned` is instead retried by the Resources which reference another resource
+	// (e.g. `azurerm_virtual_network_peering`), until the RetryPolicy has been proven against these APIs
+	return append(common.OperationInProgressRetryRules("Microsoft.Network"), common.RetryRule{
+		Namespace: "Microsoft.Network",
+		ErrorCode: "InProgressOperationOnReferencedResource",
+	})
+}
+
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/network"
 }
@@ -173,3 +193,10 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_web_application_firewall_policy":           resourceWebApplicationFirewallPolicy(),
 	}
 }
+
+// ListResources returns the List Resources supported by this Service
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		VirtualNetworkListResource{},
+	}
+}
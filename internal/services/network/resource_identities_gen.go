@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/adminrulecollections"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/adminrules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/connectivityconfigurations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/customipprefixes"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/networkgroups"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/networkmanagerconnections"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/networkmanagers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/scopeconnections"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/securityadminconfigurations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/staticmembers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/virtualwans"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(adminrulecollections.ValidateRuleCollectionID, pluginsdk.NewResourceIdentityType(&adminrulecollections.RuleCollectionId{}))
+	sdk.RegisterResourceIdentityType(adminrules.ValidateRuleID, pluginsdk.NewResourceIdentityType(&adminrules.RuleId{}))
+	sdk.RegisterResourceIdentityType(connectivityconfigurations.ValidateConnectivityConfigurationID, pluginsdk.NewResourceIdentityType(&connectivityconfigurations.ConnectivityConfigurationId{}))
+	sdk.RegisterResourceIdentityType(customipprefixes.ValidateCustomIPPrefixID, pluginsdk.NewResourceIdentityType(&customipprefixes.CustomIPPrefixId{}))
+	sdk.RegisterResourceIdentityType(networkgroups.ValidateNetworkGroupID, pluginsdk.NewResourceIdentityType(&networkgroups.NetworkGroupId{}))
+	sdk.RegisterResourceIdentityType(networkmanagerconnections.ValidateNetworkManagerConnectionID, pluginsdk.NewResourceIdentityType(&networkmanagerconnections.NetworkManagerConnectionId{}))
+	sdk.RegisterResourceIdentityType(networkmanagerconnections.ValidateProviders2NetworkManagerConnectionID, pluginsdk.NewResourceIdentityType(&networkmanagerconnections.Providers2NetworkManagerConnectionId{}))
+	sdk.RegisterResourceIdentityType(networkmanagers.ValidateNetworkManagerID, pluginsdk.NewResourceIdentityType(&networkmanagers.NetworkManagerId{}))
+	sdk.RegisterResourceIdentityType(scopeconnections.ValidateScopeConnectionID, pluginsdk.NewResourceIdentityType(&scopeconnections.ScopeConnectionId{}))
+	sdk.RegisterResourceIdentityType(securityadminconfigurations.ValidateSecurityAdminConfigurationID, pluginsdk.NewResourceIdentityType(&securityadminconfigurations.SecurityAdminConfigurationId{}))
+	sdk.RegisterResourceIdentityType(staticmembers.ValidateStaticMemberID, pluginsdk.NewResourceIdentityType(&staticmembers.StaticMemberId{}))
+	sdk.RegisterResourceIdentityType(virtualwans.ValidateRouteMapID, pluginsdk.NewResourceIdentityType(&virtualwans.RouteMapId{}))
+	sdk.RegisterResourceIdentityType(virtualwans.ValidateRoutingIntentID, pluginsdk.NewResourceIdentityType(&virtualwans.RoutingIntentId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.NetworkManagerDeploymentID, parse.NetworkManagerDeploymentID)
+}
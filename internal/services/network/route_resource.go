@@ -105,7 +105,9 @@ func resourceRouteCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_route", id.ID())
 	}
 
-	locks.ByName(id.RouteTableName, routeTableResourceName)
+	if err := locks.ByNameWithContext(ctx, id.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.RouteTableName, routeTableResourceName)
 
 	route := routes.Route{
@@ -152,7 +154,9 @@ func resourceRouteUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	payload := existing.Model
 
-	locks.ByName(id.RouteTableName, routeTableResourceName)
+	if err := locks.ByNameWithContext(ctx, id.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.RouteTableName, routeTableResourceName)
 
 	if d.HasChange("address_prefix") {
@@ -218,7 +222,9 @@ func resourceRouteDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.RouteTableName, routeTableResourceName)
+	if err := locks.ByNameWithContext(ctx, id.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.RouteTableName, routeTableResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
@@ -87,7 +87,9 @@ func resourceVirtualHubBgpConnectionCreate(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(virtHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, virtHubId.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtHubId.VirtualHubName, virtualHubResourceName)
 
 	id := commonids.NewVirtualHubBGPConnectionID(virtHubId.SubscriptionId, virtHubId.ResourceGroupName, virtHubId.VirtualHubName, d.Get("name").(string))
@@ -138,7 +140,9 @@ func resourceVirtualHubBgpConnectionUpdate(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(virtHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, virtHubId.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtHubId.VirtualHubName, virtualHubResourceName)
 
 	id, err := commonids.ParseVirtualHubBGPConnectionID(d.Id())
@@ -228,7 +232,9 @@ func resourceVirtualHubBgpConnectionDelete(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(id.HubName, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.HubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.HubName, virtualHubResourceName)
 
 	if err := client.VirtualHubBgpConnectionDeleteThenPoll(ctx, *id); err != nil {
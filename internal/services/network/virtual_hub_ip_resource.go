@@ -97,7 +97,9 @@ func resourceVirtualHubIPCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(virtualHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, virtualHubId.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualHubId.VirtualHubName, virtualHubResourceName)
 
 	id := commonids.NewVirtualHubIPConfigurationID(virtualHubId.SubscriptionId, virtualHubId.ResourceGroupName, virtualHubId.VirtualHubName, d.Get("name").(string))
@@ -154,7 +156,9 @@ func resourceVirtualHubIPUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(virtualHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, virtualHubId.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualHubId.VirtualHubName, virtualHubResourceName)
 
 	id, err := commonids.ParseVirtualHubIPConfigurationID(d.Id())
@@ -244,7 +248,9 @@ func resourceVirtualHubIPDelete(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualHubName, virtualHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
 
 	if err := client.VirtualHubIPConfigurationDeleteThenPoll(ctx, *id); err != nil {
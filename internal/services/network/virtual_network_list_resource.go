@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/virtualnetworks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.ListResource = VirtualNetworkListResource{}
+
+type VirtualNetworkListResource struct{}
+
+func (r VirtualNetworkListResource) ResourceType() string {
+	return "azurerm_virtual_network"
+}
+
+func (r VirtualNetworkListResource) IdentityType() pluginsdk.ResourceIdentityType {
+	return pluginsdk.NewResourceIdentityType(&commonids.VirtualNetworkId{})
+}
+
+func (r VirtualNetworkListResource) SupportsResourceGroupScope() bool {
+	return true
+}
+
+func (r VirtualNetworkListResource) List(ctx context.Context, client *clients.Client, scope sdk.ListResourceScope) ([]sdk.ListResourceResult, error) {
+	var items []virtualnetworks.VirtualNetwork
+	if scope.ResourceGroupId != nil {
+		resp, err := client.Network.VirtualNetworks.ListComplete(ctx, *scope.ResourceGroupId)
+		if err != nil {
+			return nil, fmt.Errorf("listing Virtual Networks within %s: %+v", scope.ResourceGroupId, err)
+		}
+		items = resp.Items
+	} else {
+		resp, err := client.Network.VirtualNetworks.ListAllComplete(ctx, scope.SubscriptionId)
+		if err != nil {
+			return nil, fmt.Errorf("listing Virtual Networks within %s: %+v", scope.SubscriptionId, err)
+		}
+		items = resp.Items
+	}
+
+	results := make([]sdk.ListResourceResult, 0)
+	for _, item := range items {
+		id, err := commonids.ParseVirtualNetworkIDInsensitively(pointer.From(item.Id))
+		if err != nil {
+			// skipped rather than failing the whole listing, since the remaining instances can still be imported
+			log.Printf("[WARN] parsing %q, skipping this Virtual Network: %+v", pointer.From(item.Id), err)
+			continue
+		}
+
+		results = append(results, sdk.ListResourceResult{
+			ID:          id.ID(),
+			DisplayName: pointer.From(item.Name),
+		})
+	}
+
+	return results, nil
+}
@@ -351,7 +351,9 @@ func resourceVpnGatewayConnectionResourceCreate(d *pluginsdk.ResourceData, meta
 		return tf.ImportAsExistsError("azurerm_vpn_gateway_connection", id.ID())
 	}
 
-	locks.ByName(gatewayId.VpnGatewayName, VPNGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, gatewayId.VpnGatewayName, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(gatewayId.VpnGatewayName, VPNGatewayResourceName)
 
 	payload := virtualwans.VpnConnection{
@@ -463,7 +465,9 @@ func resourceVpnGatewayConnectionResourceUpdate(d *pluginsdk.ResourceData, meta
 
 	gatewayId := virtualwans.NewVpnGatewayID(id.SubscriptionId, id.ResourceGroupName, id.GatewayName)
 
-	locks.ByName(gatewayId.VpnGatewayName, VPNGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, gatewayId.VpnGatewayName, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(gatewayId.VpnGatewayName, VPNGatewayResourceName)
 
 	if d.HasChange("internet_security_enabled") {
@@ -500,7 +504,9 @@ func resourceVpnGatewayConnectionResourceDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.GatewayName, VPNGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, id.GatewayName, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.GatewayName, VPNGatewayResourceName)
 
 	if err := client.VpnConnectionsDeleteThenPoll(ctx, *id); err != nil {
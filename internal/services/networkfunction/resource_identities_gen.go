@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package networkfunction
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/networkfunction/2022-11-01/azuretrafficcollectors"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/networkfunction/2022-11-01/collectorpolicies"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(azuretrafficcollectors.ValidateAzureTrafficCollectorID, pluginsdk.NewResourceIdentityType(&azuretrafficcollectors.AzureTrafficCollectorId{}))
+	sdk.RegisterResourceIdentityType(collectorpolicies.ValidateCollectorPolicyID, pluginsdk.NewResourceIdentityType(&collectorpolicies.CollectorPolicyId{}))
+}
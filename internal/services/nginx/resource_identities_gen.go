@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package nginx
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/nginx/2024-01-01-preview/nginxcertificate"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/nginx/2024-01-01-preview/nginxconfiguration"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/nginx/2024-01-01-preview/nginxdeployment"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(nginxcertificate.ValidateCertificateID, pluginsdk.NewResourceIdentityType(&nginxcertificate.CertificateId{}))
+	sdk.RegisterResourceIdentityType(nginxconfiguration.ValidateConfigurationID, pluginsdk.NewResourceIdentityType(&nginxconfiguration.ConfigurationId{}))
+	sdk.RegisterResourceIdentityType(nginxdeployment.ValidateNginxDeploymentID, pluginsdk.NewResourceIdentityType(&nginxdeployment.NginxDeploymentId{}))
+}
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package orbital
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/orbital/2022-11-01/contact"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/orbital/2022-11-01/contactprofile"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/orbital/2022-11-01/spacecraft"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(contact.ValidateContactID, pluginsdk.NewResourceIdentityType(&contact.ContactId{}))
+	sdk.RegisterResourceIdentityType(contactprofile.ValidateContactProfileID, pluginsdk.NewResourceIdentityType(&contactprofile.ContactProfileId{}))
+	sdk.RegisterResourceIdentityType(spacecraft.ValidateSpacecraftID, pluginsdk.NewResourceIdentityType(&spacecraft.SpacecraftId{}))
+}
@@ -23,6 +23,13 @@ func (r Registration) Name() string {
 	return "Palo Alto"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"PaloAltoNetworks.Cloudngfw",
+	}
+}
+
 func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{
 		LocalRulestackDataSource{},
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package paloalto
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/networkvirtualappliances"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/paloaltonetworks/2022-08-29/certificateobjectlocalrulestack"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/paloaltonetworks/2022-08-29/fqdnlistlocalrulestack"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/paloaltonetworks/2022-08-29/localrules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/paloaltonetworks/2022-08-29/localrulestacks"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/paloaltonetworks/2022-08-29/prefixlistlocalrulestack"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/paloaltonetworks/2023-09-01/firewalls"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(networkvirtualappliances.ValidateNetworkVirtualApplianceID, pluginsdk.NewResourceIdentityType(&networkvirtualappliances.NetworkVirtualApplianceId{}))
+	sdk.RegisterResourceIdentityType(certificateobjectlocalrulestack.ValidateLocalRulestackCertificateID, pluginsdk.NewResourceIdentityType(&certificateobjectlocalrulestack.LocalRulestackCertificateId{}))
+	sdk.RegisterResourceIdentityType(fqdnlistlocalrulestack.ValidateLocalRulestackFqdnListID, pluginsdk.NewResourceIdentityType(&fqdnlistlocalrulestack.LocalRulestackFqdnListId{}))
+	sdk.RegisterResourceIdentityType(localrules.ValidateLocalRuleID, pluginsdk.NewResourceIdentityType(&localrules.LocalRuleId{}))
+	sdk.RegisterResourceIdentityType(localrulestacks.ValidateLocalRulestackID, pluginsdk.NewResourceIdentityType(&localrulestacks.LocalRulestackId{}))
+	sdk.RegisterResourceIdentityType(prefixlistlocalrulestack.ValidateLocalRulestackPrefixListID, pluginsdk.NewResourceIdentityType(&prefixlistlocalrulestack.LocalRulestackPrefixListId{}))
+	sdk.RegisterResourceIdentityType(firewalls.ValidateFirewallID, pluginsdk.NewResourceIdentityType(&firewalls.FirewallId{}))
+}
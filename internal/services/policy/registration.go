@@ -39,6 +39,15 @@ func (r Registration) Name() string {
 	return "Policy"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Authorization",
+		"Microsoft.GuestConfiguration",
+		"Microsoft.PolicyInsights",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
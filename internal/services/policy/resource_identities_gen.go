@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package policy
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/policy/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/policy/validate"
+)
+
+func init() {
+	sdk.RegisterLegacyResourceIdentityType(validate.ManagementGroupAssignmentID, parse.ManagementGroupAssignmentID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ResourceGroupAssignmentID, parse.ResourceGroupAssignmentID)
+	sdk.RegisterLegacyResourceIdentityType(validate.SubscriptionAssignmentID, parse.SubscriptionAssignmentID)
+}
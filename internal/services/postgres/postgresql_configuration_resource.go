@@ -69,7 +69,9 @@ func resourcePostgreSQLConfigurationCreate(d *pluginsdk.ResourceData, meta inter
 	id := configurations.NewConfigurationID(subscriptionId, d.Get("resource_group_name").(string), d.Get("server_name").(string), d.Get("name").(string))
 	// TODO: support RequiresImport - this is possible to tell if it's the non-default value from the API (see Delete)
 
-	locks.ByName(id.ServerName, postgreSQLServerResourceName)
+	if err := locks.ByNameWithContext(ctx, id.ServerName, postgreSQLServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ServerName, postgreSQLServerResourceName)
 
 	properties := configurations.Configuration{
@@ -130,7 +132,9 @@ func resourcePostgreSQLConfigurationDelete(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(id.ServerName, postgreSQLServerResourceName)
+	if err := locks.ByNameWithContext(ctx, id.ServerName, postgreSQLServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ServerName, postgreSQLServerResourceName)
 
 	// "delete" = resetting this to the default value
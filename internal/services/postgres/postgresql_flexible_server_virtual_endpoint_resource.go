@@ -104,7 +104,9 @@ func (r PostgresqlFlexibleServerVirtualEndpointResource) Create() sdk.ResourceFu
 
 			id := virtualendpoints.NewVirtualEndpointID(sourceServerId.SubscriptionId, sourceServerId.ResourceGroupName, sourceServerId.FlexibleServerName, virtualEndpoint.Name)
 
-			locks.ByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
+			if err := locks.ByNameWithContext(ctx, id.FlexibleServerName, postgresqlFlexibleServerResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
 
 			// This API can be a bit flaky if the same named resource is created/destroyed quickly
@@ -181,7 +183,9 @@ func (r PostgresqlFlexibleServerVirtualEndpointResource) Delete() sdk.ResourceFu
 				return err
 			}
 
-			locks.ByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
+			if err := locks.ByNameWithContext(ctx, id.FlexibleServerName, postgresqlFlexibleServerResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
 
 			if err := DeletePostgresFlexibileServerVirtualEndpoint(ctx, client, id); err != nil {
@@ -214,7 +218,9 @@ func (r PostgresqlFlexibleServerVirtualEndpointResource) Update() sdk.ResourceFu
 				return err
 			}
 
-			locks.ByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
+			if err := locks.ByNameWithContext(ctx, id.FlexibleServerName, postgresqlFlexibleServerResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
 
 			if err := client.UpdateThenPoll(ctx, *id, virtualendpoints.VirtualEndpointResourceForPatch{
@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package privatednsresolver
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dnsresolver/2022-07-01/dnsforwardingrulesets"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dnsresolver/2022-07-01/dnsresolvers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dnsresolver/2022-07-01/forwardingrules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dnsresolver/2022-07-01/inboundendpoints"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dnsresolver/2022-07-01/outboundendpoints"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dnsresolver/2022-07-01/virtualnetworklinks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(dnsforwardingrulesets.ValidateDnsForwardingRulesetID, pluginsdk.NewResourceIdentityType(&dnsforwardingrulesets.DnsForwardingRulesetId{}))
+	sdk.RegisterResourceIdentityType(dnsresolvers.ValidateDnsResolverID, pluginsdk.NewResourceIdentityType(&dnsresolvers.DnsResolverId{}))
+	sdk.RegisterResourceIdentityType(forwardingrules.ValidateForwardingRuleID, pluginsdk.NewResourceIdentityType(&forwardingrules.ForwardingRuleId{}))
+	sdk.RegisterResourceIdentityType(inboundendpoints.ValidateInboundEndpointID, pluginsdk.NewResourceIdentityType(&inboundendpoints.InboundEndpointId{}))
+	sdk.RegisterResourceIdentityType(outboundendpoints.ValidateOutboundEndpointID, pluginsdk.NewResourceIdentityType(&outboundendpoints.OutboundEndpointId{}))
+	sdk.RegisterResourceIdentityType(virtualnetworklinks.ValidateVirtualNetworkLinkID, pluginsdk.NewResourceIdentityType(&virtualnetworklinks.VirtualNetworkLinkId{}))
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recoveryservices
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protectionpolicies"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/resourceguardproxy"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2022-10-01/replicationfabrics"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2022-10-01/replicationnetworkmappings"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2022-10-01/replicationpolicies"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2022-10-01/replicationprotecteditems"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2022-10-01/replicationprotectioncontainermappings"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2022-10-01/replicationrecoveryplans"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/recoveryservices/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/recoveryservices/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(protectionpolicies.ValidateBackupPolicyID, pluginsdk.NewResourceIdentityType(&protectionpolicies.BackupPolicyId{}))
+	sdk.RegisterResourceIdentityType(resourceguardproxy.ValidateBackupResourceGuardProxyID, pluginsdk.NewResourceIdentityType(&resourceguardproxy.BackupResourceGuardProxyId{}))
+	sdk.RegisterResourceIdentityType(replicationfabrics.ValidateReplicationFabricID, pluginsdk.NewResourceIdentityType(&replicationfabrics.ReplicationFabricId{}))
+	sdk.RegisterResourceIdentityType(replicationnetworkmappings.ValidateReplicationNetworkMappingID, pluginsdk.NewResourceIdentityType(&replicationnetworkmappings.ReplicationNetworkMappingId{}))
+	sdk.RegisterResourceIdentityType(replicationpolicies.ValidateReplicationPolicyID, pluginsdk.NewResourceIdentityType(&replicationpolicies.ReplicationPolicyId{}))
+	sdk.RegisterResourceIdentityType(replicationprotecteditems.ValidateReplicationProtectedItemID, pluginsdk.NewResourceIdentityType(&replicationprotecteditems.ReplicationProtectedItemId{}))
+	sdk.RegisterResourceIdentityType(replicationprotectioncontainermappings.ValidateReplicationProtectionContainerMappingID, pluginsdk.NewResourceIdentityType(&replicationprotectioncontainermappings.ReplicationProtectionContainerMappingId{}))
+	sdk.RegisterResourceIdentityType(replicationrecoveryplans.ValidateReplicationRecoveryPlanID, pluginsdk.NewResourceIdentityType(&replicationrecoveryplans.ReplicationRecoveryPlanId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.ReplicationPolicyID, parse.ReplicationPolicyID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ReplicationProtectionContainerMappingsID, parse.ReplicationProtectionContainerMappingsID)
+}
@@ -16,6 +16,13 @@ func (r Registration) Name() string {
 	return "Red Hat OpenShift"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.RedHatOpenShift",
+	}
+}
+
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/redhatopenshift"
 }
@@ -97,7 +97,9 @@ func (r RedisCacheAccessPolicyResource) Create() sdk.ResourceFunc {
 				},
 			}
 
-			locks.ByID(model.RedisCacheID)
+			if err := locks.ByIDWithContext(ctx, model.RedisCacheID); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(model.RedisCacheID)
 
 			if err := client.AccessPolicyCreateUpdateThenPoll(ctx, id, createInput); err != nil {
@@ -144,7 +146,9 @@ func (r RedisCacheAccessPolicyResource) Update() sdk.ResourceFunc {
 				model.Properties.Permissions = state.Permissions
 			}
 
-			locks.ByID(state.RedisCacheID)
+			if err := locks.ByIDWithContext(ctx, state.RedisCacheID); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(state.RedisCacheID)
 
 			if err := client.AccessPolicyCreateUpdateThenPoll(ctx, *id, model); err != nil {
@@ -206,7 +210,9 @@ func (r RedisCacheAccessPolicyResource) Delete() sdk.ResourceFunc {
 				return fmt.Errorf("while parsing resource ID: %+v", err)
 			}
 
-			locks.ByID(model.RedisCacheID)
+			if err := locks.ByIDWithContext(ctx, model.RedisCacheID); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(model.RedisCacheID)
 
 			if err := client.AccessPolicyDeleteThenPoll(ctx, *id); err != nil {
@@ -544,10 +544,14 @@ func resourceRedisCacheCreate(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
+		if err := locks.ByNameWithContext(ctx, parsed.VirtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
 
-		locks.ByName(parsed.SubnetName, network.SubnetResourceName)
+		if err := locks.ByNameWithContext(ctx, parsed.SubnetName, network.SubnetResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(parsed.SubnetName, network.SubnetResourceName)
 
 		parameters.Properties.SubnetId = utils.String(v.(string))
@@ -868,10 +872,14 @@ func resourceRedisCacheDelete(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
+		if err := locks.ByNameWithContext(ctx, parsed.VirtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
 
-		locks.ByName(parsed.SubnetName, network.SubnetResourceName)
+		if err := locks.ByNameWithContext(ctx, parsed.SubnetName, network.SubnetResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(parsed.SubnetName, network.SubnetResourceName)
 	}
 
@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package redis
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/redis/2024-03-01/redis"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(redis.ValidateAccessPolicyAssignmentID, pluginsdk.NewResourceIdentityType(&redis.AccessPolicyAssignmentId{}))
+	sdk.RegisterResourceIdentityType(redis.ValidateAccessPolicyID, pluginsdk.NewResourceIdentityType(&redis.AccessPolicyId{}))
+}
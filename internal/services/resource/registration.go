@@ -10,8 +10,9 @@ import (
 )
 
 var (
-	_ sdk.TypedServiceRegistration   = Registration{}
-	_ sdk.UntypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration             = Registration{}
+	_ sdk.UntypedServiceRegistration           = Registration{}
+	_ sdk.ServiceRegistrationWithListResources = Registration{}
 )
 
 type Registration struct{}
@@ -21,6 +22,13 @@ func (r Registration) Name() string {
 	return "Resources"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Resources",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
@@ -76,3 +84,10 @@ func (r Registration) Resources() []sdk.Resource {
 		ResourceDeploymentScriptAzureCliResource{},
 	}
 }
+
+// ListResources returns the List Resources supported by this Service
+func (r Registration) ListResources() []sdk.ListResource {
+	return []sdk.ListResource{
+		ResourceGroupListResource{},
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.ListResource = ResourceGroupListResource{}
+
+type ResourceGroupListResource struct{}
+
+func (r ResourceGroupListResource) ResourceType() string {
+	return "azurerm_resource_group"
+}
+
+func (r ResourceGroupListResource) IdentityType() pluginsdk.ResourceIdentityType {
+	return pluginsdk.NewResourceIdentityType(&commonids.ResourceGroupId{})
+}
+
+func (r ResourceGroupListResource) SupportsResourceGroupScope() bool {
+	return false
+}
+
+func (r ResourceGroupListResource) List(ctx context.Context, client *clients.Client, scope sdk.ListResourceScope) ([]sdk.ListResourceResult, error) {
+	resp, err := client.Resource.ResourceGroupsClient.ListComplete(ctx, scope.SubscriptionId, resourcegroups.DefaultListOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing Resource Groups within %s: %+v", scope.SubscriptionId, err)
+	}
+
+	results := make([]sdk.ListResourceResult, 0)
+	for _, item := range resp.Items {
+		id, err := commonids.ParseResourceGroupIDInsensitively(pointer.From(item.Id))
+		if err != nil {
+			// skipped rather than failing the whole listing, since the remaining instances can still be imported
+			log.Printf("[WARN] parsing %q, skipping this Resource Group: %+v", pointer.From(item.Id), err)
+			continue
+		}
+
+		results = append(results, sdk.ListResourceResult{
+			ID:          id.ID(),
+			DisplayName: id.ResourceGroupName,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/privatelinkassociation"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/resourcemanagementprivatelink"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-10-01/deploymentscripts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(privatelinkassociation.ValidatePrivateLinkAssociationID, pluginsdk.NewResourceIdentityType(&privatelinkassociation.PrivateLinkAssociationId{}))
+	sdk.RegisterResourceIdentityType(resourcemanagementprivatelink.ValidateResourceManagementPrivateLinkID, pluginsdk.NewResourceIdentityType(&resourcemanagementprivatelink.ResourceManagementPrivateLinkId{}))
+	sdk.RegisterResourceIdentityType(deploymentscripts.ValidateDeploymentScriptID, pluginsdk.NewResourceIdentityType(&deploymentscripts.DeploymentScriptId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.ResourceProviderID, parse.ResourceProviderID)
+}
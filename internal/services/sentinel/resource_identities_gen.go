@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sentinel
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-10-01-preview/alertrules"
+	sentinelmetadata "github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-10-01-preview/metadata"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-11-01/sentinelonboardingstates"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-11-01/watchlistitems"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-11-01/watchlists"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(alertrules.ValidateAlertRuleID, pluginsdk.NewResourceIdentityType(&alertrules.AlertRuleId{}))
+	sdk.RegisterResourceIdentityType(sentinelmetadata.ValidateMetadataID, pluginsdk.NewResourceIdentityType(&sentinelmetadata.MetadataId{}))
+	sdk.RegisterResourceIdentityType(sentinelonboardingstates.ValidateOnboardingStateID, pluginsdk.NewResourceIdentityType(&sentinelonboardingstates.OnboardingStateId{}))
+	sdk.RegisterResourceIdentityType(watchlistitems.ValidateWatchlistItemID, pluginsdk.NewResourceIdentityType(&watchlistitems.WatchlistItemId{}))
+	sdk.RegisterResourceIdentityType(watchlists.ValidateWatchlistID, pluginsdk.NewResourceIdentityType(&watchlists.WatchlistId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.DataConnectorID, parse.DataConnectorID)
+	sdk.RegisterLegacyResourceIdentityType(validate.MLAnalyticsSettingsID, parse.MLAnalyticsSettingsID)
+	sdk.RegisterLegacyResourceIdentityType(validate.ThreatIntelligenceIndicatorID, parse.ThreatIntelligenceIndicatorID)
+}
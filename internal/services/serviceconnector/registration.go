@@ -34,3 +34,10 @@ func (r Registration) Resources() []sdk.Resource {
 func (r Registration) Name() string {
 	return "ServiceConnector"
 }
+
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.ServiceLinker",
+	}
+}
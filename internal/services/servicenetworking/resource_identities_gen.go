@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package servicenetworking
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/servicenetworking/2023-11-01/associationsinterface"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/servicenetworking/2023-11-01/frontendsinterface"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/servicenetworking/2023-11-01/trafficcontrollerinterface"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(associationsinterface.ValidateAssociationID, pluginsdk.NewResourceIdentityType(&associationsinterface.AssociationId{}))
+	sdk.RegisterResourceIdentityType(frontendsinterface.ValidateFrontendID, pluginsdk.NewResourceIdentityType(&frontendsinterface.FrontendId{}))
+	sdk.RegisterResourceIdentityType(trafficcontrollerinterface.ValidateTrafficControllerID, pluginsdk.NewResourceIdentityType(&trafficcontrollerinterface.TrafficControllerId{}))
+}
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package signalr
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/signalr/2023-02-01/signalr"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/webpubsub/2023-02-01/webpubsub"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(signalr.ValidateCustomCertificateID, pluginsdk.NewResourceIdentityType(&signalr.CustomCertificateId{}))
+	sdk.RegisterResourceIdentityType(signalr.ValidateCustomDomainID, pluginsdk.NewResourceIdentityType(&signalr.CustomDomainId{}))
+	sdk.RegisterResourceIdentityType(webpubsub.ValidateCustomCertificateID, pluginsdk.NewResourceIdentityType(&webpubsub.CustomCertificateId{}))
+	sdk.RegisterResourceIdentityType(webpubsub.ValidateCustomDomainID, pluginsdk.NewResourceIdentityType(&webpubsub.CustomDomainId{}))
+}
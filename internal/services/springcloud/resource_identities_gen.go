@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package springcloud
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/appplatform/2024-01-01-preview/appplatform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/springcloud/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/springcloud/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(appplatform.ValidateApiPortalID, pluginsdk.NewResourceIdentityType(&appplatform.ApiPortalId{}))
+	sdk.RegisterResourceIdentityType(appplatform.ValidateApmID, pluginsdk.NewResourceIdentityType(&appplatform.ApmId{}))
+	sdk.RegisterResourceIdentityType(appplatform.ValidateCustomizedAcceleratorID, pluginsdk.NewResourceIdentityType(&appplatform.CustomizedAcceleratorId{}))
+	sdk.RegisterResourceIdentityType(appplatform.ValidateGatewayID, pluginsdk.NewResourceIdentityType(&appplatform.GatewayId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.SpringCloudAcceleratorID, parse.SpringCloudAcceleratorID)
+	sdk.RegisterLegacyResourceIdentityType(validate.SpringCloudApplicationLiveViewID, parse.SpringCloudApplicationLiveViewID)
+	sdk.RegisterLegacyResourceIdentityType(validate.SpringCloudConfigurationServiceID, parse.SpringCloudConfigurationServiceID)
+	sdk.RegisterLegacyResourceIdentityType(validate.SpringCloudDevToolPortalID, parse.SpringCloudDevToolPortalID)
+}
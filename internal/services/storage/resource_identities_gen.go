@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/localusers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/serverendpointresource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(localusers.ValidateLocalUserID, pluginsdk.NewResourceIdentityType(&localusers.LocalUserId{}))
+	sdk.RegisterResourceIdentityType(serverendpointresource.ValidateServerEndpointID, pluginsdk.NewResourceIdentityType(&serverendpointresource.ServerEndpointId{}))
+	sdk.RegisterLegacyResourceIdentityType(validate.StorageContainerImmutabilityPolicyID, parse.StorageContainerImmutabilityPolicyID)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+)
+
+var _ ephemeral.EphemeralResource = &StorageAccountBlobContainerSasEphemeralResource{}
+
+func NewStorageAccountBlobContainerSasEphemeralResource() ephemeral.EphemeralResource {
+	return &StorageAccountBlobContainerSasEphemeralResource{}
+}
+
+type StorageAccountBlobContainerSasEphemeralResource struct{}
+
+type StorageAccountBlobContainerSasEphemeralResourceModel struct {
+	ConnectionString   types.String                                     `tfsdk:"connection_string"`
+	ContainerName      types.String                                     `tfsdk:"container_name"`
+	HttpsOnly          types.Bool                                       `tfsdk:"https_only"`
+	IpAddress          types.String                                     `tfsdk:"ip_address"`
+	Start              types.String                                     `tfsdk:"start"`
+	Expiry             types.String                                     `tfsdk:"expiry"`
+	Permissions        []StorageAccountBlobContainerSasPermissionsModel `tfsdk:"permissions"`
+	CacheControl       types.String                                     `tfsdk:"cache_control"`
+	ContentDisposition types.String                                     `tfsdk:"content_disposition"`
+	ContentEncoding    types.String                                     `tfsdk:"content_encoding"`
+	ContentLanguage    types.String                                     `tfsdk:"content_language"`
+	ContentType        types.String                                     `tfsdk:"content_type"`
+	Sas                types.String                                     `tfsdk:"sas"`
+}
+
+type StorageAccountBlobContainerSasPermissionsModel struct {
+	Read   bool `tfsdk:"read"`
+	Add    bool `tfsdk:"add"`
+	Create bool `tfsdk:"create"`
+	Write  bool `tfsdk:"write"`
+	Delete bool `tfsdk:"delete"`
+	List   bool `tfsdk:"list"`
+}
+
+func (e *StorageAccountBlobContainerSasEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = "azurerm_storage_account_blob_container_sas"
+}
+
+func (e *StorageAccountBlobContainerSasEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	requiredBool := func() schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Required: true,
+		}
+	}
+
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"connection_string": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"container_name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"https_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Defaults to `true`.",
+			},
+
+			"ip_address": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: storageValidate.SharedAccessSignatureIP,
+					},
+				},
+			},
+
+			"start": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validate.ISO8601DateTime,
+					},
+				},
+			},
+
+			"expiry": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validate.ISO8601DateTime,
+					},
+				},
+			},
+
+			"cache_control": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"content_disposition": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"content_encoding": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"content_language": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"content_type": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"sas": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"permissions": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"read":   requiredBool(),
+						"add":    requiredBool(),
+						"create": requiredBool(),
+						"write":  requiredBool(),
+						"delete": requiredBool(),
+						"list":   requiredBool(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (e *StorageAccountBlobContainerSasEphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	var data StorageAccountBlobContainerSasEphemeralResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	perms := data.Permissions[0]
+	permissions := BuildContainerPermissionsString(map[string]interface{}{
+		"read":   perms.Read,
+		"add":    perms.Add,
+		"create": perms.Create,
+		"write":  perms.Write,
+		"delete": perms.Delete,
+		"list":   perms.List,
+	})
+
+	// Parse the connection string
+	kvp, err := storage.ParseAccountSASConnectionString(data.ConnectionString.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Error parsing Connection String", err.Error())
+		return
+	}
+
+	accountName := kvp[connStringAccountNameKey]
+	accountKey := kvp[connStringAccountKeyKey]
+	signedProtocol := "https,http"
+	if data.HttpsOnly.IsNull() || data.HttpsOnly.ValueBool() {
+		signedProtocol = "https"
+	}
+	signedIdentifier := ""
+	signedSnapshotTime := ""
+
+	sasToken, err := storage.ComputeContainerSASToken(permissions, data.Start.ValueString(), data.Expiry.ValueString(), accountName, accountKey,
+		data.ContainerName.ValueString(), signedIdentifier, data.IpAddress.ValueString(), signedProtocol, signedSnapshotTime, data.CacheControl.ValueString(),
+		data.ContentDisposition.ValueString(), data.ContentEncoding.ValueString(), data.ContentLanguage.ValueString(), data.ContentType.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Error computing Container SAS Token", err.Error())
+		return
+	}
+
+	data.Sas = types.StringValue(sasToken)
+
+	response.Diagnostics.Append(response.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.ListResource = StorageAccountListResource{}
+
+type StorageAccountListResource struct{}
+
+func (r StorageAccountListResource) ResourceType() string {
+	return "azurerm_storage_account"
+}
+
+func (r StorageAccountListResource) IdentityType() pluginsdk.ResourceIdentityType {
+	return pluginsdk.NewResourceIdentityType(&commonids.StorageAccountId{})
+}
+
+func (r StorageAccountListResource) SupportsResourceGroupScope() bool {
+	return true
+}
+
+func (r StorageAccountListResource) List(ctx context.Context, client *clients.Client, scope sdk.ListResourceScope) ([]sdk.ListResourceResult, error) {
+	var items []storageaccounts.StorageAccount
+	if scope.ResourceGroupId != nil {
+		resp, err := client.Storage.ResourceManager.StorageAccounts.ListByResourceGroupComplete(ctx, *scope.ResourceGroupId)
+		if err != nil {
+			return nil, fmt.Errorf("listing Storage Accounts within %s: %+v", scope.ResourceGroupId, err)
+		}
+		items = resp.Items
+	} else {
+		resp, err := client.Storage.ResourceManager.StorageAccounts.ListComplete(ctx, scope.SubscriptionId)
+		if err != nil {
+			return nil, fmt.Errorf("listing Storage Accounts within %s: %+v", scope.SubscriptionId, err)
+		}
+		items = resp.Items
+	}
+
+	results := make([]sdk.ListResourceResult, 0)
+	for _, item := range items {
+		id, err := commonids.ParseStorageAccountIDInsensitively(pointer.From(item.Id))
+		if err != nil {
+			// skipped rather than failing the whole listing, since the remaining instances can still be imported
+			log.Printf("[WARN] parsing %q, skipping this Storage Account: %+v", pointer.From(item.Id), err)
+			continue
+		}
+
+		results = append(results, sdk.ListResourceResult{
+			ID:          id.ID(),
+			DisplayName: pointer.From(item.Name),
+		})
+	}
+
+	return results, nil
+}
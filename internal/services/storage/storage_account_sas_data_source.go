@@ -23,11 +23,6 @@ const (
 // This is an ACCOUNT SAS : https://docs.microsoft.com/en-us/rest/api/storageservices/Constructing-an-Account-SAS
 // not Service SAS
 func dataSourceStorageAccountSharedAccessSignature() *pluginsdk.Resource {
-	var sasSignedVersion = "2017-07-29"
-	if features.FourPointOhBeta() {
-		// TODO: Update the document as well
-		sasSignedVersion = "2022-11-02"
-	}
 	return &pluginsdk.Resource{
 		Read: dataSourceStorageAccountSasRead,
 
@@ -60,7 +55,7 @@ func dataSourceStorageAccountSharedAccessSignature() *pluginsdk.Resource {
 			"signed_version": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
-				Default:  sasSignedVersion,
+				Default:  defaultStorageAccountSasSignedVersion(),
 			},
 
 			"resource_types": {
@@ -246,6 +241,14 @@ func dataSourceStorageAccountSasRead(d *pluginsdk.ResourceData, _ interface{}) e
 	return nil
 }
 
+func defaultStorageAccountSasSignedVersion() string {
+	if features.FourPointOhBeta() {
+		// TODO: Update the document as well
+		return "2022-11-02"
+	}
+	return "2017-07-29"
+}
+
 func BuildPermissionsString(perms map[string]interface{}) string {
 	retVal := ""
 
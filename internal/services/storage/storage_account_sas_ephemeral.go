@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ ephemeral.EphemeralResource = &StorageAccountSasEphemeralResource{}
+
+func NewStorageAccountSasEphemeralResource() ephemeral.EphemeralResource {
+	return &StorageAccountSasEphemeralResource{}
+}
+
+// StorageAccountSasEphemeralResource generates an ACCOUNT SAS : https://docs.microsoft.com/en-us/rest/api/storageservices/Constructing-an-Account-SAS
+// which (unlike the `azurerm_storage_account_sas` Data Source) is never persisted to the Plan or State.
+type StorageAccountSasEphemeralResource struct{}
+
+type StorageAccountSasEphemeralResourceModel struct {
+	ConnectionString types.String                          `tfsdk:"connection_string"`
+	HttpsOnly        types.Bool                            `tfsdk:"https_only"`
+	IpAddresses      types.String                          `tfsdk:"ip_addresses"`
+	SignedVersion    types.String                          `tfsdk:"signed_version"`
+	ResourceTypes    []StorageAccountSasResourceTypesModel `tfsdk:"resource_types"`
+	Services         []StorageAccountSasServicesModel      `tfsdk:"services"`
+	Start            types.String                          `tfsdk:"start"`
+	Expiry           types.String                          `tfsdk:"expiry"`
+	Permissions      []StorageAccountSasPermissionsModel   `tfsdk:"permissions"`
+	Sas              types.String                          `tfsdk:"sas"`
+}
+
+type StorageAccountSasResourceTypesModel struct {
+	Service   bool `tfsdk:"service"`
+	Container bool `tfsdk:"container"`
+	Object    bool `tfsdk:"object"`
+}
+
+type StorageAccountSasServicesModel struct {
+	Blob  bool `tfsdk:"blob"`
+	Queue bool `tfsdk:"queue"`
+	Table bool `tfsdk:"table"`
+	File  bool `tfsdk:"file"`
+}
+
+type StorageAccountSasPermissionsModel struct {
+	Read    bool `tfsdk:"read"`
+	Write   bool `tfsdk:"write"`
+	Delete  bool `tfsdk:"delete"`
+	List    bool `tfsdk:"list"`
+	Add     bool `tfsdk:"add"`
+	Create  bool `tfsdk:"create"`
+	Update  bool `tfsdk:"update"`
+	Process bool `tfsdk:"process"`
+	Tag     bool `tfsdk:"tag"`
+	Filter  bool `tfsdk:"filter"`
+}
+
+func (e *StorageAccountSasEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = "azurerm_storage_account_sas"
+}
+
+func (e *StorageAccountSasEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	requiredBool := func() schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Required: true,
+		}
+	}
+
+	response.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"connection_string": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"https_only": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Defaults to `true`.",
+			},
+
+			"ip_addresses": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validation.Any(
+							validation.IsIPv4Address,
+							validation.IsIPv4Range,
+						),
+					},
+				},
+			},
+
+			"signed_version": schema.StringAttribute{
+				Optional: true,
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"start": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validate.ISO8601DateTime,
+					},
+				},
+			},
+
+			// Always in UTC and must be ISO-8601 format
+			"expiry": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					frameworkhelpers.WrappedStringValidator{
+						Func: validate.ISO8601DateTime,
+					},
+				},
+			},
+
+			"sas": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"resource_types": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"service":   requiredBool(),
+						"container": requiredBool(),
+						"object":    requiredBool(),
+					},
+				},
+			},
+
+			"services": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"blob":  requiredBool(),
+						"queue": requiredBool(),
+						"table": requiredBool(),
+						"file":  requiredBool(),
+					},
+				},
+			},
+
+			"permissions": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"read":    requiredBool(),
+						"write":   requiredBool(),
+						"delete":  requiredBool(),
+						"list":    requiredBool(),
+						"add":     requiredBool(),
+						"create":  requiredBool(),
+						"update":  requiredBool(),
+						"process": requiredBool(),
+						"tag":     requiredBool(),
+						"filter":  requiredBool(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (e *StorageAccountSasEphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	var data StorageAccountSasEphemeralResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	resourceTypes := data.ResourceTypes[0]
+	services := data.Services[0]
+	perms := data.Permissions[0]
+
+	resourceTypesString := BuildResourceTypesString(map[string]interface{}{
+		"service":   resourceTypes.Service,
+		"container": resourceTypes.Container,
+		"object":    resourceTypes.Object,
+	})
+	servicesString := BuildServicesString(map[string]interface{}{
+		"blob":  services.Blob,
+		"queue": services.Queue,
+		"table": services.Table,
+		"file":  services.File,
+	})
+	permissions := BuildPermissionsString(map[string]interface{}{
+		"read":    perms.Read,
+		"write":   perms.Write,
+		"delete":  perms.Delete,
+		"list":    perms.List,
+		"add":     perms.Add,
+		"create":  perms.Create,
+		"update":  perms.Update,
+		"process": perms.Process,
+		"tag":     perms.Tag,
+		"filter":  perms.Filter,
+	})
+
+	// Parse the connection string
+	kvp, err := storage.ParseAccountSASConnectionString(data.ConnectionString.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Error parsing Connection String", err.Error())
+		return
+	}
+
+	accountName := kvp[connStringAccountNameKey]
+	accountKey := kvp[connStringAccountKeyKey]
+	signedProtocol := "https,http"
+	if data.HttpsOnly.IsNull() || data.HttpsOnly.ValueBool() {
+		signedProtocol = "https"
+	}
+
+	signedVersion := defaultStorageAccountSasSignedVersion()
+	if v := data.SignedVersion.ValueString(); v != "" {
+		signedVersion = v
+	}
+
+	// TODO: implement support for signedEncryptionScope
+	signedEncryptionScope := ""
+
+	sasToken, err := storage.ComputeAccountSASToken(accountName, accountKey, permissions, servicesString, resourceTypesString,
+		data.Start.ValueString(), data.Expiry.ValueString(), signedProtocol, data.IpAddresses.ValueString(), signedVersion, signedEncryptionScope)
+	if err != nil {
+		response.Diagnostics.AddError("Error computing Account SAS Token", err.Error())
+		return
+	}
+
+	data.Sas = types.StringValue(sasToken)
+
+	response.Diagnostics.Append(response.Result.Set(ctx, &data)...)
+}
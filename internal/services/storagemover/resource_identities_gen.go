@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storagemover
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagemover/2023-03-01/agents"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagemover/2023-03-01/endpoints"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagemover/2023-03-01/jobdefinitions"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagemover/2023-03-01/projects"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storagemover/2023-03-01/storagemovers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(agents.ValidateAgentID, pluginsdk.NewResourceIdentityType(&agents.AgentId{}))
+	sdk.RegisterResourceIdentityType(endpoints.ValidateEndpointID, pluginsdk.NewResourceIdentityType(&endpoints.EndpointId{}))
+	sdk.RegisterResourceIdentityType(jobdefinitions.ValidateJobDefinitionID, pluginsdk.NewResourceIdentityType(&jobdefinitions.JobDefinitionId{}))
+	sdk.RegisterResourceIdentityType(projects.ValidateProjectID, pluginsdk.NewResourceIdentityType(&projects.ProjectId{}))
+	sdk.RegisterResourceIdentityType(storagemovers.ValidateStorageMoverID, pluginsdk.NewResourceIdentityType(&storagemovers.StorageMoverId{}))
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package streamanalytics
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/streamanalytics/2020-03-01/clusters"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/streamanalytics/2020-03-01/inputs"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/streamanalytics/2020-03-01/privateendpoints"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/streamanalytics/2021-10-01-preview/outputs"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/streamanalytics/parse"
+	streamAnalyticsValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/streamanalytics/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(clusters.ValidateClusterID, pluginsdk.NewResourceIdentityType(&clusters.ClusterId{}))
+	sdk.RegisterResourceIdentityType(inputs.ValidateInputID, pluginsdk.NewResourceIdentityType(&inputs.InputId{}))
+	sdk.RegisterResourceIdentityType(privateendpoints.ValidatePrivateEndpointID, pluginsdk.NewResourceIdentityType(&privateendpoints.PrivateEndpointId{}))
+	sdk.RegisterResourceIdentityType(outputs.ValidateOutputID, pluginsdk.NewResourceIdentityType(&outputs.OutputId{}))
+	sdk.RegisterLegacyResourceIdentityType(streamAnalyticsValidate.StreamingJobScheduleID, parse.StreamingJobScheduleID)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package systemcentervirtualmachinemanager
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/systemcentervirtualmachinemanager/2023-10-07/availabilitysets"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/systemcentervirtualmachinemanager/2023-10-07/clouds"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/systemcentervirtualmachinemanager/2023-10-07/virtualmachinetemplates"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/systemcentervirtualmachinemanager/2023-10-07/virtualnetworks"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/systemcentervirtualmachinemanager/2023-10-07/vmmservers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(availabilitysets.ValidateAvailabilitySetID, pluginsdk.NewResourceIdentityType(&availabilitysets.AvailabilitySetId{}))
+	sdk.RegisterResourceIdentityType(clouds.ValidateCloudID, pluginsdk.NewResourceIdentityType(&clouds.CloudId{}))
+	sdk.RegisterResourceIdentityType(virtualmachinetemplates.ValidateVirtualMachineTemplateID, pluginsdk.NewResourceIdentityType(&virtualmachinetemplates.VirtualMachineTemplateId{}))
+	sdk.RegisterResourceIdentityType(virtualnetworks.ValidateVirtualNetworkID, pluginsdk.NewResourceIdentityType(&virtualnetworks.VirtualNetworkId{}))
+	sdk.RegisterResourceIdentityType(vmmservers.ValidateVMmServerID, pluginsdk.NewResourceIdentityType(&vmmservers.VMmServerId{}))
+}
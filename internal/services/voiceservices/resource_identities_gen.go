@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package voiceservices
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/voiceservices/2023-04-03/communicationsgateways"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/voiceservices/2023-04-03/testlines"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func init() {
+	sdk.RegisterResourceIdentityType(communicationsgateways.ValidateCommunicationsGatewayID, pluginsdk.NewResourceIdentityType(&communicationsgateways.CommunicationsGatewayId{}))
+	sdk.RegisterResourceIdentityType(testlines.ValidateTestLineID, pluginsdk.NewResourceIdentityType(&testlines.TestLineId{}))
+}
@@ -98,10 +98,14 @@ func resourceAppServiceSlotVirtualNetworkSwiftConnectionCreateUpdate(d *pluginsd
 		}
 	}
 
-	locks.ByName(virtualNetworkName, network.VirtualNetworkResourceName)
+	if err := locks.ByNameWithContext(ctx, virtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, network.VirtualNetworkResourceName)
 
-	locks.ByName(subnetName, network.SubnetResourceName)
+	if err := locks.ByNameWithContext(ctx, subnetName, network.SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetName, network.SubnetResourceName)
 
 	appServiceExists, err := client.Get(ctx, resourceGroup, name)
@@ -250,10 +254,14 @@ func resourceAppServiceSlotVirtualNetworkSwiftConnectionDelete(d *pluginsdk.Reso
 	subnetName := subnetID.SubnetName
 	virtualNetworkName := subnetID.VirtualNetworkName
 
-	locks.ByName(virtualNetworkName, network.VirtualNetworkResourceName)
+	if err := locks.ByNameWithContext(ctx, virtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, network.VirtualNetworkResourceName)
 
-	locks.ByName(subnetName, network.SubnetResourceName)
+	if err := locks.ByNameWithContext(ctx, subnetName, network.SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetName, network.SubnetResourceName)
 
 	resp, err := client.DeleteSwiftVirtualNetworkSlot(ctx, id.ResourceGroup, id.SiteName, id.SlotName)
@@ -92,10 +92,14 @@ func resourceAppServiceVirtualNetworkSwiftConnectionCreateUpdate(d *pluginsdk.Re
 		}
 	}
 
-	locks.ByName(virtualNetworkName, network.VirtualNetworkResourceName)
+	if err := locks.ByNameWithContext(ctx, virtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, network.VirtualNetworkResourceName)
 
-	locks.ByName(subnetName, network.SubnetResourceName)
+	if err := locks.ByNameWithContext(ctx, subnetName, network.SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetName, network.SubnetResourceName)
 
 	exists, err := client.Get(ctx, resourceGroup, name)
@@ -225,10 +229,14 @@ func resourceAppServiceVirtualNetworkSwiftConnectionDelete(d *pluginsdk.Resource
 	subnetName := subnetID.SubnetName
 	virtualNetworkName := subnetID.VirtualNetworkName
 
-	locks.ByName(virtualNetworkName, network.VirtualNetworkResourceName)
+	if err := locks.ByNameWithContext(ctx, virtualNetworkName, network.VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(virtualNetworkName, network.VirtualNetworkResourceName)
 
-	locks.ByName(subnetName, network.SubnetResourceName)
+	if err := locks.ByNameWithContext(ctx, subnetName, network.SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetName, network.SubnetResourceName)
 
 	resp, err := client.DeleteSwiftVirtualNetwork(ctx, id.ResourceGroup, id.SiteName)
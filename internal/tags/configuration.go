@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// Configuration is the configuration for Tags defined in the Provider block, which is applied to each Resource
+// with a top-level `tags` field - regardless of whether the Resource uses this package or the `tags` and
+// `commonschema` packages from `go-azure-helpers`.
+//
+// This is stored on the Client for each instance of the Provider, since each Provider alias can define its
+// own `default_tags` and `ignore_tags`.
+type Configuration struct {
+	// DefaultTags are the Tags defined in the `default_tags` block, which are merged into the Tags of each Resource
+	DefaultTags map[string]string
+
+	// IgnoredTags are the Tags defined in the `ignore_tags` block, which are managed outside of Terraform
+	IgnoredTags IgnoredTags
+}
+
+// AllTags returns all of the Tags which should be assigned to a Resource, which is the Tags configured on the
+// Resource, any Default Tags which aren't overridden by the Resource and any ignored Tags which are currently
+// assigned to the Resource - so that Tags managed outside of Terraform aren't removed when it's updated.
+func (c Configuration) AllTags(configured map[string]interface{}, existing map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(configured)+len(c.DefaultTags))
+	for k, v := range configured {
+		// Validate should have ignored this error already
+		value, _ := TagValueToString(v)
+		output[k] = value
+	}
+
+	for k, v := range c.DefaultTags {
+		if _, exists := output[k]; exists {
+			continue
+		}
+
+		output[k] = v
+	}
+
+	for k, v := range existing {
+		if _, exists := output[k]; exists || !c.IgnoredTags.IsIgnored(k) {
+			continue
+		}
+
+		value, _ := TagValueToString(v)
+		output[k] = value
+	}
+
+	return output
+}
+
+// Flatten returns the Tags assigned to a Resource without any ignored Tags
+func (c Configuration) Flatten(input map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input))
+	for k, v := range input {
+		if c.IgnoredTags.IsIgnored(k) {
+			continue
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+// ConfiguredTags returns the Tags assigned to a Resource which are persisted into `tags`, that is the Tags without any
+// ignored Tags or any Default Tags which aren't configured on the Resource - so that `tags` only contains the Tags
+// defined on the Resource itself, and a plan only shows changes to these.
+//
+// A Default Tag is omitted only when its value matches the Default Tag, since a different value has been set either on
+// the Resource or outside of Terraform - and either way should be shown in the plan.
+func (c Configuration) ConfiguredTags(input map[string]interface{}, configured map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(input))
+	for k, v := range input {
+		if c.IgnoredTags.IsIgnored(k) {
+			continue
+		}
+
+		if _, isConfigured := configured[k]; !isConfigured {
+			value, _ := TagValueToString(v)
+			if defaultValue, isDefault := c.DefaultTags[k]; isDefault && defaultValue == value {
+				continue
+			}
+		}
+
+		output[k] = v
+	}
+
+	return output
+}
+
+// ExpandResourceTags merges the Default Tags and any ignored Tags which are currently assigned to a Resource into
+// its `tags` prior to it being created or updated, so that all of these are sent to Azure.
+//
+// The Tags configured on the Resource are returned, which should be passed to FlattenResourceTags once the Resource
+// has been read. These are determined from `tags` rather than the planned `tags_all`, since `tags_all` is unknown
+// when `tags` is unknown at plan time.
+func (c Configuration) ExpandResourceTags(d *pluginsdk.ResourceData) (map[string]interface{}, error) {
+	configured, _ := d.Get("tags").(map[string]interface{})
+	existing, _ := d.GetChange("tags_all")
+	existingTags, _ := existing.(map[string]interface{})
+
+	allTags := c.AllTags(configured, existingTags)
+	if len(allTags) == 0 {
+		return configured, nil
+	}
+
+	if err := d.Set("tags", allTags); err != nil {
+		return nil, fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	return configured, nil
+}
+
+// FlattenResourceTags sets `tags_all` to all of the Tags assigned to a Resource once it has been read, and `tags` to
+// only the Tags configured on the Resource (see ConfiguredTags).
+func (c Configuration) FlattenResourceTags(d *pluginsdk.ResourceData, configured map[string]interface{}) error {
+	// the ID is unset when the Resource no longer exists
+	if d.Id() == "" {
+		return nil
+	}
+
+	allTags, _ := d.Get("tags").(map[string]interface{})
+	if err := d.Set("tags_all", allTags); err != nil {
+		return fmt.Errorf("setting `tags_all`: %+v", err)
+	}
+	if err := d.Set("tags", c.ConfiguredTags(allTags, configured)); err != nil {
+		return fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	return nil
+}
+
+// FlattenDataSourceTags removes any ignored Tags from the `tags` of a Data Source once it has been read.
+func (c Configuration) FlattenDataSourceTags(d *pluginsdk.ResourceData) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	allTags, _ := d.Get("tags").(map[string]interface{})
+	if err := d.Set("tags", c.Flatten(allTags)); err != nil {
+		return fmt.Errorf("setting `tags`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigurationAllTags(t *testing.T) {
+	configuration := Configuration{
+		DefaultTags: map[string]string{
+			"environment": "production",
+			"team":        "platform",
+		},
+		IgnoredTags: NewIgnoredTags([]string{"CreatedBy"}, []string{"hidden-link:"}),
+	}
+
+	testData := []struct {
+		Name       string
+		Configured map[string]interface{}
+		Existing   map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name:       "Only Default Tags",
+			Configured: map[string]interface{}{},
+			Existing:   map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"environment": "production",
+				"team":        "platform",
+			},
+		},
+		{
+			Name: "Overridden Default Tag",
+			Configured: map[string]interface{}{
+				"team":  "networking",
+				"owner": "bob",
+			},
+			Existing: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"environment": "production",
+				"team":        "networking",
+				"owner":       "bob",
+			},
+		},
+		{
+			Name: "Existing Ignored Tags are retained",
+			Configured: map[string]interface{}{
+				"owner": "bob",
+			},
+			Existing: map[string]interface{}{
+				"createdBy":                 "Azure Policy",
+				"hidden-link:/app-insights": "Resource",
+				"environment":               "staging",
+				"removed":                   "value",
+			},
+			Expected: map[string]interface{}{
+				"createdBy":                 "Azure Policy",
+				"hidden-link:/app-insights": "Resource",
+				"environment":               "production",
+				"team":                      "platform",
+				"owner":                     "bob",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := configuration.AllTags(v.Configured, v.Existing)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestConfigurationFlatten(t *testing.T) {
+	configuration := Configuration{
+		IgnoredTags: NewIgnoredTags([]string{"CreatedBy"}, []string{"hidden-link:"}),
+	}
+
+	actual := configuration.Flatten(map[string]interface{}{
+		"createdBy":                 "Azure Policy",
+		"hidden-link:/app-insights": "Resource",
+		"environment":               "production",
+	})
+
+	expected := map[string]interface{}{
+		"environment": "production",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestConfigurationConfiguredTags(t *testing.T) {
+	configuration := Configuration{
+		DefaultTags: map[string]string{
+			"environment": "production",
+			"team":        "platform",
+		},
+		IgnoredTags: NewIgnoredTags([]string{"CreatedBy"}, []string{"hidden-link:"}),
+	}
+
+	testData := []struct {
+		Name       string
+		Input      map[string]interface{}
+		Configured map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name: "Default Tags are omitted",
+			Input: map[string]interface{}{
+				"environment": "production",
+				"team":        "platform",
+				"owner":       "bob",
+			},
+			Configured: map[string]interface{}{
+				"owner": "bob",
+			},
+			Expected: map[string]interface{}{
+				"owner": "bob",
+			},
+		},
+		{
+			Name: "Configured Default Tags are retained",
+			Input: map[string]interface{}{
+				"environment": "production",
+				"team":        "platform",
+			},
+			Configured: map[string]interface{}{
+				"environment": "production",
+			},
+			Expected: map[string]interface{}{
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Default Tags with a different value are retained",
+			Input: map[string]interface{}{
+				"environment": "production",
+				"team":        "networking",
+			},
+			Configured: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"team": "networking",
+			},
+		},
+		{
+			Name: "Ignored Tags are omitted",
+			Input: map[string]interface{}{
+				"createdBy":                 "Azure Policy",
+				"hidden-link:/app-insights": "Resource",
+				"owner":                     "bob",
+			},
+			Configured: map[string]interface{}{
+				"createdBy": "Azure Policy",
+				"owner":     "bob",
+			},
+			Expected: map[string]interface{}{
+				"owner": "bob",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Test %q", v.Name)
+
+		actual := configuration.ConfiguredTags(v.Input, v.Configured)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
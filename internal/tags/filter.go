@@ -5,6 +5,8 @@ package tags
 
 import "strings"
 
+// Filter returns the Tags without those named in tagNames, which are matched case-insensitively - the `ignore_tags`
+// block of the Provider is applied separately, once each Resource or Data Source has been read.
 func Filter(tagsMap *map[string]string, tagNames ...string) *map[string]string {
 	if len(tagNames) == 0 || tagsMap == nil {
 		return tagsMap
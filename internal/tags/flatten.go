@@ -9,6 +9,9 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
+// Flatten returns the Tags in the format used by the `tags` field - the `default_tags` and `ignore_tags` blocks of the
+// Provider aren't applied here, since they're configured per instance of the Provider, and are instead applied to each
+// Resource and Data Source with a top-level `tags` field once it has been read (see Configuration).
 func Flatten(tagMap map[string]*string) map[string]interface{} {
 	// If tagsMap is nil, len(tagsMap) will be 0.
 	output := make(map[string]interface{}, len(tagMap))
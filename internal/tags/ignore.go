@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"strings"
+)
+
+// IgnoredTags are the Tags defined in the `ignore_tags` block of the Provider, which are managed outside of
+// Terraform and as such are removed when reading the Tags for a Resource - and retained when it's updated.
+//
+// Tags are matched case-insensitively, since Azure treats the keys of Tags as case-insensitive.
+type IgnoredTags struct {
+	keys        map[string]struct{}
+	keyPrefixes []string
+}
+
+// NewIgnoredTags returns the IgnoredTags for the `keys` and `key_prefixes` defined in the `ignore_tags` block
+func NewIgnoredTags(keys []string, keyPrefixes []string) IgnoredTags {
+	output := IgnoredTags{
+		keys:        make(map[string]struct{}, len(keys)),
+		keyPrefixes: make([]string, 0, len(keyPrefixes)),
+	}
+
+	for _, key := range keys {
+		if key != "" {
+			output.keys[strings.ToLower(key)] = struct{}{}
+		}
+	}
+
+	for _, prefix := range keyPrefixes {
+		if prefix != "" {
+			output.keyPrefixes = append(output.keyPrefixes, strings.ToLower(prefix))
+		}
+	}
+
+	return output
+}
+
+// IsIgnored returns whether the Tag with the specified key is ignored, either because the key is
+// listed within `keys` or begins with one of the `key_prefixes` defined in the `ignore_tags` block.
+func (i IgnoredTags) IsIgnored(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := i.keys[key]; ok {
+		return true
+	}
+
+	for _, prefix := range i.keyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
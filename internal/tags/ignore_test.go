@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"testing"
+)
+
+func TestIsIgnored(t *testing.T) {
+	ignored := NewIgnoredTags([]string{"CreatedBy", ""}, []string{"hidden-link:", ""})
+
+	testData := map[string]bool{
+		"CreatedBy":                   true,
+		"createdby":                   true,
+		"CreatedByTerraform":          false,
+		"hidden-link:/app-insights":   true,
+		"Hidden-Link:/app-insights":   true,
+		"environment":                 false,
+		"not-a-hidden-link:/whatever": false,
+	}
+
+	for key, expected := range testData {
+		if actual := ignored.IsIgnored(key); actual != expected {
+			t.Fatalf("Expected IsIgnored(%q) to be %t but got %t", key, expected, actual)
+		}
+	}
+}
+
+func TestIsIgnoredEmpty(t *testing.T) {
+	if (IgnoredTags{}).IsIgnored("environment") {
+		t.Fatalf("Expected no Tags to be ignored when `ignore_tags` isn't configured")
+	}
+}
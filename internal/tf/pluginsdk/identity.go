@@ -0,0 +1,478 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceIdentityType converts between a Resource ID and the values for the fields within its Resource Identity
+type ResourceIdentityType interface {
+	// Fields returns the names of the fields within the Resource Identity
+	Fields() []string
+
+	// ValuesFromId parses the Resource ID and returns the values for each of the fields within the Resource Identity
+	ValuesFromId(input string) (map[string]string, error)
+
+	// IdFromValues returns the Resource ID from the values for each of the fields within the Resource Identity
+	IdFromValues(values map[string]string) (string, error)
+}
+
+// NewResourceIdentityType returns a ResourceIdentityType derived from the segments of the Resource ID type `id`.
+func NewResourceIdentityType(id resourceids.ResourceId) ResourceIdentityType {
+	return resourceIdIdentityType{
+		id: id,
+	}
+}
+
+var _ ResourceIdentityType = resourceIdIdentityType{}
+
+type resourceIdIdentityType struct {
+	id resourceids.ResourceId
+}
+
+func (t resourceIdIdentityType) Fields() []string {
+	out := make([]string, 0)
+	for field := range ResourceIdentitySchema(t.id) {
+		out = append(out, field)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (t resourceIdIdentityType) ValuesFromId(input string) (map[string]string, error) {
+	return ResourceIdentityValuesFromId(t.id, input)
+}
+
+func (t resourceIdIdentityType) IdFromValues(values map[string]string) (string, error) {
+	return ResourceIdFromIdentityValues(t.id, values)
+}
+
+// NewLegacyResourceIdentityType returns a ResourceIdentityType derived from a Resource ID parser generated by
+// `generator-resource-id`, in the form `func(input string) (*FooId, error)`. The fields within the Resource Identity
+// are derived from the fields of the Resource ID struct, which must all be strings, and the struct must have an
+// `ID() string` method.
+func NewLegacyResourceIdentityType(parseFunc interface{}) (ResourceIdentityType, error) {
+	fn := reflect.ValueOf(parseFunc)
+	fnType := fn.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumIn() != 1 || fnType.In(0).Kind() != reflect.String || fnType.NumOut() != 2 {
+		return nil, fmt.Errorf("expected a function in the form `func(string) (*Id, error)` but got %s", fnType)
+	}
+	if fnType.Out(0).Kind() != reflect.Ptr || fnType.Out(0).Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected the function to return a pointer to a struct but got %s", fnType.Out(0))
+	}
+
+	idType := fnType.Out(0).Elem()
+	if _, ok := idType.MethodByName("ID"); !ok {
+		return nil, fmt.Errorf("expected %s to have an `ID` method", idType)
+	}
+
+	fields := make([]string, 0)
+	for i := 0; i < idType.NumField(); i++ {
+		field := idType.Field(i)
+		if field.Type.Kind() != reflect.String {
+			return nil, fmt.Errorf("expected the field %q within %s to be a string but got %s", field.Name, idType, field.Type)
+		}
+
+		switch field.Name {
+		case "SubscriptionId":
+			fields = append(fields, "subscription_id")
+		case "ResourceGroup":
+			fields = append(fields, "resource_group_name")
+		default:
+			fields = append(fields, convertSegmentNameToFieldName(field.Name))
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("expected %s to contain at least one field", idType)
+	}
+
+	// consistent with the Resource ID types, the final field is exposed as `name`
+	if last := idType.Field(idType.NumField() - 1).Name; last != "SubscriptionId" && last != "ResourceGroup" {
+		fields[len(fields)-1] = "name"
+	}
+
+	return legacyIdentityType{
+		parseFunc: fn,
+		idType:    idType,
+		fields:    fields,
+	}, nil
+}
+
+var _ ResourceIdentityType = legacyIdentityType{}
+
+type legacyIdentityType struct {
+	parseFunc reflect.Value
+	idType    reflect.Type
+
+	// fields contains the name of the field within the Resource Identity for each field within idType
+	fields []string
+}
+
+func (t legacyIdentityType) Fields() []string {
+	out := append([]string{}, t.fields...)
+	sort.Strings(out)
+	return out
+}
+
+func (t legacyIdentityType) ValuesFromId(input string) (map[string]string, error) {
+	result := t.parseFunc.Call([]reflect.Value{reflect.ValueOf(input)})
+	if err, ok := result[1].Interface().(error); ok && err != nil {
+		return nil, err
+	}
+
+	parsed := result[0].Elem()
+	out := make(map[string]string)
+	for i, field := range t.fields {
+		out[field] = parsed.Field(i).String()
+	}
+
+	return out, nil
+}
+
+func (t legacyIdentityType) IdFromValues(values map[string]string) (string, error) {
+	id := reflect.New(t.idType).Elem()
+	for i, field := range t.fields {
+		value := values[field]
+		if value == "" {
+			return "", fmt.Errorf("a value must be specified for the identity field %q", field)
+		}
+		id.Field(i).SetString(value)
+	}
+
+	out := id.MethodByName("ID").Call(nil)[0].String()
+
+	// finally parse the Resource ID to ensure the values are valid
+	if _, err := t.ValuesFromId(out); err != nil {
+		return "", err
+	}
+
+	return out, nil
+}
+
+// NewValidatedResourceIdentityType returns a ResourceIdentityType containing a single `id` field, validated using
+// validateFunc - which is used for Resource IDs which can't be split into fields, such as those comprising other
+// Resource IDs or Data Plane IDs.
+func NewValidatedResourceIdentityType(validateFunc SchemaValidateFunc) ResourceIdentityType {
+	return validatedIdentityType{
+		validateFunc: validateFunc,
+	}
+}
+
+var _ ResourceIdentityType = validatedIdentityType{}
+
+type validatedIdentityType struct {
+	validateFunc SchemaValidateFunc
+}
+
+func (t validatedIdentityType) Fields() []string {
+	return []string{"id"}
+}
+
+func (t validatedIdentityType) ValuesFromId(input string) (map[string]string, error) {
+	if _, errors := t.validateFunc(input, "id"); len(errors) > 0 {
+		return nil, errors[0]
+	}
+
+	return map[string]string{
+		"id": input,
+	}, nil
+}
+
+func (t validatedIdentityType) IdFromValues(values map[string]string) (string, error) {
+	value := values["id"]
+	if value == "" {
+		return "", fmt.Errorf("a value must be specified for the identity field %q", "id")
+	}
+
+	if _, err := t.ValuesFromId(value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// NewResourceIdentity returns the Resource Identity for the specified ResourceIdentityType, where each field
+// must be specified when importing using an `identity` block.
+func NewResourceIdentity(identityType ResourceIdentityType) *ResourceIdentity {
+	return &ResourceIdentity{
+		Version: 0,
+		SchemaFunc: func() map[string]*schema.Schema {
+			out := make(map[string]*schema.Schema)
+			for _, field := range identityType.Fields() {
+				out[field] = &schema.Schema{
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+				}
+			}
+			return out
+		},
+	}
+}
+
+// SetResourceIdentity sets the Resource Identity for the Resource from its ID, using the specified ResourceIdentityType.
+//
+// The Resource Identity isn't set when the ID can't be parsed, since parsing is case-sensitive and IDs within existing
+// state may have been stored using a different casing - which shouldn't cause reading the Resource to fail.
+func SetResourceIdentity(d *ResourceData, identityType ResourceIdentityType) error {
+	values, err := identityType.ValuesFromId(d.Id())
+	if err != nil {
+		log.Printf("[WARN] parsing the Resource Identity from %q, the Resource Identity will not be set: %+v", d.Id(), err)
+		return nil
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("retrieving the Resource Identity: %+v", err)
+	}
+	for field, value := range values {
+		if err := identity.Set(field, value); err != nil {
+			return fmt.Errorf("setting the Resource Identity field %q: %+v", field, err)
+		}
+	}
+
+	return nil
+}
+
+// WithResourceIdentity configures the Resource Identity for an (untyped) Plugin SDK Resource using identityType - which
+// is set once the Resource has been created, read or updated, and allows the Resource to be imported using an
+// `identity` block. Resources which already define a Resource Identity are returned as-is.
+func WithResourceIdentity(resource *schema.Resource, identityType ResourceIdentityType) *schema.Resource {
+	if resource.Identity != nil {
+		return resource
+	}
+	resource.Identity = NewResourceIdentity(identityType)
+
+	setIdentity := func(d *schema.ResourceData) error {
+		// the ID is unset when the Resource no longer exists
+		if d.Id() == "" {
+			return nil
+		}
+		return SetResourceIdentity(d, identityType)
+	}
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		return func(d *schema.ResourceData, meta interface{}) error {
+			if err := f(d, meta); err != nil {
+				return err
+			}
+			return setIdentity(d)
+		}
+	}
+	wrapContext := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := f(ctx, d, meta)
+			if diags.HasError() {
+				return diags
+			}
+			if err := setIdentity(d); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+			return diags
+		}
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if resource.Create != nil { //nolint:staticcheck
+		resource.Create = wrap(resource.Create) //nolint:staticcheck
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if resource.Read != nil { //nolint:staticcheck
+		resource.Read = wrap(resource.Read) //nolint:staticcheck
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if resource.Update != nil { //nolint:staticcheck
+		resource.Update = wrap(resource.Update) //nolint:staticcheck
+	}
+	if resource.CreateContext != nil {
+		resource.CreateContext = wrapContext(resource.CreateContext)
+	}
+	if resource.ReadContext != nil {
+		resource.ReadContext = wrapContext(resource.ReadContext)
+	}
+	if resource.UpdateContext != nil {
+		resource.UpdateContext = wrapContext(resource.UpdateContext)
+	}
+	if resource.CreateWithoutTimeout != nil {
+		resource.CreateWithoutTimeout = wrapContext(resource.CreateWithoutTimeout)
+	}
+	if resource.ReadWithoutTimeout != nil {
+		resource.ReadWithoutTimeout = wrapContext(resource.ReadWithoutTimeout)
+	}
+	if resource.UpdateWithoutTimeout != nil {
+		resource.UpdateWithoutTimeout = wrapContext(resource.UpdateWithoutTimeout)
+	}
+
+	if importer := resource.Importer; importer != nil && importer.StateContext != nil {
+		stateContext := importer.StateContext
+		importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if d.Id() == "" {
+				id, err := resourceIdFromIdentity(d, identityType)
+				if err != nil {
+					return nil, fmt.Errorf("building the Resource ID from the Resource Identity: %+v", err)
+				}
+				d.SetId(id)
+			}
+			return stateContext(ctx, d, meta)
+		}
+	}
+
+	return resource
+}
+
+// resourceIdFromIdentity returns the Resource ID from the Resource Identity specified when importing the Resource
+func resourceIdFromIdentity(d *ResourceData, identityType ResourceIdentityType) (string, error) {
+	identity, err := d.Identity()
+	if err != nil {
+		return "", fmt.Errorf("retrieving the Resource Identity: %+v", err)
+	}
+
+	values := make(map[string]string)
+	for _, field := range identityType.Fields() {
+		if v, ok := identity.GetOk(field); ok {
+			values[field] = v.(string)
+		}
+	}
+
+	return identityType.IdFromValues(values)
+}
+
+// ResourceIdentitySchema returns the Schema for the Resource Identity of the specified Resource ID type, which
+// contains a field for each of the non-static segments within the Resource ID.
+//
+// For example the Resource Identity for a Resource Group scoped Resource ID comprises `subscription_id`,
+// `resource_group_name` and `name` - where `name` is always the final user-specified segment.
+func ResourceIdentitySchema(id resourceids.ResourceId) map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema)
+	for _, field := range resourceIdentityFields(id) {
+		out[field] = &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		}
+	}
+	return out
+}
+
+// ResourceIdentityValuesFromId parses the Resource ID string `input` using the Resource ID type `id` and returns
+// the values for each of the fields defined in the Resource Identity Schema.
+func ResourceIdentityValuesFromId(id resourceids.ResourceId, input string) (map[string]string, error) {
+	parsed, err := resourceids.NewParserFromResourceIdType(id).Parse(input, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := resourceIdentityFields(id)
+	out := make(map[string]string)
+	for _, segment := range id.Segments() {
+		field, ok := fields[segment.Name]
+		if !ok {
+			continue
+		}
+
+		value, ok := parsed.Parsed[segment.Name]
+		if !ok {
+			return nil, fmt.Errorf("the segment %q was not found in the Resource ID %q", segment.Name, input)
+		}
+		out[field] = value
+	}
+
+	return out, nil
+}
+
+// ResourceIdFromIdentityValues returns the Resource ID string for the Resource ID type `id` from the values
+// for each of the fields defined in the Resource Identity Schema - which is the inverse of ResourceIdentityValuesFromId.
+func ResourceIdFromIdentityValues(id resourceids.ResourceId, values map[string]string) (string, error) {
+	fields := resourceIdentityFields(id)
+	components := make([]string, 0)
+	for _, segment := range id.Segments() {
+		if segment.Type == resourceids.StaticSegmentType || segment.Type == resourceids.ResourceProviderSegmentType {
+			if segment.FixedValue == nil {
+				return "", fmt.Errorf("the segment %q is missing a fixed value", segment.Name)
+			}
+			components = append(components, *segment.FixedValue)
+			continue
+		}
+
+		field := fields[segment.Name]
+		value := values[field]
+		if value == "" {
+			return "", fmt.Errorf("a value must be specified for the identity field %q", field)
+		}
+
+		if segment.Type == resourceids.ScopeSegmentType {
+			value = strings.TrimPrefix(value, "/")
+		}
+		components = append(components, value)
+	}
+
+	out := "/" + strings.Join(components, "/")
+
+	// finally parse the Resource ID to ensure the values are valid (e.g. Constants and Scopes)
+	if _, err := resourceids.NewParserFromResourceIdType(id).Parse(out, false); err != nil {
+		return "", err
+	}
+
+	return out, nil
+}
+
+// resourceIdentityFields returns a map of the Segment Name to the name of the field within the Resource Identity
+func resourceIdentityFields(id resourceids.ResourceId) map[string]string {
+	segments := id.Segments()
+
+	lastUserSpecifiedSegment := ""
+	for _, segment := range segments {
+		if segment.Type == resourceids.UserSpecifiedSegmentType {
+			lastUserSpecifiedSegment = segment.Name
+		}
+	}
+
+	out := make(map[string]string)
+	for _, segment := range segments {
+		switch segment.Type {
+		case resourceids.StaticSegmentType, resourceids.ResourceProviderSegmentType:
+			continue
+
+		case resourceids.SubscriptionIdSegmentType:
+			out[segment.Name] = "subscription_id"
+
+		case resourceids.ResourceGroupSegmentType:
+			out[segment.Name] = "resource_group_name"
+
+		case resourceids.ScopeSegmentType:
+			out[segment.Name] = "scope"
+
+		default:
+			out[segment.Name] = convertSegmentNameToFieldName(segment.Name)
+			if segment.Name == lastUserSpecifiedSegment {
+				out[segment.Name] = "name"
+			}
+		}
+	}
+
+	return out
+}
+
+// convertSegmentNameToFieldName converts the camelCased Segment Name into a snake_cased field name
+func convertSegmentNameToFieldName(input string) string {
+	out := ""
+	for i, c := range input {
+		if unicode.IsUpper(c) {
+			if i > 0 {
+				out += "_"
+			}
+			c = unicode.ToLower(c)
+		}
+		out += string(c)
+	}
+	return out
+}
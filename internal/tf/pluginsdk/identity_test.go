@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/localusers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceIdentitySchema(t *testing.T) {
+	testData := []struct {
+		id       resourceids.ResourceId
+		expected []string
+	}{
+		{
+			id:       &commonids.ResourceGroupId{},
+			expected: []string{"resource_group_name", "subscription_id"},
+		},
+		{
+			id:       &localusers.LocalUserId{},
+			expected: []string{"name", "resource_group_name", "storage_account_name", "subscription_id"},
+		},
+		{
+			id:       &commonids.ScopeId{},
+			expected: []string{"scope"},
+		},
+	}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %T..", v.id)
+
+		actual := ResourceIdentitySchema(v.id)
+		if len(actual) != len(v.expected) {
+			t.Fatalf("expected %d fields but got %d", len(v.expected), len(actual))
+		}
+		for _, field := range v.expected {
+			if _, ok := actual[field]; !ok {
+				t.Fatalf("expected the field %q to be present but it wasn't", field)
+			}
+		}
+	}
+}
+
+func TestResourceIdentityValues(t *testing.T) {
+	testData := []struct {
+		id       resourceids.ResourceId
+		input    string
+		expected map[string]string
+	}{
+		{
+			id:    &localusers.LocalUserId{},
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/account1/localUsers/user1",
+			expected: map[string]string{
+				"subscription_id":      "12345678-1234-9876-4563-123456789012",
+				"resource_group_name":  "example-resources",
+				"storage_account_name": "account1",
+				"name":                 "user1",
+			},
+		},
+		{
+			id:    &commonids.ScopeId{},
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources",
+			expected: map[string]string{
+				"scope": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources",
+			},
+		},
+	}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		values, err := ResourceIdentityValuesFromId(v.id, v.input)
+		if err != nil {
+			t.Fatalf("parsing: %+v", err)
+		}
+		if !reflect.DeepEqual(values, v.expected) {
+			t.Fatalf("expected the values to be %+v but got %+v", v.expected, values)
+		}
+
+		actual, err := ResourceIdFromIdentityValues(v.id, values)
+		if err != nil {
+			t.Fatalf("building the Resource ID: %+v", err)
+		}
+		if actual != v.input {
+			t.Fatalf("expected the Resource ID to be %q but got %q", v.input, actual)
+		}
+	}
+}
+
+func TestResourceIdFromIdentityValues_Invalid(t *testing.T) {
+	testData := map[string]map[string]string{
+		"missing field": {
+			"subscription_id": "12345678-1234-9876-4563-123456789012",
+		},
+		"empty field": {
+			"subscription_id":     "12345678-1234-9876-4563-123456789012",
+			"resource_group_name": "",
+		},
+	}
+	for name, values := range testData {
+		t.Logf("[DEBUG] Testing %q..", name)
+		if _, err := ResourceIdFromIdentityValues(&commonids.ResourceGroupId{}, values); err == nil {
+			t.Fatalf("expected an error for %q but didn't get one", name)
+		}
+	}
+}
+
+// legacyWidgetId mirrors the Resource IDs output by `generator-resource-id`
+type legacyWidgetId struct {
+	SubscriptionId string
+	ResourceGroup  string
+	WidgetName     string
+}
+
+func (id legacyWidgetId) ID() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Example/widgets/%s", id.SubscriptionId, id.ResourceGroup, id.WidgetName)
+}
+
+func parseLegacyWidgetID(input string) (*legacyWidgetId, error) {
+	segments := strings.Split(input, "/")
+	if len(segments) != 9 || segments[1] != "subscriptions" || segments[3] != "resourceGroups" || segments[7] != "widgets" {
+		return nil, fmt.Errorf("parsing %q as a Widget ID", input)
+	}
+
+	return &legacyWidgetId{
+		SubscriptionId: segments[2],
+		ResourceGroup:  segments[4],
+		WidgetName:     segments[8],
+	}, nil
+}
+
+func TestLegacyResourceIdentityType(t *testing.T) {
+	identityType, err := NewLegacyResourceIdentityType(parseLegacyWidgetID)
+	if err != nil {
+		t.Fatalf("building the ResourceIdentityType: %+v", err)
+	}
+
+	expectedFields := []string{"name", "resource_group_name", "subscription_id"}
+	if actual := identityType.Fields(); !reflect.DeepEqual(actual, expectedFields) {
+		t.Fatalf("expected the fields to be %+v but got %+v", expectedFields, actual)
+	}
+
+	input := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Example/widgets/widget1"
+	values, err := identityType.ValuesFromId(input)
+	if err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+	expectedValues := map[string]string{
+		"subscription_id":     "12345678-1234-9876-4563-123456789012",
+		"resource_group_name": "example-resources",
+		"name":                "widget1",
+	}
+	if !reflect.DeepEqual(values, expectedValues) {
+		t.Fatalf("expected the values to be %+v but got %+v", expectedValues, values)
+	}
+
+	actual, err := identityType.IdFromValues(values)
+	if err != nil {
+		t.Fatalf("building the Resource ID: %+v", err)
+	}
+	if actual != input {
+		t.Fatalf("expected the Resource ID to be %q but got %q", input, actual)
+	}
+
+	delete(values, "name")
+	if _, err := identityType.IdFromValues(values); err == nil {
+		t.Fatalf("expected an error when a field is missing but didn't get one")
+	}
+}
+
+func TestLegacyResourceIdentityType_Invalid(t *testing.T) {
+	testData := map[string]interface{}{
+		"not a function":         "parse",
+		"returns a value":        func(input string) (legacyWidgetId, error) { return legacyWidgetId{}, nil },
+		"returns a non-struct":   func(input string) (*string, error) { return nil, nil },
+		"returns no error":       func(input string) *legacyWidgetId { return nil },
+		"returns a non-resource": func(input string) (*struct{ Name string }, error) { return nil, nil },
+	}
+	for name, parseFunc := range testData {
+		t.Logf("[DEBUG] Testing %q..", name)
+		if _, err := NewLegacyResourceIdentityType(parseFunc); err == nil {
+			t.Fatalf("expected an error for %q but didn't get one", name)
+		}
+	}
+}
+
+func TestValidatedResourceIdentityType(t *testing.T) {
+	identityType := NewValidatedResourceIdentityType(func(input interface{}, key string) ([]string, []error) {
+		if _, err := parseLegacyWidgetID(input.(string)); err != nil {
+			return nil, []error{err}
+		}
+		return nil, nil
+	})
+
+	input := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources/providers/Microsoft.Example/widgets/widget1"
+	values, err := identityType.ValuesFromId(input)
+	if err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+	if expected := map[string]string{"id": input}; !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected the values to be %+v but got %+v", expected, values)
+	}
+
+	if _, err := identityType.IdFromValues(map[string]string{"id": "/subscriptions/12345678-1234-9876-4563-123456789012"}); err == nil {
+		t.Fatalf("expected an error for an invalid Resource ID but didn't get one")
+	}
+}
+
+func TestImporterValidatingResourceIdOrIdentity(t *testing.T) {
+	identityType := NewResourceIdentityType(&commonids.ResourceGroupId{})
+	resource := &Resource{
+		Schema:   map[string]*Schema{},
+		Identity: NewResourceIdentity(identityType),
+		Importer: ImporterValidatingResourceIdOrIdentityThen(identityType, func(id string) error {
+			_, err := commonids.ParseResourceGroupID(id)
+			return err
+		}, func(ctx context.Context, d *ResourceData, meta interface{}) ([]*ResourceData, error) {
+			return []*ResourceData{d}, nil
+		}),
+	}
+	expected := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resources"
+
+	// importing using an `identity` block
+	d := resource.Data(&terraform.InstanceState{
+		Identity: map[string]string{
+			"subscription_id":     "12345678-1234-9876-4563-123456789012",
+			"resource_group_name": "example-resources",
+		},
+	})
+	if _, err := resource.Importer.StateContext(context.Background(), d, nil); err != nil {
+		t.Fatalf("importing using the Resource Identity: %+v", err)
+	}
+	if d.Id() != expected {
+		t.Fatalf("expected the ID to be %q but got %q", expected, d.Id())
+	}
+
+	// importing using an ID
+	d = resource.Data(&terraform.InstanceState{ID: expected})
+	if _, err := resource.Importer.StateContext(context.Background(), d, nil); err != nil {
+		t.Fatalf("importing using the Resource ID: %+v", err)
+	}
+	if err := SetResourceIdentity(d, identityType); err != nil {
+		t.Fatalf("setting the Resource Identity: %+v", err)
+	}
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatalf("retrieving the Resource Identity: %+v", err)
+	}
+	if v := identity.Get("resource_group_name").(string); v != "example-resources" {
+		t.Fatalf("expected the Resource Identity to contain the Resource Group Name but got %q", v)
+	}
+
+	// an ID which can't be parsed (e.g. using a different casing) is read without setting the Resource Identity
+	d = resource.Data(&terraform.InstanceState{ID: "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/example-resources"})
+	if err := SetResourceIdentity(d, identityType); err != nil {
+		t.Fatalf("expected no error setting the Resource Identity from an ID which can't be parsed but got: %+v", err)
+	}
+	identity, err = d.Identity()
+	if err != nil {
+		t.Fatalf("retrieving the Resource Identity: %+v", err)
+	}
+	if v := identity.Get("resource_group_name").(string); v != "" {
+		t.Fatalf("expected the Resource Identity not to be set but got %q", v)
+	}
+
+	// an incomplete identity is rejected
+	d = resource.Data(&terraform.InstanceState{
+		Identity: map[string]string{
+			"subscription_id": "12345678-1234-9876-4563-123456789012",
+		},
+	})
+	if _, err := resource.Importer.StateContext(context.Background(), d, nil); err == nil {
+		t.Fatalf("expected an error for an incomplete Resource Identity but didn't get one")
+	}
+}
@@ -5,6 +5,7 @@ package pluginsdk
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,8 +27,23 @@ func ImporterValidatingResourceId(validateFunc IDValidationFunc) *schema.Resourc
 // ImporterValidatingResourceIdThen validates the ID provided at import time is valid
 // using the validateFunc then runs the 'thenFunc', allowing the import to be customised.
 func ImporterValidatingResourceIdThen(validateFunc IDValidationFunc, thenFunc ImporterFunc) *schema.ResourceImporter {
+	return ImporterValidatingResourceIdOrIdentityThen(nil, validateFunc, thenFunc)
+}
+
+// ImporterValidatingResourceIdOrIdentityThen validates the ID provided at import time is valid using the validateFunc
+// then runs the 'thenFunc', allowing the import to be customised. When the Resource is imported using an `identity`
+// block rather than an ID, the ID is built from the Resource Identity using the identityType.
+func ImporterValidatingResourceIdOrIdentityThen(identityType ResourceIdentityType, validateFunc IDValidationFunc, thenFunc ImporterFunc) *schema.ResourceImporter {
 	return &schema.ResourceImporter{
 		StateContext: func(ctx context.Context, d *ResourceData, meta interface{}) ([]*ResourceData, error) {
+			if d.Id() == "" && identityType != nil {
+				id, err := resourceIdFromIdentity(d, identityType)
+				if err != nil {
+					return nil, fmt.Errorf("building the Resource ID from the Resource Identity: %+v", err)
+				}
+				d.SetId(id)
+			}
+
 			log.Printf("[DEBUG] Importing Resource - parsing %q", d.Id())
 
 			if _, ok := ctx.Deadline(); !ok {
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// GetWriteOnly retrieves the value of the top-level Write-Only attribute 'name' from the configuration.
+//
+// Write-Only attributes are never persisted into the state, so this is only available during
+// Create and Update - and returns a null value when the attribute isn't specified in the configuration.
+func GetWriteOnly(d *ResourceData, name string, attrType cty.Type) (cty.Value, error) {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(name))
+	if diags.HasError() {
+		return cty.NullVal(attrType), fmt.Errorf("retrieving the write-only attribute %q from the configuration: %+v", name, diags)
+	}
+
+	if !value.Type().Equals(attrType) {
+		return cty.NullVal(attrType), fmt.Errorf("the write-only attribute %q was of the type %q but expected %q", name, value.Type().FriendlyName(), attrType.FriendlyName())
+	}
+
+	return value, nil
+}
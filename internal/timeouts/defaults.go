@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package timeouts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// Operations are the timeouts for each operation, where a nil value uses the timeout defined by the resource
+type Operations struct {
+	Create *time.Duration
+	Read   *time.Duration
+	Update *time.Duration
+	Delete *time.Duration
+}
+
+func (o Operations) forOperation(operation string) *time.Duration {
+	switch operation {
+	case pluginsdk.TimeoutCreate:
+		return o.Create
+	case pluginsdk.TimeoutRead:
+		return o.Read
+	case pluginsdk.TimeoutUpdate:
+		return o.Update
+	case pluginsdk.TimeoutDelete:
+		return o.Delete
+	}
+	return nil
+}
+
+// Defaults are the timeouts specified within the `default_timeouts` block in the Provider block, which take
+// precedence over the timeouts defined by each resource - but not over those within a resource's `timeouts` block.
+//
+// These are stored on the Client for each instance of the Provider, since each Provider alias can define its own
+// `default_timeouts` - and are resolved each time ForCreate, ForRead, ForUpdate or ForDelete is called.
+type Defaults struct {
+	Operations
+
+	// ResourceTypes are the timeouts for specific resource types (e.g. `azurerm_kubernetes_cluster`), which take
+	// precedence over the Operations
+	ResourceTypes map[string]Operations
+}
+
+// For returns the timeout for the operation (e.g. `create`) on the resource type, which is either the timeout
+// specified in the Provider block or resourceTimeout (the timeout defined by the resource) when none is specified
+func (d *Defaults) For(resourceType string, operation string, resourceTimeout time.Duration) time.Duration {
+	if d == nil {
+		return resourceTimeout
+	}
+
+	if v := d.ResourceTypes[resourceType].forOperation(operation); v != nil {
+		return *v
+	}
+	if v := d.Operations.forOperation(operation); v != nil {
+		return *v
+	}
+	return resourceTimeout
+}
+
+// inflight holds the resource type (and Defaults) for each ResourceData currently being used by a resource, so that
+// ForCreate, ForRead, ForUpdate and ForDelete can apply the Defaults for the instance of the Provider being used
+var inflight sync.Map
+
+type inflightResource struct {
+	resourceType     string
+	resourceTimeouts pluginsdk.ResourceTimeout
+	defaults         *Defaults
+
+	// lockContext records the address of the resource acquiring locks during this operation (see locks.WithResourceAddress)
+	lockContext context.Context
+}
+
+// timeout returns the timeout for the operation, where configured is the value returned by the ResourceData - which
+// is either the value from the resource's `timeouts` block (in which case inTimeoutsBlock is true), or the timeout
+// defined by the resource if that's unset
+func (r inflightResource) timeout(operation string, configured time.Duration, inTimeoutsBlock bool) time.Duration {
+	var resourceTimeout *time.Duration
+	switch operation {
+	case pluginsdk.TimeoutCreate:
+		resourceTimeout = r.resourceTimeouts.Create
+	case pluginsdk.TimeoutRead:
+		resourceTimeout = r.resourceTimeouts.Read
+	case pluginsdk.TimeoutUpdate:
+		resourceTimeout = r.resourceTimeouts.Update
+	case pluginsdk.TimeoutDelete:
+		resourceTimeout = r.resourceTimeouts.Delete
+	}
+
+	// the Defaults only apply to the operations supported by the resource, and the `timeouts` block takes precedence
+	if resourceTimeout == nil || inTimeoutsBlock {
+		return configured
+	}
+
+	return r.defaults.For(r.resourceType, operation, configured)
+}
+
+// withResourceAddress returns the context recording the address of the resource for the ResourceData, so that the
+// holder of a lock acquired by a resource which isn't using the Typed SDK can be identified
+func withResourceAddress(ctx context.Context, d *pluginsdk.ResourceData) context.Context {
+	if locks.HasResourceAddress(ctx) {
+		return ctx
+	}
+	if v, ok := inflight.Load(d); ok {
+		return locks.WithResourceAddressFrom(ctx, v.(inflightResource).lockContext)
+	}
+	return ctx
+}
+
+func timeoutFor(d *pluginsdk.ResourceData, operation string) time.Duration {
+	configured := d.Timeout(operation)
+	if v, ok := inflight.Load(d); ok {
+		return v.(inflightResource).timeout(operation, configured, specifiedInTimeoutsBlock(d, operation))
+	}
+	return configured
+}
+
+// specifiedInTimeoutsBlock returns whether the timeout for the operation is specified within the resource's `timeouts`
+// block - using the raw config, or the raw state when the config isn't available (e.g. when reading or deleting)
+func specifiedInTimeoutsBlock(d *pluginsdk.ResourceData, operation string) bool {
+	if config := d.GetRawConfig(); !config.IsNull() {
+		return timeoutsBlockSpecifies(config, operation)
+	}
+	return timeoutsBlockSpecifies(d.GetRawState(), operation)
+}
+
+func timeoutsBlockSpecifies(raw cty.Value, operation string) bool {
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute("timeouts") {
+		return false
+	}
+
+	block := raw.GetAttr("timeouts")
+	if block.IsNull() || !block.IsKnown() || !block.Type().IsObjectType() {
+		return false
+	}
+	for _, attribute := range []string{operation, pluginsdk.TimeoutDefault} {
+		if block.Type().HasAttribute(attribute) && !block.GetAttr(attribute).IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// WithDefaults wraps the functions of the resource (or data source), such that the Defaults returned by `defaults`
+// (from the Provider Meta) are used by ForCreate, ForRead, ForUpdate and ForDelete.
+//
+// The timeouts defined by the resource aren't updated, rather the functions which are passed a context (and as such
+// would otherwise be called with a context using the timeout defined by the resource) are called using the context
+// returned by ForCreate, ForRead, ForUpdate or ForDelete.
+//
+// The contexts returned by ForCreate, ForRead, ForUpdate and ForDelete also record the address of the resource
+// acquiring any locks - and any warnings from waiting on a lock are returned as diagnostics, which is why functions
+// which only return an error are called as the equivalent function returning diagnostics.
+func WithDefaults(resourceType string, resource *pluginsdk.Resource, defaults func(meta interface{}) *Defaults) *pluginsdk.Resource {
+	if resource.Timeouts == nil {
+		return resource
+	}
+	resourceTimeouts := *resource.Timeouts
+
+	// track returns the context recording the address of the resource, and a function which must be deferred such
+	// that the ResourceData is removed from `inflight` on every return path (including panics) - restoring any value
+	// being tracked when this is called within another operation
+	track := func(d *pluginsdk.ResourceData, meta interface{}) (context.Context, func()) {
+		address := resourceType
+		if id := d.Id(); id != "" {
+			address = fmt.Sprintf("%s (%s)", resourceType, id)
+		}
+		lockContext := locks.WithResourceAddress(context.Background(), address)
+
+		previous, tracked := inflight.Swap(d, inflightResource{
+			resourceType:     resourceType,
+			resourceTimeouts: resourceTimeouts,
+			defaults:         defaults(meta),
+			lockContext:      lockContext,
+		})
+		return lockContext, func() {
+			if tracked {
+				inflight.Store(d, previous)
+				return
+			}
+			inflight.Delete(d)
+		}
+	}
+
+	wrapContext := func(f func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics, withTimeout func(context.Context, *pluginsdk.ResourceData) (context.Context, context.CancelFunc)) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			lockContext, untrack := track(d, meta)
+			defer untrack()
+			if withTimeout != nil {
+				var cancel context.CancelFunc
+				ctx, cancel = withTimeout(ctx, d)
+				defer cancel()
+			}
+
+			diags := f(withResourceAddress(ctx, d), d, meta)
+			for _, warning := range locks.Warnings(lockContext) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Lock warning whilst modifying %s", resourceType),
+					Detail:   warning,
+				})
+			}
+			return diags
+		}
+	}
+	wrap := func(f func(*pluginsdk.ResourceData, interface{}) error) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+		return wrapContext(func(_ context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := f(d, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		}, nil)
+	}
+
+	// these are called without a context, so the resource determines the timeout used - and are mutually exclusive
+	// with the equivalent functions called without a timeout
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if f := resource.Create; f != nil { //nolint:staticcheck
+		resource.Create = nil //nolint:staticcheck
+		resource.CreateWithoutTimeout = wrap(f)
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if f := resource.Read; f != nil { //nolint:staticcheck
+		resource.Read = nil //nolint:staticcheck
+		resource.ReadWithoutTimeout = wrap(f)
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if f := resource.Update; f != nil { //nolint:staticcheck
+		resource.Update = nil //nolint:staticcheck
+		resource.UpdateWithoutTimeout = wrap(f)
+	}
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if f := resource.Delete; f != nil { //nolint:staticcheck
+		resource.Delete = nil //nolint:staticcheck
+		resource.DeleteWithoutTimeout = wrap(f)
+	}
+
+	// these are called without a timeout, so the resource determines the timeout used
+	if f := resource.CreateWithoutTimeout; f != nil {
+		resource.CreateWithoutTimeout = wrapContext(f, nil)
+	}
+	if f := resource.ReadWithoutTimeout; f != nil {
+		resource.ReadWithoutTimeout = wrapContext(f, nil)
+	}
+	if f := resource.UpdateWithoutTimeout; f != nil {
+		resource.UpdateWithoutTimeout = wrapContext(f, nil)
+	}
+	if f := resource.DeleteWithoutTimeout; f != nil {
+		resource.DeleteWithoutTimeout = wrapContext(f, nil)
+	}
+
+	// the Plugin SDK calls these using a context with the timeout defined by the resource, so these are instead
+	// called without a timeout - using the context returned by ForCreate, ForRead, ForUpdate or ForDelete
+	if f := resource.CreateContext; f != nil {
+		resource.CreateContext = nil
+		resource.CreateWithoutTimeout = wrapContext(f, ForCreate)
+	}
+	if f := resource.ReadContext; f != nil {
+		resource.ReadContext = nil
+		resource.ReadWithoutTimeout = wrapContext(f, ForRead)
+	}
+	if f := resource.UpdateContext; f != nil {
+		resource.UpdateContext = nil
+		resource.UpdateWithoutTimeout = wrapContext(f, ForUpdate)
+	}
+	if f := resource.DeleteContext; f != nil {
+		resource.DeleteContext = nil
+		resource.DeleteWithoutTimeout = wrapContext(f, ForDelete)
+	}
+
+	return resource
+}
@@ -0,0 +1,309 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package timeouts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestDefaults_For(t *testing.T) {
+	duration := func(input time.Duration) *time.Duration {
+		return &input
+	}
+
+	defaults := &Defaults{
+		Operations: Operations{
+			Create: duration(time.Hour),
+		},
+		ResourceTypes: map[string]Operations{
+			"azurerm_kubernetes_cluster": {
+				Create: duration(3 * time.Hour),
+				Delete: duration(2 * time.Hour),
+			},
+		},
+	}
+
+	testData := []struct {
+		resourceType string
+		operation    string
+		expected     time.Duration
+	}{
+		{
+			resourceType: "azurerm_kubernetes_cluster",
+			operation:    pluginsdk.TimeoutCreate,
+			expected:     3 * time.Hour,
+		},
+		{
+			resourceType: "azurerm_kubernetes_cluster",
+			operation:    pluginsdk.TimeoutDelete,
+			expected:     2 * time.Hour,
+		},
+		{
+			resourceType: "azurerm_kubernetes_cluster",
+			operation:    pluginsdk.TimeoutRead,
+			expected:     5 * time.Minute,
+		},
+		{
+			resourceType: "azurerm_resource_group",
+			operation:    pluginsdk.TimeoutCreate,
+			expected:     time.Hour,
+		},
+		{
+			resourceType: "azurerm_resource_group",
+			operation:    pluginsdk.TimeoutDelete,
+			expected:     5 * time.Minute,
+		},
+	}
+
+	for _, v := range testData {
+		if actual := defaults.For(v.resourceType, v.operation, 5*time.Minute); actual != v.expected {
+			t.Fatalf("expected the %s timeout for %q to be %s but got %s", v.operation, v.resourceType, v.expected, actual)
+		}
+	}
+
+	var unset *Defaults
+	if actual := unset.For("azurerm_kubernetes_cluster", pluginsdk.TimeoutCreate, 5*time.Minute); actual != 5*time.Minute {
+		t.Fatalf("expected the timeout defined by the resource to be used when no defaults are configured but got %s", actual)
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	duration := func(input time.Duration) *time.Duration {
+		return &input
+	}
+	deadline := func(ctx context.Context) time.Duration {
+		v, ok := ctx.Deadline()
+		if !ok {
+			t.Fatalf("expected the context to have a deadline")
+		}
+		return time.Until(v).Round(time.Hour)
+	}
+
+	var createTimeout, deleteTimeout time.Duration
+	resource := WithDefaults("azurerm_kubernetes_cluster", &pluginsdk.Resource{
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			ctx, cancel := ForCreate(context.Background(), d)
+			defer cancel()
+			createTimeout = deadline(ctx)
+			return nil
+		},
+		DeleteContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			deleteTimeout = deadline(ctx)
+			return nil
+		},
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: duration(time.Hour),
+			Delete: duration(time.Hour),
+		},
+	}, func(meta interface{}) *Defaults {
+		return meta.(*Defaults)
+	})
+
+	// each instance of the Provider has its own Defaults, which are passed as the Provider Meta
+	defaults := &Defaults{
+		ResourceTypes: map[string]Operations{
+			"azurerm_kubernetes_cluster": {
+				Create: duration(3 * time.Hour),
+				Delete: duration(2 * time.Hour),
+			},
+		},
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
+	if resource.Create != nil || resource.CreateWithoutTimeout == nil { //nolint:staticcheck
+		t.Fatalf("expected Create to be called as CreateWithoutTimeout, so that diagnostics can be returned")
+	}
+	if diags := resource.CreateWithoutTimeout(context.Background(), resource.Data(nil), defaults); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+	if createTimeout != 3*time.Hour {
+		t.Fatalf("expected the create timeout to be 3h but got %s", createTimeout)
+	}
+
+	if resource.DeleteContext != nil || resource.DeleteWithoutTimeout == nil {
+		t.Fatalf("expected DeleteContext to be called without the timeout defined by the resource")
+	}
+	if diags := resource.DeleteWithoutTimeout(context.Background(), resource.Data(nil), defaults); diags.HasError() {
+		t.Fatalf("deleting: %+v", diags)
+	}
+	if deleteTimeout != 2*time.Hour {
+		t.Fatalf("expected the delete timeout to be 2h but got %s", deleteTimeout)
+	}
+
+	// the timeouts defined by the resource are unchanged, and are used by other instances of the Provider
+	if *resource.Timeouts.Create != time.Hour {
+		t.Fatalf("expected the timeouts defined by the resource to be unchanged but got %s", *resource.Timeouts.Create)
+	}
+	if diags := resource.CreateWithoutTimeout(context.Background(), resource.Data(nil), (*Defaults)(nil)); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+	if createTimeout != time.Hour {
+		t.Fatalf("expected the create timeout to be 1h without any defaults but got %s", createTimeout)
+	}
+
+	// the ResourceData is no longer tracked once the operation has completed, including when it panics
+	panicking := WithDefaults("azurerm_resource_group", &pluginsdk.Resource{
+		DeleteContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			if _, ok := inflight.Load(d); !ok {
+				t.Fatalf("expected the ResourceData to be tracked during the operation")
+			}
+			panic("deleting")
+		},
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Delete: duration(time.Hour),
+		},
+	}, func(meta interface{}) *Defaults {
+		return nil
+	})
+	d := panicking.Data(nil)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected the delete to panic")
+			}
+		}()
+		panicking.DeleteWithoutTimeout(context.Background(), d, nil)
+	}()
+	if _, ok := inflight.Load(d); ok {
+		t.Fatalf("expected the ResourceData to no longer be tracked after a panic")
+	}
+}
+
+func TestWithDefaults_ResourceAddress(t *testing.T) {
+	duration := time.Hour
+	var recorded bool
+	resource := WithDefaults("azurerm_subnet", &pluginsdk.Resource{
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			ctx, cancel := ForCreate(context.Background(), d)
+			defer cancel()
+			recorded = locks.HasResourceAddress(ctx)
+			return nil
+		},
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: &duration,
+		},
+	}, func(meta interface{}) *Defaults {
+		return nil
+	})
+
+	if diags := resource.CreateWithoutTimeout(context.Background(), resource.Data(nil), nil); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+	if !recorded {
+		t.Fatalf("expected the context returned by ForCreate to record the address of the resource acquiring locks")
+	}
+
+	// outside of an operation there's no resource to record
+	ctx, cancel := ForCreate(context.Background(), resource.Data(nil))
+	defer cancel()
+	if locks.HasResourceAddress(ctx) {
+		t.Fatalf("expected the context to not record a resource address outside of an operation")
+	}
+}
+
+func TestInflightResource_Timeout(t *testing.T) {
+	duration := func(input time.Duration) *time.Duration {
+		return &input
+	}
+
+	resource := inflightResource{
+		resourceType: "azurerm_resource_group",
+		resourceTimeouts: pluginsdk.ResourceTimeout{
+			Create: duration(30 * time.Minute),
+			Read:   duration(5 * time.Minute),
+		},
+		defaults: &Defaults{
+			Operations: Operations{
+				Create: duration(time.Hour),
+				Read:   duration(10 * time.Minute),
+				Update: duration(time.Hour),
+			},
+		},
+	}
+
+	// the timeout defined by the resource is replaced by the default
+	if actual := resource.timeout(pluginsdk.TimeoutCreate, 30*time.Minute, false); actual != time.Hour {
+		t.Fatalf("expected the default create timeout to be used but got %s", actual)
+	}
+
+	// a value from the resource's `timeouts` block takes precedence, even when it matches the timeout defined by the resource
+	if actual := resource.timeout(pluginsdk.TimeoutCreate, 30*time.Minute, true); actual != 30*time.Minute {
+		t.Fatalf("expected the create timeout from the `timeouts` block to be used but got %s", actual)
+	}
+	if actual := resource.timeout(pluginsdk.TimeoutRead, 2*time.Minute, true); actual != 2*time.Minute {
+		t.Fatalf("expected the read timeout from the `timeouts` block to be used but got %s", actual)
+	}
+
+	// operations which aren't supported by the resource are unchanged
+	if actual := resource.timeout(pluginsdk.TimeoutUpdate, 20*time.Minute, false); actual != 20*time.Minute {
+		t.Fatalf("expected the update timeout to be unchanged but got %s", actual)
+	}
+}
+
+func TestTimeoutsBlockSpecifies(t *testing.T) {
+	timeoutsType := cty.Object(map[string]cty.Type{
+		"create": cty.String,
+		"read":   cty.String,
+	})
+	raw := func(timeouts cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"name":     cty.StringVal("example"),
+			"timeouts": timeouts,
+		})
+	}
+
+	testData := []struct {
+		name      string
+		raw       cty.Value
+		expected  bool
+		operation string
+	}{
+		{
+			name:      "no timeouts block",
+			raw:       raw(cty.NullVal(timeoutsType)),
+			operation: pluginsdk.TimeoutCreate,
+		},
+		{
+			name: "operation specified",
+			raw: raw(cty.ObjectVal(map[string]cty.Value{
+				"create": cty.StringVal("30m"),
+				"read":   cty.NullVal(cty.String),
+			})),
+			operation: pluginsdk.TimeoutCreate,
+			expected:  true,
+		},
+		{
+			name: "other operation specified",
+			raw: raw(cty.ObjectVal(map[string]cty.Value{
+				"create": cty.StringVal("30m"),
+				"read":   cty.NullVal(cty.String),
+			})),
+			operation: pluginsdk.TimeoutRead,
+		},
+		{
+			name:      "unknown timeouts block",
+			raw:       raw(cty.UnknownVal(timeoutsType)),
+			operation: pluginsdk.TimeoutCreate,
+		},
+		{
+			name:      "null config",
+			raw:       cty.NullVal(cty.DynamicPseudoType),
+			operation: pluginsdk.TimeoutCreate,
+		},
+	}
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			if actual := timeoutsBlockSpecifies(v.raw, v.operation); actual != v.expected {
+				t.Fatalf("expected %t but got %t", v.expected, actual)
+			}
+		})
+	}
+}
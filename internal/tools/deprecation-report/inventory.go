@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+const (
+	typeResource   = "resource"
+	typeDataSource = "data_source"
+)
+
+var (
+	// replacementRegex matches the replacements within a deprecation message, for example
+	// "`enable_express` has been superseded by `express_enabled`" or "superseded by the `a` and `b` data sources"
+	replacementRegex = regexp.MustCompile("(?i)(?:in favou?r of|superseded by|replaced by|please use|use)\\s+(?:the\\s+)?(?:property\\s+)?(`[^`]+`(?:(?:,\\s*|\\s+and\\s+|\\s+or\\s+)`[^`]+`)*)")
+	quotedNameRegex  = regexp.MustCompile("`([^`]+)`")
+)
+
+// DeprecatedResource is a Resource or Data Source which has been deprecated
+type DeprecatedResource struct {
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	ReplacedBy []string `json:"replacedBy,omitempty"`
+	Message    string   `json:"message"`
+}
+
+// DeprecatedProperty is a property within a Resource or Data Source which has been deprecated
+type DeprecatedProperty struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// Path is the path to the property, where nested blocks are separated by `.` - e.g. `network_rules.ip_rules`
+	Path       string   `json:"path"`
+	ReplacedBy []string `json:"replacedBy,omitempty"`
+	Message    string   `json:"message"`
+}
+
+// Inventory is every deprecated Resource, Data Source and property within the Provider
+type Inventory struct {
+	Resources  []DeprecatedResource `json:"resources"`
+	Properties []DeprecatedProperty `json:"properties"`
+}
+
+// resourceDeprecation returns the deprecated Resource/Data Source, or nil if this isn't deprecated
+func (i Inventory) resourceDeprecation(resourceType, name string) *DeprecatedResource {
+	for _, v := range i.Resources {
+		if v.Type == resourceType && v.Name == name {
+			return &v
+		}
+	}
+	return nil
+}
+
+// propertyDeprecations returns the deprecated properties within the Resource/Data Source, keyed by path
+func (i Inventory) propertyDeprecations(resourceType, name string) map[string]DeprecatedProperty {
+	out := make(map[string]DeprecatedProperty)
+	for _, v := range i.Properties {
+		if v.Type == resourceType && v.Name == name {
+			out[v.Path] = v
+		}
+	}
+	return out
+}
+
+// typedResourceReplacements returns the replacement for each Typed Resource implementing
+// `sdk.ResourceWithDeprecationReplacedBy`, keyed by the deprecated resource type
+func typedResourceReplacements() map[string]string {
+	out := make(map[string]string)
+	for _, service := range provider.SupportedTypedServices() {
+		for _, resource := range service.Resources() {
+			if v, ok := resource.(sdk.ResourceWithDeprecationReplacedBy); ok {
+				out[resource.ResourceType()] = v.DeprecatedInFavourOfResource()
+			}
+		}
+	}
+	return out
+}
+
+// buildInventory returns the Inventory for the Provider schema, where `replacements` are the replacements for
+// deprecated Resources which are known up front (rather than parsed from the deprecation message)
+func buildInventory(input *providerjson.ProviderSchemaJSON, replacements map[string]string) Inventory {
+	inventory := Inventory{
+		Resources:  make([]DeprecatedResource, 0),
+		Properties: make([]DeprecatedProperty, 0),
+	}
+
+	for resourceType, resources := range map[string]map[string]providerjson.ResourceJSON{
+		typeResource:   input.ResourcesMap,
+		typeDataSource: input.DataSourcesMap,
+	} {
+		for name, resource := range resources {
+			if resource.DeprecationMessage != "" {
+				replacedBy := replacementFromMessage(resource.DeprecationMessage, name)
+				if v, ok := replacements[name]; ok && resourceType == typeResource {
+					replacedBy = []string{v}
+				}
+
+				inventory.Resources = append(inventory.Resources, DeprecatedResource{
+					Type:       resourceType,
+					Name:       name,
+					ReplacedBy: replacedBy,
+					Message:    strings.TrimSpace(resource.DeprecationMessage),
+				})
+			}
+
+			for _, property := range deprecatedProperties(resource.Schema, "") {
+				property.Type = resourceType
+				property.Name = name
+				inventory.Properties = append(inventory.Properties, property)
+			}
+		}
+	}
+
+	sort.Slice(inventory.Resources, func(i, j int) bool {
+		return fmt.Sprintf("%s/%s", inventory.Resources[i].Type, inventory.Resources[i].Name) < fmt.Sprintf("%s/%s", inventory.Resources[j].Type, inventory.Resources[j].Name)
+	})
+	sort.Slice(inventory.Properties, func(i, j int) bool {
+		return fmt.Sprintf("%s/%s/%s", inventory.Properties[i].Type, inventory.Properties[i].Name, inventory.Properties[i].Path) < fmt.Sprintf("%s/%s/%s", inventory.Properties[j].Type, inventory.Properties[j].Name, inventory.Properties[j].Path)
+	})
+
+	return inventory
+}
+
+func deprecatedProperties(input map[string]providerjson.SchemaJSON, path string) []DeprecatedProperty {
+	out := make([]DeprecatedProperty, 0)
+	for name, property := range input {
+		propertyPath := name
+		if path != "" {
+			propertyPath = fmt.Sprintf("%s.%s", path, name)
+		}
+
+		if property.Deprecated != "" {
+			out = append(out, DeprecatedProperty{
+				Path:       propertyPath,
+				ReplacedBy: replacementFromMessage(property.Deprecated, name),
+				Message:    strings.TrimSpace(property.Deprecated),
+			})
+		}
+
+		// the nested block is a value when loaded from a file and a pointer when loaded from the Provider
+		switch elem := property.Elem.(type) {
+		case providerjson.ResourceJSON:
+			out = append(out, deprecatedProperties(elem.Schema, propertyPath)...)
+		case *providerjson.ResourceJSON:
+			if elem != nil {
+				out = append(out, deprecatedProperties(elem.Schema, propertyPath)...)
+			}
+		}
+	}
+	return out
+}
+
+// replacementFromMessage returns the replacements named in the deprecation message, if any
+func replacementFromMessage(message, name string) []string {
+	for _, match := range replacementRegex.FindAllStringSubmatch(message, -1) {
+		out := make([]string, 0)
+		for _, quoted := range quotedNameRegex.FindAllStringSubmatch(match[1], -1) {
+			if quoted[1] != name {
+				out = append(out, quoted[1])
+			}
+		}
+		if len(out) > 0 {
+			return out
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func main() {
+	f := flag.NewFlagSet("deprecation-report", flag.ExitOnError)
+
+	scanPath := f.String("scan", "", "the path to a Terraform configuration file, or a directory which is scanned recursively, to list the deprecated Resources, Data Sources and properties used within it")
+	outputJSON := f.Bool("json", false, "output the report as JSON. Defaults to `false`")
+	errorOnFindings := f.Bool("error-on-findings", false, "should the scan exit with a non-zero exit code when deprecated usages are found. Defaults to `false`")
+
+	if err := f.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("error parsing args: %+v", err)
+	}
+
+	s, err := providerjson.ProviderFromRaw(providerjson.LoadData())
+	if err != nil {
+		log.Fatalf("error loading the provider schema: %+v", err)
+	}
+	inventory := buildInventory(s, typedResourceReplacements())
+
+	if *scanPath == "" {
+		if err := writeInventory(os.Stdout, inventory, *outputJSON); err != nil {
+			log.Fatalf("error writing the report: %+v", err)
+		}
+		return
+	}
+
+	findings, err := scan(*scanPath, inventory)
+	if err != nil {
+		log.Fatalf("error scanning %q: %+v", *scanPath, err)
+	}
+
+	if err := writeFindings(os.Stdout, findings, *outputJSON); err != nil {
+		log.Fatalf("error writing the report: %+v", err)
+	}
+
+	if len(findings) > 0 && *errorOnFindings {
+		os.Exit(1)
+	}
+}
+
+func writeInventory(w io.Writer, inventory Inventory, outputJSON bool) error {
+	if outputJSON {
+		return writeJSON(w, inventory)
+	}
+
+	fmt.Fprintf(w, "Deprecated Resources and Data Sources (%d):\n\n", len(inventory.Resources))
+	for _, v := range inventory.Resources {
+		replacement := ""
+		if len(v.ReplacedBy) > 0 {
+			replacement = fmt.Sprintf(" (replaced by %s)", quotedList(v.ReplacedBy))
+		}
+		fmt.Fprintf(w, "* %s %q%s\n", typeDescription(v.Type), v.Name, replacement)
+	}
+
+	fmt.Fprintf(w, "\nDeprecated Properties (%d):\n\n", len(inventory.Properties))
+	for _, v := range inventory.Properties {
+		replacement := ""
+		if len(v.ReplacedBy) > 0 {
+			replacement = fmt.Sprintf(" (replaced by %s)", quotedList(v.ReplacedBy))
+		}
+		fmt.Fprintf(w, "* %s %q: %q%s\n", typeDescription(v.Type), v.Name, v.Path, replacement)
+	}
+
+	return nil
+}
+
+func writeFindings(w io.Writer, findings []Finding, outputJSON bool) error {
+	if outputJSON {
+		return writeJSON(w, struct {
+			Findings []Finding `json:"findings"`
+		}{
+			Findings: findings,
+		})
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "No deprecated Resources, Data Sources or properties are used.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "The following must be changed before upgrading to the next major version of the Provider (%d):\n\n", len(findings))
+	for _, v := range findings {
+		fmt.Fprintln(w, v.String())
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, input interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(input)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func testInventory() Inventory {
+	return buildInventory(&providerjson.ProviderSchemaJSON{
+		ResourcesMap: map[string]providerjson.ResourceJSON{
+			"azurerm_legacy": {
+				DeprecationMessage: "The `azurerm_legacy` resource is deprecated and will be removed in v5.0 of the AzureRM Provider.",
+				Schema: map[string]providerjson.SchemaJSON{
+					"name": {
+						Type:     providerjson.SchemaTypeString,
+						Required: true,
+					},
+				},
+			},
+			"azurerm_example": {
+				Schema: map[string]providerjson.SchemaJSON{
+					"enable_express": {
+						Type:       providerjson.SchemaTypeBool,
+						Optional:   true,
+						Deprecated: "The property `enable_express` has been superseded by `express_enabled` and `express_tier` and will be removed in v5.0 of the AzureRM Provider.",
+					},
+					"network_rules": {
+						Type:     providerjson.SchemaTypeList,
+						Optional: true,
+						Elem: &providerjson.ResourceJSON{
+							Schema: map[string]providerjson.SchemaJSON{
+								"ip_rules": {
+									Type:       providerjson.SchemaTypeList,
+									Optional:   true,
+									Deprecated: "This property is deprecated and will be removed in v5.0 of the AzureRM Provider.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourcesMap: map[string]providerjson.ResourceJSON{
+			"azurerm_example": {
+				Schema: map[string]providerjson.SchemaJSON{
+					"express": {
+						Type:       providerjson.SchemaTypeBool,
+						Computed:   true,
+						Deprecated: "`express` will be removed in favour of the property `express_enabled` in version 5.0 of the AzureRM Provider.",
+					},
+				},
+			},
+		},
+	}, map[string]string{
+		"azurerm_legacy": "azurerm_replacement",
+	})
+}
+
+func TestBuildInventory(t *testing.T) {
+	inventory := testInventory()
+
+	if len(inventory.Resources) != 1 || inventory.Resources[0].Name != "azurerm_legacy" || strings.Join(inventory.Resources[0].ReplacedBy, ",") != "azurerm_replacement" {
+		t.Fatalf("expected `azurerm_legacy` to be replaced by `azurerm_replacement` but got %+v", inventory.Resources)
+	}
+
+	expected := []string{
+		"data_source/azurerm_example/express/express_enabled",
+		"resource/azurerm_example/enable_express/express_enabled,express_tier",
+		"resource/azurerm_example/network_rules.ip_rules/",
+	}
+	actual := make([]string, 0)
+	for _, v := range inventory.Properties {
+		actual = append(actual, strings.Join([]string{v.Type, v.Name, v.Path, strings.Join(v.ReplacedBy, ",")}, "/"))
+	}
+
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected the deprecated properties:\n\n%s\n\nbut got:\n\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestScanFile(t *testing.T) {
+	config := `
+resource "azurerm_legacy" "test" {
+  name = "example"
+}
+
+resource "azurerm_example" "test" {
+  enable_express = true
+
+  dynamic "network_rules" {
+    for_each = [1]
+    content {
+      ip_rules = ["10.0.0.1"]
+    }
+  }
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}
+
+data "azurerm_example" "test" {
+}
+
+output "express" {
+  value = data.azurerm_example.test.express
+}
+`
+
+	findings, err := scanFile("main.tf", []byte(config), testInventory())
+	if err != nil {
+		t.Fatalf("scanning: %+v", err)
+	}
+
+	expected := []string{
+		"main.tf:2: azurerm_legacy.test: ",
+		"main.tf:7: azurerm_example.test: enable_express",
+		"main.tf:12: azurerm_example.test: network_rules.ip_rules",
+		"main.tf:25: data.azurerm_example.test: express",
+	}
+	actual := make([]string, 0)
+	for _, v := range findings {
+		actual = append(actual, fmt.Sprintf("%s:%d: %s: %s", v.File, v.Line, v.Block, v.Path))
+	}
+
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected the findings:\n\n%s\n\nbut got:\n\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	if !strings.Contains(findings[0].Action, `replaced by "azurerm_replacement"`) {
+		t.Fatalf("expected the finding for `azurerm_legacy` to name the replacement but got %q", findings[0].Action)
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Finding is a usage of a deprecated Resource, Data Source or property within the scanned configuration, which must
+// be changed before upgrading to the next major version of the Provider
+type Finding struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Block  string `json:"block"`
+	Path   string `json:"path,omitempty"`
+	Action string `json:"action"`
+
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", f.File, f.Line, f.Block, f.Action)
+}
+
+// scan returns the usages of deprecated Resources, Data Sources and properties within the Terraform configuration
+// files at `path`, which is either a `.tf` file or a directory which is scanned recursively
+func scan(path string, inventory Inventory) ([]Finding, error) {
+	files := make([]string, 0)
+	err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// modules downloaded by `terraform init` aren't managed by the user
+			if info.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(filePath, ".tf") {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding Terraform configuration files in %q: %+v", path, err)
+	}
+
+	findings := make([]Finding, 0)
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %+v", file, err)
+		}
+
+		result, err := scanFile(file, contents, inventory)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, result...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+
+	return findings, nil
+}
+
+func scanFile(fileName string, contents []byte, inventory Inventory) ([]Finding, error) {
+	file, diags := hclsyntax.ParseConfig(contents, fileName, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %q: %s", fileName, diags.Error())
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("parsing %q: unexpected body type %T", fileName, file.Body)
+	}
+
+	findings := make([]Finding, 0)
+	for _, block := range body.Blocks {
+		if (block.Type != "resource" && block.Type != "data") || len(block.Labels) != 2 {
+			continue
+		}
+
+		resourceType := typeResource
+		address := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+		if block.Type == "data" {
+			resourceType = typeDataSource
+			address = fmt.Sprintf("data.%s", address)
+		}
+		name := block.Labels[0]
+
+		newFinding := func(rng hcl.Range, path, action, message string) Finding {
+			return Finding{
+				File:    fileName,
+				Line:    rng.Start.Line,
+				Type:    resourceType,
+				Name:    name,
+				Block:   address,
+				Path:    path,
+				Action:  action,
+				Message: message,
+			}
+		}
+
+		if v := inventory.resourceDeprecation(resourceType, name); v != nil {
+			action := fmt.Sprintf("the %s %q is deprecated and must be removed", typeDescription(resourceType), name)
+			if len(v.ReplacedBy) > 0 {
+				action = fmt.Sprintf("the %s %q is deprecated and must be replaced by %s", typeDescription(resourceType), name, quotedList(v.ReplacedBy))
+			}
+			findings = append(findings, newFinding(block.DefRange(), "", action, v.Message))
+		}
+
+		properties := inventory.propertyDeprecations(resourceType, name)
+		if len(properties) == 0 {
+			continue
+		}
+
+		for _, usage := range propertyUsages(block.Body, "") {
+			v, ok := properties[usage.path]
+			if !ok {
+				continue
+			}
+
+			action := fmt.Sprintf("the property %q is deprecated and must be removed", usage.path)
+			if len(v.ReplacedBy) > 0 {
+				action = fmt.Sprintf("the property %q is deprecated and must be replaced by %s", usage.path, quotedList(v.ReplacedBy))
+			}
+			findings = append(findings, newFinding(usage.rng, usage.path, action, v.Message))
+		}
+	}
+
+	findings = append(findings, scanReferences(fileName, body, inventory)...)
+
+	return findings, nil
+}
+
+type propertyUsage struct {
+	path string
+	rng  hcl.Range
+}
+
+// propertyUsages returns the path of each attribute and nested block specified within the body
+func propertyUsages(body *hclsyntax.Body, path string) []propertyUsage {
+	out := make([]propertyUsage, 0)
+	join := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return fmt.Sprintf("%s.%s", path, name)
+	}
+
+	for name, attribute := range body.Attributes {
+		out = append(out, propertyUsage{
+			path: join(name),
+			rng:  attribute.SrcRange,
+		})
+	}
+
+	for _, block := range body.Blocks {
+		// meta-arguments such as `lifecycle` aren't part of the Resource's schema
+		if path == "" && (block.Type == "lifecycle" || block.Type == "provisioner" || block.Type == "connection") {
+			continue
+		}
+
+		// `dynamic "name" { content { ... } }` generates the block `name`
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			blockPath := join(block.Labels[0])
+			out = append(out, propertyUsage{
+				path: blockPath,
+				rng:  block.DefRange(),
+			})
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == "content" {
+					out = append(out, propertyUsages(nested.Body, blockPath)...)
+				}
+			}
+			continue
+		}
+
+		out = append(out, propertyUsage{
+			path: join(block.Type),
+			rng:  block.DefRange(),
+		})
+		out = append(out, propertyUsages(block.Body, join(block.Type))...)
+	}
+
+	return out
+}
+
+// scanReferences returns references to deprecated (top-level) attributes of Resources and Data Sources, for example
+// `azurerm_example.test.deprecated_attribute` within an output
+func scanReferences(fileName string, body *hclsyntax.Body, inventory Inventory) []Finding {
+	findings := make([]Finding, 0)
+
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return nil
+		}
+
+		// indexes (e.g. when using `count`) and splats are skipped, since only the names are needed
+		steps := make([]string, 0)
+		for _, step := range expr.Traversal {
+			if v := traversalStepName(step); v != "" {
+				steps = append(steps, v)
+			}
+		}
+
+		resourceType := typeResource
+		if len(steps) > 0 && steps[0] == "data" {
+			resourceType = typeDataSource
+			steps = steps[1:]
+		}
+		if len(steps) < 3 {
+			return nil
+		}
+
+		name, attribute := steps[0], steps[2]
+
+		v, ok := inventory.propertyDeprecations(resourceType, name)[attribute]
+		if !ok {
+			return nil
+		}
+
+		address := fmt.Sprintf("%s.%s", name, steps[1])
+		if resourceType == typeDataSource {
+			address = fmt.Sprintf("data.%s", address)
+		}
+
+		action := fmt.Sprintf("the reference to the deprecated attribute %q must be removed", fmt.Sprintf("%s.%s", address, attribute))
+		if len(v.ReplacedBy) > 0 {
+			action = fmt.Sprintf("the reference to the deprecated attribute %q must be replaced by %s", fmt.Sprintf("%s.%s", address, attribute), quotedList(v.ReplacedBy))
+		}
+
+		findings = append(findings, Finding{
+			File:    fileName,
+			Line:    expr.SrcRange.Start.Line,
+			Type:    resourceType,
+			Name:    name,
+			Block:   address,
+			Path:    attribute,
+			Action:  action,
+			Message: v.Message,
+		})
+		return nil
+	})
+
+	return findings
+}
+
+func traversalStepName(input hcl.Traverser) string {
+	switch v := input.(type) {
+	case hcl.TraverseRoot:
+		return v.Name
+	case hcl.TraverseAttr:
+		return v.Name
+	}
+	return ""
+}
+
+func typeDescription(resourceType string) string {
+	if resourceType == typeDataSource {
+		return "data source"
+	}
+	return resourceType
+}
+
+// quotedList returns the values quoted and separated by commas, e.g. `"a", "b"`
+func quotedList(input []string) string {
+	out := make([]string, 0, len(input))
+	for _, v := range input {
+		out = append(out, fmt.Sprintf("%q", v))
+	}
+	return strings.Join(out, ", ")
+}
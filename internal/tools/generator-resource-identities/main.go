@@ -0,0 +1,648 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath     = "github.com/hashicorp/terraform-provider-azurerm"
+	outputFileName = "resource_identities_gen.go"
+)
+
+func main() {
+	filePath := flag.String("path", "", "The relative path to the root directory")
+	showHelp := flag.Bool("help", false, "Display this message")
+
+	flag.Parse()
+
+	if *showHelp {
+		flag.Usage()
+		return
+	}
+
+	if err := run(*filePath); err != nil {
+		panic(err)
+	}
+}
+
+func run(rootDirectory string) error {
+	servicesDirectory := filepath.Join(rootDirectory, "internal", "services")
+	entries, err := os.ReadDir(servicesDirectory)
+	if err != nil {
+		return fmt.Errorf("listing services within %q: %+v", servicesDirectory, err)
+	}
+
+	p := &packages{
+		rootDirectory: rootDirectory,
+		loaded:        map[string]*goPackage{},
+	}
+
+	services := make([]*service, 0)
+	externalPackages := make(map[string]struct{})
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		s, err := findValidationFuncs(filepath.Join(servicesDirectory, entry.Name()), path.Join(modulePath, "internal", "services", entry.Name()))
+		if err != nil {
+			return fmt.Errorf("finding the IDValidationFuncs for the Service %q: %+v", entry.Name(), err)
+		}
+		services = append(services, s)
+
+		for _, fn := range s.validationFuncs {
+			if !strings.HasPrefix(fn.importPath, modulePath+"/") {
+				externalPackages[fn.importPath] = struct{}{}
+			}
+		}
+	}
+
+	if err := p.locateExternalPackages(externalPackages); err != nil {
+		return err
+	}
+
+	for _, s := range services {
+		registrations := make([]registration, 0)
+		for _, fn := range s.validationFuncs {
+			r, err := p.registrationFor(fn)
+			if err != nil {
+				return fmt.Errorf("determining the Resource ID validated by %s.%s: %+v", fn.importPath, fn.name, err)
+			}
+			if r == nil {
+				log.Printf("[DEBUG] The Resource ID validated by %s.%s can't be split into fields, so the Resource Identity contains the `id`", fn.importPath, fn.name)
+				continue
+			}
+			registrations = append(registrations, *r)
+		}
+
+		if err := s.write(registrations); err != nil {
+			return fmt.Errorf("writing the Resource Identities for %q: %+v", s.importPath, err)
+		}
+	}
+
+	return nil
+}
+
+// validationFunc is a function returned from the IDValidationFunc of a Typed Resource
+type validationFunc struct {
+	importPath string
+	name       string
+
+	// alias is the name used to import importPath within the Service Package
+	alias string
+}
+
+type service struct {
+	directory       string
+	importPath      string
+	packageName     string
+	validationFuncs []validationFunc
+}
+
+// findValidationFuncs parses the Service Package within directory and returns each function returned from the
+// IDValidationFunc method of a Typed Resource
+func findValidationFuncs(directory, importPath string) (*service, error) {
+	files, err := parseDirectory(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &service{
+		directory:  directory,
+		importPath: importPath,
+	}
+	seen := make(map[string]struct{})
+	for _, file := range files {
+		s.packageName = file.Name.Name
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != "IDValidationFunc" || fn.Body == nil || len(fn.Body.List) != 1 {
+				continue
+			}
+			ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+
+			var v *validationFunc
+			switch result := ret.Results[0].(type) {
+			case *ast.Ident:
+				v = &validationFunc{
+					importPath: importPath,
+					name:       result.Name,
+				}
+			case *ast.SelectorExpr:
+				alias, ok := result.X.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if path := importPathForAlias(file, alias.Name); path != "" {
+					v = &validationFunc{
+						importPath: path,
+						name:       result.Sel.Name,
+						alias:      alias.Name,
+					}
+				}
+			}
+			if v == nil {
+				// closures and function calls can't be resolved, so the Resource Identity for these contains the `id`
+				continue
+			}
+
+			key := v.importPath + "." + v.name
+			if _, exists := seen[key]; exists {
+				continue
+			}
+			seen[key] = struct{}{}
+			s.validationFuncs = append(s.validationFuncs, *v)
+		}
+	}
+
+	sort.Slice(s.validationFuncs, func(i, j int) bool {
+		a, b := s.validationFuncs[i], s.validationFuncs[j]
+		if a.importPath == b.importPath {
+			return a.name < b.name
+		}
+		return a.importPath < b.importPath
+	})
+
+	return s, nil
+}
+
+// importPathForAlias returns the import path for the package imported as alias within file
+func importPathForAlias(file *ast.File, alias string) string {
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == alias {
+			return importPath
+		}
+	}
+
+	return ""
+}
+
+// registration describes how the ResourceIdentityType for the Resource ID validated by validateFunc is registered
+type registration struct {
+	validateFunc validationFunc
+
+	// idImportPath and idType are set when the Resource ID implements resourceids.ResourceId
+	idImportPath string
+	idType       string
+
+	// parseImportPath and parseFunc are set when the Resource ID was generated by `generator-resource-id`
+	parseImportPath string
+	parseFunc       string
+}
+
+type goPackage struct {
+	files []*ast.File
+	funcs map[string]*ast.FuncDecl
+
+	// methods contains the methods defined for each type within this package
+	methods map[string]map[string]struct{}
+	types   map[string]*ast.TypeSpec
+}
+
+type packages struct {
+	rootDirectory string
+
+	// externalDirectories contains the directory for each package outside of this module
+	externalDirectories map[string]string
+	loaded              map[string]*goPackage
+}
+
+// locateExternalPackages determines the directory containing each package outside of this module
+func (p *packages) locateExternalPackages(importPaths map[string]struct{}) error {
+	p.externalDirectories = make(map[string]string)
+	if len(importPaths) == 0 {
+		return nil
+	}
+
+	args := []string{"list", "-f", "{{.ImportPath}} {{.Dir}}"}
+	for importPath := range importPaths {
+		args = append(args, importPath)
+	}
+	sort.Strings(args[3:])
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = p.rootDirectory
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("locating packages: %+v\n\n%s", err, stderr.String())
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		importPath, directory, ok := strings.Cut(line, " ")
+		if ok {
+			p.externalDirectories[importPath] = directory
+		}
+	}
+
+	return nil
+}
+
+// located returns whether the directory for the package importPath is known, which excludes the standard library
+func (p *packages) located(importPath string) bool {
+	_, ok := p.externalDirectories[importPath]
+	return ok || strings.HasPrefix(importPath, modulePath+"/")
+}
+
+func (p *packages) load(importPath string) (*goPackage, error) {
+	if v, ok := p.loaded[importPath]; ok {
+		return v, nil
+	}
+
+	directory, ok := p.externalDirectories[importPath]
+	if strings.HasPrefix(importPath, modulePath+"/") {
+		directory = filepath.Join(p.rootDirectory, filepath.FromSlash(strings.TrimPrefix(importPath, modulePath+"/")))
+		ok = true
+	}
+	if !ok {
+		return nil, fmt.Errorf("the directory for the package %q couldn't be determined", importPath)
+	}
+
+	files, err := parseDirectory(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &goPackage{
+		files:   files,
+		funcs:   map[string]*ast.FuncDecl{},
+		methods: map[string]map[string]struct{}{},
+		types:   map[string]*ast.TypeSpec{},
+	}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch v := decl.(type) {
+			case *ast.FuncDecl:
+				if v.Recv == nil {
+					pkg.funcs[v.Name.Name] = v
+					continue
+				}
+				if len(v.Recv.List) != 1 {
+					continue
+				}
+				recv := v.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					if _, ok := pkg.methods[ident.Name]; !ok {
+						pkg.methods[ident.Name] = map[string]struct{}{}
+					}
+					pkg.methods[ident.Name][v.Name.Name] = struct{}{}
+				}
+			case *ast.GenDecl:
+				for _, spec := range v.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+						pkg.types[typeSpec.Name.Name] = typeSpec
+					}
+				}
+			}
+		}
+	}
+
+	p.loaded[importPath] = pkg
+	return pkg, nil
+}
+
+// registrationFor determines the Resource ID validated by fn, by locating the parse function called within fn, and
+// returns nil if this can't be determined
+func (p *packages) registrationFor(fn validationFunc) (*registration, error) {
+	pkg, err := p.load(fn.importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	decl, ok := pkg.funcs[fn.name]
+	if !ok || decl.Body == nil {
+		return nil, nil
+	}
+
+	var out *registration
+	var inspectErr error
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		if out != nil || inspectErr != nil {
+			return false
+		}
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		parseImportPath := fn.importPath
+		parseFunc := ""
+		switch v := call.Fun.(type) {
+		case *ast.Ident:
+			parseFunc = v.Name
+		case *ast.SelectorExpr:
+			alias, ok := v.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			file := fileContaining(pkg, decl)
+			if file == nil {
+				return true
+			}
+			parseImportPath = importPathForAlias(file, alias.Name)
+			parseFunc = v.Sel.Name
+		}
+		if parseImportPath == "" || parseFunc == "" || !p.located(parseImportPath) {
+			return true
+		}
+
+		parsePkg, err := p.load(parseImportPath)
+		if err != nil {
+			inspectErr = err
+			return false
+		}
+		idType := parsedResourceIdType(parsePkg, parseFunc)
+		if idType == "" {
+			return true
+		}
+
+		if _, ok := parsePkg.methods[parsePkg.underlyingType(idType)]["Segments"]; ok {
+			out = &registration{
+				validateFunc: fn,
+				idImportPath: parseImportPath,
+				idType:       idType,
+			}
+			return false
+		}
+
+		if isLegacyResourceId(parsePkg, idType) {
+			out = &registration{
+				validateFunc:    fn,
+				parseImportPath: parseImportPath,
+				parseFunc:       parseFunc,
+			}
+		}
+		return false
+	})
+
+	return out, inspectErr
+}
+
+// underlyingType returns the name of the type aliased by the type named typeName, or typeName if this isn't an alias
+func (pkg *goPackage) underlyingType(typeName string) string {
+	for i := 0; i < len(pkg.types); i++ {
+		spec, ok := pkg.types[typeName]
+		if !ok || !spec.Assign.IsValid() {
+			break
+		}
+		ident, ok := spec.Type.(*ast.Ident)
+		if !ok {
+			break
+		}
+		typeName = ident.Name
+	}
+
+	return typeName
+}
+
+// parsedResourceIdType returns the name of the type returned from the function named parseFunc, when this has the
+// signature `func(string) (*T, error)`
+func parsedResourceIdType(pkg *goPackage, parseFunc string) string {
+	decl, ok := pkg.funcs[parseFunc]
+	if !ok {
+		return ""
+	}
+
+	params := decl.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return ""
+	}
+	if ident, ok := params[0].Type.(*ast.Ident); !ok || ident.Name != "string" {
+		return ""
+	}
+
+	if decl.Type.Results == nil || len(decl.Type.Results.List) != 2 {
+		return ""
+	}
+	if ident, ok := decl.Type.Results.List[1].Type.(*ast.Ident); !ok || ident.Name != "error" {
+		return ""
+	}
+	star, ok := decl.Type.Results.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	ident, ok := star.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if _, ok := pkg.types[ident.Name]; !ok {
+		return ""
+	}
+
+	return ident.Name
+}
+
+// isLegacyResourceId returns whether the type named idType is a Resource ID generated by `generator-resource-id`,
+// which is a struct containing only string fields with an ID method
+func isLegacyResourceId(pkg *goPackage, idType string) bool {
+	if _, ok := pkg.methods[idType]["ID"]; !ok {
+		return false
+	}
+
+	structType, ok := pkg.types[idType].Type.(*ast.StructType)
+	if !ok || structType.Fields == nil || len(structType.Fields.List) == 0 {
+		return false
+	}
+	for _, field := range structType.Fields.List {
+		if ident, ok := field.Type.(*ast.Ident); !ok || ident.Name != "string" || len(field.Names) == 0 {
+			return false
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func fileContaining(pkg *goPackage, decl *ast.FuncDecl) *ast.File {
+	for _, file := range pkg.files {
+		if file.Pos() <= decl.Pos() && decl.End() <= file.End() {
+			return file
+		}
+	}
+
+	return nil
+}
+
+// write outputs the registrations into the Service Package, removing any existing file if there are none
+func (s *service) write(registrations []registration) error {
+	outputPath := filepath.Join(s.directory, outputFileName)
+	if len(registrations) == 0 {
+		if err := os.Remove(outputPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing %q: %+v", outputPath, err)
+		}
+		return nil
+	}
+
+	imports := newImportAliases(s.importPath)
+	sdkAlias := imports.add(modulePath+"/internal/sdk", "sdk")
+	pluginSdkAlias := ""
+
+	lines := make([]string, 0)
+	for _, r := range registrations {
+		validateFunc := imports.qualify(r.validateFunc.importPath, r.validateFunc.alias, r.validateFunc.name)
+		if r.idType != "" {
+			if pluginSdkAlias == "" {
+				pluginSdkAlias = imports.add(modulePath+"/internal/tf/pluginsdk", "pluginsdk")
+			}
+			idType := imports.qualify(r.idImportPath, "", r.idType)
+			lines = append(lines, fmt.Sprintf("%s.RegisterResourceIdentityType(%s, %s.NewResourceIdentityType(&%s{}))", sdkAlias, validateFunc, pluginSdkAlias, idType))
+			continue
+		}
+
+		parseFunc := imports.qualify(r.parseImportPath, "", r.parseFunc)
+		lines = append(lines, fmt.Sprintf("%s.RegisterLegacyResourceIdentityType(%s, %s)", sdkAlias, validateFunc, parseFunc))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package %s
+
+// NOTE: this file is generated - manual changes will be overwritten.
+
+import (
+%s
+)
+
+func init() {
+	%s
+}
+`, s.packageName, imports.String(), strings.Join(lines, "\n"))
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting %q: %+v", outputPath, err)
+	}
+
+	return os.WriteFile(outputPath, formatted, 0o644)
+}
+
+// importAliases assigns a unique alias to each package imported into the generated file
+type importAliases struct {
+	currentImportPath string
+	aliases           map[string]string
+	used              map[string]struct{}
+}
+
+func newImportAliases(currentImportPath string) *importAliases {
+	return &importAliases{
+		currentImportPath: currentImportPath,
+		aliases:           map[string]string{},
+		used:              map[string]struct{}{},
+	}
+}
+
+// add imports importPath using preferredAlias where possible, returning the alias used
+func (a *importAliases) add(importPath, preferredAlias string) string {
+	if v, ok := a.aliases[importPath]; ok {
+		return v
+	}
+
+	if preferredAlias == "" {
+		preferredAlias = path.Base(importPath)
+	}
+	alias := preferredAlias
+	for i := 2; ; i++ {
+		if _, used := a.used[alias]; !used {
+			break
+		}
+		alias = fmt.Sprintf("%s%d", preferredAlias, i)
+	}
+
+	a.aliases[importPath] = alias
+	a.used[alias] = struct{}{}
+	return alias
+}
+
+// qualify returns the qualified name for name within importPath
+func (a *importAliases) qualify(importPath, preferredAlias, name string) string {
+	if importPath == a.currentImportPath {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", a.add(importPath, preferredAlias), name)
+}
+
+func (a *importAliases) String() string {
+	importPaths := make([]string, 0, len(a.aliases))
+	for importPath := range a.aliases {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+
+	lines := make([]string, 0, len(importPaths))
+	for _, importPath := range importPaths {
+		alias := a.aliases[importPath]
+		if alias == path.Base(importPath) {
+			lines = append(lines, strconv.Quote(importPath))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %q", alias, importPath))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// parseDirectory parses the non-test Go files within directory, excluding the file output by this generator
+func parseDirectory(directory string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("listing %q: %+v", directory, err)
+	}
+
+	fileSet := token.NewFileSet()
+	files := make([]*ast.File, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == outputFileName {
+			continue
+		}
+
+		file, err := parser.ParseFile(fileSet, filepath.Join(directory, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %+v", name, err)
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hclItem is an attribute or block within a Terraform Configuration used in the acceptance tests
+type hclItem struct {
+	name  string
+	value string
+
+	// block are the items within a nested block, when this isn't an attribute
+	block []hclItem
+}
+
+// testConfig returns the Terraform Configuration for the resource, containing either the required arguments or all
+// of the arguments
+func (r resourceScaffold) testConfig(name string, complete bool) string {
+	items := make([]hclItem, 0)
+	for _, arg := range r.idArguments {
+		items = append(items, hclItem{
+			name:  arg.name,
+			value: arg.example,
+		})
+	}
+	items = append(items, exampleItems(r.createMap.properties, complete)...)
+
+	return fmt.Sprintf("resource %q %q {\n%s}\n", r.resourceType, name, renderHCL(items, "  "))
+}
+
+// requiresImportConfig returns the Terraform Configuration for importing the resource defined in the basic
+// configuration, by referencing its arguments
+func (r resourceScaffold) requiresImportConfig() string {
+	items := make([]hclItem, 0)
+	for _, arg := range r.idArguments {
+		items = append(items, hclItem{
+			name:  arg.name,
+			value: fmt.Sprintf("%s.test.%s", r.resourceType, arg.name),
+		})
+	}
+	for _, item := range exampleItems(r.createMap.properties, false) {
+		if item.block == nil {
+			item.value = fmt.Sprintf("%s.test.%s", r.resourceType, item.name)
+		}
+		items = append(items, item)
+	}
+
+	return fmt.Sprintf("resource %q %q {\n%s}\n", r.resourceType, "import", renderHCL(items, "  "))
+}
+
+func exampleItems(properties []*property, complete bool) []hclItem {
+	items := make([]hclItem, 0)
+	for _, p := range properties {
+		if !p.required && !complete {
+			continue
+		}
+
+		switch {
+		case p.identity != nil:
+			if !p.identity.supportsSystemAssigned {
+				// a User Assigned Identity needs to be added to the test configuration
+				continue
+			}
+			items = append(items, hclItem{
+				name: p.name,
+				block: []hclItem{
+					{
+						name:  "type",
+						value: `"SystemAssigned"`,
+					},
+				},
+			})
+
+		case len(p.nested) > 0:
+			items = append(items, hclItem{
+				name:  p.name,
+				block: exampleItems(sortedProperties(p.nested), complete),
+			})
+
+		default:
+			items = append(items, hclItem{
+				name:  p.name,
+				value: p.example,
+			})
+		}
+	}
+	return items
+}
+
+// renderHCL renders the attributes and then the blocks, aligning the attributes as `terraform fmt` does
+func renderHCL(items []hclItem, indent string) string {
+	out := strings.Builder{}
+
+	attributes := make([]hclItem, 0)
+	multiLine := make([]hclItem, 0)
+	blocks := make([]hclItem, 0)
+	for _, item := range items {
+		switch {
+		case item.block != nil:
+			blocks = append(blocks, item)
+		case strings.Contains(item.value, "\n"):
+			multiLine = append(multiLine, item)
+		default:
+			attributes = append(attributes, item)
+		}
+	}
+
+	width := 0
+	for _, item := range attributes {
+		width = max(width, len(item.name))
+	}
+	for _, item := range attributes {
+		out.WriteString(fmt.Sprintf("%s%-*s = %s\n", indent, width, item.name, item.value))
+	}
+
+	for _, item := range blocks {
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(fmt.Sprintf("%s%s {\n%s%s}\n", indent, item.name, renderHCL(item.block, indent+"  "), indent))
+	}
+
+	for _, item := range multiLine {
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		value := strings.ReplaceAll(item.value, "\n", "\n"+indent)
+		out.WriteString(fmt.Sprintf("%s%s = %s\n", indent, item.name, value))
+	}
+
+	return out.String()
+}
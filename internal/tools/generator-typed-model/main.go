@@ -17,12 +17,27 @@ import (
 )
 
 func main() {
+	opts := scaffoldOptions{}
+	flag.StringVar(&opts.sdkService, "sdk-service", "", "The Service within go-azure-sdk used to scaffold a Typed Resource (e.g. `loadtestservice`)")
+	flag.StringVar(&opts.apiVersion, "api-version", "", "The API Version within go-azure-sdk used to scaffold a Typed Resource (e.g. `2022-12-01`)")
+	flag.StringVar(&opts.sdkPackage, "sdk-package", "", "The package within go-azure-sdk used to scaffold a Typed Resource (e.g. `loadtests`)")
+	flag.StringVar(&opts.clientPath, "client", "", "The path to the go-azure-sdk client within `clients.Client` (e.g. `LoadTestService.V20221201.LoadTests`)")
+	flag.StringVar(&opts.outputDirectory, "output-dir", "", "The Service Package to write the scaffolded Typed Resource to, when omitted this is output to stdout")
 	flag.Parse()
 	if len(flag.Args()) != 1 {
-		fmt.Fprintln(os.Stderr, "Usage: generator-typed-model <resource_type>")
+		fmt.Fprintln(os.Stderr, "Usage: generator-typed-model [-sdk-service <service> -api-version <version> -sdk-package <package> [-client <path>] [-output-dir <dir>]] <resource_type>")
 		os.Exit(1)
 	}
 	rt := flag.Args()[0]
+
+	if opts.sdkPackage != "" {
+		opts.resourceType = rt
+		if err := scaffold(opts); err != nil {
+			log.Fatalf("scaffolding %s: %+v", rt, err)
+		}
+		return
+	}
+
 	resource, ok := provider.AzureProvider().ResourcesMap[rt]
 	if !ok {
 		log.Fatalf("unknown resource type: %s", rt)
@@ -0,0 +1,534 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxNestingDepth is the depth to which nested models are mapped, beyond which properties need to be added manually
+const maxNestingDepth = 4
+
+// property is an argument within the schema, mapped from a field within the go-azure-sdk model
+type property struct {
+	name      string
+	fieldName string
+	modelType string
+
+	// sdkField is the name of the field within the go-azure-sdk model
+	sdkField string
+
+	required bool
+	forceNew bool
+
+	// inProperties is whether the field is within the `Properties` model, rather than the top-level model
+	inProperties bool
+
+	schemaType   string
+	validateFunc string
+	maxItems     int
+
+	// elem is the Elem for a List/Map of primitives
+	elem string
+
+	// nested are the properties within a nested block
+	nested []*property
+
+	// commonSchema is the function within `commonschema` used for this property, rather than a schema literal
+	commonSchema string
+
+	// identity is the type of Managed Identity, when this property is the `identity` block
+	identity *identityType
+
+	// expand and flatten return the expression which converts the value between the go-azure-sdk model and the
+	// typed model
+	expand  func(value string) string
+	flatten func(value string) string
+
+	// example is an example value used in the acceptance test configurations
+	example string
+}
+
+func (p property) isBlock() bool {
+	return len(p.nested) > 0 || p.identity != nil
+}
+
+// identityType is a Managed Identity type defined in go-azure-helpers, which has a common schema
+type identityType struct {
+	schema                 string
+	model                  string
+	expandFunc             string
+	flattenFunc            string
+	supportsSystemAssigned bool
+
+	// flattenReturnsPointer and flattenReturnsError describe the signature of the flattenFunc, which differs by type
+	flattenReturnsPointer bool
+	flattenReturnsError   bool
+}
+
+var identityTypes = map[string]identityType{
+	"LegacySystemAndUserAssignedMap": {
+		schema:                 "SystemAssignedUserAssignedIdentityOptional",
+		model:                  "ModelSystemAssignedUserAssigned",
+		expandFunc:             "ExpandLegacySystemAndUserAssignedMapFromModel",
+		flattenFunc:            "FlattenLegacySystemAndUserAssignedMapToModel",
+		supportsSystemAssigned: true,
+		flattenReturnsError:    true,
+	},
+	"SystemAndUserAssignedList": {
+		schema:                 "SystemAssignedUserAssignedIdentityOptional",
+		model:                  "ModelSystemAssignedUserAssigned",
+		expandFunc:             "ExpandSystemAndUserAssignedListFromModel",
+		flattenFunc:            "FlattenSystemAndUserAssignedListToModel",
+		supportsSystemAssigned: true,
+		flattenReturnsPointer:  true,
+		flattenReturnsError:    true,
+	},
+	"SystemAndUserAssignedMap": {
+		schema:                 "SystemAssignedUserAssignedIdentityOptional",
+		model:                  "ModelSystemAssignedUserAssigned",
+		expandFunc:             "ExpandSystemAndUserAssignedMapFromModel",
+		flattenFunc:            "FlattenSystemAndUserAssignedMapToModel",
+		supportsSystemAssigned: true,
+		flattenReturnsPointer:  true,
+		flattenReturnsError:    true,
+	},
+	"SystemAssigned": {
+		schema:                 "SystemAssignedIdentityOptional",
+		model:                  "ModelSystemAssigned",
+		expandFunc:             "ExpandSystemAssignedFromModel",
+		flattenFunc:            "FlattenSystemAssignedToModel",
+		supportsSystemAssigned: true,
+	},
+	"SystemOrUserAssignedList": {
+		schema:                 "SystemOrUserAssignedIdentityOptional",
+		model:                  "ModelSystemAssignedUserAssigned",
+		expandFunc:             "ExpandSystemOrUserAssignedListFromModel",
+		flattenFunc:            "FlattenSystemAssignedOrUserAssignedListToModel",
+		supportsSystemAssigned: true,
+		flattenReturnsPointer:  true,
+		flattenReturnsError:    true,
+	},
+	"SystemOrUserAssignedMap": {
+		schema:                 "SystemOrUserAssignedIdentityOptional",
+		model:                  "ModelSystemAssignedUserAssigned",
+		expandFunc:             "ExpandSystemOrUserAssignedMapFromModel",
+		flattenFunc:            "FlattenSystemOrUserAssignedMapToModel",
+		supportsSystemAssigned: true,
+		flattenReturnsPointer:  true,
+		flattenReturnsError:    true,
+	},
+	"UserAssignedList": {
+		schema:                "UserAssignedIdentityOptional",
+		model:                 "ModelUserAssigned",
+		expandFunc:            "ExpandUserAssignedListFromModel",
+		flattenFunc:           "FlattenUserAssignedListToModel",
+		flattenReturnsPointer: true,
+		flattenReturnsError:   true,
+	},
+	"UserAssignedMap": {
+		schema:                "UserAssignedIdentityOptional",
+		model:                 "ModelUserAssigned",
+		expandFunc:            "ExpandUserAssignedMapFromModel",
+		flattenFunc:           "FlattenUserAssignedMapToModel",
+		flattenReturnsPointer: true,
+		flattenReturnsError:   true,
+	},
+}
+
+// sdkField is a field within a go-azure-sdk model
+type sdkField struct {
+	name     string
+	jsonName string
+	typeExpr ast.Expr
+
+	// required is whether the field is always sent to the API, which go-azure-sdk generates without `omitempty`
+	required bool
+}
+
+func sdkFields(s *ast.StructType) []sdkField {
+	out := make([]sdkField, 0)
+	for _, field := range s.Fields.List {
+		jsonName := ""
+		omitEmpty := false
+		if field.Tag != nil {
+			if tag, err := strconv.Unquote(field.Tag.Value); err == nil {
+				values := strings.Split(reflect.StructTag(tag).Get("json"), ",")
+				jsonName = values[0]
+				for _, v := range values[1:] {
+					if v == "omitempty" {
+						omitEmpty = true
+					}
+				}
+			}
+		}
+
+		for _, name := range field.Names {
+			if jsonName == "" || jsonName == "-" {
+				continue
+			}
+			out = append(out, sdkField{
+				name:     name.Name,
+				jsonName: jsonName,
+				typeExpr: field.Type,
+				required: !omitEmpty,
+			})
+		}
+	}
+	return out
+}
+
+// nestedModel is a typed model (and the expand/flatten functions) generated for a go-azure-sdk model
+type nestedModel struct {
+	sdkType    string
+	name       string
+	properties []*property
+
+	// single and list are whether functions are needed to map a single object and/or a list of objects
+	single bool
+	list   bool
+}
+
+// functionName is the name used for the expand/flatten functions for this model
+func (m nestedModel) functionName() string {
+	return strings.TrimSuffix(m.name, "Model")
+}
+
+// mapper maps the fields within the go-azure-sdk models into properties for the schema and typed model
+type mapper struct {
+	pkg *sdkPackage
+
+	// prefix is prepended to the names of the nested models and functions, to avoid conflicts within the package
+	prefix string
+
+	nestedModels map[string]*nestedModel
+
+	// enumLists are the constant types used within a list, for which expand/flatten functions are generated
+	enumLists map[string]struct{}
+
+	// unsupported are the fields which couldn't be mapped and need to be added manually
+	unsupported []string
+}
+
+func newMapper(pkg *sdkPackage, prefix string) *mapper {
+	return &mapper{
+		pkg:          pkg,
+		prefix:       prefix,
+		nestedModels: make(map[string]*nestedModel),
+		enumLists:    make(map[string]struct{}),
+	}
+}
+
+// properties returns the properties for the fields within the go-azure-sdk model
+func (m *mapper) properties(sdkType string, fields []sdkField, depth int) []*property {
+	out := make([]*property, 0)
+	for _, field := range fields {
+		if p := m.property(sdkType, field, depth); p != nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (m *mapper) property(sdkType string, field sdkField, depth int) *property {
+	name := camelToSnake(field.jsonName)
+	p := property{
+		name:      name,
+		fieldName: snake2Camel(name),
+		sdkField:  field.name,
+		required:  field.required,
+	}
+
+	unsupported := func() *property {
+		m.unsupported = append(m.unsupported, fmt.Sprintf("%s.%s (%s)", sdkType, field.name, typeString(field.typeExpr)))
+		return nil
+	}
+
+	typeExpr := field.typeExpr
+	pointer := false
+	if star, ok := typeExpr.(*ast.StarExpr); ok {
+		pointer = true
+		typeExpr = star.X
+	}
+
+	// expand/flatten for values which only need to be converted between a pointer and a value
+	direct := func() {
+		p.expand = func(value string) string {
+			if pointer {
+				return fmt.Sprintf("pointer.To(%s)", value)
+			}
+			return value
+		}
+		p.flatten = func(value string) string {
+			if pointer {
+				return fmt.Sprintf("pointer.From(%s)", value)
+			}
+			return value
+		}
+	}
+
+	switch t := typeExpr.(type) {
+	case *ast.Ident:
+		if primitive, ok := primitives[t.Name]; ok {
+			p.modelType = t.Name
+			p.schemaType = primitive.schemaType
+			p.validateFunc = primitive.validateFunc
+			p.example = primitive.example
+			direct()
+			return &p
+		}
+
+		if m.pkg.isEnum(t.Name) {
+			enum := fmt.Sprintf("%s.%s", m.pkg.name, t.Name)
+			p.modelType = "string"
+			p.schemaType = "pluginsdk.TypeString"
+			p.validateFunc = fmt.Sprintf("validation.StringInSlice(%s.PossibleValuesFor%s(), false)", m.pkg.name, t.Name)
+			p.example = `"TODO"`
+			if values := m.pkg.constants[t.Name]; len(values) > 0 {
+				p.example = strconv.Quote(values[0])
+			}
+			p.expand = func(value string) string {
+				if pointer {
+					return fmt.Sprintf("pointer.To(%s(%s))", enum, value)
+				}
+				return fmt.Sprintf("%s(%s)", enum, value)
+			}
+			p.flatten = func(value string) string {
+				if pointer {
+					return fmt.Sprintf("string(pointer.From(%s))", value)
+				}
+				return fmt.Sprintf("string(%s)", value)
+			}
+			return &p
+		}
+
+		nested := m.nestedModel(t.Name, depth)
+		if nested == nil {
+			return unsupported()
+		}
+		nested.single = true
+		p.modelType = "[]" + nested.name
+		p.schemaType = "pluginsdk.TypeList"
+		p.maxItems = 1
+		p.nested = nested.properties
+		p.expand = func(value string) string {
+			if pointer {
+				return fmt.Sprintf("expand%s(%s)", nested.functionName(), value)
+			}
+			return fmt.Sprintf("pointer.From(expand%s(%s))", nested.functionName(), value)
+		}
+		p.flatten = func(value string) string {
+			if pointer {
+				return fmt.Sprintf("flatten%s(%s)", nested.functionName(), value)
+			}
+			return fmt.Sprintf("flatten%s(&%s)", nested.functionName(), value)
+		}
+		return &p
+
+	case *ast.ArrayType:
+		elem, ok := t.Elt.(*ast.Ident)
+		if !ok || t.Len != nil {
+			return unsupported()
+		}
+
+		if primitive, ok := primitives[elem.Name]; ok {
+			p.modelType = "[]" + elem.Name
+			p.schemaType = "pluginsdk.TypeList"
+			p.elem = primitive.elem()
+			p.example = fmt.Sprintf("[%s]", primitive.example)
+			direct()
+			return &p
+		}
+
+		if m.pkg.isEnum(elem.Name) {
+			m.enumLists[elem.Name] = struct{}{}
+			functionName := m.prefix + elem.Name + "List"
+			p.modelType = "[]string"
+			p.schemaType = "pluginsdk.TypeList"
+			p.elem = fmt.Sprintf(`&pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringInSlice(%s.PossibleValuesFor%s(), false),
+			}`, m.pkg.name, elem.Name)
+			p.example = `["TODO"]`
+			if values := m.pkg.constants[elem.Name]; len(values) > 0 {
+				p.example = fmt.Sprintf("[%q]", values[0])
+			}
+			p.expand = func(value string) string {
+				if pointer {
+					return fmt.Sprintf("expand%s(%s)", functionName, value)
+				}
+				return fmt.Sprintf("pointer.From(expand%s(%s))", functionName, value)
+			}
+			p.flatten = func(value string) string {
+				if pointer {
+					return fmt.Sprintf("flatten%s(%s)", functionName, value)
+				}
+				return fmt.Sprintf("flatten%s(&%s)", functionName, value)
+			}
+			return &p
+		}
+
+		nested := m.nestedModel(elem.Name, depth)
+		if nested == nil {
+			return unsupported()
+		}
+		nested.list = true
+		p.modelType = "[]" + nested.name
+		p.schemaType = "pluginsdk.TypeList"
+		p.nested = nested.properties
+		p.expand = func(value string) string {
+			if pointer {
+				return fmt.Sprintf("expand%sList(%s)", nested.functionName(), value)
+			}
+			return fmt.Sprintf("pointer.From(expand%sList(%s))", nested.functionName(), value)
+		}
+		p.flatten = func(value string) string {
+			if pointer {
+				return fmt.Sprintf("flatten%sList(%s)", nested.functionName(), value)
+			}
+			return fmt.Sprintf("flatten%sList(&%s)", nested.functionName(), value)
+		}
+		return &p
+
+	case *ast.MapType:
+		key, keyOk := t.Key.(*ast.Ident)
+		value, valueOk := t.Value.(*ast.Ident)
+		if !keyOk || !valueOk || key.Name != "string" || value.Name != "string" {
+			return unsupported()
+		}
+
+		p.modelType = "map[string]string"
+		p.schemaType = "pluginsdk.TypeMap"
+		p.elem = primitives["string"].elem()
+		p.example = "{\n  key = \"value\"\n}"
+		direct()
+		return &p
+
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "identity" && depth == 0 {
+			identity, ok := identityTypes[t.Sel.Name]
+			if !ok {
+				return unsupported()
+			}
+
+			p.identity = &identity
+			p.modelType = fmt.Sprintf("[]identity.%s", identity.model)
+			if !pointer {
+				return unsupported()
+			}
+			return &p
+		}
+	}
+
+	return unsupported()
+}
+
+// nestedModel returns the typed model for the go-azure-sdk model, or nil if this can't be mapped (e.g. as it's
+// a discriminated type, or is nested too deeply)
+func (m *mapper) nestedModel(sdkType string, depth int) *nestedModel {
+	if existing, ok := m.nestedModels[sdkType]; ok {
+		if existing.properties == nil {
+			// this is a recursive model, which needs to be mapped manually
+			return nil
+		}
+		return existing
+	}
+
+	s, ok := m.pkg.structType(sdkType)
+	if !ok || depth >= maxNestingDepth {
+		return nil
+	}
+
+	model := &nestedModel{
+		sdkType: sdkType,
+		name:    m.prefix + strings.TrimPrefix(sdkType, m.prefix) + "Model",
+	}
+	// register this prior to mapping the fields to handle recursive models
+	m.nestedModels[sdkType] = model
+	model.properties = m.properties(sdkType, sdkFields(s), depth+1)
+	if len(model.properties) == 0 {
+		delete(m.nestedModels, sdkType)
+		return nil
+	}
+
+	return model
+}
+
+type primitive struct {
+	schemaType   string
+	validateFunc string
+	example      string
+}
+
+func (p primitive) elem() string {
+	if p.validateFunc == "" {
+		return fmt.Sprintf("&pluginsdk.Schema{\nType: %s,\n}", p.schemaType)
+	}
+	return fmt.Sprintf("&pluginsdk.Schema{\nType: %s,\nValidateFunc: %s,\n}", p.schemaType, p.validateFunc)
+}
+
+var primitives = map[string]primitive{
+	"bool": {
+		schemaType: "pluginsdk.TypeBool",
+		example:    "true",
+	},
+	"float64": {
+		schemaType: "pluginsdk.TypeFloat",
+		example:    "1.5",
+	},
+	"int64": {
+		schemaType: "pluginsdk.TypeInt",
+		example:    "1",
+	},
+	"string": {
+		schemaType:   "pluginsdk.TypeString",
+		validateFunc: "validation.StringIsNotEmpty",
+		example:      `"TODO"`,
+	},
+}
+
+// camelToSnake converts the name of a field in the API (e.g. `dataPlaneURI`) into the name of a property in the
+// schema (e.g. `data_plane_uri`)
+func camelToSnake(input string) string {
+	runes := []rune(input)
+	out := strings.Builder{}
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				out.WriteRune('_')
+			}
+		}
+		if r == '-' || r == '.' {
+			out.WriteRune('_')
+			continue
+		}
+		out.WriteRune(unicode.ToLower(r))
+	}
+	return out.String()
+}
+
+func typeString(input ast.Expr) string {
+	switch v := input.(type) {
+	case *ast.Ident:
+		return v.Name
+	case *ast.StarExpr:
+		return "*" + typeString(v.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(v.Elt)
+	case *ast.MapType:
+		return fmt.Sprintf("map[%s]%s", typeString(v.Key), typeString(v.Value))
+	case *ast.SelectorExpr:
+		return fmt.Sprintf("%s.%s", typeString(v.X), v.Sel.Name)
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	return fmt.Sprintf("%T", input)
+}
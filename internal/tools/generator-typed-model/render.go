@@ -0,0 +1,828 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const resourceTemplate = `// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package {{ .PackageName }}
+
+import (
+{{ range .Imports }}{{ if . }}	"{{ . }}"{{ end }}
+{{ end }})
+
+var _ sdk.ResourceWithUpdate = {{ .Name }}Resource{}
+
+type {{ .Name }}Resource struct{}
+
+type {{ .Name }}ResourceModel struct {
+{{ .ModelFields }}}
+
+{{ .NestedModels }}
+
+func (r {{ .Name }}Resource) ModelObject() interface{} {
+	return &{{ .Name }}ResourceModel{}
+}
+
+func (r {{ .Name }}Resource) ResourceType() string {
+	return "{{ .ResourceType }}"
+}
+
+func (r {{ .Name }}Resource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return {{ .IDValidateFunc }}
+}
+
+func (r {{ .Name }}Resource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+{{ .Arguments }}	}
+}
+
+func (r {{ .Name }}Resource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r {{ .Name }}Resource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.{{ .ClientPath }}
+
+			var config {{ .Name }}ResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+{{ .CreateID }}
+			existing, err := client.Get(ctx, id{{ .GetArguments }})
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+{{ .CreatePayload }}
+			{{ .CreateCall }}
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r {{ .Name }}Resource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.{{ .ClientPath }}
+
+			id, err := {{ .IDParseFunc }}(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id{{ .GetArguments }})
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+{{ .ReadState }}
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r {{ .Name }}Resource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.{{ .ClientPath }}
+
+			id, err := {{ .IDParseFunc }}(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config {{ .Name }}ResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+{{ .UpdatePayload }}
+			{{ .UpdateCall }}
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r {{ .Name }}Resource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.{{ .ClientPath }}
+
+			id, err := {{ .IDParseFunc }}(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			{{ .DeleteCall }}
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+{{ .Functions }}
+`
+
+const testTemplate = `// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package {{ .PackageName }}_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"{{ .IDImportPath }}"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type {{ .Name }}TestResource struct{}
+
+func TestAcc{{ .Name }}_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "{{ .ResourceType }}", "test")
+	r := {{ .Name }}TestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAcc{{ .Name }}_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "{{ .ResourceType }}", "test")
+	r := {{ .Name }}TestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAcc{{ .Name }}_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "{{ .ResourceType }}", "test")
+	r := {{ .Name }}TestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAcc{{ .Name }}_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "{{ .ResourceType }}", "test")
+	r := {{ .Name }}TestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r {{ .Name }}TestResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := {{ .IDParseFunc }}(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.{{ .ClientPath }}.Get(ctx, *id{{ .GetArguments }})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r {{ .Name }}TestResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%[1]s
+
+{{ .BasicConfig }}` + "`" + `, r.template(data), data.RandomInteger)
+}
+
+func (r {{ .Name }}TestResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%s
+
+{{ .RequiresImportConfig }}` + "`" + `, r.basic(data))
+}
+
+func (r {{ .Name }}TestResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+%[1]s
+
+{{ .CompleteConfig }}` + "`" + `, r.template(data), data.RandomInteger)
+}
+
+func (r {{ .Name }}TestResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(` + "`" + `
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+` + "`" + `, data.RandomInteger, data.Locations.Primary)
+}
+`
+
+func (r resourceScaffold) renderResource() (string, error) {
+	data := map[string]string{
+		"PackageName":    r.packageName,
+		"Name":           r.name,
+		"ResourceType":   r.resourceType,
+		"ClientPath":     r.clientPath,
+		"IDValidateFunc": r.id.validateFunc(),
+		"IDParseFunc":    r.id.parseFunc(),
+		"GetArguments":   r.get.arguments(r.sdk.name, ""),
+		"ModelFields":    r.modelFields(),
+		"Arguments":      r.arguments(),
+		"CreateID":       r.createID(),
+		"CreatePayload":  r.createPayload(),
+		"CreateCall":     r.call(r.create, "id", "payload"),
+		"ReadState":      r.readState(),
+		"UpdatePayload":  r.updatePayload(),
+		"UpdateCall":     r.call(*r.update, "*id", "payload"),
+		"DeleteCall":     r.call(r.delete, "*id", ""),
+		"NestedModels":   r.nestedModels(),
+		"Functions":      r.functions(),
+	}
+
+	// the imports are determined from the generated code, since the packages used depend on the properties
+	body := strings.Join(sortedValues(data), "\n")
+	imports := []string{
+		"context",
+		"fmt",
+		"time",
+		"github.com/hashicorp/go-azure-helpers/lang/response",
+		r.sdk.importPath,
+		"github.com/hashicorp/terraform-provider-azurerm/internal/sdk",
+		"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk",
+	}
+	if r.id.packageName != r.sdk.name || (r.parentId != nil && r.parentId.packageName != r.sdk.name) {
+		imports = append(imports, commonIdsImportPath)
+	}
+	for pkg, importPath := range map[string]string{
+		"pointer.":      "github.com/hashicorp/go-azure-helpers/lang/pointer",
+		"commonschema.": "github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema",
+		"identity.":     "github.com/hashicorp/go-azure-helpers/resourcemanager/identity",
+		"location.":     "github.com/hashicorp/go-azure-helpers/resourcemanager/location",
+		"validation.":   "github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation",
+	} {
+		if strings.Contains(body, pkg) {
+			imports = append(imports, importPath)
+		}
+	}
+
+	return renderTemplate(resourceTemplate, data, sortImports(imports))
+}
+
+func (r resourceScaffold) renderTest() (string, error) {
+	importPath := r.sdk.importPath
+	if r.id.packageName != r.sdk.name {
+		importPath = commonIdsImportPath
+	}
+
+	data := map[string]string{
+		"PackageName":          r.packageName,
+		"Name":                 r.name,
+		"ResourceType":         r.resourceType,
+		"ClientPath":           r.clientPath,
+		"IDImportPath":         importPath,
+		"IDParseFunc":          r.id.parseFunc(),
+		"GetArguments":         r.get.arguments(r.sdk.name, ""),
+		"BasicConfig":          r.testConfig("test", false),
+		"CompleteConfig":       r.testConfig("test", true),
+		"RequiresImportConfig": r.requiresImportConfig(),
+	}
+
+	// the Get operation is called on the go-azure-sdk package, even if the Resource ID is defined in commonids
+	if r.get.optionsType != "" && importPath != r.sdk.importPath {
+		return "", fmt.Errorf("the `Get` operation has options, the import for %q needs to be added manually", r.sdk.importPath)
+	}
+
+	return renderTemplate(testTemplate, data, nil)
+}
+
+func renderTemplate(input string, data map[string]string, imports []string) (string, error) {
+	tpl, err := template.New("").Parse(input)
+	if err != nil {
+		return "", err
+	}
+
+	values := make(map[string]interface{})
+	for k, v := range data {
+		values[k] = v
+	}
+	values["Imports"] = imports
+
+	buf := bytes.Buffer{}
+	if err := tpl.Execute(&buf, values); err != nil {
+		return "", err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("formatting the generated code: %+v\n\n%s", err, buf.String())
+	}
+	return string(formatted), nil
+}
+
+func (r resourceScaffold) modelFields() string {
+	out := strings.Builder{}
+	for _, arg := range r.idArguments {
+		out.WriteString(fmt.Sprintf("%s string `tfschema:%q`\n", arg.fieldName, arg.name))
+	}
+	for _, p := range r.createMap.properties {
+		out.WriteString(fmt.Sprintf("%s %s `tfschema:%q`\n", p.fieldName, p.modelType, p.name))
+	}
+	return out.String()
+}
+
+func (r resourceScaffold) nestedModels() string {
+	out := strings.Builder{}
+	for _, model := range r.sortedNestedModels() {
+		out.WriteString(fmt.Sprintf("type %s struct {\n", model.name))
+		for _, p := range sortedProperties(model.properties) {
+			out.WriteString(fmt.Sprintf("%s %s `tfschema:%q`\n", p.fieldName, p.modelType, p.name))
+		}
+		out.WriteString("}\n\n")
+	}
+	return out.String()
+}
+
+func (r resourceScaffold) arguments() string {
+	out := strings.Builder{}
+	for _, arg := range r.idArguments {
+		out.WriteString(fmt.Sprintf("%q: %s,\n\n", arg.name, arg.schema))
+	}
+	for _, p := range r.createMap.properties {
+		out.WriteString(fmt.Sprintf("%q: %s,\n\n", p.name, schemaFor(*p, p.forceNew)))
+	}
+	return out.String()
+}
+
+// schemaFor returns the schema for the property, where ForceNew is propagated into any nested properties
+func schemaFor(p property, forceNew bool) string {
+	if p.commonSchema != "" {
+		if forceNew && p.commonSchema == "commonschema.Tags()" {
+			return "commonschema.TagsForceNew()"
+		}
+		return p.commonSchema
+	}
+
+	if p.identity != nil {
+		if forceNew {
+			return fmt.Sprintf("commonschema.%sForceNew()", p.identity.schema)
+		}
+		return fmt.Sprintf("commonschema.%s()", p.identity.schema)
+	}
+
+	out := strings.Builder{}
+	out.WriteString(fmt.Sprintf("{\nType: %s,\n", p.schemaType))
+	if p.required {
+		out.WriteString("Required: true,\n")
+	} else {
+		out.WriteString("Optional: true,\n")
+	}
+	if forceNew {
+		out.WriteString("ForceNew: true,\n")
+	}
+	if p.maxItems > 0 {
+		out.WriteString(fmt.Sprintf("MaxItems: %d,\n", p.maxItems))
+	}
+	if p.validateFunc != "" {
+		out.WriteString(fmt.Sprintf("ValidateFunc: %s,\n", p.validateFunc))
+	}
+	if p.elem != "" {
+		out.WriteString(fmt.Sprintf("Elem: %s,\n", p.elem))
+	}
+	if len(p.nested) > 0 {
+		out.WriteString("Elem: &pluginsdk.Resource{\nSchema: map[string]*pluginsdk.Schema{\n")
+		for _, nested := range sortedProperties(p.nested) {
+			out.WriteString(fmt.Sprintf("%q: %s,\n\n", nested.name, schemaFor(*nested, forceNew)))
+		}
+		out.WriteString("},\n},\n")
+	}
+	out.WriteString("}")
+	return out.String()
+}
+
+func (r resourceScaffold) createID() string {
+	args := make([]string, 0)
+	out := strings.Builder{}
+
+	segments := r.id.segments
+	if r.parentId != nil {
+		out.WriteString(fmt.Sprintf(`parentId, err := %s(config.%s)
+if err != nil {
+	return err
+}
+
+`, r.parentId.parseFunc(), r.idArguments[0].fieldName))
+		for _, segment := range r.parentId.segments {
+			args = append(args, "parentId."+segment)
+		}
+		segments = segments[len(r.parentId.segments):]
+	}
+
+	for _, segment := range segments {
+		if segment == "SubscriptionId" {
+			out.WriteString("subscriptionId := metadata.Client.Account.SubscriptionId\n")
+			args = append(args, "subscriptionId")
+			continue
+		}
+		for _, arg := range r.idArguments {
+			if arg.segment == segment {
+				args = append(args, "config."+arg.fieldName)
+			}
+		}
+	}
+
+	out.WriteString(fmt.Sprintf("id := %s(%s)\n", r.id.constructor(), strings.Join(args, ", ")))
+	return out.String()
+}
+
+func (r resourceScaffold) createPayload() string {
+	out := strings.Builder{}
+	fields := strings.Builder{}
+	properties := strings.Builder{}
+	for _, p := range r.createMap.properties {
+		if p.identity != nil {
+			out.WriteString(fmt.Sprintf(`expandedIdentity, err := identity.%s(config.%s)
+if err != nil {
+	return fmt.Errorf("expanding `+"`%s`"+`: %%+v", err)
+}
+
+`, p.identity.expandFunc, p.fieldName, p.name))
+			fields.WriteString(fmt.Sprintf("%s: expandedIdentity,\n", p.sdkField))
+			continue
+		}
+
+		value := fmt.Sprintf("%s: %s,\n", p.sdkField, p.expand("config."+p.fieldName))
+		if p.inProperties {
+			properties.WriteString(value)
+		} else {
+			fields.WriteString(value)
+		}
+	}
+
+	if r.createMap.propertiesType != "" {
+		reference := ""
+		if r.createMap.propertiesPointer {
+			reference = "&"
+		}
+		fields.WriteString(fmt.Sprintf("Properties: %s%s.%s{\n%s},\n", reference, r.sdk.name, r.createMap.propertiesType, properties.String()))
+	}
+
+	out.WriteString(fmt.Sprintf("payload := %s.%s{\n%s}\n", r.sdk.name, r.createMap.sdkType, fields.String()))
+	return out.String()
+}
+
+func (r resourceScaffold) call(op operation, id, input string) string {
+	args := op.arguments(r.sdk.name, input)
+	if op.returnsErrorOnly {
+		return fmt.Sprintf("if err := client.%s(ctx, %s%s); err != nil {", op.name, id, args)
+	}
+	return fmt.Sprintf("if _, err := client.%s(ctx, %s%s); err != nil {", op.name, id, args)
+}
+
+func (r resourceScaffold) readState() string {
+	out := strings.Builder{}
+	out.WriteString(fmt.Sprintf("state := %sResourceModel{\n", r.name))
+	for _, arg := range r.idArguments {
+		if arg.segment != "" {
+			out.WriteString(fmt.Sprintf("%s: id.%s,\n", arg.fieldName, arg.segment))
+			continue
+		}
+
+		// the ID of the parent resource
+		segments := make([]string, 0)
+		for _, segment := range r.parentId.segments {
+			segments = append(segments, "id."+segment)
+		}
+		out.WriteString(fmt.Sprintf("%s: %s(%s).ID(),\n", arg.fieldName, r.parentId.constructor(), strings.Join(segments, ", ")))
+	}
+	out.WriteString("}\n\n")
+
+	out.WriteString("if model := resp.Model; model != nil {\n")
+	properties := strings.Builder{}
+	for _, p := range r.createMap.properties {
+		if p.identity != nil {
+			out.WriteString("\n" + flattenIdentity(*p, "model."+p.sdkField))
+			continue
+		}
+
+		value := fmt.Sprintf("state.%s = %s\n", p.fieldName, p.flatten("model."+p.sdkField))
+		if p.inProperties {
+			value = fmt.Sprintf("state.%s = %s\n", p.fieldName, p.flatten("props."+p.sdkField))
+			properties.WriteString(value)
+			continue
+		}
+		out.WriteString(value)
+	}
+	if properties.Len() > 0 {
+		if r.createMap.propertiesPointer {
+			out.WriteString(fmt.Sprintf("\nif props := model.Properties; props != nil {\n%s}\n", properties.String()))
+		} else {
+			out.WriteString(fmt.Sprintf("\nprops := model.Properties\n%s", properties.String()))
+		}
+	}
+	out.WriteString("}\n")
+
+	return out.String()
+}
+
+func flattenIdentity(p property, value string) string {
+	out := fmt.Sprintf("state.%s = identity.%s(%s)\n\n", p.fieldName, p.identity.flattenFunc, value)
+	if p.identity.flattenReturnsError {
+		flattened := "flattenedIdentity"
+		if p.identity.flattenReturnsPointer {
+			flattened = "pointer.From(flattenedIdentity)"
+		}
+		out = fmt.Sprintf(`flattenedIdentity, err := identity.%s(%s)
+if err != nil {
+	return fmt.Errorf("flattening `+"`%s`"+`: %%+v", err)
+}
+state.%s = %s
+
+`, p.identity.flattenFunc, value, p.name, p.fieldName, flattened)
+	}
+	return out
+}
+
+func (r resourceScaffold) updatePayload() string {
+	out := strings.Builder{}
+	mapping := r.createMap
+	if r.updateMap != nil {
+		mapping = *r.updateMap
+
+		payload := fmt.Sprintf("payload := %s.%s{}\n", r.sdk.name, mapping.sdkType)
+		for _, p := range mapping.properties {
+			if p.inProperties && r.createMap.property(p.name) != nil {
+				reference := ""
+				if mapping.propertiesPointer {
+					reference = "&"
+				}
+				payload = fmt.Sprintf("payload := %s.%s{\nProperties: %s%s.%s{},\n}\n", r.sdk.name, mapping.sdkType, reference, r.sdk.name, mapping.propertiesType)
+				break
+			}
+		}
+		out.WriteString(payload)
+	} else {
+		out.WriteString(fmt.Sprintf(`existing, err := client.Get(ctx, *id%s)
+if err != nil {
+	return fmt.Errorf("retrieving %%s: %%+v", *id, err)
+}
+if existing.Model == nil {
+	return fmt.Errorf("retrieving %%s: `+"`model`"+` was nil", *id)
+}
+payload := *existing.Model
+`, r.get.arguments(r.sdk.name, "")))
+		if mapping.propertiesType != "" && mapping.propertiesPointer {
+			out.WriteString(fmt.Sprintf(`if payload.Properties == nil {
+	payload.Properties = &%s.%s{}
+}
+`, r.sdk.name, mapping.propertiesType))
+		}
+	}
+
+	for _, p := range mapping.properties {
+		if p.name == "location" || r.createMap.property(p.name) == nil {
+			continue
+		}
+
+		out.WriteString(fmt.Sprintf("\nif metadata.ResourceData.HasChange(%q) {\n", p.name))
+		target := "payload." + p.sdkField
+		if p.inProperties {
+			target = "payload.Properties." + p.sdkField
+		}
+
+		if p.identity != nil {
+			out.WriteString(fmt.Sprintf(`expandedIdentity, err := identity.%s(config.%s)
+if err != nil {
+	return fmt.Errorf("expanding `+"`%s`"+`: %%+v", err)
+}
+%s = expandedIdentity
+`, p.identity.expandFunc, p.fieldName, p.name, target))
+		} else {
+			out.WriteString(fmt.Sprintf("%s = %s\n", target, p.expand("config."+p.fieldName)))
+		}
+		out.WriteString("}\n")
+	}
+
+	return out.String()
+}
+
+// functions returns the expand and flatten functions for the nested models and lists of constants
+func (r resourceScaffold) functions() string {
+	out := strings.Builder{}
+	for _, model := range r.sortedNestedModels() {
+		properties := sortedProperties(model.properties)
+		expandFields := func(source string) string {
+			fields := strings.Builder{}
+			for _, p := range properties {
+				fields.WriteString(fmt.Sprintf("%s: %s,\n", p.sdkField, p.expand(source+"."+p.fieldName)))
+			}
+			return fields.String()
+		}
+		flattenFields := func(source string) string {
+			fields := strings.Builder{}
+			for _, p := range properties {
+				fields.WriteString(fmt.Sprintf("%s: %s,\n", p.fieldName, p.flatten(source+"."+p.sdkField)))
+			}
+			return fields.String()
+		}
+		sdkType := fmt.Sprintf("%s.%s", r.sdk.name, model.sdkType)
+
+		if model.single {
+			out.WriteString(fmt.Sprintf(`func expand%[5]s(input []%[1]s) *%[2]s {
+	if len(input) == 0 {
+		return nil
+	}
+	v := input[0]
+
+	return &%[2]s{
+%[3]s	}
+}
+
+func flatten%[5]s(input *%[2]s) []%[1]s {
+	if input == nil {
+		return []%[1]s{}
+	}
+
+	return []%[1]s{
+		{
+%[4]s		},
+	}
+}
+
+`, model.name, sdkType, expandFields("v"), flattenFields("input"), model.functionName()))
+		}
+
+		if model.list {
+			out.WriteString(fmt.Sprintf(`func expand%[5]sList(input []%[1]s) *[]%[2]s {
+	output := make([]%[2]s, 0)
+	for _, v := range input {
+		output = append(output, %[2]s{
+%[3]s		})
+	}
+	return &output
+}
+
+func flatten%[5]sList(input *[]%[2]s) []%[1]s {
+	output := make([]%[1]s, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		output = append(output, %[1]s{
+%[4]s		})
+	}
+	return output
+}
+
+`, model.name, sdkType, expandFields("v"), flattenFields("v"), model.functionName()))
+		}
+	}
+
+	for _, enum := range sortedKeys(r.mapper.enumLists) {
+		out.WriteString(fmt.Sprintf(`func expand%[1]s%[2]sList(input []string) *[]%[3]s.%[2]s {
+	output := make([]%[3]s.%[2]s, 0)
+	for _, v := range input {
+		output = append(output, %[3]s.%[2]s(v))
+	}
+	return &output
+}
+
+func flatten%[1]s%[2]sList(input *[]%[3]s.%[2]s) []string {
+	output := make([]string, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		output = append(output, string(v))
+	}
+	return output
+}
+
+`, r.name, enum, r.sdk.name))
+	}
+
+	return out.String()
+}
+
+func (r resourceScaffold) sortedNestedModels() []*nestedModel {
+	out := make([]*nestedModel, 0)
+	for _, name := range sortedKeys(r.mapper.nestedModels) {
+		out = append(out, r.mapper.nestedModels[name])
+	}
+	return out
+}
+
+func sortedProperties(input []*property) []*property {
+	out := append([]*property{}, input...)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].name < out[j].name
+	})
+	return out
+}
+
+func sortedValues(input map[string]string) []string {
+	out := make([]string, 0, len(input))
+	for _, k := range sortedKeys(input) {
+		out = append(out, input[k])
+	}
+	return out
+}
+
+// sortImports groups the imports into the standard library and third-party packages, as goimports does
+func sortImports(input []string) []string {
+	standard := make([]string, 0)
+	thirdParty := make([]string, 0)
+	for _, v := range input {
+		if strings.Contains(v, ".") {
+			thirdParty = append(thirdParty, v)
+		} else {
+			standard = append(standard, v)
+		}
+	}
+	sort.Strings(standard)
+	sort.Strings(thirdParty)
+	return append(append(standard, ""), thirdParty...)
+}
@@ -0,0 +1,440 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// scaffoldOptions are the options used to generate the scaffolding for a Typed Resource
+type scaffoldOptions struct {
+	resourceType string
+
+	// sdkService, apiVersion and sdkPackage identify the package within go-azure-sdk, for example
+	// `loadtestservice`, `2022-12-01` and `loadtests`
+	sdkService string
+	apiVersion string
+	sdkPackage string
+
+	// clientPath is the path to the go-azure-sdk client within `clients.Client`, for example
+	// `LoadTestService.V20221201.LoadTests`
+	clientPath string
+
+	// outputDirectory is the Service Package the files are written to, when empty these are output to stdout
+	outputDirectory string
+}
+
+// idArgument is an argument within the schema which is a segment of the Resource ID (or the ID of the parent resource)
+type idArgument struct {
+	name      string
+	fieldName string
+
+	// segment is the segment within the Resource ID, or the parent Resource ID
+	segment string
+
+	schema  string
+	example string
+}
+
+// modelMapping is the mapping between a go-azure-sdk model and the properties within the schema
+type modelMapping struct {
+	sdkType    string
+	properties []*property
+
+	// propertiesType is the type of the `Properties` field within the model, if any
+	propertiesType    string
+	propertiesPointer bool
+}
+
+// resourceScaffold describes the Typed Resource generated for a go-azure-sdk package
+type resourceScaffold struct {
+	resourceType string
+	packageName  string
+	clientPath   string
+
+	// name is the name of the resource used for the Go types, for example `LoadTest`
+	name string
+
+	sdk      *sdkPackage
+	id       resourceId
+	parentId *resourceId
+
+	idArguments []idArgument
+
+	get       operation
+	create    operation
+	update    *operation
+	delete    operation
+	createMap modelMapping
+
+	// updateMap is the mapping for the model used by the Update operation, when this differs to the model used for
+	// the Create operation (e.g. a PATCH request)
+	updateMap *modelMapping
+
+	mapper *mapper
+}
+
+func scaffold(opts scaffoldOptions) error {
+	for flagName, value := range map[string]string{
+		"sdk-service": opts.sdkService,
+		"api-version": opts.apiVersion,
+		"sdk-package": opts.sdkPackage,
+	} {
+		if value == "" {
+			return fmt.Errorf("`-%s` must be specified", flagName)
+		}
+	}
+	if !strings.HasPrefix(opts.resourceType, "azurerm_") {
+		return fmt.Errorf("the resource type %q must be prefixed with `azurerm_`", opts.resourceType)
+	}
+
+	pkg, err := loadSdkPackage(strings.Join([]string{resourceManagerImportPath, opts.sdkService, opts.apiVersion, opts.sdkPackage}, "/"))
+	if err != nil {
+		return err
+	}
+
+	commonIds, err := loadSdkPackage(commonIdsImportPath)
+	if err != nil {
+		return err
+	}
+
+	r, err := newResourceScaffold(opts, pkg, commonIds)
+	if err != nil {
+		return err
+	}
+
+	fileName := strings.TrimPrefix(opts.resourceType, "azurerm_") + "_resource"
+	files := map[string]func() (string, error){
+		fileName + ".go":      r.renderResource,
+		fileName + "_test.go": r.renderTest,
+	}
+
+	for _, name := range sortedKeys(files) {
+		contents, err := files[name]()
+		if err != nil {
+			return fmt.Errorf("generating %q: %+v", name, err)
+		}
+
+		if opts.outputDirectory == "" {
+			fmt.Printf("// %s\n%s\n", name, contents)
+			continue
+		}
+
+		path := filepath.Join(opts.outputDirectory, name)
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("the file %q already exists", path)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			return fmt.Errorf("writing %q: %+v", path, err)
+		}
+		fmt.Printf("Generated %q\n", path)
+	}
+
+	if unsupported := r.mapper.unsupportedFields(); len(unsupported) > 0 {
+		fmt.Fprintf(os.Stderr, "The following fields couldn't be mapped and need to be added manually:\n\n  * %s\n", strings.Join(unsupported, "\n  * "))
+	}
+
+	return nil
+}
+
+func newResourceScaffold(opts scaffoldOptions, pkg *sdkPackage, commonIds *sdkPackage) (*resourceScaffold, error) {
+	r := resourceScaffold{
+		resourceType: opts.resourceType,
+		packageName:  opts.sdkService,
+		clientPath:   opts.clientPath,
+		name:         snake2Camel(strings.TrimPrefix(opts.resourceType, "azurerm_")),
+		sdk:          pkg,
+	}
+	if opts.outputDirectory != "" {
+		r.packageName = filepath.Base(opts.outputDirectory)
+	}
+	if r.clientPath == "" {
+		r.clientPath = fmt.Sprintf("%s.%s", snake2Camel(opts.sdkService), pkg.clientName)
+	}
+	r.mapper = newMapper(pkg, r.name)
+
+	get := pkg.operation("Get")
+	if get == nil {
+		return nil, fmt.Errorf("the package %q doesn't contain a `Get` operation", pkg.importPath)
+	}
+	r.get = *get
+
+	id, err := resourceIdForOperation(pkg, commonIds, pkg.methods[get.name])
+	if err != nil {
+		return nil, err
+	}
+	r.id = *id
+	r.parentId = parentResourceId(r.id, pkg, commonIds)
+	r.idArguments = idArguments(r.id, r.parentId)
+
+	create := pkg.operation("CreateOrUpdateThenPoll", "CreateThenPoll", "PutThenPoll", "CreateOrUpdate", "Create", "Put")
+	if create == nil || create.inputType == "" {
+		return nil, fmt.Errorf("the package %q doesn't contain a Create operation", pkg.importPath)
+	}
+	r.create = *create
+
+	deleteOperation := pkg.operation("DeleteThenPoll", "Delete")
+	if deleteOperation == nil {
+		return nil, fmt.Errorf("the package %q doesn't contain a `Delete` operation", pkg.importPath)
+	}
+	r.delete = *deleteOperation
+
+	createMap, err := r.mapModel(create.inputType)
+	if err != nil {
+		return nil, err
+	}
+	r.createMap = *createMap
+
+	// when the API supports a PATCH then only the fields within that model can be updated, otherwise the resource
+	// is updated by sending the existing model (with any changes) to the Create operation
+	r.update = pkg.operation("UpdateThenPoll", "PatchThenPoll", "Update", "Patch")
+	if r.update != nil && r.update.inputType != "" && r.update.inputType != create.inputType {
+		updateMap, err := r.mapModel(r.update.inputType)
+		if err != nil {
+			return nil, err
+		}
+		r.updateMap = updateMap
+
+		for _, p := range r.createMap.properties {
+			if r.updateMap.property(p.name) == nil {
+				p.forceNew = true
+			}
+		}
+	} else {
+		r.update = create
+	}
+
+	return &r, nil
+}
+
+// resourceIdForOperation returns the Resource ID used by the operation
+func resourceIdForOperation(pkg *sdkPackage, commonIds *sdkPackage, method *ast.FuncDecl) (*resourceId, error) {
+	if len(method.Type.Params.List) < 2 {
+		return nil, fmt.Errorf("the method %q doesn't have a Resource ID parameter", method.Name.Name)
+	}
+
+	var id *resourceId
+	switch v := method.Type.Params.List[1].Type.(type) {
+	case *ast.Ident:
+		id = resourceIdFromPackage(pkg, v.Name)
+	case *ast.SelectorExpr:
+		if x, ok := v.X.(*ast.Ident); ok && x.Name == commonIds.name {
+			id = resourceIdFromPackage(commonIds, v.Sel.Name)
+		}
+	}
+
+	if id == nil || len(id.segments) == 0 {
+		return nil, fmt.Errorf("unable to find the Resource ID type %q used by the method %q", typeString(method.Type.Params.List[1].Type), method.Name.Name)
+	}
+
+	return id, nil
+}
+
+// idArguments returns the arguments within the schema which are used to build the Resource ID
+func idArguments(id resourceId, parent *resourceId) []idArgument {
+	out := make([]idArgument, 0)
+	segments := id.segments
+	if parent != nil {
+		name := camelToSnake(parent.name()) + "_id"
+		out = append(out, idArgument{
+			name:      name,
+			fieldName: snake2Camel(name),
+			schema: fmt.Sprintf(`{
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: %s,
+			}`, parent.validateFunc()),
+			// the parent resource needs to be added to the test configuration
+			example: `"TODO"`,
+		})
+		segments = segments[len(parent.segments):]
+	}
+
+	for i, segment := range segments {
+		arg := idArgument{
+			segment: segment,
+			schema: `{
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			}`,
+			example: `"TODO"`,
+		}
+
+		switch {
+		case segment == "SubscriptionId":
+			// this is sourced from the Provider
+			continue
+		case i == len(segments)-1:
+			arg.name = "name"
+			arg.example = `"acctest-%[2]d"`
+		case segment == "ResourceGroupName":
+			arg.name = "resource_group_name"
+			arg.schema = "commonschema.ResourceGroupName()"
+			arg.example = "azurerm_resource_group.test.name"
+		default:
+			arg.name = camelToSnake(segment)
+		}
+		arg.fieldName = snake2Camel(arg.name)
+		out = append(out, arg)
+	}
+
+	// by convention `name` is the first argument
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].name == "name" && out[j].name != "name"
+	})
+
+	return out
+}
+
+// mapModel returns the properties for the fields within the (top-level) go-azure-sdk model
+func (r *resourceScaffold) mapModel(sdkType string) (*modelMapping, error) {
+	s, ok := r.sdk.structType(sdkType)
+	if !ok {
+		return nil, fmt.Errorf("the model %q wasn't found in %q", sdkType, r.sdk.importPath)
+	}
+
+	idArgumentNames := make(map[string]struct{})
+	for _, arg := range r.idArguments {
+		idArgumentNames[arg.name] = struct{}{}
+	}
+
+	output := modelMapping{
+		sdkType:    sdkType,
+		properties: make([]*property, 0),
+	}
+	add := func(p *property, inProperties bool) {
+		if p == nil {
+			return
+		}
+		if _, ok := idArgumentNames[p.name]; ok {
+			return
+		}
+		p.inProperties = inProperties
+		output.properties = append(output.properties, p)
+	}
+
+	for _, field := range sdkFields(s) {
+		switch field.name {
+		case "Id", "Name", "Type", "SystemData", "Etag":
+			// these are either part of the Resource ID or are returned from the API
+			continue
+
+		case "Location":
+			pointer := isPointer(field.typeExpr)
+			add(&property{
+				name:         "location",
+				fieldName:    "Location",
+				modelType:    "string",
+				sdkField:     field.name,
+				required:     true,
+				commonSchema: "commonschema.Location()",
+				expand: func(value string) string {
+					if pointer {
+						return fmt.Sprintf("pointer.To(location.Normalize(%s))", value)
+					}
+					return fmt.Sprintf("location.Normalize(%s)", value)
+				},
+				flatten: func(value string) string {
+					if pointer {
+						return fmt.Sprintf("location.NormalizeNilable(%s)", value)
+					}
+					return fmt.Sprintf("location.Normalize(%s)", value)
+				},
+				example: "azurerm_resource_group.test.location",
+			}, false)
+
+		case "Tags":
+			p := r.mapper.property(sdkType, field, 0)
+			if p == nil {
+				continue
+			}
+			p.commonSchema = "commonschema.Tags()"
+			p.example = "{\n  ENV = \"Test\"\n}"
+			add(p, false)
+
+		case "Properties":
+			typeExpr := field.typeExpr
+			if star, ok := typeExpr.(*ast.StarExpr); ok {
+				typeExpr = star.X
+				output.propertiesPointer = true
+			}
+			ident, ok := typeExpr.(*ast.Ident)
+			if !ok {
+				return nil, fmt.Errorf("the `Properties` field within %q isn't a model", sdkType)
+			}
+			propertiesModel, ok := r.sdk.structType(ident.Name)
+			if !ok {
+				return nil, fmt.Errorf("the model %q wasn't found in %q", ident.Name, r.sdk.importPath)
+			}
+			output.propertiesType = ident.Name
+
+			for _, v := range sdkFields(propertiesModel) {
+				if v.name == "ProvisioningState" {
+					continue
+				}
+				add(r.mapper.property(ident.Name, v, 0), true)
+			}
+
+		default:
+			add(r.mapper.property(sdkType, field, 0), false)
+		}
+	}
+
+	sort.SliceStable(output.properties, func(i, j int) bool {
+		return argumentOrder(output.properties[i].name) < argumentOrder(output.properties[j].name)
+	})
+
+	return &output, nil
+}
+
+func (m modelMapping) property(name string) *property {
+	for _, p := range m.properties {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// argumentOrder returns the sort key for the argument, which puts `location` first and `tags` last as is the
+// convention within the Provider
+func argumentOrder(name string) string {
+	switch name {
+	case "location":
+		return "0"
+	case "tags":
+		return "2"
+	}
+	return "1" + name
+}
+
+func isPointer(input ast.Expr) bool {
+	_, ok := input.(*ast.StarExpr)
+	return ok
+}
+
+func (m *mapper) unsupportedFields() []string {
+	unique := make(map[string]struct{})
+	for _, v := range m.unsupported {
+		unique[v] = struct{}{}
+	}
+	return sortedKeys(unique)
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	out := make([]string, 0, len(input))
+	for k := range input {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
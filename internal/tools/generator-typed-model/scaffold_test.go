@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSdkPackage = `package widgets
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+)
+
+type WidgetsClient struct{}
+
+type WidgetId struct {
+	SubscriptionId    string
+	ResourceGroupName string
+	WidgetName        string
+}
+
+type SkuName string
+
+const (
+	SkuNameBasic    SkuName = "Basic"
+	SkuNameStandard SkuName = "Standard"
+)
+
+func PossibleValuesForSkuName() []string {
+	return []string{string(SkuNameBasic), string(SkuNameStandard)}
+}
+
+type Widget struct {
+	Id         *string            ` + "`json:\"id,omitempty\"`" + `
+	Location   string             ` + "`json:\"location\"`" + `
+	Name       *string            ` + "`json:\"name,omitempty\"`" + `
+	Properties *WidgetProperties  ` + "`json:\"properties,omitempty\"`" + `
+	Tags       *map[string]string ` + "`json:\"tags,omitempty\"`" + `
+}
+
+type WidgetProperties struct {
+	Capacity          int64        ` + "`json:\"capacity\"`" + `
+	Description       *string      ` + "`json:\"description,omitempty\"`" + `
+	ProvisioningState *string      ` + "`json:\"provisioningState,omitempty\"`" + `
+	Settings          *Settings    ` + "`json:\"settings,omitempty\"`" + `
+	Sku               *SkuName     ` + "`json:\"sku,omitempty\"`" + `
+	Zones             *[]string    ` + "`json:\"zones,omitempty\"`" + `
+}
+
+type Settings struct {
+	Enabled *bool ` + "`json:\"enabled,omitempty\"`" + `
+}
+
+type WidgetUpdate struct {
+	Properties *WidgetUpdateProperties ` + "`json:\"properties,omitempty\"`" + `
+	Tags       *map[string]string      ` + "`json:\"tags,omitempty\"`" + `
+}
+
+type WidgetUpdateProperties struct {
+	Description *string ` + "`json:\"description,omitempty\"`" + `
+}
+
+type GetOperationResponse struct {
+	Model *Widget
+}
+
+func (c WidgetsClient) Get(ctx context.Context, id WidgetId) (result GetOperationResponse, err error) {
+	return
+}
+
+func (c WidgetsClient) CreateOrUpdateThenPoll(ctx context.Context, id WidgetId, input Widget) error {
+	return nil
+}
+
+func (c WidgetsClient) UpdateThenPoll(ctx context.Context, id WidgetId, input WidgetUpdate) error {
+	return nil
+}
+
+func (c WidgetsClient) DeleteThenPoll(ctx context.Context, id WidgetId) error {
+	return nil
+}
+
+var _ = commonids.ResourceGroupId{}
+`
+
+func TestScaffold(t *testing.T) {
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "client.go"), []byte(testSdkPackage), 0o644); err != nil {
+		t.Fatalf("writing the test package: %+v", err)
+	}
+	pkg, err := parseSdkPackage(directory, resourceManagerImportPath+"/example/2024-01-01/widgets")
+	if err != nil {
+		t.Fatalf("parsing the test package: %+v", err)
+	}
+
+	commonIdsDirectory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(commonIdsDirectory, "ids.go"), []byte("package commonids\n"), 0o644); err != nil {
+		t.Fatalf("writing the commonids package: %+v", err)
+	}
+	commonIds, err := parseSdkPackage(commonIdsDirectory, commonIdsImportPath)
+	if err != nil {
+		t.Fatalf("parsing the commonids package: %+v", err)
+	}
+
+	r, err := newResourceScaffold(scaffoldOptions{
+		resourceType: "azurerm_example_widget",
+		sdkService:   "example",
+		apiVersion:   "2024-01-01",
+		sdkPackage:   "widgets",
+		clientPath:   "Example.Widgets",
+	}, pkg, commonIds)
+	if err != nil {
+		t.Fatalf("building the scaffold: %+v", err)
+	}
+
+	resource, err := r.renderResource()
+	if err != nil {
+		t.Fatalf("rendering the resource: %+v", err)
+	}
+	for _, expected := range []string{
+		"type ExampleWidgetResource struct{}",
+		"var _ sdk.ResourceWithUpdate = ExampleWidgetResource{}",
+		"\"resource_group_name\": commonschema.ResourceGroupName(),",
+		"\"location\": commonschema.Location(),",
+		"ValidateFunc: validation.StringInSlice(widgets.PossibleValuesForSkuName(), false),",
+		"func expandExampleWidgetSettings(",
+		"widgets.NewWidgetID(subscriptionId, config.ResourceGroupName, config.Name)",
+		"client.UpdateThenPoll(ctx, *id, payload)",
+		"metadata.ResourceData.HasChange(\"description\")",
+	} {
+		if !strings.Contains(resource, expected) {
+			t.Errorf("expected the resource to contain %q but it didn't:\n\n%s", expected, resource)
+		}
+	}
+	if strings.Contains(resource, "provisioning_state") {
+		t.Errorf("expected `provisioning_state` to be omitted from the resource:\n\n%s", resource)
+	}
+
+	// only the fields in the PATCH model can be updated
+	if p := r.createMap.property("capacity"); p == nil || !p.forceNew {
+		t.Errorf("expected `capacity` to be ForceNew")
+	}
+	if p := r.createMap.property("description"); p == nil || p.forceNew {
+		t.Errorf("expected `description` not to be ForceNew")
+	}
+
+	test, err := r.renderTest()
+	if err != nil {
+		t.Fatalf("rendering the test: %+v", err)
+	}
+	for _, expected := range []string{
+		"func TestAccExampleWidget_basic(t *testing.T) {",
+		"func TestAccExampleWidget_requiresImport(t *testing.T) {",
+		"resource \"azurerm_example_widget\" \"test\" {",
+		"clients.Example.Widgets.Get(ctx, *id)",
+	} {
+		if !strings.Contains(test, expected) {
+			t.Errorf("expected the test to contain %q but it didn't:\n\n%s", expected, test)
+		}
+	}
+}
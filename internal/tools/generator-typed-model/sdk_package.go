@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	resourceManagerImportPath = "github.com/hashicorp/go-azure-sdk/resource-manager"
+	commonIdsImportPath       = "github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+)
+
+// sdkPackage is the parsed source code for a package within go-azure-sdk (or go-azure-helpers)
+type sdkPackage struct {
+	name       string
+	importPath string
+
+	// clientName is the name of the Client within this package, e.g. `LoadTestsClient`
+	clientName string
+
+	// types are the type declarations within this package, keyed by name
+	types map[string]ast.Expr
+
+	// methods are the methods on the Client, keyed by name
+	methods map[string]*ast.FuncDecl
+
+	// functions are the names of the package-level functions
+	functions map[string]struct{}
+
+	// constants are the values for each (string) constant type, in the order they're defined
+	constants map[string][]string
+}
+
+// loadSdkPackage parses the package with the specified import path from the Go module cache
+func loadSdkPackage(importPath string) (*sdkPackage, error) {
+	cmd := exec.Command("go", "list", "-mod=mod", "-f", "{{.Dir}}", importPath)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("locating the package %q: %+v", importPath, err)
+	}
+
+	return parseSdkPackage(strings.TrimSpace(string(out)), importPath)
+}
+
+func parseSdkPackage(directory, importPath string) (*sdkPackage, error) {
+	files, err := filepath.Glob(filepath.Join(directory, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := sdkPackage{
+		importPath: importPath,
+		types:      make(map[string]ast.Expr),
+		methods:    make(map[string]*ast.FuncDecl),
+		functions:  make(map[string]struct{}),
+		constants:  make(map[string][]string),
+	}
+
+	fileSet := token.NewFileSet()
+	for _, fileName := range files {
+		if strings.HasSuffix(fileName, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fileSet, fileName, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %+v", fileName, err)
+		}
+		pkg.name = file.Name.Name
+
+		for _, decl := range file.Decls {
+			switch v := decl.(type) {
+			case *ast.FuncDecl:
+				if v.Recv == nil {
+					pkg.functions[v.Name.Name] = struct{}{}
+					continue
+				}
+
+				if recv, ok := v.Recv.List[0].Type.(*ast.Ident); ok && strings.HasSuffix(recv.Name, "Client") {
+					pkg.clientName = recv.Name
+					pkg.methods[v.Name.Name] = v
+				}
+
+			case *ast.GenDecl:
+				for _, spec := range v.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						pkg.types[s.Name.Name] = s.Type
+
+					case *ast.ValueSpec:
+						typeName, ok := s.Type.(*ast.Ident)
+						if !ok || v.Tok != token.CONST {
+							continue
+						}
+						for _, value := range s.Values {
+							if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+								if unquoted, err := strconv.Unquote(lit.Value); err == nil {
+									pkg.constants[typeName.Name] = append(pkg.constants[typeName.Name], unquoted)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if pkg.name == "" {
+		return nil, fmt.Errorf("no Go files were found in %q", directory)
+	}
+
+	return &pkg, nil
+}
+
+// isEnum returns whether the type is a constant type, for which go-azure-sdk generates a `PossibleValuesFor` function
+func (p sdkPackage) isEnum(typeName string) bool {
+	_, ok := p.functions["PossibleValuesFor"+typeName]
+	return ok
+}
+
+// structType returns the struct with the specified name, if it exists
+func (p sdkPackage) structType(typeName string) (*ast.StructType, bool) {
+	v, ok := p.types[typeName].(*ast.StructType)
+	return v, ok
+}
+
+// operation is a method on the Client which performs an operation on the Resource
+type operation struct {
+	name string
+
+	// inputType is the name of the model sent in the request body, if any
+	inputType string
+
+	// optionsType is the name of the Options struct for this operation, if any
+	optionsType string
+
+	// returnsErrorOnly is whether the method only returns an error, as is the case for the `ThenPoll` methods
+	returnsErrorOnly bool
+}
+
+// operation returns the first method on the Client which exists from the specified names
+func (p sdkPackage) operation(names ...string) *operation {
+	for _, name := range names {
+		method, ok := p.methods[name]
+		if !ok {
+			continue
+		}
+
+		out := operation{
+			name:             name,
+			returnsErrorOnly: method.Type.Results != nil && len(method.Type.Results.List) == 1,
+		}
+
+		// the parameters are `ctx`, `id` and then optionally the `input` and `options`
+		params := make([]ast.Expr, 0)
+		for _, field := range method.Type.Params.List {
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				params = append(params, field.Type)
+			}
+		}
+		for _, param := range params[min(2, len(params)):] {
+			ident, ok := param.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if strings.HasSuffix(ident.Name, "OperationOptions") {
+				out.optionsType = ident.Name
+			} else {
+				out.inputType = ident.Name
+			}
+		}
+
+		return &out
+	}
+
+	return nil
+}
+
+// arguments returns the arguments for calling this operation, in addition to the context and the Resource ID
+func (o operation) arguments(packageName, input string) string {
+	args := ""
+	if o.inputType != "" {
+		args += ", " + input
+	}
+	if o.optionsType != "" {
+		args += fmt.Sprintf(", %s.Default%s()", packageName, o.optionsType)
+	}
+	return args
+}
+
+// resourceId is a Resource ID type defined in go-azure-sdk, or in the `commonids` package within go-azure-helpers
+type resourceId struct {
+	packageName string
+	typeName    string
+	segments    []string
+}
+
+func (id resourceId) name() string {
+	return strings.TrimSuffix(id.typeName, "Id")
+}
+
+func (id resourceId) constructor() string {
+	return fmt.Sprintf("%s.New%sID", id.packageName, id.name())
+}
+
+func (id resourceId) parseFunc() string {
+	return fmt.Sprintf("%s.Parse%sID", id.packageName, id.name())
+}
+
+func (id resourceId) validateFunc() string {
+	return fmt.Sprintf("%s.Validate%sID", id.packageName, id.name())
+}
+
+// resourceIdFromPackage returns the Resource ID with the specified name within the package, if it exists
+func resourceIdFromPackage(pkg *sdkPackage, typeName string) *resourceId {
+	s, ok := pkg.structType(typeName)
+	if !ok {
+		return nil
+	}
+
+	id := resourceId{
+		packageName: pkg.name,
+		typeName:    typeName,
+	}
+	for _, field := range s.Fields.List {
+		for _, name := range field.Names {
+			id.segments = append(id.segments, name.Name)
+		}
+	}
+	return &id
+}
+
+// parentResourceId returns the Resource ID for the parent resource within the packages, if it exists - which is the
+// Resource ID containing all of the segments except the last one
+func parentResourceId(id resourceId, packages ...*sdkPackage) *resourceId {
+	if len(id.segments) < 2 {
+		return nil
+	}
+	parentSegments := id.segments[:len(id.segments)-1]
+
+	// Resources within a Resource Group (or Subscription) instead expose the `resource_group_name` field
+	if strings.Join(parentSegments, ",") == "SubscriptionId,ResourceGroupName" || strings.Join(parentSegments, ",") == "SubscriptionId" {
+		return nil
+	}
+
+	for _, pkg := range packages {
+		if pkg == nil {
+			continue
+		}
+		for _, typeName := range sortedKeys(pkg.types) {
+			if !strings.HasSuffix(typeName, "Id") {
+				continue
+			}
+			if candidate := resourceIdFromPackage(pkg, typeName); candidate != nil && strings.Join(candidate.segments, ",") == strings.Join(parentSegments, ",") {
+				return candidate
+			}
+		}
+	}
+
+	return nil
+}
@@ -5,114 +5,199 @@ package differ
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
 )
 
+const (
+	ViolationTypeResource   = "resource"
+	ViolationTypeDataSource = "data_source"
+)
+
+// Violation is a breaking change detected between the base (released) schema and the current schema
+type Violation struct {
+	// Type is either `resource` or `data_source`
+	Type string `json:"type"`
+
+	// Name is the name of the Resource or Data Source, e.g. `azurerm_resource_group`
+	Name string `json:"name"`
+
+	// Property is the path to the property within the Resource or Data Source, e.g. `network_rules.ip_rules`,
+	// this is empty when the Resource or Data Source itself has been removed
+	Property string `json:"property,omitempty"`
+
+	// Rule is the ID of the rule which detected this breaking change
+	Rule string `json:"rule"`
+
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %q: %s", v.Type, v.Name, v.Message)
+}
+
 type Differ struct {
 	base    *providerjson.ProviderWrapper
 	current *providerjson.ProviderWrapper
 }
 
-func (d *Differ) Diff(fileName string, providerName string) []string {
+func (d *Differ) Diff(fileName string, providerName string) ([]Violation, error) {
 	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
 	}
 
-	violations := make([]string, 0)
+	return d.compare(), nil
+}
+
+// compare returns the breaking changes between the base and current schemas, ordered by Resource/Data Source name
+func (d *Differ) compare() []Violation {
+	violations := make([]Violation, 0)
+	violations = append(violations, compareResources(ViolationTypeResource, d.base.ProviderSchema.ResourcesMap, d.current.ProviderSchema.ResourcesMap, schema_rules.BreakingChangeRules)...)
+	violations = append(violations, compareResources(ViolationTypeDataSource, d.base.ProviderSchema.DataSourcesMap, d.current.ProviderSchema.DataSourcesMap, schema_rules.BreakingChangeRulesDataSource)...)
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
-		if !ok {
-			// New resource, no breaking changes to worry about
-			continue
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Type != violations[j].Type {
+			return violations[i].Type < violations[j].Type
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+		if violations[i].Name != violations[j].Name {
+			return violations[i].Name < violations[j].Name
 		}
-	}
+		return violations[i].Property < violations[j].Property
+	})
 
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
+	return violations
+}
+
+func compareResources(resourceType string, base, current map[string]providerjson.ResourceJSON, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
+
+	for name, baseResource := range base {
+		currentResource, ok := current[name]
 		if !ok {
-			// New data source, no breaking changes to worry about
+			violations = append(violations, Violation{
+				Type:    resourceType,
+				Name:    name,
+				Rule:    fmt.Sprintf("%s_removed", resourceType),
+				Message: fmt.Sprintf("the %s %q has been removed", resourceTypeDescription(resourceType), name),
+			})
 			continue
 		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+
+		// New resources are in `current` but not `base` and aren't compared, since these can't be used in existing
+		// configurations - however new properties of an existing resource are compared against an empty schema by
+		// compareSchemas, since these could still be breaking (e.g. a new Required property)
+		for _, v := range compareSchemas(baseResource.Schema, currentResource.Schema, "", rules) {
+			v.Type = resourceType
+			v.Name = name
+			violations = append(violations, v)
 		}
 	}
 
 	return violations
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
-		}
+func compareSchemas(base, current map[string]providerjson.SchemaJSON, path string, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
+
+	propertyNames := make(map[string]struct{})
+	for k := range base {
+		propertyNames[k] = struct{}{}
+	}
+	for k := range current {
+		propertyNames[k] = struct{}{}
 	}
 
-	for _, v := range schema_rules.BreakingChangeRules {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
-		}
+	for propertyName := range propertyNames {
+		// Get the same from the base (released) json, an empty schema indicates the property doesn't exist
+		baseItem := base[propertyName]
+		currentItem := current[propertyName]
+		violations = append(violations, compareNode(baseItem, currentItem, propertyPath(path, propertyName), rules)...)
 	}
 
-	return
+	return violations
 }
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
+func compareNode(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
+
+	for _, v := range rules {
+		if err := v.Check(base, current, nodeName); err != nil {
+			violations = append(violations, Violation{
+				Property: nodeName,
+				Rule:     v.ID(),
+				Message:  *err,
+			})
 		}
 	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	// when the block has been removed, or has changed type, the nested properties don't need to be compared too
+	if base.Type != "" && current.Type != "" && base.Type == current.Type {
+		baseBlock, baseIsBlock := nodeBlock(base)
+		currentBlock, currentIsBlock := nodeBlock(current)
+		if baseIsBlock && currentIsBlock {
+			violations = append(violations, compareSchemas(baseBlock, currentBlock, nodeName, rules)...)
+		}
+
+		// a List/Set of a primitive type, e.g. a List of Strings limited to a set of values
+		baseElem, baseIsSchema := nodeElemSchema(base)
+		currentElem, currentIsSchema := nodeElemSchema(current)
+		if baseIsSchema && currentIsSchema {
+			violations = append(violations, compareNode(baseElem, currentElem, nodeName, rules)...)
 		}
 	}
 
-	return
+	return violations
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
+// nodeBlock returns the schema for the nested block, noting that this is a value when loaded from the JSON file and
+// a pointer when loaded from the Provider
+func nodeBlock(input providerjson.SchemaJSON) (map[string]providerjson.SchemaJSON, bool) {
+	if input.Type != providerjson.SchemaTypeList && input.Type != providerjson.SchemaTypeSet {
+		return nil, false
+	}
+
+	switch elem := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		return elem.Schema, true
+	case *providerjson.ResourceJSON:
+		if elem != nil {
+			return elem.Schema, true
 		}
 	}
 
-	return false
+	return nil, false
+}
+
+// nodeElemSchema returns the schema for the elements within a List/Set of a primitive type, this is only available
+// when loaded from the Provider since the JSON file only contains the type of the element
+func nodeElemSchema(input providerjson.SchemaJSON) (providerjson.SchemaJSON, bool) {
+	if elem, ok := input.Elem.(providerjson.SchemaJSON); ok {
+		return elem, true
+	}
+
+	return providerjson.SchemaJSON{}, false
+}
+
+func propertyPath(path, propertyName string) string {
+	if path == "" {
+		return propertyName
+	}
+	return fmt.Sprintf("%s.%s", path, propertyName)
+}
+
+func resourceTypeDescription(resourceType string) string {
+	if resourceType == ViolationTypeDataSource {
+		return "data source"
+	}
+	return resourceType
 }
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestDiffer_Compare(t *testing.T) {
+	base := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"azurerm_example": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+						ForceNew: true,
+					},
+					"sku": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"Basic", "Standard"}, false),
+					},
+					"legacy": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"network_rules": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"ip_rules": {
+									Type:     schema.TypeList,
+									Optional: true,
+									MaxItems: 10,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+								"default_action": {
+									Type:     schema.TypeString,
+									Optional: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"azurerm_removed": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"azurerm_example": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"location": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+
+	current := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"azurerm_example": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+						ForceNew: true,
+					},
+					"sku": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"Premium", "Standard"}, false),
+					},
+					"network_rules": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"ip_rules": {
+									Type:     schema.TypeList,
+									Optional: true,
+									MaxItems: 5,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+								"default_action": {
+									Type:     schema.TypeString,
+									Optional: true,
+									ForceNew: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"azurerm_new": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"azurerm_example": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+
+	d := Differ{
+		base:    differTestReleasedSchema(t, base),
+		current: differTestSchema(t, current),
+	}
+
+	expected := []string{
+		"data_source|azurerm_example|location|property_removed",
+		"resource|azurerm_example|legacy|property_removed",
+		"resource|azurerm_example|network_rules.default_action|force_new_added",
+		"resource|azurerm_example|network_rules.ip_rules|max_items_reduced",
+		"resource|azurerm_example|sku|allowed_values_narrowed",
+		"resource|azurerm_removed||resource_removed",
+	}
+
+	actual := make([]string, 0)
+	for _, v := range d.compare() {
+		actual = append(actual, strings.Join([]string{v.Type, v.Name, v.Property, v.Rule}, "|"))
+	}
+
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expected the violations:\n\n%s\n\nbut got:\n\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, "azurerm", nil); err != nil {
+		t.Fatalf("writing: %+v", err)
+	}
+
+	var output Output
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+	if output.Breaking || output.Violations == nil || len(output.Violations) != 0 {
+		t.Fatalf("expected no violations to be output as an empty list but got %s", buf.String())
+	}
+}
+
+func differTestSchema(t *testing.T, input *schema.Provider) *providerjson.ProviderWrapper {
+	s, err := providerjson.ProviderFromRaw((*providerjson.ProviderJSON)(input))
+	if err != nil {
+		t.Fatalf("converting the provider: %+v", err)
+	}
+
+	return &providerjson.ProviderWrapper{
+		ProviderName:   "azurerm",
+		ProviderSchema: s,
+	}
+}
+
+// differTestReleasedSchema returns the provider schema as loaded from the file exported during the release process
+func differTestReleasedSchema(t *testing.T, input *schema.Provider) *providerjson.ProviderWrapper {
+	raw, err := json.Marshal(differTestSchema(t, input))
+	if err != nil {
+		t.Fatalf("marshalling: %+v", err)
+	}
+
+	output := &providerjson.ProviderWrapper{}
+	if err := json.Unmarshal(raw, output); err != nil {
+		t.Fatalf("unmarshalling: %+v", err)
+	}
+	return output
+}
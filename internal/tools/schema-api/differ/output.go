@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Output is the JSON output of the detect mode, intended for gating the release process
+type Output struct {
+	ProviderName string      `json:"providerName"`
+	Breaking     bool        `json:"breaking"`
+	Violations   []Violation `json:"violations"`
+}
+
+func WriteJSON(w io.Writer, providerName string, violations []Violation) error {
+	if violations == nil {
+		violations = make([]Violation, 0)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Output{
+		ProviderName: providerName,
+		Breaking:     len(violations) > 0,
+		Violations:   violations,
+	})
+}
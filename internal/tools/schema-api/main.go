@@ -26,6 +26,7 @@ func main() {
 	exportSchema := f.String("export", "", "export the schema to the given path/filename. Intended for use in the release process")
 	detectBreakingChanges := f.String("detect", "", "compare current schema to named dump.")
 	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code. Defaults to `false`")
+	outputJSON := f.Bool("json", false, "output the violations found in detect mode to stdout as JSON, for use in the release process. Defaults to `false`")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		fmt.Printf("error parsing args: %+v", err)
@@ -53,13 +54,23 @@ func main() {
 	case pointer.From(detectBreakingChanges) != "":
 		{
 			d := differ.Differ{}
-			if violations := d.Diff(*detectBreakingChanges, *providerName); violations != nil {
+			violations, err := d.Diff(*detectBreakingChanges, *providerName)
+			if err != nil {
+				log.Fatalf("error detecting breaking changes: %+v", err)
+			}
+
+			if pointer.From(outputJSON) {
+				if err := differ.WriteJSON(os.Stdout, *providerName, violations); err != nil {
+					log.Fatalf("error writing violations: %+v", err)
+				}
+			} else {
 				for _, v := range violations {
 					log.Println(v)
 				}
-				if pointer.From(errorOnBreakingChange) {
-					os.Exit(1)
-				}
+			}
+
+			if len(violations) > 0 && pointer.From(errorOnBreakingChange) {
+				os.Exit(1)
 			}
 
 			os.Exit(0)
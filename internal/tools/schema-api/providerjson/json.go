@@ -33,6 +33,11 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+
+	// AllowedValues are the values permitted by the ValidateFunc, when this is limited to a set of values
+	AllowedValues []string `json:"allowedValues,omitempty"`
+
+	Deprecated string `json:"deprecated,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -48,11 +53,19 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 	b.Description, _ = m["description"].(string)
 	b.Computed, _ = m["computed"].(bool)
 	b.ForceNew, _ = m["forceNew"].(bool)
+	b.Deprecated, _ = m["deprecated"].(string)
 	if max, ok := m["maxItems"].(float64); ok {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
+	}
+	if values, ok := m["allowedValues"].([]interface{}); ok {
+		for _, v := range values {
+			if value, ok := v.(string); ok {
+				b.AllowedValues = append(b.AllowedValues, value)
+			}
+		}
 	}
 
 	if def, ok := m["default"]; ok && def != nil {
@@ -71,21 +84,16 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 	}
 
 	if e, ok := m["elem"]; ok && e != nil {
-		elem := e.(map[string]interface{})
-		if schema, ok := elem["schema"]; ok {
-			b.Elem = ResourceFromMap(schema.(map[string]interface{}))
-		}
-		if t, ok := elem["type"]; ok {
-			b.Elem = t.(string)
-		}
+		b.Elem = elemFromMap(e)
 	}
 
 	return nil
 }
 
 type ResourceJSON struct {
-	Schema   map[string]SchemaJSON `json:"schema"`
-	Timeouts *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+	Schema             map[string]SchemaJSON `json:"schema"`
+	Timeouts           *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+	DeprecationMessage string                `json:"deprecationMessage,omitempty"`
 }
 
 type ResourceTimeoutJSON struct {
@@ -21,6 +21,7 @@ func resourceFromRaw(input *schema.Resource) (*ResourceJSON, error) {
 		translatedSchema[k] = schemaFromRaw(s)
 	}
 	result.Schema = translatedSchema
+	result.DeprecationMessage = input.DeprecationMessage
 
 	if input.Timeouts != nil {
 		timeouts := &ResourceTimeoutJSON{}
@@ -57,6 +58,9 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Elem:        decodeElem(input.Elem),
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
+
+		AllowedValues: allowedValues(input),
+		Deprecated:    input.Deprecated,
 	}
 }
 
@@ -94,12 +98,12 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.ForceNew = t.(bool)
 	}
 
-	if t, ok := input["forceNew"]; ok {
-		result.ForceNew = t.(bool)
+	if t, ok := input["deprecated"]; ok {
+		result.Deprecated = t.(string)
 	}
 
 	if t, ok := input["elem"]; ok {
-		result.Elem = decodeElem(t)
+		result.Elem = elemFromMap(t)
 	}
 
 	if t, ok := input["minItems"]; ok {
@@ -110,6 +114,12 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.MaxItems = int(t.(float64))
 	}
 
+	if t, ok := input["allowedValues"]; ok {
+		for _, v := range t.([]interface{}) {
+			result.AllowedValues = append(result.AllowedValues, v.(string))
+		}
+	}
+
 	return result
 }
 
@@ -123,6 +133,24 @@ func ResourceFromMap(input map[string]interface{}) ResourceJSON {
 	return result
 }
 
+// elemFromMap returns the Elem loaded from JSON, which is either a nested block (ResourceJSON) or the schema for the
+// elements within a List/Set/Map (SchemaJSON)
+func elemFromMap(input interface{}) interface{} {
+	elem, ok := input.(map[string]interface{})
+	if !ok {
+		return decodeElem(input)
+	}
+
+	if schema, ok := elem["schema"]; ok {
+		return ResourceFromMap(schema.(map[string]interface{}))
+	}
+	if _, ok := elem["type"]; ok {
+		return SchemaFromMap(elem)
+	}
+
+	return nil
+}
+
 func decodeConfigMode(input schema.SchemaConfigMode) (out string) {
 	switch input {
 	case 1:
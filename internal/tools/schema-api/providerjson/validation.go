@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// allowedValuesProbe is a value which isn't expected to be valid for any property
+const allowedValuesProbe = "\x00schema-api-probe"
+
+var (
+	// allowedValuesRegex matches the error returned from `validation.StringInSlice`, for example
+	// `expected sku to be one of ["Basic" "Standard"], got foo`
+	allowedValuesRegex = regexp.MustCompile(`to be one of \[(.*)\], got`)
+	quotedValueRegex   = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+)
+
+// allowedValues returns the values permitted by the ValidateFunc for a String property, when this is limited to a
+// set of values (e.g. `validation.StringInSlice`). Since a ValidateFunc can't be inspected, these are determined by
+// validating a value which won't be valid, and parsing the values listed in the error.
+func allowedValues(input *schema.Schema) (out []string) {
+	if input == nil || input.Type != schema.TypeString || input.ValidateFunc == nil {
+		return nil
+	}
+
+	defer func() {
+		// the ValidateFunc isn't expected to panic - but this is only informational, so shouldn't stop the export
+		if r := recover(); r != nil {
+			out = nil
+		}
+	}()
+
+	_, errs := input.ValidateFunc(allowedValuesProbe, "probe")
+	for _, err := range errs {
+		match := allowedValuesRegex.FindStringSubmatch(err.Error())
+		if len(match) != 2 {
+			continue
+		}
+
+		for _, quoted := range quotedValueRegex.FindAllString(match[1], -1) {
+			if v, err := strconv.Unquote(quoted); err == nil {
+				out = append(out, v)
+			}
+		}
+	}
+
+	sort.Strings(out)
+	return out
+}
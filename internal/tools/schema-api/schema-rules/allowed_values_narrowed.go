@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = allowedValuesNarrowed{}
+
+type allowedValuesNarrowed struct{}
+
+func (allowedValuesNarrowed) ID() string {
+	return "allowed_values_narrowed"
+}
+
+// Check - Checks that values which were allowed by the ValidateFunc of an existing property are still allowed, since these may be in users configurations.
+func (allowedValuesNarrowed) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	// when either is empty the property isn't limited to a set of values (or this can't be determined)
+	if len(base.AllowedValues) == 0 || len(current.AllowedValues) == 0 {
+		return nil
+	}
+
+	allowed := make(map[string]struct{}, len(current.AllowedValues))
+	for _, v := range current.AllowedValues {
+		allowed[v] = struct{}{}
+	}
+
+	removed := make([]string, 0)
+	for _, v := range base.AllowedValues {
+		if _, ok := allowed[v]; !ok {
+			removed = append(removed, v)
+		}
+	}
+
+	if len(removed) > 0 {
+		return pointer.To(fmt.Sprintf("cannot remove the allowed values %q from property %q", removed, propertyName))
+	}
+
+	return nil
+}
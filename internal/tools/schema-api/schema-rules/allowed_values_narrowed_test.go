@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var allowedValuesNarrowedBaseNode = providerjson.SchemaJSON{
+	Type:          providerjson.SchemaTypeString,
+	Optional:      true,
+	AllowedValues: []string{"Basic", "Standard"},
+}
+
+var allowedValuesNarrowedPasses = providerjson.SchemaJSON{
+	Type:          providerjson.SchemaTypeString,
+	Optional:      true,
+	AllowedValues: []string{"Basic", "Premium", "Standard"},
+}
+
+var allowedValuesNarrowedUnrestricted = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeString,
+	Optional: true,
+}
+
+var allowedValuesNarrowedViolates = providerjson.SchemaJSON{
+	Type:          providerjson.SchemaTypeString,
+	Optional:      true,
+	AllowedValues: []string{"Premium", "Standard"}, // violation
+}
+
+func TestAllowedValuesNarrowed_Check(t *testing.T) {
+	data := allowedValuesNarrowed{}
+	if res := data.Check(allowedValuesNarrowedBaseNode, allowedValuesNarrowedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(allowedValuesNarrowedBaseNode, allowedValuesNarrowedUnrestricted, ""); res != nil {
+		t.Errorf("expected no violation when removing the allowed values, got %+v", res)
+	}
+	if res := data.Check(allowedValuesNarrowedBaseNode, allowedValuesNarrowedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
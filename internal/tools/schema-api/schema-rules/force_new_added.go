@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = forceNewAdded{}
+
+type forceNewAdded struct{}
+
+func (forceNewAdded) ID() string {
+	return "force_new_added"
+}
+
+// Check - Checks that an existing property is not updated to become ForceNew, since changes which were previously made in-place would recreate the resource.
+func (forceNewAdded) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && !base.ForceNew && current.ForceNew {
+		return pointer.To(fmt.Sprintf("cannot change property %q to ForceNew as changes to it would now recreate the resource", propertyName))
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = minItemsIncreased{}
+
+type minItemsIncreased struct{}
+
+func (minItemsIncreased) ID() string {
+	return "min_items_increased"
+}
+
+// Check - Checks that the MinItems of an existing property is not increased, since users configurations may specify fewer items.
+func (minItemsIncreased) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.Type == "" {
+		return nil
+	}
+
+	if current.MinItems > base.MinItems {
+		return pointer.To(fmt.Sprintf("cannot increase the MinItems of property %q (%d to %d)", propertyName, base.MinItems, current.MinItems))
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = propertyRemoved{}
+
+type propertyRemoved struct{}
+
+func (propertyRemoved) ID() string {
+	return "property_removed"
+}
+
+// Check - Checks that an existing property has not been removed, since this may be in users configurations or referenced elsewhere.
+func (propertyRemoved) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && current.Type == "" {
+		return pointer.To(fmt.Sprintf("property %q has been removed", propertyName))
+	}
+
+	return nil
+}
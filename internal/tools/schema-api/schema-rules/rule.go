@@ -6,17 +6,31 @@ package schema_rules
 import "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 
 type BreakingChangeRule interface {
+	// ID returns the identifier for this rule, used in the JSON output
+	ID() string
+
 	Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string
 }
 
 var BreakingChangeRules = []BreakingChangeRule{
+	allowedValuesNarrowed{},
 	becomeComputedOnly{},
+	forceNewAdded{},
+	maxItemsReduced{},
+	minItemsIncreased{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
+	propertyRemoved{},
 	propertyType{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
+	allowedValuesNarrowed{},
+	maxItemsReduced{},
+	minItemsIncreased{},
+	newRequiredPropertyExistingResource{},
+	optionalToRequired{},
+	propertyRemoved{},
 	propertyType{},
 }
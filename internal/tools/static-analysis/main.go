@@ -4,7 +4,10 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"slices"
@@ -14,7 +17,11 @@ import (
 )
 
 var allRules = map[string]rules.Rule{
-	rules.TypedSDKBitCheck{}.Name(): rules.TypedSDKBitCheck{},
+	rules.TypedSDKBitCheck{}.Name():       rules.TypedSDKBitCheck{},
+	rules.TypedSDKModelTagsCheck{}.Name(): rules.TypedSDKModelTagsCheck{},
+	rules.TypedSDKUpdateCheck{}.Name():    rules.TypedSDKUpdateCheck{},
+	rules.TypedSDKReadCheck{}.Name():      rules.TypedSDKReadCheck{},
+	rules.TypedSDKTimeoutsCheck{}.Name():  rules.TypedSDKTimeoutsCheck{},
 }
 
 func main() {
@@ -22,6 +29,7 @@ func main() {
 
 	rulesToCheck := f.String("rules", "all", "Comma separated list of rules to run. Defaults to all. ")
 	failOnError := f.Bool("fail-on-error", true, "If set to true will fail on error, otherwise will only log. Defaults to true.")
+	outputJSON := f.Bool("json", false, "If set to true will output the violations as JSON (including the file and line) to stdout. Defaults to false.")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		log.Fatalf("failed to parse flags: %v", err)
@@ -37,25 +45,53 @@ func main() {
 		specifiedRules = []string{"all"}
 	}
 
-	errors := make([]error, 0)
+	violations := make([]error, 0)
 	for _, rule := range specifiedRules {
 		if strings.EqualFold(rule, "all") {
 			for _, r := range allRules {
-				errors = append(errors, r.Run()...)
+				violations = append(violations, r.Run()...)
 			}
 		}
 
 		if r, ok := allRules[rule]; ok {
-			errors = append(errors, r.Run()...)
+			violations = append(violations, r.Run()...)
 		}
 	}
 
-	if len(errors) > 0 {
+	if *outputJSON {
+		if err := writeJSON(violations); err != nil {
+			log.Fatalf("failed to write the violations as JSON: %v", err)
+		}
+	}
+
+	if len(violations) > 0 {
 		if *failOnError {
-			log.Fatalf("failed to run rules: %v", errors)
+			log.Fatalf("failed to run rules: %v", violations)
 		} else {
-			log.Printf("failed to run rules: %v", errors)
+			log.Printf("failed to run rules: %v", violations)
 			os.Exit(0)
 		}
 	}
 }
+
+// writeJSON outputs the violations as a JSON array, where errors which aren't a `rules.Violation` only have a message
+func writeJSON(input []error) error {
+	output := make([]rules.Violation, 0, len(input))
+	for _, err := range input {
+		var violation rules.Violation
+		if !errors.As(err, &violation) {
+			violation = rules.Violation{
+				Message: err.Error(),
+			}
+		}
+		output = append(output, violation)
+	}
+
+	b, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, string(b))
+	return err
+}
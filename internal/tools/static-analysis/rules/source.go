@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const modulePath = "github.com/hashicorp/terraform-provider-azurerm"
+
+// maxCallDepth is the depth to which functions called from a function are also searched, since the Typed Resources
+// commonly use helper functions (e.g. `expandX` and `flattenX`) to handle properties
+const maxCallDepth = 3
+
+// sourcePackage is the parsed source code for a package within this repository
+type sourcePackage struct {
+	fileSet *token.FileSet
+	files   []*ast.File
+
+	// functions are the package-level functions and methods (keyed by name) within the package
+	functions map[string][]*ast.FuncDecl
+}
+
+var (
+	sourcePackagesLock sync.Mutex
+	sourcePackages     = make(map[string]*sourcePackage)
+)
+
+// sourcePackageForType returns the parsed source code for the package which defines the (struct) type
+func sourcePackageForType(input reflect.Type) (*sourcePackage, error) {
+	for input.Kind() == reflect.Ptr {
+		input = input.Elem()
+	}
+
+	sourcePackagesLock.Lock()
+	defer sourcePackagesLock.Unlock()
+
+	if pkg, ok := sourcePackages[input.PkgPath()]; ok {
+		return pkg, nil
+	}
+
+	if !strings.HasPrefix(input.PkgPath(), modulePath+"/") {
+		return nil, fmt.Errorf("the type %q isn't defined within this repository", input.String())
+	}
+
+	root, err := repositoryRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := parseSourcePackage(filepath.Join(root, strings.TrimPrefix(input.PkgPath(), modulePath+"/")), root)
+	if err != nil {
+		return nil, err
+	}
+	sourcePackages[input.PkgPath()] = pkg
+	return pkg, nil
+}
+
+// parseSourcePackage parses the (non-test) Go files within the directory, where file names are relative to `root`
+func parseSourcePackage(directory string, root string) (*sourcePackage, error) {
+	pkg := &sourcePackage{
+		fileSet:   token.NewFileSet(),
+		functions: make(map[string][]*ast.FuncDecl),
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %+v", directory, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+
+		fileName := filepath.Join(directory, entry.Name())
+		contents, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %+v", fileName, err)
+		}
+
+		if relative, err := filepath.Rel(root, fileName); err == nil {
+			fileName = relative
+		}
+
+		file, err := parser.ParseFile(pkg.fileSet, fileName, contents, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %+v", fileName, err)
+		}
+		pkg.files = append(pkg.files, file)
+
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+				pkg.functions[fn.Name.Name] = append(pkg.functions[fn.Name.Name], fn)
+			}
+		}
+	}
+
+	return pkg, nil
+}
+
+// repositoryRoot returns the root of this repository, which is the closest parent directory containing a go.mod
+func repositoryRoot() (string, error) {
+	directory, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(directory, "go.mod")); err == nil {
+			return directory, nil
+		}
+
+		parent := filepath.Dir(directory)
+		if parent == directory {
+			return "", fmt.Errorf("unable to find the root of the repository, this must be run from within the repository")
+		}
+		directory = parent
+	}
+}
+
+func (p *sourcePackage) position(pos token.Pos) token.Position {
+	return p.fileSet.Position(pos)
+}
+
+// typeSpec returns the declaration for the named type, if found
+func (p *sourcePackage) typeSpec(name string) *ast.TypeSpec {
+	for _, file := range p.files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if v, ok := spec.(*ast.TypeSpec); ok && v.Name.Name == name {
+					return v
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fieldPosition returns the position of the field within the named struct type, or of the type itself when the field
+// isn't found (e.g. as it's defined within an embedded struct)
+func (p *sourcePackage) fieldPosition(typeName, fieldName string) token.Position {
+	spec := p.typeSpec(typeName)
+	if spec == nil {
+		return token.Position{}
+	}
+
+	if s, ok := spec.Type.(*ast.StructType); ok {
+		for _, field := range s.Fields.List {
+			for _, name := range field.Names {
+				if name.Name == fieldName {
+					return p.position(name.Pos())
+				}
+			}
+		}
+	}
+
+	return p.position(spec.Pos())
+}
+
+// method returns the declaration of the method on the named type, if found
+func (p *sourcePackage) method(typeName, methodName string) *ast.FuncDecl {
+	for _, fn := range p.functions[methodName] {
+		if fn.Recv == nil || len(fn.Recv.List) != 1 {
+			continue
+		}
+
+		recv := fn.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		if ident, ok := recv.(*ast.Ident); ok && ident.Name == typeName {
+			return fn
+		}
+	}
+	return nil
+}
+
+// reachableFunctions returns the function and the functions within the same package which are called from it (up to
+// `maxCallDepth` calls deep), which together implement the function
+func (p *sourcePackage) reachableFunctions(fn *ast.FuncDecl) []*ast.FuncDecl {
+	seen := map[*ast.FuncDecl]struct{}{
+		fn: {},
+	}
+	out := []*ast.FuncDecl{fn}
+
+	current := []*ast.FuncDecl{fn}
+	for depth := 0; depth < maxCallDepth && len(current) > 0; depth++ {
+		next := make([]*ast.FuncDecl, 0)
+		for _, v := range current {
+			ast.Inspect(v.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				name := ""
+				switch fun := call.Fun.(type) {
+				case *ast.Ident:
+					name = fun.Name
+				case *ast.SelectorExpr:
+					// methods on the Resource (e.g. `r.mapModelToProperties`), functions within other packages
+					// won't be found so are ignored
+					name = fun.Sel.Name
+				}
+
+				for _, callee := range p.functions[name] {
+					if _, ok := seen[callee]; !ok {
+						seen[callee] = struct{}{}
+						out = append(out, callee)
+						next = append(next, callee)
+					}
+				}
+				return true
+			})
+		}
+		current = next
+	}
+
+	return out
+}
+
+// referencesProperty returns whether any of the functions reference the property, either via the field within the
+// model (e.g. `model.Tags` or `Model{Tags: ...}`) or via the name of the property in the schema (e.g.
+// `metadata.ResourceData.HasChange("tags")`)
+func referencesProperty(functions []*ast.FuncDecl, fieldName, propertyName string) bool {
+	found := false
+	for _, fn := range functions {
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			if found {
+				return false
+			}
+
+			switch v := node.(type) {
+			case *ast.SelectorExpr:
+				if fieldName != "" && v.Sel.Name == fieldName {
+					found = true
+				}
+			case *ast.KeyValueExpr:
+				if ident, ok := v.Key.(*ast.Ident); ok && fieldName != "" && ident.Name == fieldName {
+					found = true
+				}
+			case *ast.BasicLit:
+				if v.Kind == token.STRING {
+					if value, err := strconv.Unquote(v.Value); err == nil && value == propertyName {
+						found = true
+					}
+				}
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// modelFieldNames returns the name of the field within the model struct for each top-level property, keyed by the
+// name of the property in the schema
+func modelFieldNames(model reflect.Type) map[string]string {
+	out := make(map[string]string)
+	for model.Kind() == reflect.Ptr {
+		model = model.Elem()
+	}
+	if model.Kind() != reflect.Struct {
+		return out
+	}
+
+	for i := 0; i < model.NumField(); i++ {
+		field := model.Field(i)
+		if name := tfschemaName(field.Tag); name != "" {
+			out[name] = field.Name
+		}
+	}
+	return out
+}
+
+// tfschemaName returns the name of the property from the `tfschema` struct tag, if any
+func tfschemaName(tag reflect.StructTag) string {
+	v, ok := tag.Lookup("tfschema")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(strings.Split(v, ",")[0])
+}
+
+func typeName(input reflect.Type) string {
+	for input.Kind() == reflect.Ptr {
+		input = input.Elem()
+	}
+	return input.Name()
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testResourceSource = `package example
+
+type ExampleModel struct {
+	Name string ` + "`tfschema:\"name\"`" + `
+	Tags map[string]string ` + "`tfschema:\"tags\"`" + `
+	Sku  string ` + "`tfschema:\"sku\"`" + `
+}
+
+type ExampleResource struct{}
+
+func (r ExampleResource) Update() error {
+	var model ExampleModel
+	if hasChange("tags") {
+		model.Tags = expandTags()
+	}
+	return nil
+}
+
+func (r ExampleResource) Read() error {
+	state := ExampleModel{
+		Name: "example",
+	}
+	return r.flatten(&state)
+}
+
+func (r ExampleResource) flatten(state *ExampleModel) error {
+	state.Sku = "Standard"
+	return nil
+}
+
+func hasChange(string) bool { return true }
+
+func expandTags() map[string]string { return nil }
+`
+
+func TestSourcePackage(t *testing.T) {
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "example.go"), []byte(testResourceSource), 0o600); err != nil {
+		t.Fatalf("writing the example source: %+v", err)
+	}
+
+	pkg, err := parseSourcePackage(directory, directory)
+	if err != nil {
+		t.Fatalf("parsing the example package: %+v", err)
+	}
+
+	if position := pkg.fieldPosition("ExampleModel", "Tags"); position.Filename != "example.go" || position.Line != 5 {
+		t.Fatalf("expected the field Tags to be at example.go:5 but got %s", position)
+	}
+
+	update := pkg.method("ExampleResource", "Update")
+	if update == nil {
+		t.Fatalf("expected the method Update to be found")
+	}
+	updateFunctions := pkg.reachableFunctions(update)
+
+	read := pkg.method("ExampleResource", "Read")
+	if read == nil {
+		t.Fatalf("expected the method Read to be found")
+	}
+	readFunctions := pkg.reachableFunctions(read)
+
+	testData := []struct {
+		name     string
+		field    string
+		property string
+		update   bool
+		read     bool
+	}{
+		{
+			name:     "set via a field in a composite literal",
+			field:    "Name",
+			property: "name",
+			update:   false,
+			read:     true,
+		},
+		{
+			name:     "referenced via the field and the property name",
+			field:    "Tags",
+			property: "tags",
+			update:   true,
+			read:     false,
+		},
+		{
+			name:     "set within a called method",
+			field:    "Sku",
+			property: "sku",
+			update:   false,
+			read:     true,
+		},
+		{
+			name:     "referenced via the property name only",
+			field:    "",
+			property: "tags",
+			update:   true,
+			read:     false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			if actual := referencesProperty(updateFunctions, v.field, v.property); actual != v.update {
+				t.Fatalf("expected the property %q to be referenced in Update to be %t but got %t", v.property, v.update, actual)
+			}
+			if actual := referencesProperty(readFunctions, v.field, v.property); actual != v.read {
+				t.Fatalf("expected the property %q to be referenced in Read to be %t but got %t", v.property, v.read, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// typedResources returns the Typed Resources and Data Sources registered in the Provider, keyed by their type
+func typedResources() (resources map[string]sdk.Resource, dataSources map[string]sdk.DataSource) {
+	resources = make(map[string]sdk.Resource)
+	dataSources = make(map[string]sdk.DataSource)
+	for _, s := range provider.SupportedTypedServices() {
+		for _, r := range s.Resources() {
+			resources[r.ResourceType()] = r
+		}
+		for _, ds := range s.DataSources() {
+			dataSources[ds.ResourceType()] = ds
+		}
+	}
+	return
+}
+
+// methodFunctions returns the position of the method on the Resource (e.g. `Update`) and the functions which
+// implement the ResourceFunc returned from it
+func methodFunctions(resource interface{}, methodName string) (*sourcePackage, *ast.FuncDecl, []*ast.FuncDecl, error) {
+	resourceType := reflect.TypeOf(resource)
+	pkg, err := sourcePackageForType(resourceType)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	method := pkg.method(typeName(resourceType), methodName)
+	if method == nil {
+		// e.g. the method is defined on an embedded type within another package
+		return pkg, nil, nil, fmt.Errorf("unable to find the source code for the method %s on %s", methodName, resourceType.String())
+	}
+
+	return pkg, method, pkg.reachableFunctions(method), nil
+}
+
+// keyPosition returns the position of the key (e.g. `Timeout`) within a composite literal inside the function,
+// falling back to the position of the function
+func (p *sourcePackage) keyPosition(fn *ast.FuncDecl, key string) token.Position {
+	pos := fn.Pos()
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		if kv, ok := node.(*ast.KeyValueExpr); ok {
+			if ident, ok := kv.Key.(*ast.Ident); ok && ident.Name == key {
+				pos = kv.Pos()
+				return false
+			}
+		}
+		return true
+	})
+	return p.position(pos)
+}
+
+// reflectTypeOf returns the type of the model, or of an empty struct when the resource doesn't have a model
+func reflectTypeOf(model interface{}) reflect.Type {
+	if model == nil {
+		return reflect.TypeOf(struct{}{})
+	}
+	return reflect.TypeOf(model)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var _ Rule = TypedSDKModelTagsCheck{}
+
+type TypedSDKModelTagsCheck struct{}
+
+func (r TypedSDKModelTagsCheck) Run() (errors []error) {
+	resources, dataSources := typedResources()
+
+	check := func(resourceType string, model interface{}, arguments, attributes map[string]*schema.Schema) {
+		modelType := reflect.TypeOf(model)
+		if modelType == nil {
+			// base types (e.g. roleAssignmentBaseResource) don't have a model
+			return
+		}
+
+		combined := make(map[string]*schema.Schema)
+		for k, v := range arguments {
+			combined[k] = v
+		}
+		for k, v := range attributes {
+			combined[k] = v
+		}
+
+		errors = append(errors, r.checkModel(resourceType, modelType, combined)...)
+	}
+
+	for _, resourceType := range sortedKeys(resources) {
+		resource := resources[resourceType]
+		check(resourceType, resource.ModelObject(), resource.Arguments(), resource.Attributes())
+	}
+	for _, dataSourceType := range sortedKeys(dataSources) {
+		dataSource := dataSources[dataSourceType]
+		check(dataSourceType, dataSource.ModelObject(), dataSource.Arguments(), dataSource.Attributes())
+	}
+
+	return
+}
+
+func (r TypedSDKModelTagsCheck) Name() string {
+	return "checkModelTags"
+}
+
+func (r TypedSDKModelTagsCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check that the 'tfschema' tags on the fields within a TypedSDK model match the keys in 'Arguments()' and 'Attributes()' (including nested blocks).
+`, r.Name())
+}
+
+func (r TypedSDKModelTagsCheck) checkModel(resourceType string, model reflect.Type, properties map[string]*schema.Schema) (errors []error) {
+	for model.Kind() == reflect.Ptr {
+		model = model.Elem()
+	}
+	if model.Kind() != reflect.Struct {
+		return
+	}
+
+	if !strings.HasPrefix(model.PkgPath(), modulePath+"/") {
+		// shared models (e.g. for `identity`) are defined in go-azure-helpers alongside their schema
+		return
+	}
+
+	pkg, err := sourcePackageForType(model)
+	if err != nil {
+		return []error{err}
+	}
+
+	tagged := false
+	for i := 0; i < model.NumField(); i++ {
+		field := model.Field(i)
+		tag, ok := field.Tag.Lookup("tfschema")
+		if !ok {
+			continue
+		}
+		tagged = true
+
+		name := tfschemaName(field.Tag)
+		property, ok := properties[name]
+		if !ok {
+			if strings.Contains(tag, "removedInNextMajorVersion") || strings.Contains(tag, "addedInNextMajorVersion") {
+				// these are conditionally present in the schema depending on the major version
+				continue
+			}
+
+			errors = append(errors, newViolation(r.Name(), pkg.fieldPosition(model.Name(), field.Name), "%s: the field %s in the model %s has the tag `tfschema:%q` but there's no property named %q in the schema", resourceType, field.Name, model.Name(), tag, name))
+			continue
+		}
+
+		if nested, ok := property.Elem.(*schema.Resource); ok {
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				errors = append(errors, r.checkModel(fmt.Sprintf("%s.%s", resourceType, name), fieldType, nested.Schema)...)
+			}
+		}
+	}
+
+	if !tagged && len(properties) > 0 {
+		errors = append(errors, newViolation(r.Name(), pkg.fieldPosition(model.Name(), ""), "%s: the model %s doesn't contain any fields with a `tfschema` tag - check that ModelObject returns the correct model", resourceType, model.Name()))
+	}
+
+	return
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	out := make([]string, 0, len(input))
+	for k := range input {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var _ Rule = TypedSDKReadCheck{}
+
+type TypedSDKReadCheck struct{}
+
+func (r TypedSDKReadCheck) Run() (errors []error) {
+	resources, dataSources := typedResources()
+
+	check := func(resourceType string, resource interface{}, model interface{}, attributes map[string]*schema.Schema) {
+		pkg, method, functions, err := methodFunctions(resource, "Read")
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%s: %+v", resourceType, err))
+			return
+		}
+
+		fieldNames := modelFieldNames(reflectTypeOf(model))
+		for _, name := range sortedKeys(attributes) {
+			if !attributes[name].Computed {
+				continue
+			}
+
+			if !referencesProperty(functions, fieldNames[name], name) {
+				errors = append(errors, newViolation(r.Name(), pkg.position(method.Pos()), "%s: the computed attribute %q is never set in the Read function", resourceType, name))
+			}
+		}
+	}
+
+	for _, resourceType := range sortedKeys(resources) {
+		resource := resources[resourceType]
+		check(resourceType, resource, resource.ModelObject(), resource.Attributes())
+	}
+	for _, dataSourceType := range sortedKeys(dataSources) {
+		dataSource := dataSources[dataSourceType]
+		check(dataSourceType, dataSource, dataSource.ModelObject(), dataSource.Attributes())
+	}
+
+	return
+}
+
+func (r TypedSDKReadCheck) Name() string {
+	return "checkReadAttributes"
+}
+
+func (r TypedSDKReadCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check that the 'Read' function of a TypedSDK resource or data source sets every computed attribute defined in 'Attributes()'.
+`, r.Name())
+}
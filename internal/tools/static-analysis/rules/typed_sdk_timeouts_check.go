@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ Rule = TypedSDKTimeoutsCheck{}
+
+// longRunningOperation is the minimum duration of each operation for a resource whose Long Running Operations are
+// known to take a long time, beyond the (usual) default timeouts
+type longRunningOperation struct {
+	Create time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// knownLongRunningOperations are the resources with operations which are known to take a long time in Azure, these
+// are the durations observed during acceptance testing, rather than the time documented for the API.
+var knownLongRunningOperations = map[string]longRunningOperation{
+	"azurerm_ai_services": {
+		Create: 3 * time.Hour,
+		Update: 3 * time.Hour,
+		Delete: 3 * time.Hour,
+	},
+	"azurerm_app_service_environment_v3": {
+		Create: 6 * time.Hour,
+		Update: 6 * time.Hour,
+		Delete: 6 * time.Hour,
+	},
+	"azurerm_custom_ip_prefix": {
+		// (de)commissioning a Custom IP Prefix can take many hours
+		Create: 9 * time.Hour,
+		Update: 17 * time.Hour,
+		Delete: 17 * time.Hour,
+	},
+	"azurerm_log_analytics_cluster": {
+		Create: 6 * time.Hour,
+		Update: 6 * time.Hour,
+	},
+	"azurerm_mobile_network_packet_core_control_plane": {
+		Create: 3 * time.Hour,
+		Delete: 3 * time.Hour,
+	},
+	"azurerm_mssql_managed_instance": {
+		Create: 24 * time.Hour,
+		Update: 24 * time.Hour,
+		Delete: 24 * time.Hour,
+	},
+	"azurerm_network_manager_deployment": {
+		Create: 24 * time.Hour,
+		Update: 24 * time.Hour,
+		Delete: 24 * time.Hour,
+	},
+	"azurerm_redhat_openshift_cluster": {
+		Create: 90 * time.Minute,
+		Update: 90 * time.Minute,
+		Delete: 90 * time.Minute,
+	},
+	"azurerm_service_fabric_managed_cluster": {
+		Create: 90 * time.Minute,
+		Update: 90 * time.Minute,
+		Delete: 90 * time.Minute,
+	},
+	"azurerm_stream_analytics_cluster": {
+		Create: 90 * time.Minute,
+		Update: 90 * time.Minute,
+		Delete: 90 * time.Minute,
+	},
+	"azurerm_system_center_virtual_machine_manager_server": {
+		Create: 3 * time.Hour,
+		Update: 3 * time.Hour,
+		Delete: 3 * time.Hour,
+	},
+}
+
+type TypedSDKTimeoutsCheck struct{}
+
+func (r TypedSDKTimeoutsCheck) Run() (errors []error) {
+	resources, _ := typedResources()
+	for _, resourceType := range sortedKeys(knownLongRunningOperations) {
+		resource, ok := resources[resourceType]
+		if !ok {
+			continue
+		}
+		known := knownLongRunningOperations[resourceType]
+
+		timeouts := map[string]time.Duration{
+			"Create": resource.Create().Timeout,
+			"Delete": resource.Delete().Timeout,
+		}
+		minimums := map[string]time.Duration{
+			"Create": known.Create,
+			"Delete": known.Delete,
+		}
+		if v, ok := resource.(sdk.ResourceWithUpdate); ok {
+			timeouts["Update"] = v.Update().Timeout
+			minimums["Update"] = known.Update
+		}
+
+		for _, operation := range sortedKeys(timeouts) {
+			timeout, minimum := timeouts[operation], minimums[operation]
+			if timeout >= minimum {
+				continue
+			}
+
+			message := fmt.Sprintf("%s: the %s timeout of %s is shorter than the known duration of %s", resourceType, operation, timeout, minimum)
+			pkg, method, _, err := methodFunctions(resource, operation)
+			if err != nil {
+				errors = append(errors, Violation{Rule: r.Name(), Message: message})
+				continue
+			}
+			errors = append(errors, newViolation(r.Name(), pkg.keyPosition(method, "Timeout"), "%s", message))
+		}
+	}
+
+	return
+}
+
+func (r TypedSDKTimeoutsCheck) Name() string {
+	return "checkTimeouts"
+}
+
+func (r TypedSDKTimeoutsCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check that the default Create, Update and Delete timeouts of TypedSDK resources with known long running operations are at least as long as these operations are known to take.
+`, r.Name())
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ Rule = TypedSDKUpdateCheck{}
+
+type TypedSDKUpdateCheck struct{}
+
+func (r TypedSDKUpdateCheck) Run() (errors []error) {
+	resources, _ := typedResources()
+	for _, resourceType := range sortedKeys(resources) {
+		resource, ok := resources[resourceType].(sdk.ResourceWithUpdate)
+		if !ok {
+			continue
+		}
+
+		pkg, method, functions, err := methodFunctions(resource, "Update")
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%s: %+v", resourceType, err))
+			continue
+		}
+
+		fieldNames := modelFieldNames(reflectTypeOf(resource.ModelObject()))
+		arguments := resource.Arguments()
+		for _, name := range sortedKeys(arguments) {
+			argument := arguments[name]
+			if argument.ForceNew || (!argument.Optional && !argument.Required) {
+				continue
+			}
+
+			if !referencesProperty(functions, fieldNames[name], name) {
+				errors = append(errors, newViolation(r.Name(), pkg.position(method.Pos()), "%s: the argument %q isn't ForceNew but isn't referenced in the Update function", resourceType, name))
+			}
+		}
+	}
+
+	return
+}
+
+func (r TypedSDKUpdateCheck) Name() string {
+	return "checkUpdateArguments"
+}
+
+func (r TypedSDKUpdateCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check that the 'Update' function of a TypedSDK resource references every argument which isn't ForceNew, since changes to any other argument would be silently ignored.
+`, r.Name())
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/token"
+)
+
+// Violation is an error found by a Rule at a specific location within the source code
+type Violation struct {
+	Rule    string `json:"rule"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+var _ error = Violation{}
+
+func (v Violation) Error() string {
+	if v.File == "" {
+		return v.Message
+	}
+	return fmt.Sprintf("%s:%d: %s", v.File, v.Line, v.Message)
+}
+
+func newViolation(rule string, position token.Position, format string, args ...interface{}) Violation {
+	return Violation{
+		Rule:    rule,
+		File:    position.Filename,
+		Line:    position.Line,
+		Message: fmt.Sprintf(format, args...),
+	}
+}